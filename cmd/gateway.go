@@ -19,11 +19,15 @@ func (c *GatewayCreator) NewServer(userConfig legacy.UserConfig, config legacy.C
 		AutoplanFileList:          userConfig.AutoplanFileList,
 		AppCfg:                    appConfig,
 		RepoAllowList:             userConfig.RepoAllowlist,
+		RepoMirrorCacheMB:         userConfig.RepoMirrorCacheMB,
+		DurableEventIngestion:     userConfig.DurableEventIngestion,
 		MaxProjectsPerPR:          userConfig.MaxProjectsPerPR,
 		FFOwner:                   userConfig.FFOwner,
 		FFRepo:                    userConfig.FFRepo,
 		FFBranch:                  userConfig.FFBranch,
 		FFPath:                    userConfig.FFPath,
+		FFSource:                  userConfig.FFSource,
+		FFURL:                     userConfig.FFURL,
 		GithubHostname:            userConfig.GithubHostname,
 		GithubWebhookSecret:       userConfig.GithubWebhookSecret,
 		GithubAppID:               userConfig.GithubAppID,
@@ -40,6 +44,9 @@ func (c *GatewayCreator) NewServer(userConfig legacy.UserConfig, config legacy.C
 		SSLCertFile:               userConfig.SSLCertFile,
 		DefaultCheckrunDetailsURL: userConfig.DefaultCheckrunDetailsURL,
 		DefaultTFVersion:          userConfig.DefaultTFVersion,
+		SlackToken:                userConfig.SlackToken,
+		SlackSigningSecret:        userConfig.SlackSigningSecret,
+		SlackGithubUserField:      userConfig.SlackGithubUserField,
 	}
 	return gateway.NewServer(cfg)
 }
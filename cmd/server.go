@@ -46,12 +46,15 @@ const (
 	DisableApplyFlag           = "disable-apply"
 	DisableAutoplanFlag        = "disable-autoplan"
 	DisableMarkdownFoldingFlag = "disable-markdown-folding"
+	DurableEventIngestionFlag  = "durable-event-ingestion"
 	EnableRegExpCmdFlag        = "enable-regexp-cmd"
 	EnableDiffMarkdownFormat   = "enable-diff-markdown-format"
 	FFOwnerFlag                = "ff-owner"
 	FFRepoFlag                 = "ff-repo"
 	FFBranchFlag               = "ff-branch"
 	FFPathFlag                 = "ff-path"
+	FFSourceFlag               = "ff-source"
+	FFURLFlag                  = "ff-url"
 	GHHostnameFlag             = "gh-hostname"
 	GHTokenFlag                = "gh-token"
 	GHUserFlag                 = "gh-user"
@@ -73,7 +76,10 @@ const (
 	// RepoWhitelistFlag is deprecated for RepoAllowlistFlag.
 	RepoWhitelistFlag            = "repo-whitelist"
 	RepoAllowlistFlag            = "repo-allowlist"
+	RepoMirrorCacheMBFlag        = "repo-mirror-cache-mb"
 	SlackTokenFlag               = "slack-token"
+	SlackSigningSecretFlag       = "slack-signing-secret" // nolint: gosec
+	SlackGithubUserFieldFlag     = "slack-github-user-field"
 	SSLCertFileFlag              = "ssl-cert-file"
 	SSLKeyFileFlag               = "ssl-key-file"
 	TFDownloadURLFlag            = "tf-download-url"
@@ -140,7 +146,13 @@ var stringFlags = map[string]stringFlag{
 		description: "Branch on repo to pull the feature flag configuration.",
 	},
 	FFPathFlag: {
-		description: "Path in repo to get feature flag configuration.",
+		description: "Path in repo to get feature flag configuration. When --" + FFSourceFlag + "=file this is a path on local disk.",
+	},
+	FFSourceFlag: {
+		description: "Source of the feature flag configuration, one of github, file or http. Defaults to github.",
+	},
+	FFURLFlag: {
+		description: "URL to get feature flag configuration from when --" + FFSourceFlag + "=http.",
 	},
 	GHHostnameFlag: {
 		description:  "Hostname of your Github Enterprise installation. If using github.com, no need to set.",
@@ -200,6 +212,15 @@ var stringFlags = map[string]stringFlag{
 	SlackTokenFlag: {
 		description: "API token for Slack notifications.",
 	},
+	SlackSigningSecretFlag: {
+		description: "Signing secret of the Slack app used to verify interactive requests, ie. plan reviews from Slack. " +
+			"Interactive requests are not handled if this is not specified. " +
+			"Should be specified via the ATLANTIS_SLACK_SIGNING_SECRET environment variable.",
+	},
+	SlackGithubUserFieldFlag: {
+		description: fmt.Sprintf("ID of the Slack custom profile field containing a user's GitHub username. Required with --%s. ", SlackSigningSecretFlag) +
+			"This field should only be editable by workspace admins since it is used to authorize plan reviews.",
+	},
 	SSLCertFileFlag: {
 		description: "File containing x509 Certificate used for serving HTTPS. If the cert is signed by a CA, the file should be the concatenation of the server's certificate, any intermediates, and the CA's certificate.",
 	},
@@ -254,6 +275,11 @@ var boolFlags = map[string]boolFlag{
 		description:  "Disable atlantis auto planning feature",
 		defaultValue: false,
 	},
+	DurableEventIngestionFlag: {
+		description: "Hand push and pull request events accepted by the gateway off to a temporal workflow which clones the repo and signals the affected roots. " +
+			"Events survive gateway restarts and are retried on failure at the cost of some added latency.",
+		defaultValue: false,
+	},
 	EnableRegExpCmdFlag: {
 		description:  "Enable Atlantis to use regular expressions on plan/apply commands when \"-p\" flag is passed with it.",
 		defaultValue: false,
@@ -295,6 +321,10 @@ var intFlags = map[string]intFlag{
 		description:  "Port to bind to.",
 		defaultValue: DefaultPort,
 	},
+	RepoMirrorCacheMBFlag: {
+		description: "Disk budget in megabytes for the gateway's cache of bare repository mirrors which clones are made from. " +
+			"Least recently used mirrors are evicted once exceeded. Disabled if not set.",
+	},
 }
 
 var int64Flags = map[string]int64Flag{
@@ -590,6 +620,12 @@ func (s *ServerCmd) validate(userConfig server.UserConfig, logger logging.Logger
 		return errors.New("invalid checkout strategy: not one of branch or merge")
 	}
 
+	switch userConfig.FFSource {
+	case "", "github", "file", "http":
+	default:
+		return fmt.Errorf("invalid --%s: not one of github, file or http", FFSourceFlag)
+	}
+
 	if (userConfig.SSLKeyFile == "") != (userConfig.SSLCertFile == "") {
 		return fmt.Errorf("--%s and --%s are both required for ssl", SSLKeyFileFlag, SSLCertFileFlag)
 	}
@@ -630,11 +666,15 @@ func (s *ServerCmd) validate(userConfig server.UserConfig, logger logging.Logger
 	if userConfig.RepoConfig != "" && userConfig.RepoConfigJSON != "" {
 		return fmt.Errorf("cannot use --%s and --%s at the same time", RepoConfigFlag, RepoConfigJSONFlag)
 	}
+	if userConfig.SlackSigningSecret != "" && (userConfig.SlackToken == "" || userConfig.SlackGithubUserField == "") {
+		return fmt.Errorf("--%s and --%s must be set when --%s is set", SlackTokenFlag, SlackGithubUserFieldFlag, SlackSigningSecretFlag)
+	}
 
 	// Warn if any tokens have newlines.
 	for name, token := range map[string]string{
-		GHTokenFlag:         userConfig.GithubToken,
-		GHWebhookSecretFlag: userConfig.GithubWebhookSecret,
+		GHTokenFlag:            userConfig.GithubToken,
+		GHWebhookSecretFlag:    userConfig.GithubWebhookSecret,
+		SlackSigningSecretFlag: userConfig.SlackSigningSecret,
 	} {
 		if strings.Contains(token, "\n") {
 			logger.Warn(fmt.Sprintf("--%s contains a newline which is usually unintentional", name))
@@ -60,6 +60,7 @@ var testFlags = map[string]interface{}{
 	DisableApplyAllFlag:          true,
 	DisableApplyFlag:             true,
 	DisableMarkdownFoldingFlag:   true,
+	DurableEventIngestionFlag:    true,
 	GHHostnameFlag:               "ghhostname",
 	GHTokenFlag:                  "token",
 	GHUserFlag:                   "user",
@@ -74,7 +75,10 @@ var testFlags = map[string]interface{}{
 	PortFlag:                     8181,
 	ParallelPoolSize:             100,
 	RepoAllowlistFlag:            "github.com/runatlantis/atlantis",
+	RepoMirrorCacheMBFlag:        1024,
 	SlackTokenFlag:               "slack-token",
+	SlackSigningSecretFlag:       "slack-signing-secret",
+	SlackGithubUserFieldFlag:     "Xf0GITHUB",
 	SSLCertFileFlag:              "cert-file",
 	SSLKeyFileFlag:               "key-file",
 	TFDownloadURLFlag:            "https://my-hostname.com",
@@ -504,6 +508,18 @@ func TestExecute_BothAllowAndWhitelist(t *testing.T) {
 	ErrEquals(t, "both --repo-allowlist and --repo-whitelist cannot be set–use --repo-allowlist", err)
 }
 
+func TestExecute_SlackSigningSecretRequiresUserField(t *testing.T) {
+	c := setup(map[string]interface{}{
+		GHUserFlag:             "user",
+		GHTokenFlag:            "token",
+		RepoAllowlistFlag:      "github.com",
+		SlackTokenFlag:         "slack-token",
+		SlackSigningSecretFlag: "secret",
+	}, t)
+	err := c.Execute()
+	ErrEquals(t, "--slack-token and --slack-github-user-field must be set when --slack-signing-secret is set", err)
+}
+
 // Must set allow or whitelist.
 func TestExecute_AllowAndWhitelist(t *testing.T) {
 	c := setup(map[string]interface{}{
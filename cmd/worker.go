@@ -55,6 +55,8 @@ func (t *TemporalWorker) NewServer(userConfig legacy.UserConfig, config legacy.C
 			FFRepo:   userConfig.FFRepo,
 			FFPath:   userConfig.FFPath,
 			FFBranch: userConfig.FFBranch,
+			FFSource: userConfig.FFSource,
+			FFURL:    userConfig.FFURL,
 		},
 		TerraformCfg: neptune.TerraformConfig{
 			DefaultVersion: userConfig.DefaultTFVersion,
@@ -76,6 +78,10 @@ func (t *TemporalWorker) NewServer(userConfig legacy.UserConfig, config legacy.C
 		Metrics:                  globalCfg.Metrics,
 		LyftAuditJobsSnsTopicArn: userConfig.LyftAuditJobsSnsTopicArn,
 		RevisionSetter:           globalCfg.RevisionSetter,
+		AuditCfg:                 globalCfg.Audit,
+		GlobalCfg:                globalCfg,
+		GithubHostname:           userConfig.GithubHostname,
+		SlackToken:               userConfig.SlackToken,
 	}
 	return temporalworker.NewServer(cfg)
 }
@@ -9,6 +9,12 @@ type ApplySettings struct {
 	PRRequirements    []string `yaml:"pr_requirements" json:"pr_requirements"`
 	BranchRestriction string   `yaml:"branch_restriction" json:"branch_restriction"`
 	Team              string   `yaml:"team" json:"team"`
+	// MinApprovals only applies with the approved pr requirement
+	MinApprovals          int  `yaml:"min_approvals" json:"min_approvals"`
+	DismissStaleApprovals bool `yaml:"dismiss_stale_approvals" json:"dismiss_stale_approvals"`
+	TwoPersonRule         bool `yaml:"two_person_rule" json:"two_person_rule"`
+	// RequiredChecks are check run names or commit status contexts
+	RequiredChecks []string `yaml:"required_checks" json:"required_checks"`
 }
 
 func (s ApplySettings) Validate() error {
@@ -17,11 +23,14 @@ func (s ApplySettings) Validate() error {
 			v := value.([]string)
 
 			for _, item := range v {
-				return validation.In(valid.ApprovedApplyReq).Validate(item)
+				if err := validation.In(valid.ApprovedApplyReq, valid.OwnersApplyReq).Validate(item); err != nil {
+					return err
+				}
 			}
 			return nil
 		})),
 		validation.Field(&s.BranchRestriction, validation.In(string(valid.NoBranchRestriction), string(valid.DefaultBranchRestriction))),
+		validation.Field(&s.MinApprovals, validation.Min(0)),
 	)
 }
 
@@ -32,8 +41,26 @@ func (s ApplySettings) ToValid() valid.ApplySettings {
 	}
 
 	return valid.ApplySettings{
-		PRRequirements:    s.PRRequirements,
-		BranchRestriction: branchRestriction,
-		Team:              s.Team,
+		PRRequirements:        s.PRRequirements,
+		BranchRestriction:     branchRestriction,
+		Team:                  s.Team,
+		MinApprovals:          s.MinApprovals,
+		DismissStaleApprovals: s.DismissStaleApprovals,
+		TwoPersonRule:         s.TwoPersonRule,
+		RequiredChecks:        s.RequiredChecks,
+	}
+}
+
+// ToOverride converts root level settings, unlike ToValid unset fields are left
+// empty so that they fall back to the repo's settings.
+func (s ApplySettings) ToOverride() valid.ApplySettings {
+	return valid.ApplySettings{
+		PRRequirements:        s.PRRequirements,
+		BranchRestriction:     valid.BranchRestriction(s.BranchRestriction),
+		Team:                  s.Team,
+		MinApprovals:          s.MinApprovals,
+		DismissStaleApprovals: s.DismissStaleApprovals,
+		TwoPersonRule:         s.TwoPersonRule,
+		RequiredChecks:        s.RequiredChecks,
 	}
 }
@@ -0,0 +1,110 @@
+package raw
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/runatlantis/atlantis/server/config/valid"
+)
+
+// Audit configures where audit events emitted by gateway and worker are written.
+type Audit struct {
+	Sinks []AuditSink `yaml:"sinks" json:"sinks"`
+}
+
+func (a Audit) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.Sinks),
+	)
+}
+
+func (a Audit) ToValid() valid.Audit {
+	var sinks []valid.AuditSink
+	for _, s := range a.Sinks {
+		sinks = append(sinks, s.ToValid())
+	}
+	return valid.Audit{
+		Sinks: sinks,
+	}
+}
+
+// AuditSink represents a single sink, only one of the backends should be set.
+type AuditSink struct {
+	File    *FileAuditSink    `yaml:"file" json:"file"`
+	Webhook *WebhookAuditSink `yaml:"webhook" json:"webhook"`
+	SNS     *SNSAuditSink     `yaml:"sns" json:"sns"`
+}
+
+func (s AuditSink) Validate() error {
+	var configured int
+	for _, isSet := range []bool{s.File != nil, s.Webhook != nil, s.SNS != nil} {
+		if isSet {
+			configured++
+		}
+	}
+
+	if configured != 1 {
+		return errors.New("exactly one of file, webhook or sns must be configured per audit sink")
+	}
+
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.File),
+		validation.Field(&s.Webhook),
+		validation.Field(&s.SNS),
+	)
+}
+
+func (s AuditSink) ToValid() valid.AuditSink {
+	// Serially checks for non-nil supported backends
+	switch {
+	case s.File != nil:
+		return valid.AuditSink{
+			Type: valid.FileAuditSink,
+			Path: s.File.Path,
+		}
+	case s.Webhook != nil:
+		return valid.AuditSink{
+			Type:   valid.WebhookAuditSink,
+			URL:    s.Webhook.URL,
+			Secret: s.Webhook.Secret,
+		}
+	default:
+		return valid.AuditSink{
+			Type:     valid.SNSAuditSink,
+			TopicArn: s.SNS.TopicArn,
+		}
+	}
+}
+
+type FileAuditSink struct {
+	Path string `yaml:"path" json:"path"`
+}
+
+func (f FileAuditSink) Validate() error {
+	return validation.ValidateStruct(&f,
+		validation.Field(&f.Path, validation.Required),
+	)
+}
+
+type WebhookAuditSink struct {
+	URL    string `yaml:"url" json:"url"`
+	Secret string `yaml:"secret" json:"secret"`
+}
+
+func (w WebhookAuditSink) Validate() error {
+	return validation.ValidateStruct(&w,
+		validation.Field(&w.URL, validation.Required, is.URL),
+		validation.Field(&w.Secret, validation.Required),
+	)
+}
+
+type SNSAuditSink struct {
+	TopicArn string `yaml:"topic_arn" json:"topic_arn"`
+}
+
+func (s SNSAuditSink) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.TopicArn, validation.Required),
+	)
+}
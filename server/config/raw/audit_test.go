@@ -0,0 +1,146 @@
+package raw_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/config/raw"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestAudit_Unmarshal(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		rawYaml := `
+sinks:
+  - file:
+      path: /var/log/atlantis/audit.jsonl
+  - webhook:
+      url: https://audit.example.com/events
+      secret: shh
+  - sns:
+      topic_arn: arn:aws:sns:us-east-1:123456789012:audit
+`
+
+		var result raw.Audit
+
+		err := yaml.UnmarshalStrict([]byte(rawYaml), &result)
+		assert.NoError(t, err)
+		assert.Len(t, result.Sinks, 3)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		rawJSON := `
+	{
+		"sinks": [
+			{
+				"file": {
+					"path": "/var/log/atlantis/audit.jsonl"
+				}
+			},
+			{
+				"webhook": {
+					"url": "https://audit.example.com/events",
+					"secret": "shh"
+				}
+			}
+		]
+	}
+	`
+		var result raw.Audit
+
+		err := json.Unmarshal([]byte(rawJSON), &result)
+		assert.NoError(t, err)
+		assert.Len(t, result.Sinks, 2)
+	})
+}
+
+func TestAudit_Validate(t *testing.T) {
+	cases := []struct {
+		description string
+		subject     raw.Audit
+		expectErr   bool
+	}{
+		{
+			description: "empty",
+			subject:     raw.Audit{},
+		},
+		{
+			description: "all sinks",
+			subject: raw.Audit{
+				Sinks: []raw.AuditSink{
+					{File: &raw.FileAuditSink{Path: "/tmp/audit.jsonl"}},
+					{Webhook: &raw.WebhookAuditSink{URL: "https://audit.example.com", Secret: "shh"}},
+					{SNS: &raw.SNSAuditSink{TopicArn: "arn"}},
+				},
+			},
+		},
+		{
+			description: "no backend",
+			subject: raw.Audit{
+				Sinks: []raw.AuditSink{{}},
+			},
+			expectErr: true,
+		},
+		{
+			description: "multiple backends",
+			subject: raw.Audit{
+				Sinks: []raw.AuditSink{
+					{
+						File: &raw.FileAuditSink{Path: "/tmp/audit.jsonl"},
+						SNS:  &raw.SNSAuditSink{TopicArn: "arn"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			description: "webhook missing secret",
+			subject: raw.Audit{
+				Sinks: []raw.AuditSink{
+					{Webhook: &raw.WebhookAuditSink{URL: "https://audit.example.com"}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			description: "file missing path",
+			subject: raw.Audit{
+				Sinks: []raw.AuditSink{
+					{File: &raw.FileAuditSink{}},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			err := c.subject.Validate()
+			if c.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAudit_ToValid(t *testing.T) {
+	subject := raw.Audit{
+		Sinks: []raw.AuditSink{
+			{File: &raw.FileAuditSink{Path: "/tmp/audit.jsonl"}},
+			{Webhook: &raw.WebhookAuditSink{URL: "https://audit.example.com", Secret: "shh"}},
+			{SNS: &raw.SNSAuditSink{TopicArn: "arn"}},
+		},
+	}
+
+	assert.Equal(t, valid.Audit{
+		Sinks: []valid.AuditSink{
+			{Type: valid.FileAuditSink, Path: "/tmp/audit.jsonl"},
+			{Type: valid.WebhookAuditSink, URL: "https://audit.example.com", Secret: "shh"},
+			{Type: valid.SNSAuditSink, TopicArn: "arn"},
+		},
+	}, subject.ToValid())
+}
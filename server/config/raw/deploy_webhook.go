@@ -0,0 +1,57 @@
+package raw
+
+import (
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"github.com/runatlantis/atlantis/server/config/valid"
+)
+
+type DeployWebhook struct {
+	URL    string   `yaml:"url" json:"url"`
+	Secret string   `yaml:"secret" json:"secret"`
+	Roots  []string `yaml:"roots" json:"roots"`
+	Events []string `yaml:"events" json:"events"`
+}
+
+func (w DeployWebhook) Validate() error {
+	validEvents := func(value interface{}) error {
+		events := value.([]string)
+		for _, e := range events {
+			if !isDeployWebhookEvent(e) {
+				return fmt.Errorf("%q is not a valid deploy webhook event", e)
+			}
+		}
+		return nil
+	}
+
+	return validation.ValidateStruct(&w,
+		validation.Field(&w.URL, validation.Required, is.URL),
+		validation.Field(&w.Secret, validation.Required),
+		validation.Field(&w.Events, validation.By(validEvents)),
+	)
+}
+
+func isDeployWebhookEvent(event string) bool {
+	for _, e := range valid.DeployWebhookEvents {
+		if string(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (w DeployWebhook) ToValid() valid.DeployWebhook {
+	var events []valid.DeployWebhookEvent
+	for _, e := range w.Events {
+		events = append(events, valid.DeployWebhookEvent(e))
+	}
+
+	return valid.DeployWebhook{
+		URL:    w.URL,
+		Secret: w.Secret,
+		Roots:  w.Roots,
+		Events: events,
+	}
+}
@@ -0,0 +1,100 @@
+package raw_test
+
+import (
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/config/raw"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestDeployWebhook_Unmarshal(t *testing.T) {
+	rawYaml := `
+url: https://hooks.example.com/deploy
+secret: shh
+roots:
+  - root
+events:
+  - applied
+  - failed
+`
+
+	var result raw.DeployWebhook
+
+	err := yaml.UnmarshalStrict([]byte(rawYaml), &result)
+	assert.NoError(t, err)
+	assert.Equal(t, raw.DeployWebhook{
+		URL:    "https://hooks.example.com/deploy",
+		Secret: "shh",
+		Roots:  []string{"root"},
+		Events: []string{"applied", "failed"},
+	}, result)
+}
+
+func TestDeployWebhook_Validate(t *testing.T) {
+	cases := []struct {
+		description string
+		subject     raw.DeployWebhook
+		expectErr   bool
+	}{
+		{
+			description: "minimal",
+			subject:     raw.DeployWebhook{URL: "https://hooks.example.com", Secret: "shh"},
+		},
+		{
+			description: "all events",
+			subject: raw.DeployWebhook{
+				URL:    "https://hooks.example.com",
+				Secret: "shh",
+				Events: []string{"queued", "planned", "awaiting_approval", "applied", "failed"},
+			},
+		},
+		{
+			description: "invalid event",
+			subject: raw.DeployWebhook{
+				URL:    "https://hooks.example.com",
+				Secret: "shh",
+				Events: []string{"merged"},
+			},
+			expectErr: true,
+		},
+		{
+			description: "missing secret",
+			subject:     raw.DeployWebhook{URL: "https://hooks.example.com"},
+			expectErr:   true,
+		},
+		{
+			description: "missing url",
+			subject:     raw.DeployWebhook{Secret: "shh"},
+			expectErr:   true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			err := c.subject.Validate()
+			if c.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDeployWebhook_ToValid(t *testing.T) {
+	subject := raw.DeployWebhook{
+		URL:    "https://hooks.example.com",
+		Secret: "shh",
+		Roots:  []string{"root"},
+		Events: []string{"applied"},
+	}
+
+	assert.Equal(t, valid.DeployWebhook{
+		URL:    "https://hooks.example.com",
+		Secret: "shh",
+		Roots:  []string{"root"},
+		Events: []valid.DeployWebhookEvent{valid.AppliedDeployWebhookEvent},
+	}, subject.ToValid())
+}
@@ -27,15 +27,18 @@ type GlobalCfg struct {
 	RevisionSetter       RevisionSetter       `yaml:"revision_setter" json:"revision_setter"`
 	Admin                Admin                `yaml:"admin" json:"admin"`
 	AdhocMode            AdhocMode            `yaml:"adhoc_mode" json:"adhoc_mode"`
+	Audit                Audit                `yaml:"audit" json:"audit"`
 }
 
 type AdhocMode struct {
+	Owner string `yaml:"owner" json:"owner"`
 	Repo  string `yaml:"repo" json:"repo"`
 	PRNum int    `yaml:"pr_num" json:"pr_num"`
 }
 
 func (t AdhocMode) ToValid() valid.AdhocMode {
 	return valid.AdhocMode{
+		Owner: t.Owner,
 		Repo:  t.Repo,
 		PRNum: t.PRNum,
 	}
@@ -81,21 +84,25 @@ func (g Admin) ToValid() valid.Admin {
 
 // Repo is the raw schema for repos in the server-side repo config.
 type Repo struct {
-	ID                          string            `yaml:"id" json:"id"`
-	Branch                      string            `yaml:"branch" json:"branch"`
-	ApplyRequirements           []string          `yaml:"apply_requirements" json:"apply_requirements"`
-	PreWorkflowHooks            []PreWorkflowHook `yaml:"pre_workflow_hooks" json:"pre_workflow_hooks"`
-	Workflow                    *string           `yaml:"workflow,omitempty" json:"workflow,omitempty"`
-	PullRequestWorkflow         *string           `yaml:"pull_request_workflow,omitempty" json:"pull_request_workflow,omitempty"`
-	DeploymentWorkflow          *string           `yaml:"deployment_workflow,omitempty" json:"deployment_workflow,omitempty"`
-	AllowedWorkflows            []string          `yaml:"allowed_workflows,omitempty" json:"allowed_workflows,omitempty"`
-	AllowedPullRequestWorkflows []string          `yaml:"allowed_pull_request_workflows,omitempty" json:"allowed_pull_request_workflows,omitempty"`
-	AllowedDeploymentWorkflows  []string          `yaml:"allowed_deployment_workflows,omitempty" json:"allowed_deployment_workflows,omitempty"`
-	AllowedOverrides            []string          `yaml:"allowed_overrides" json:"allowed_overrides"`
-	AllowCustomWorkflows        *bool             `yaml:"allow_custom_workflows,omitempty" json:"allow_custom_workflows,omitempty"`
-	TemplateOverrides           map[string]string `yaml:"template_overrides,omitempty" json:"template_overrides,omitempty"`
-	CheckoutStrategy            string            `yaml:"checkout_strategy,omitempty" json:"checkout_strategy,omitempty"`
-	ApplySettings               ApplySettings     `yaml:"apply_settings" json:"apply_settings"`
+	ID                          string              `yaml:"id" json:"id"`
+	Branch                      string              `yaml:"branch" json:"branch"`
+	ApplyRequirements           []string            `yaml:"apply_requirements" json:"apply_requirements"`
+	PreWorkflowHooks            []PreWorkflowHook   `yaml:"pre_workflow_hooks" json:"pre_workflow_hooks"`
+	Workflow                    *string             `yaml:"workflow,omitempty" json:"workflow,omitempty"`
+	PullRequestWorkflow         *string             `yaml:"pull_request_workflow,omitempty" json:"pull_request_workflow,omitempty"`
+	DeploymentWorkflow          *string             `yaml:"deployment_workflow,omitempty" json:"deployment_workflow,omitempty"`
+	AllowedWorkflows            []string            `yaml:"allowed_workflows,omitempty" json:"allowed_workflows,omitempty"`
+	AllowedPullRequestWorkflows []string            `yaml:"allowed_pull_request_workflows,omitempty" json:"allowed_pull_request_workflows,omitempty"`
+	AllowedDeploymentWorkflows  []string            `yaml:"allowed_deployment_workflows,omitempty" json:"allowed_deployment_workflows,omitempty"`
+	AllowedOverrides            []string            `yaml:"allowed_overrides" json:"allowed_overrides"`
+	AllowCustomWorkflows        *bool               `yaml:"allow_custom_workflows,omitempty" json:"allow_custom_workflows,omitempty"`
+	TemplateOverrides           map[string]string   `yaml:"template_overrides,omitempty" json:"template_overrides,omitempty"`
+	CheckoutStrategy            string              `yaml:"checkout_strategy,omitempty" json:"checkout_strategy,omitempty"`
+	ApplySettings               ApplySettings       `yaml:"apply_settings" json:"apply_settings"`
+	DeployWebhooks              []DeployWebhook     `yaml:"deploy_webhooks,omitempty" json:"deploy_webhooks,omitempty"`
+	SlackNotifications          []SlackNotification `yaml:"slack_notifications,omitempty" json:"slack_notifications,omitempty"`
+	MaxConcurrentPlans          int                 `yaml:"max_concurrent_plans,omitempty" json:"max_concurrent_plans,omitempty"`
+	MaxConcurrentRepoPlans      int                 `yaml:"max_concurrent_repo_plans,omitempty" json:"max_concurrent_repo_plans,omitempty"`
 }
 
 func (g GlobalCfg) GetWorkflowNames() []string {
@@ -132,6 +139,7 @@ func (g GlobalCfg) Validate() error {
 		validation.Field(&g.Github),
 		validation.Field(&g.TerraformLogFilters),
 		validation.Field(&g.Persistence),
+		validation.Field(&g.Audit),
 	)
 	if err != nil {
 		return err
@@ -215,6 +223,7 @@ func (g GlobalCfg) ToValid(defaultCfg valid.GlobalCfg) valid.GlobalCfg {
 		Admin:                g.Admin.ToValid(),
 		RevisionSetter:       g.RevisionSetter.ToValid(),
 		AdhocMode:            g.AdhocMode.ToValid(),
+		Audit:                g.Audit.ToValid(),
 	}
 }
 
@@ -252,6 +261,7 @@ func (r Repo) Validate() error {
 		overrides := value.([]string)
 		for _, o := range overrides {
 			if o != valid.ApplyRequirementsKey &&
+				o != valid.ApplySettingsKey &&
 				o != valid.WorkflowKey &&
 				o != valid.PullRequestWorkflowKey &&
 				o != valid.DeploymentWorkflowKey {
@@ -277,6 +287,10 @@ func (r Repo) Validate() error {
 		validation.Field(&r.PullRequestWorkflow, validation.By(workflowExists)),
 		validation.Field(&r.DeploymentWorkflow, validation.By(workflowExists)),
 		validation.Field(&r.ApplySettings),
+		validation.Field(&r.DeployWebhooks),
+		validation.Field(&r.SlackNotifications),
+		validation.Field(&r.MaxConcurrentPlans, validation.Min(0)),
+		validation.Field(&r.MaxConcurrentRepoPlans, validation.Min(0)),
 	)
 }
 
@@ -347,6 +361,16 @@ OUTER:
 		checkoutStrategy = r.CheckoutStrategy
 	}
 
+	var deployWebhooks []valid.DeployWebhook
+	for _, w := range r.DeployWebhooks {
+		deployWebhooks = append(deployWebhooks, w.ToValid())
+	}
+
+	var slackNotifications []valid.SlackNotification
+	for _, n := range r.SlackNotifications {
+		slackNotifications = append(slackNotifications, n.ToValid())
+	}
+
 	return valid.Repo{
 		ID:                          id,
 		IDRegex:                     idRegex,
@@ -362,6 +386,10 @@ OUTER:
 		TemplateOverrides:           r.TemplateOverrides,
 		CheckoutStrategy:            checkoutStrategy,
 		ApplySettings:               r.ApplySettings.ToValid(),
+		DeployWebhooks:              deployWebhooks,
+		SlackNotifications:          slackNotifications,
+		MaxConcurrentPlans:          r.MaxConcurrentPlans,
+		MaxConcurrentRepoPlans:      r.MaxConcurrentRepoPlans,
 	}
 }
 
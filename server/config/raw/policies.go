@@ -1,25 +1,51 @@
 package raw
 
 import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/fileutils"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/hashicorp/go-version"
+	"github.com/pkg/errors"
 	"github.com/runatlantis/atlantis/server/config/valid"
 )
 
 // PolicySets is the raw schema for repo-level atlantis.yaml config.
 type PolicySets struct {
-	Version      *string     `yaml:"conftest_version,omitempty" json:"conftest_version,omitempty"`
-	PolicySets   []PolicySet `yaml:"policy_sets" json:"policy_sets"`
-	Organization string      `yaml:"organization" json:"organization"`
+	Version      *string           `yaml:"conftest_version,omitempty" json:"conftest_version,omitempty"`
+	PolicySets   []PolicySet       `yaml:"policy_sets" json:"policy_sets"`
+	Organization string            `yaml:"organization" json:"organization"`
+	Exceptions   []PolicyException `yaml:"exceptions,omitempty" json:"exceptions,omitempty"`
 }
 
 func (p PolicySets) Validate() error {
+	policySetExists := func(value interface{}) error {
+		for _, exception := range value.([]PolicyException) {
+			if !p.hasPolicySet(exception.PolicySet) {
+				return fmt.Errorf("policy set %q is not defined", exception.PolicySet)
+			}
+		}
+		return nil
+	}
 	return validation.ValidateStruct(&p,
 		validation.Field(&p.Version, validation.By(VersionValidator)),
 		validation.Field(&p.PolicySets, validation.Required.Error("cannot be empty; Declare policies that you would like to enforce")),
+		validation.Field(&p.Exceptions, validation.By(policySetExists)),
 	)
 }
 
+func (p PolicySets) hasPolicySet(name string) bool {
+	for _, policySet := range p.PolicySets {
+		if policySet.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (p PolicySets) ToValid() valid.PolicySets {
 	policySets := valid.PolicySets{}
 
@@ -35,20 +61,41 @@ func (p PolicySets) ToValid() valid.PolicySets {
 	}
 	policySets.PolicySets = validPolicySets
 
+	for _, rawException := range p.Exceptions {
+		policySets.Exceptions = append(policySets.Exceptions, rawException.ToValid())
+	}
+
 	return policySets
 }
 
 type PolicySet struct {
-	Name  string   `yaml:"name" json:"name"`
-	Owner string   `yaml:"owner,omitempty" json:"owner,omitempty"`
-	Paths []string `yaml:"paths" json:"paths"`
+	Name      string          `yaml:"name" json:"name"`
+	Owner     string          `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Paths     []string        `yaml:"paths" json:"paths"`
+	AppliesTo *PolicySetScope `yaml:"applies_to,omitempty" json:"applies_to,omitempty"`
+	Source    *PolicySource   `yaml:"source,omitempty" json:"source,omitempty"`
 }
 
 func (p PolicySet) Validate() error {
+	// paths are relative to the bundle of remote policy sets and default to its root
+	relativePaths := func(value interface{}) error {
+		for _, policyPath := range value.([]string) {
+			if path.IsAbs(policyPath) || strings.HasPrefix(path.Clean(policyPath), "..") {
+				return fmt.Errorf("%q must be relative to the policy bundle", policyPath)
+			}
+		}
+		return nil
+	}
+	pathRules := []validation.Rule{validation.Required.Error("is required")}
+	if p.Source != nil {
+		pathRules = []validation.Rule{validation.By(relativePaths)}
+	}
 	return validation.ValidateStruct(&p,
 		validation.Field(&p.Name, validation.Required.Error("is required")),
 		validation.Field(&p.Owner, validation.Required.Error("is required")),
-		validation.Field(&p.Paths, validation.Required.Error("is required")),
+		validation.Field(&p.Paths, pathRules...),
+		validation.Field(&p.AppliesTo),
+		validation.Field(&p.Source),
 	)
 }
 
@@ -58,6 +105,143 @@ func (p PolicySet) ToValid() valid.PolicySet {
 	policySet.Name = p.Name
 	policySet.Paths = p.Paths
 	policySet.Owner = p.Owner
+	if p.AppliesTo != nil {
+		policySet.AppliesTo = p.AppliesTo.ToValid()
+	}
+	if p.Source != nil {
+		source := p.Source.ToValid()
+		policySet.Source = &source
+	}
 
 	return policySet
 }
+
+// PolicySource references a versioned policy bundle, either from a git repository or an OCI registry
+type PolicySource struct {
+	Git     string `yaml:"git,omitempty" json:"git,omitempty"`
+	OCI     string `yaml:"oci,omitempty" json:"oci,omitempty"`
+	Version string `yaml:"version" json:"version"`
+}
+
+func (s PolicySource) Validate() error {
+	oneOf := func(value interface{}) error {
+		if (s.Git == "") == (s.OCI == "") {
+			return errors.New("exactly one of git or oci must be set")
+		}
+		return nil
+	}
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Git, validation.By(oneOf)),
+		validation.Field(&s.Version, validation.Required.Error("is required")),
+	)
+}
+
+func (s PolicySource) ToValid() valid.PolicySource {
+	if s.OCI != "" {
+		return valid.PolicySource{
+			Type:    valid.OCIPolicySource,
+			URL:     s.OCI,
+			Version: s.Version,
+		}
+	}
+	return valid.PolicySource{
+		Type:    valid.GitPolicySource,
+		URL:     s.Git,
+		Version: s.Version,
+	}
+}
+
+// PolicySetScope limits the roots a policy set is run against
+type PolicySetScope struct {
+	Repos []string          `yaml:"repos,omitempty" json:"repos,omitempty"`
+	Roots []string          `yaml:"roots,omitempty" json:"roots,omitempty"`
+	Paths []string          `yaml:"paths,omitempty" json:"paths,omitempty"`
+	Tags  map[string]string `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+func (s PolicySetScope) Validate() error {
+	globs := func(value interface{}) error {
+		for _, pattern := range value.([]string) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return errors.Wrapf(err, "invalid pattern %q", pattern)
+			}
+		}
+		return nil
+	}
+	paths := func(value interface{}) error {
+		if _, err := fileutils.NewPatternMatcher(value.([]string)); err != nil {
+			return errors.Wrap(err, "invalid pattern")
+		}
+		return nil
+	}
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Repos, validation.By(globs)),
+		validation.Field(&s.Roots, validation.By(globs)),
+		validation.Field(&s.Paths, validation.By(paths)),
+	)
+}
+
+func (s PolicySetScope) ToValid() valid.PolicySetScope {
+	return valid.PolicySetScope{
+		Repos: s.Repos,
+		Roots: s.Roots,
+		Paths: s.Paths,
+		Tags:  s.Tags,
+	}
+}
+
+// PolicyException bypasses failures of a policy set for matching roots until the end of its expiry date
+type PolicyException struct {
+	PolicySet     string `yaml:"policy_set" json:"policy_set"`
+	Repo          string `yaml:"repo" json:"repo"`
+	Root          string `yaml:"root,omitempty" json:"root,omitempty"`
+	Resource      string `yaml:"resource,omitempty" json:"resource,omitempty"`
+	Justification string `yaml:"justification" json:"justification"`
+	Owner         string `yaml:"owner" json:"owner"`
+	Expiry        string `yaml:"expiry" json:"expiry"`
+}
+
+func (e PolicyException) Validate() error {
+	glob := func(value interface{}) error {
+		pattern := value.(string)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(err, "invalid pattern %q", pattern)
+		}
+		return nil
+	}
+	date := func(value interface{}) error {
+		if _, err := time.Parse(valid.PolicyExceptionExpiryFormat, value.(string)); err != nil {
+			return fmt.Errorf("must be formatted as %s", valid.PolicyExceptionExpiryFormat)
+		}
+		return nil
+	}
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.PolicySet, validation.Required.Error("is required")),
+		validation.Field(&e.Repo, validation.Required.Error("is required"), validation.By(glob)),
+		validation.Field(&e.Root, validation.By(glob)),
+		validation.Field(&e.Resource, validation.By(glob)),
+		validation.Field(&e.Justification, validation.Required.Error("is required")),
+		validation.Field(&e.Owner, validation.Required.Error("is required")),
+		validation.Field(&e.Expiry, validation.Required.Error("is required"), validation.By(date)),
+	)
+}
+
+func (e PolicyException) ToValid() valid.PolicyException {
+	// validated beforehand
+	expiry, _ := time.Parse(valid.PolicyExceptionExpiryFormat, e.Expiry)
+
+	root := e.Root
+	if root == "" {
+		root = "*"
+	}
+
+	return valid.PolicyException{
+		PolicySet:     e.PolicySet,
+		Repo:          e.Repo,
+		Root:          root,
+		Resource:      e.Resource,
+		Justification: e.Justification,
+		Owner:         e.Owner,
+		Expiry:        expiry,
+	}
+}
@@ -2,6 +2,7 @@ package raw_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/runatlantis/atlantis/server/config/raw"
@@ -138,6 +139,99 @@ func TestPolicySets_Validate(t *testing.T) {
 			},
 			expErr: "conftest_version: version \"version123\" could not be parsed: Malformed version: version123.",
 		},
+		{
+			description: "invalid scope pattern",
+			input: raw.PolicySets{
+				PolicySets: []raw.PolicySet{
+					{
+						Name:  "policy-name-1",
+						Owner: "owner1",
+						Paths: []string{"rel/path/to/source"},
+						AppliesTo: &raw.PolicySetScope{
+							Repos: []string{"owner/[repo"},
+						},
+					},
+				},
+			},
+			expErr: "policy_sets: (0: (applies_to: (repos: invalid pattern \"owner/[repo\": syntax error in pattern.).).).",
+		},
+		{
+			description: "remote policy set without paths",
+			input: raw.PolicySets{
+				PolicySets: []raw.PolicySet{
+					{
+						Name:  "policy-name-1",
+						Owner: "owner1",
+						Source: &raw.PolicySource{
+							OCI:     "ghcr.io/owner/policies",
+							Version: "1.0.0",
+						},
+					},
+				},
+			},
+			expErr: "",
+		},
+		{
+			description: "remote policy set with ambiguous source",
+			input: raw.PolicySets{
+				PolicySets: []raw.PolicySet{
+					{
+						Name:  "policy-name-1",
+						Owner: "owner1",
+						Paths: []string{"../aws"},
+						Source: &raw.PolicySource{
+							Git: "github.com/owner/policies",
+							OCI: "ghcr.io/owner/policies",
+						},
+					},
+				},
+			},
+			expErr: "policy_sets: (0: (paths: \"../aws\" must be relative to the policy bundle; source: (git: exactly one of git or oci must be set; version: is required.).).).",
+		},
+		{
+			description: "exception for undefined policy set",
+			input: raw.PolicySets{
+				PolicySets: []raw.PolicySet{
+					{
+						Name:  "policy-name-1",
+						Owner: "owner1",
+						Paths: []string{"rel/path/to/source"},
+					},
+				},
+				Exceptions: []raw.PolicyException{
+					{
+						PolicySet:     "undefined",
+						Repo:          "owner/repo",
+						Justification: "legacy",
+						Owner:         "infra",
+						Expiry:        "2030-01-31",
+					},
+				},
+			},
+			expErr: "exceptions: policy set \"undefined\" is not defined.",
+		},
+		{
+			description: "exception with invalid expiry",
+			input: raw.PolicySets{
+				PolicySets: []raw.PolicySet{
+					{
+						Name:  "policy-name-1",
+						Owner: "owner1",
+						Paths: []string{"rel/path/to/source"},
+					},
+				},
+				Exceptions: []raw.PolicyException{
+					{
+						PolicySet:     "policy-name-1",
+						Repo:          "owner/repo",
+						Justification: "legacy",
+						Owner:         "infra",
+						Expiry:        "31/01/2030",
+					},
+				},
+			},
+			expErr: "exceptions: (0: (expiry: must be formatted as 2006-01-02.).).",
+		},
 	}
 
 	for _, c := range cases {
@@ -205,6 +299,120 @@ func TestPolicySets_ToValid(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "remote policies",
+			input: raw.PolicySets{
+				PolicySets: []raw.PolicySet{
+					{
+						Name:  "git-policy",
+						Paths: []string{"aws"},
+						Source: &raw.PolicySource{
+							Git:     "github.com/owner/policies//bundle",
+							Version: "v1.2.0",
+						},
+					},
+					{
+						Name: "oci-policy",
+						Source: &raw.PolicySource{
+							OCI:     "ghcr.io/owner/policies",
+							Version: "1.2.0",
+						},
+					},
+				},
+			},
+			exp: valid.PolicySets{
+				PolicySets: []valid.PolicySet{
+					{
+						Name:  "git-policy",
+						Paths: []string{"aws"},
+						Source: &valid.PolicySource{
+							Type:    valid.GitPolicySource,
+							URL:     "github.com/owner/policies//bundle",
+							Version: "v1.2.0",
+						},
+					},
+					{
+						Name: "oci-policy",
+						Source: &valid.PolicySource{
+							Type:    valid.OCIPolicySource,
+							URL:     "ghcr.io/owner/policies",
+							Version: "1.2.0",
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "scoped policies",
+			input: raw.PolicySets{
+				PolicySets: []raw.PolicySet{
+					{
+						Name:  "scoped-policy",
+						Paths: []string{"rel/path/to/source"},
+						AppliesTo: &raw.PolicySetScope{
+							Repos: []string{"owner/*"},
+							Roots: []string{"aws-*"},
+							Paths: []string{"infra/**"},
+							Tags:  map[string]string{"env": "prod"},
+						},
+					},
+				},
+			},
+			exp: valid.PolicySets{
+				PolicySets: []valid.PolicySet{
+					{
+						Name:  "scoped-policy",
+						Paths: []string{"rel/path/to/source"},
+						AppliesTo: valid.PolicySetScope{
+							Repos: []string{"owner/*"},
+							Roots: []string{"aws-*"},
+							Paths: []string{"infra/**"},
+							Tags:  map[string]string{"env": "prod"},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "policy exceptions",
+			input: raw.PolicySets{
+				PolicySets: []raw.PolicySet{
+					{
+						Name:  "good-policy",
+						Paths: []string{"rel/path/to/source"},
+					},
+				},
+				Exceptions: []raw.PolicyException{
+					{
+						PolicySet:     "good-policy",
+						Repo:          "owner/repo",
+						Resource:      "aws_iam_role.legacy_*",
+						Justification: "legacy roles are being migrated",
+						Owner:         "infra",
+						Expiry:        "2030-01-31",
+					},
+				},
+			},
+			exp: valid.PolicySets{
+				PolicySets: []valid.PolicySet{
+					{
+						Name:  "good-policy",
+						Paths: []string{"rel/path/to/source"},
+					},
+				},
+				Exceptions: []valid.PolicyException{
+					{
+						PolicySet:     "good-policy",
+						Repo:          "owner/repo",
+						Root:          "*",
+						Resource:      "aws_iam_role.legacy_*",
+						Justification: "legacy roles are being migrated",
+						Owner:         "infra",
+						Expiry:        time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
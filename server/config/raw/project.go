@@ -41,6 +41,11 @@ type Project struct {
 	Autoplan                *Autoplan         `yaml:"autoplan,omitempty"`
 	ApplyRequirements       []string          `yaml:"apply_requirements,omitempty"`
 	Tags                    map[string]string `yaml:"tags,omitempty"`
+	// Owners take precedence over the repo's CODEOWNERS for the root, they are only read
+	// from the default branch so PRs can't change them.
+	Owners []string `yaml:"owners,omitempty"`
+	// ApplySettings override the repo's apply settings for the root
+	ApplySettings *ApplySettings `yaml:"apply_settings,omitempty"`
 	// Deprecated
 	WorkflowModeType *string `yaml:"workflow_mode_type,omitempty"`
 }
@@ -71,6 +76,7 @@ func (p Project) Validate() error {
 		validation.Field(&p.ApplyRequirements, validation.By(validApplyReq)),
 		validation.Field(&p.TerraformVersion, validation.By(VersionValidator)),
 		validation.Field(&p.Name, validation.By(validName)),
+		validation.Field(&p.ApplySettings),
 	)
 }
 
@@ -109,10 +115,24 @@ func (p Project) ToValid(defaultWorkflowModeType valid.WorkflowModeType) valid.P
 
 	v.Tags = p.Tags
 	v.Name = p.Name
+	v.Owners = normalizeOwners(p.Owners)
+	if p.ApplySettings != nil {
+		applySettings := p.ApplySettings.ToOverride()
+		v.ApplySettings = &applySettings
+	}
 
 	return v
 }
 
+// normalizeOwners strips the leading @ so owners can be written as they are in CODEOWNERS.
+func normalizeOwners(owners []string) []string {
+	var normalized []string
+	for _, o := range owners {
+		normalized = append(normalized, strings.TrimPrefix(o, "@"))
+	}
+	return normalized
+}
+
 // validProjectName returns true if the project name is valid.
 // Since the name might be used in URLs and definitely in files we don't
 // support any characters that must be url escaped *except* for '/' because
@@ -441,6 +441,29 @@ func TestProject_ToValid(t *testing.T) {
 				WorkflowModeType: valid.PlatformWorkflowMode,
 			},
 		},
+		{
+			description: "apply settings leave unset fields empty",
+			input: raw.Project{
+				Dir: String("."),
+				ApplySettings: &raw.ApplySettings{
+					Team:         "sre",
+					MinApprovals: 2,
+				},
+			},
+			exp: valid.Project{
+				Dir:       ".",
+				Workspace: "default",
+				Autoplan: valid.Autoplan{
+					WhenModified: []string{"**/*.tf*", "**/terragrunt.hcl"},
+					Enabled:      true,
+				},
+				WorkflowModeType: valid.PlatformWorkflowMode,
+				ApplySettings: &valid.ApplySettings{
+					Team:         "sre",
+					MinApprovals: 2,
+				},
+			},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.description, func(t *testing.T) {
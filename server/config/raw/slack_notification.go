@@ -0,0 +1,53 @@
+package raw
+
+import (
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/runatlantis/atlantis/server/config/valid"
+)
+
+type SlackNotification struct {
+	ChannelID string   `yaml:"channel_id" json:"channel_id"`
+	Roots     []string `yaml:"roots" json:"roots"`
+	Events    []string `yaml:"events" json:"events"`
+}
+
+func (n SlackNotification) Validate() error {
+	validEvents := func(value interface{}) error {
+		events := value.([]string)
+		for _, e := range events {
+			if !isDeploySlackEvent(e) {
+				return fmt.Errorf("%q is not a valid slack notification event", e)
+			}
+		}
+		return nil
+	}
+
+	return validation.ValidateStruct(&n,
+		validation.Field(&n.ChannelID, validation.Required),
+		validation.Field(&n.Events, validation.By(validEvents)),
+	)
+}
+
+func (n SlackNotification) ToValid() valid.SlackNotification {
+	var events []valid.DeploySlackEvent
+	for _, e := range n.Events {
+		events = append(events, valid.DeploySlackEvent(e))
+	}
+
+	return valid.SlackNotification{
+		ChannelID: n.ChannelID,
+		Roots:     n.Roots,
+		Events:    events,
+	}
+}
+
+func isDeploySlackEvent(event string) bool {
+	for _, e := range valid.DeploySlackEvents {
+		if string(e) == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package raw_test
+
+import (
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/config/raw"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestSlackNotification_Unmarshal(t *testing.T) {
+	rawYaml := `
+channel_id: C123
+roots:
+  - root
+events:
+  - started
+  - drifted
+`
+
+	var result raw.SlackNotification
+
+	err := yaml.UnmarshalStrict([]byte(rawYaml), &result)
+	assert.NoError(t, err)
+	assert.Equal(t, raw.SlackNotification{
+		ChannelID: "C123",
+		Roots:     []string{"root"},
+		Events:    []string{"started", "drifted"},
+	}, result)
+}
+
+func TestSlackNotification_Validate(t *testing.T) {
+	cases := []struct {
+		description string
+		subject     raw.SlackNotification
+		expectErr   bool
+	}{
+		{
+			description: "minimal",
+			subject:     raw.SlackNotification{ChannelID: "C123"},
+		},
+		{
+			description: "all events",
+			subject: raw.SlackNotification{
+				ChannelID: "C123",
+				Events:    []string{"started", "awaiting_confirmation", "applied", "failed", "drifted"},
+			},
+		},
+		{
+			description: "invalid event",
+			subject: raw.SlackNotification{
+				ChannelID: "C123",
+				Events:    []string{"queued"},
+			},
+			expectErr: true,
+		},
+		{
+			description: "missing channel",
+			subject:     raw.SlackNotification{},
+			expectErr:   true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			err := c.subject.Validate()
+			if c.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSlackNotification_ToValid(t *testing.T) {
+	subject := raw.SlackNotification{
+		ChannelID: "C123",
+		Roots:     []string{"root"},
+		Events:    []string{"applied"},
+	}
+
+	assert.Equal(t, valid.SlackNotification{
+		ChannelID: "C123",
+		Roots:     []string{"root"},
+		Events:    []valid.DeploySlackEvent{valid.AppliedDeploySlackEvent},
+	}, subject.ToValid())
+}
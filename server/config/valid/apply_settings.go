@@ -11,6 +11,49 @@ type ApplySettings struct {
 	PRRequirements    []string
 	BranchRestriction BranchRestriction
 	Team              string
+	// MinApprovals is the number of distinct approvals required by the approved pr requirement,
+	// a single approval is required when unset.
+	MinApprovals int
+	// DismissStaleApprovals ignores approvals of commits other than the pull's head.
+	DismissStaleApprovals bool
+	// TwoPersonRule requires force applies and manual API deploys to be confirmed at the
+	// plan review gate by someone other than the requester.
+	TwoPersonRule bool
+	// RequiredChecks are the check run names or commit status contexts which must be
+	// successful on the deployed revision.
+	RequiredChecks []string
+}
+
+// RequiredApprovals returns the number of approvals needed to satisfy the approved pr requirement.
+func (s ApplySettings) RequiredApprovals() int {
+	if s.MinApprovals > 0 {
+		return s.MinApprovals
+	}
+	return 1
+}
+
+// Override returns a copy of the settings with every field set in root applied on top.
+// Since unset and false are indistinguishable, roots can only enable stale approval dismissal
+// and the two person rule.
+func (s ApplySettings) Override(root ApplySettings) ApplySettings {
+	if root.PRRequirements != nil {
+		s.PRRequirements = root.PRRequirements
+	}
+	if root.BranchRestriction != "" {
+		s.BranchRestriction = root.BranchRestriction
+	}
+	if root.Team != "" {
+		s.Team = root.Team
+	}
+	if root.RequiredChecks != nil {
+		s.RequiredChecks = root.RequiredChecks
+	}
+	if root.MinApprovals != 0 {
+		s.MinApprovals = root.MinApprovals
+	}
+	s.DismissStaleApprovals = s.DismissStaleApprovals || root.DismissStaleApprovals
+	s.TwoPersonRule = s.TwoPersonRule || root.TwoPersonRule
+	return s
 }
 
 func (s ApplySettings) ContainsPRRequirement(req string) bool {
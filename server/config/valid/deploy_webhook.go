@@ -0,0 +1,57 @@
+package valid
+
+type DeployWebhookEvent string
+
+const (
+	QueuedDeployWebhookEvent           DeployWebhookEvent = "queued"
+	PlannedDeployWebhookEvent          DeployWebhookEvent = "planned"
+	AwaitingApprovalDeployWebhookEvent DeployWebhookEvent = "awaiting_approval"
+	AppliedDeployWebhookEvent          DeployWebhookEvent = "applied"
+	FailedDeployWebhookEvent           DeployWebhookEvent = "failed"
+)
+
+var DeployWebhookEvents = []DeployWebhookEvent{
+	QueuedDeployWebhookEvent,
+	PlannedDeployWebhookEvent,
+	AwaitingApprovalDeployWebhookEvent,
+	AppliedDeployWebhookEvent,
+	FailedDeployWebhookEvent,
+}
+
+// DeployWebhook is an outbound webhook which is called for deploy lifecycle events.
+// Empty Roots or Events match everything.
+type DeployWebhook struct {
+	URL    string
+	Secret string
+	Roots  []string
+	Events []DeployWebhookEvent
+}
+
+// Matches returns true if this webhook should be called for the given root and event.
+func (w DeployWebhook) Matches(rootName string, event DeployWebhookEvent) bool {
+	return w.matchesRoot(rootName) && w.matchesEvent(event)
+}
+
+func (w DeployWebhook) matchesRoot(rootName string) bool {
+	if len(w.Roots) == 0 {
+		return true
+	}
+	for _, r := range w.Roots {
+		if r == rootName {
+			return true
+		}
+	}
+	return false
+}
+
+func (w DeployWebhook) matchesEvent(event DeployWebhookEvent) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -18,10 +18,12 @@ const (
 
 const MergeableApplyReq = "mergeable"
 const ApprovedApplyReq = "approved"
+const OwnersApplyReq = "owners"
 const UnDivergedApplyReq = "undiverged"
 const SQUnlockedApplyReq = "unlocked"
 const PoliciesPassedApplyReq = "policies_passed"
 const ApplyRequirementsKey = "apply_requirements"
+const ApplySettingsKey = "apply_settings"
 const WorkflowKey = "workflow"
 const PullRequestWorkflowKey = "pull_request_workflow"
 const DeploymentWorkflowKey = "deployment_workflow"
@@ -64,9 +66,11 @@ type GlobalCfg struct {
 	RevisionSetter       RevisionSetter
 	Admin                Admin
 	AdhocMode            AdhocMode
+	Audit                Audit
 }
 
 type AdhocMode struct {
+	Owner string
 	Repo  string
 	PRNum int
 }
@@ -150,6 +154,8 @@ type Temporal struct {
 	TerraformTaskQueue string
 }
 
+// Github holds the default installations of each app.  These are only used for requests which
+// aren't scoped to a repository, repo requests use the installation of the repo's owner.
 type Github struct {
 	GatewayAppInstallationID  int64
 	TemporalAppInstallationID int64
@@ -159,6 +165,34 @@ type TerraformLogFilters struct {
 	Regexes []*regexp.Regexp
 }
 
+type AuditSinkType string
+
+const (
+	FileAuditSink    AuditSinkType = "file"
+	WebhookAuditSink AuditSinkType = "webhook"
+	SNSAuditSink     AuditSinkType = "sns"
+)
+
+type Audit struct {
+	Sinks []AuditSink
+}
+
+// AuditSink holds the settings for a single audit sink, only the fields
+// relevant to Type are populated.
+type AuditSink struct {
+	Type AuditSinkType
+
+	// file
+	Path string
+
+	// webhook
+	URL    string
+	Secret string
+
+	// sns
+	TopicArn string
+}
+
 type BasicAuth struct {
 	Username string
 	Password string
@@ -191,6 +225,25 @@ type MergedProjectCfg struct {
 	RepoCfgVersion      int
 	PolicySets          PolicySets
 	Tags                map[string]string
+	SlackNotifications  []SlackNotification
+	// ApplySettings are the repo's apply settings with any overrides of the root applied.
+	ApplySettings ApplySettings
+	// Owners are the github users and org/team slugs which must approve changes to the root,
+	// only populated for repos with the owners pr requirement. They are resolved from the default
+	// branch rather than merged from the root's config, see config.Builder.
+	Owners []string
+	// PolicyTarget is the root as configured on the default branch, only populated if any policy
+	// set is scoped. Nil for roots which aren't on the default branch yet.
+	PolicyTarget *PolicyTarget
+	// MaxConcurrentPlans caps the number of roots planned at once for a single PR,
+	// 0 means there is no limit.
+	MaxConcurrentPlans int
+	// MaxConcurrentRepoPlans caps the number of roots planned at once across all PRs
+	// of the repo, 0 means there is no limit.
+	MaxConcurrentRepoPlans int
+	// Fingerprint identifies the contents of the root and its local modules at a commit,
+	// only populated for PR builds.
+	Fingerprint string
 }
 
 // PreWorkflowHook is a map of custom run commands to run before workflows.
@@ -322,6 +375,8 @@ func (g GlobalCfg) MergeProjectCfg(repoID string, proj Project, rCfg RepoCfg) Me
 	pullRequestWorkflow = *repo.PullRequestWorkflow
 	deploymentWorkflow = *repo.DeploymentWorkflow
 
+	applySettings := g.RootApplySettings(repoID, &proj)
+
 	// If repos are allowed to override certain keys then override them.
 	for _, key := range repo.AllowedOverrides {
 		switch key {
@@ -352,36 +407,64 @@ func (g GlobalCfg) MergeProjectCfg(repoID string, proj Project, rCfg RepoCfg) Me
 	}
 
 	return MergedProjectCfg{
-		ApplyRequirements:   applyReqs,
-		PullRequestWorkflow: pullRequestWorkflow,
-		DeploymentWorkflow:  deploymentWorkflow,
-		RepoRelDir:          proj.Dir,
-		Workspace:           proj.Workspace,
-		Name:                proj.GetName(),
-		AutoplanEnabled:     proj.Autoplan.Enabled,
-		WhenModified:        proj.Autoplan.WhenModified,
-		TerraformVersion:    proj.TerraformVersion,
-		RepoCfgVersion:      rCfg.Version,
-		PolicySets:          g.PolicySets,
-		Tags:                proj.Tags,
+		ApplyRequirements:      applyReqs,
+		PullRequestWorkflow:    pullRequestWorkflow,
+		DeploymentWorkflow:     deploymentWorkflow,
+		RepoRelDir:             proj.Dir,
+		Workspace:              proj.Workspace,
+		Name:                   proj.GetName(),
+		AutoplanEnabled:        proj.Autoplan.Enabled,
+		WhenModified:           proj.Autoplan.WhenModified,
+		TerraformVersion:       proj.TerraformVersion,
+		RepoCfgVersion:         rCfg.Version,
+		PolicySets:             g.PolicySets,
+		Tags:                   proj.Tags,
+		SlackNotifications:     repo.slackNotificationsFor(proj.GetName()),
+		ApplySettings:          applySettings,
+		MaxConcurrentPlans:     repo.MaxConcurrentPlans,
+		MaxConcurrentRepoPlans: repo.MaxConcurrentRepoPlans,
 	}
 }
 
+// RootApplySettings returns the repo's apply settings with the overrides of the root applied if the
+// repo allows them, root is nil if the root's overrides aren't known.
+func (g GlobalCfg) RootApplySettings(repoID string, root *Project) ApplySettings {
+	var applySettings ApplySettings
+	if match := g.MatchingRepo(repoID); match != nil {
+		applySettings = match.ApplySettings
+	}
+	if root == nil || root.ApplySettings == nil || !g.AllowsOverride(repoID, ApplySettingsKey) {
+		return applySettings
+	}
+	return applySettings.Override(*root.ApplySettings)
+}
+
+// AllowsOverride returns true if the repo config of the repo is allowed to override the key
+func (g GlobalCfg) AllowsOverride(repoID string, key string) bool {
+	return sliceContains(g.foldMatchingRepos(repoID).AllowedOverrides, key)
+}
+
 // DefaultProjCfg returns the default project config for all projects under the
 // repo with id repoID. It is used when there is no repo config.
 func (g GlobalCfg) DefaultProjCfg(log logging.Logger, repoID string, repoRelDir string, workspace string) MergedProjectCfg {
 	repo := g.foldMatchingRepos(repoID)
 
 	mrgPrj := MergedProjectCfg{
-		ApplyRequirements:   repo.ApplyRequirements,
-		PullRequestWorkflow: *repo.PullRequestWorkflow,
-		DeploymentWorkflow:  *repo.DeploymentWorkflow,
-		RepoRelDir:          repoRelDir,
-		Workspace:           workspace,
-		Name:                "",
-		AutoplanEnabled:     DefaultAutoPlanEnabled,
-		TerraformVersion:    nil,
-		PolicySets:          g.PolicySets,
+		ApplyRequirements:      repo.ApplyRequirements,
+		PullRequestWorkflow:    *repo.PullRequestWorkflow,
+		DeploymentWorkflow:     *repo.DeploymentWorkflow,
+		RepoRelDir:             repoRelDir,
+		Workspace:              workspace,
+		Name:                   "",
+		AutoplanEnabled:        DefaultAutoPlanEnabled,
+		TerraformVersion:       nil,
+		PolicySets:             g.PolicySets,
+		MaxConcurrentPlans:     repo.MaxConcurrentPlans,
+		MaxConcurrentRepoPlans: repo.MaxConcurrentRepoPlans,
+	}
+
+	if match := g.MatchingRepo(repoID); match != nil {
+		mrgPrj.ApplySettings = match.ApplySettings
 	}
 
 	return mrgPrj
@@ -414,6 +497,15 @@ func (g GlobalCfg) foldMatchingRepos(repoID string) Repo {
 			if repo.AllowedOverrides != nil {
 				foldedRepo.AllowedOverrides = repo.AllowedOverrides
 			}
+			if repo.SlackNotifications != nil {
+				foldedRepo.SlackNotifications = repo.SlackNotifications
+			}
+			if repo.MaxConcurrentPlans != 0 {
+				foldedRepo.MaxConcurrentPlans = repo.MaxConcurrentPlans
+			}
+			if repo.MaxConcurrentRepoPlans != 0 {
+				foldedRepo.MaxConcurrentRepoPlans = repo.MaxConcurrentRepoPlans
+			}
 		}
 	}
 
@@ -319,6 +319,7 @@ policies:
 				WorkflowName: String("custom"),
 			},
 			exp: valid.MergedProjectCfg{
+				ApplySettings:     valid.ApplySettings{BranchRestriction: valid.DefaultBranchRestriction},
 				ApplyRequirements: []string{"policies_passed"},
 				PullRequestWorkflow: valid.Workflow{
 					Name:        "default",
@@ -362,6 +363,7 @@ policies:
 				WorkflowName: String("custom"),
 			},
 			exp: valid.MergedProjectCfg{
+				ApplySettings:     valid.ApplySettings{BranchRestriction: valid.DefaultBranchRestriction},
 				ApplyRequirements: []string{"policies_passed"},
 				PullRequestWorkflow: valid.Workflow{
 					Name:        "default",
@@ -443,6 +445,7 @@ deployment_workflows:
 				DeploymentWorkflowName:  String("custom"),
 			},
 			exp: valid.MergedProjectCfg{
+				ApplySettings:     valid.ApplySettings{BranchRestriction: valid.DefaultBranchRestriction},
 				ApplyRequirements: []string{},
 				PullRequestWorkflow: valid.Workflow{
 					Name:        "custom",
@@ -487,6 +490,7 @@ repos:
 				ApplyRequirements: []string{"mergeable"},
 			},
 			exp: valid.MergedProjectCfg{
+				ApplySettings:     valid.ApplySettings{BranchRestriction: valid.DefaultBranchRestriction},
 				ApplyRequirements: []string{"mergeable"},
 				PullRequestWorkflow: valid.Workflow{
 					Name:        "default",
@@ -522,6 +526,7 @@ repos:
 				Name:      String("myname"),
 			},
 			exp: valid.MergedProjectCfg{
+				ApplySettings:     valid.ApplySettings{BranchRestriction: valid.DefaultBranchRestriction},
 				ApplyRequirements: []string{"approved", "mergeable"},
 				PullRequestWorkflow: valid.Workflow{
 					Name:        "default",
@@ -553,6 +558,7 @@ repos:
 				},
 			},
 			exp: valid.MergedProjectCfg{
+				ApplySettings:     valid.ApplySettings{BranchRestriction: valid.DefaultBranchRestriction},
 				ApplyRequirements: []string{},
 				PullRequestWorkflow: valid.Workflow{
 					Name:        "default",
@@ -585,6 +591,7 @@ repos:
 				},
 			},
 			exp: valid.MergedProjectCfg{
+				ApplySettings:     valid.ApplySettings{BranchRestriction: valid.DefaultBranchRestriction},
 				ApplyRequirements: []string{},
 				PullRequestWorkflow: valid.Workflow{
 					Name:        "default",
@@ -618,6 +625,7 @@ repos:
 				WorkflowModeType: valid.PlatformWorkflowMode,
 			},
 			exp: valid.MergedProjectCfg{
+				ApplySettings:     valid.ApplySettings{BranchRestriction: valid.DefaultBranchRestriction},
 				ApplyRequirements: []string{},
 				PullRequestWorkflow: valid.Workflow{
 					Name:        "default",
@@ -637,6 +645,166 @@ repos:
 				PolicySets:      emptyPolicySets,
 			},
 		},
+		"every matching slack notification route": {
+			gCfg: `
+repos:
+- id: /.*/
+  slack_notifications:
+  - channel_id: C1
+  - channel_id: C2
+    roots: [myname]
+    events: [failed]
+  - channel_id: C3
+    roots: [othername]`,
+			repoID: "github.com/owner/repo",
+			proj: valid.Project{
+				Dir:       "mydir",
+				Workspace: "myworkspace",
+				Name:      String("myname"),
+			},
+			exp: valid.MergedProjectCfg{
+				ApplySettings:     valid.ApplySettings{BranchRestriction: valid.DefaultBranchRestriction},
+				ApplyRequirements: []string{},
+				PullRequestWorkflow: valid.Workflow{
+					Name:        "default",
+					PolicyCheck: valid.DefaultPolicyCheckStage,
+					Plan:        valid.DefaultLocklessPlanStage,
+				},
+				DeploymentWorkflow: valid.Workflow{
+					Name:  "default",
+					Apply: valid.DefaultApplyStage,
+					Plan:  valid.DefaultPlanStage,
+				},
+				RepoRelDir: "mydir",
+				Workspace:  "myworkspace",
+				Name:       "myname",
+				PolicySets: emptyPolicySets,
+				SlackNotifications: []valid.SlackNotification{
+					{
+						ChannelID: "C1",
+					},
+					{
+						ChannelID: "C2",
+						Roots:     []string{"myname"},
+						Events:    []valid.DeploySlackEvent{valid.FailedDeploySlackEvent},
+					},
+				},
+			},
+		},
+		"root apply settings override the repo's": {
+			gCfg: `
+repos:
+- id: /.*/
+  allowed_overrides: [apply_settings]
+  apply_settings:
+    team: eng
+    pr_requirements: [approved]`,
+			repoID: "github.com/owner/repo",
+			proj: valid.Project{
+				Dir:       "mydir",
+				Workspace: "myworkspace",
+				Name:      String("myname"),
+				ApplySettings: &valid.ApplySettings{
+					Team:                  "sre",
+					MinApprovals:          2,
+					DismissStaleApprovals: true,
+				},
+			},
+			exp: valid.MergedProjectCfg{
+				ApplySettings: valid.ApplySettings{
+					PRRequirements:        []string{"approved"},
+					BranchRestriction:     valid.DefaultBranchRestriction,
+					Team:                  "sre",
+					MinApprovals:          2,
+					DismissStaleApprovals: true,
+				},
+				ApplyRequirements: []string{},
+				PullRequestWorkflow: valid.Workflow{
+					Name:        "default",
+					PolicyCheck: valid.DefaultPolicyCheckStage,
+					Plan:        valid.DefaultLocklessPlanStage,
+				},
+				DeploymentWorkflow: valid.Workflow{
+					Name:  "default",
+					Apply: valid.DefaultApplyStage,
+					Plan:  valid.DefaultPlanStage,
+				},
+				RepoRelDir: "mydir",
+				Workspace:  "myworkspace",
+				Name:       "myname",
+				PolicySets: emptyPolicySets,
+			},
+		},
+		"max concurrent plans from the last matching repo": {
+			gCfg: `
+repos:
+- id: /.*/
+  max_concurrent_plans: 10
+  max_concurrent_repo_plans: 20
+- id: github.com/owner/repo
+  max_concurrent_plans: 4`,
+			repoID: "github.com/owner/repo",
+			proj: valid.Project{
+				Dir:       "mydir",
+				Workspace: "myworkspace",
+				Name:      String("myname"),
+			},
+			exp: valid.MergedProjectCfg{
+				ApplySettings:     valid.ApplySettings{BranchRestriction: valid.DefaultBranchRestriction},
+				ApplyRequirements: []string{},
+				PullRequestWorkflow: valid.Workflow{
+					Name:        "default",
+					PolicyCheck: valid.DefaultPolicyCheckStage,
+					Plan:        valid.DefaultLocklessPlanStage,
+				},
+				DeploymentWorkflow: valid.Workflow{
+					Name:  "default",
+					Apply: valid.DefaultApplyStage,
+					Plan:  valid.DefaultPlanStage,
+				},
+				RepoRelDir:             "mydir",
+				Workspace:              "myworkspace",
+				Name:                   "myname",
+				PolicySets:             emptyPolicySets,
+				MaxConcurrentPlans:     4,
+				MaxConcurrentRepoPlans: 20,
+			},
+		},
+		"root apply settings ignored when not allowed": {
+			gCfg: `
+repos:
+- id: /.*/
+  apply_settings:
+    team: eng`,
+			repoID: "github.com/owner/repo",
+			proj: valid.Project{
+				Dir:           "mydir",
+				Workspace:     "myworkspace",
+				Name:          String("myname"),
+				ApplySettings: &valid.ApplySettings{Team: "sre"},
+			},
+			exp: valid.MergedProjectCfg{
+				ApplySettings: valid.ApplySettings{
+					BranchRestriction: valid.DefaultBranchRestriction,
+					Team:              "eng",
+				},
+				ApplyRequirements: []string{},
+				PullRequestWorkflow: valid.Workflow{
+					Name:        "default",
+					PolicyCheck: valid.DefaultPolicyCheckStage,
+					Plan:        valid.DefaultLocklessPlanStage,
+				},
+				DeploymentWorkflow: valid.Workflow{
+					Name:  "default",
+					Apply: valid.DefaultApplyStage,
+					Plan:  valid.DefaultPlanStage,
+				},
+				RepoRelDir: "mydir",
+				Workspace:  "myworkspace",
+				Name:       "myname",
+				PolicySets: emptyPolicySets,
+			},
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -22,6 +22,26 @@ type Repo struct {
 	TemplateOverrides           map[string]string
 	CheckoutStrategy            string
 	ApplySettings               ApplySettings
+	DeployWebhooks              []DeployWebhook
+	SlackNotifications          []SlackNotification
+	// MaxConcurrentPlans caps the number of roots planned at once for a single PR,
+	// 0 means there is no limit.
+	MaxConcurrentPlans int
+	// MaxConcurrentRepoPlans caps the number of roots planned at once across all PRs
+	// of the repo, 0 means there is no limit.
+	MaxConcurrentRepoPlans int
+}
+
+// slackNotificationsFor returns every slack route matching rootName in the order
+// they're configured.
+func (r Repo) slackNotificationsFor(rootName string) []SlackNotification {
+	var result []SlackNotification
+	for _, n := range r.SlackNotifications {
+		if n.MatchesRoot(rootName) {
+			result = append(result, n)
+		}
+	}
+	return result
 }
 
 // IDMatches returns true if the repo ID otherID matches this config.
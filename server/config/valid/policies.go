@@ -1,6 +1,8 @@
 package valid
 
 import (
+	"time"
+
 	"github.com/hashicorp/go-version"
 )
 
@@ -9,6 +11,11 @@ const (
 	GithubPolicySet string = "github"
 )
 
+const (
+	GitPolicySource string = "git"
+	OCIPolicySource string = "oci"
+)
+
 // PolicySets defines version of policy checker binary(conftest) and a list of
 // PolicySet objects. PolicySets struct is used by PolicyCheck workflow to build
 // context to enforce policies.
@@ -16,6 +23,8 @@ type PolicySets struct {
 	Version      *version.Version
 	PolicySets   []PolicySet
 	Organization string // Github organization each policy set owner belongs to
+	// Exceptions bypass matching policy failures until they expire
+	Exceptions []PolicyException
 }
 
 type PolicyOwners struct {
@@ -26,8 +35,90 @@ type PolicySet struct {
 	Name  string
 	Owner string
 	Paths []string
+	// AppliesTo scopes the policy set to matching roots, an empty scope matches every root
+	AppliesTo PolicySetScope
+	// Source is the remote bundle the policies are fetched from, Paths are relative to the
+	// bundle if set and local to the worker otherwise
+	Source *PolicySource
+}
+
+// PolicySource is a versioned policy bundle hosted in a git repository or as an OCI artifact
+type PolicySource struct {
+	// Type is either GitPolicySource or OCIPolicySource
+	Type string
+	// URL is a go-getter git url (ie. github.com/org/policies//bundle) or an OCI repository
+	URL string
+	// Version is the git ref or the OCI tag of the bundle, it should be immutable since
+	// bundles are cached by version
+	Version string
+}
+
+// PolicySetScope matches roots by repo, name, path and tags.  A root must match every
+// non-empty field, and any of the values within a field.
+type PolicySetScope struct {
+	// Repos are glob patterns matched against the repo's full name
+	Repos []string
+	// Roots are glob patterns matched against the root's name
+	Roots []string
+	// Paths are patterns matched against the root's path relative to the repo
+	Paths []string
+	// Tags must all be set on the root with the same values
+	Tags map[string]string
 }
 
 func (p *PolicySets) HasPolicies() bool {
 	return len(p.PolicySets) > 0
 }
+
+// HasScopedPolicies returns true if any policy set only applies to some roots
+func (p *PolicySets) HasScopedPolicies() bool {
+	for _, policySet := range p.PolicySets {
+		if !policySet.AppliesTo.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty returns true if the scope matches every root
+func (s PolicySetScope) IsEmpty() bool {
+	return len(s.Repos) == 0 && len(s.Roots) == 0 && len(s.Paths) == 0 && len(s.Tags) == 0
+}
+
+// PolicyTarget are the attributes of a root policy set scopes are matched against, they are read
+// from the default branch since the PR's repo config is controlled by its author.
+type PolicyTarget struct {
+	Name string
+	// Path of the root relative to the repo
+	Path string
+	Tags map[string]string
+}
+
+// PolicyExceptionExpiryFormat is the layout of exception expiry dates
+const PolicyExceptionExpiryFormat = "2006-01-02"
+
+// PolicyException bypasses failures of a policy set for matching roots until it expires
+type PolicyException struct {
+	PolicySet string
+	// Repo and Root are glob patterns matched against the repo's full name and the root's name
+	Repo string
+	Root string
+	// Resource is an optional glob pattern, if set the exception only applies when every failure
+	// mentions a matching resource address
+	Resource      string
+	Justification string
+	Owner         string
+	// Expiry is the last day the exception applies
+	Expiry time.Time
+}
+
+// IsExpired returns true once the expiry date has passed
+func (e PolicyException) IsExpired(now time.Time) bool {
+	return PolicyExceptionExpired(e.Expiry, now)
+}
+
+// PolicyExceptionExpired returns true once the expiry date has passed, exceptions apply through
+// the whole of their expiry date
+func PolicyExceptionExpired(expiry time.Time, now time.Time) bool {
+	return !now.Before(expiry.AddDate(0, 0, 1))
+}
@@ -27,6 +27,9 @@ type Project struct {
 	ApplyRequirements       []string
 	Tags                    map[string]string
 	WorkflowModeType        WorkflowModeType
+	Owners                  []string
+	// ApplySettings overrides the repo's apply settings for the root, nil if none are set.
+	ApplySettings *ApplySettings
 }
 
 // GetName returns the name of the project or an empty string if there is no
@@ -56,6 +59,9 @@ func (p Project) ValidateAllowedOverrides(allowedOverrides []string) error {
 	if p.ApplyRequirements != nil && !sliceContains(allowedOverrides, ApplyRequirementsKey) {
 		return fmt.Errorf("repo config not allowed to set '%s' key: server-side config needs '%s: [%s]'", ApplyRequirementsKey, AllowedOverridesKey, ApplyRequirementsKey)
 	}
+	if p.ApplySettings != nil && !sliceContains(allowedOverrides, ApplySettingsKey) {
+		return fmt.Errorf("repo config not allowed to set '%s' key: server-side config needs '%s: [%s]'", ApplySettingsKey, AllowedOverridesKey, ApplySettingsKey)
+	}
 
 	return nil
 }
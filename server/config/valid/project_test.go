@@ -278,6 +278,13 @@ func TestProject_ValidateAllowedOverrides(t *testing.T) {
 			},
 			expErr: "repo config not allowed to set 'apply_requirements' key: server-side config needs 'allowed_overrides: [apply_requirements]'",
 		},
+		"apply_settings is not allowed override": {
+			allowedOverrides: []string{},
+			project: valid.Project{
+				ApplySettings: &valid.ApplySettings{Team: "sre"},
+			},
+			expErr: "repo config not allowed to set 'apply_settings' key: server-side config needs 'allowed_overrides: [apply_settings]'",
+		},
 		"no errors when allowed override": {
 			allowedOverrides: []string{"apply_requirements", "deployment_workflow", "pull_request_workflow", "workflow"},
 			project: valid.Project{
@@ -0,0 +1,42 @@
+package valid
+
+type DeploySlackEvent string
+
+const (
+	StartedDeploySlackEvent              DeploySlackEvent = "started"
+	AwaitingConfirmationDeploySlackEvent DeploySlackEvent = "awaiting_confirmation"
+	AppliedDeploySlackEvent              DeploySlackEvent = "applied"
+	FailedDeploySlackEvent               DeploySlackEvent = "failed"
+	DriftedDeploySlackEvent              DeploySlackEvent = "drifted"
+	LockedDeploySlackEvent               DeploySlackEvent = "locked"
+)
+
+var DeploySlackEvents = []DeploySlackEvent{
+	StartedDeploySlackEvent,
+	AwaitingConfirmationDeploySlackEvent,
+	AppliedDeploySlackEvent,
+	FailedDeploySlackEvent,
+	DriftedDeploySlackEvent,
+	LockedDeploySlackEvent,
+}
+
+// SlackNotification routes deploy outcomes for a repo's roots to a slack channel.
+// Empty Roots match every root and empty Events enable every event.
+type SlackNotification struct {
+	ChannelID string
+	Roots     []string
+	Events    []DeploySlackEvent
+}
+
+// MatchesRoot returns true if this route applies to the given root.
+func (n SlackNotification) MatchesRoot(rootName string) bool {
+	if len(n.Roots) == 0 {
+		return true
+	}
+	for _, r := range n.Roots {
+		if r == rootName {
+			return true
+		}
+	}
+	return false
+}
@@ -63,6 +63,12 @@ func (h unsupportedCheckSuiteEventHandler) Handle(ctx context.Context, event eve
 	return fmt.Errorf("check suite events are not supported in this context")
 }
 
+type unsupportedTeamMembershipEventHandler struct{}
+
+func (h unsupportedTeamMembershipEventHandler) Handle(ctx context.Context, event event_types.TeamMembership) error {
+	return fmt.Errorf("team membership events are not supported in this context")
+}
+
 func NewRequestResolvers(
 	providerResolverInitializer map[models.VCSHostType]func() RequestResolver,
 	supportedProviders []models.VCSHostType,
@@ -132,6 +138,7 @@ func NewVCSEventsController(
 				pullRequestReviewHandler,
 				unsupportedCheckRunEventHandler{},
 				unsupportedCheckSuiteEventHandler{},
+				unsupportedTeamMembershipEventHandler{},
 				allowDraftPRs,
 				repoConverter,
 				pullConverter,
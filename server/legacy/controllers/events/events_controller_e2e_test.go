@@ -74,6 +74,12 @@ func (h noopCheckSuiteEventHandler) Handle(ctx context.Context, event event_type
 	return nil
 }
 
+type noopTeamMembershipEventHandler struct{}
+
+func (h noopTeamMembershipEventHandler) Handle(ctx context.Context, event event_types.TeamMembership) error {
+	return nil
+}
+
 type NoopTFDownloader struct{}
 
 func (m *NoopTFDownloader) GetFile(dst, src string, opts ...getter.ClientOption) error {
@@ -723,6 +729,7 @@ func setupE2E(t *testing.T, repoFixtureDir string, userConfig *server.UserConfig
 				prrHandler,
 				noopCheckRunEventHandler{},
 				noopCheckSuiteEventHandler{},
+				noopTeamMembershipEventHandler{},
 				false,
 				repoConverter,
 				pullConverter,
@@ -23,6 +23,8 @@ const (
 	Autoplan
 	// Version is a command to run terraform version.
 	Version
+	// ApprovePolicies is a command to approve failing policies as a policy owner.
+	ApprovePolicies
 	// Adding more? Don't forget to update String() below
 )
 
@@ -45,6 +47,8 @@ func (c Name) String() string {
 		return "policy_check"
 	case Version:
 		return "version"
+	case ApprovePolicies:
+		return "approve_policies"
 	}
 	return ""
 }
@@ -30,3 +30,9 @@ func TestUnlockCommand_String(t *testing.T) {
 
 	Equals(t, "unlock", uc.String())
 }
+
+func TestApprovePoliciesCommand_String(t *testing.T) {
+	uc := command.ApprovePolicies
+
+	Equals(t, "approve_policies", uc.String())
+}
@@ -88,6 +88,7 @@ func (p *ApprovedPolicyFilter) Filter(ctx context.Context, installationToken int
 func (p *ApprovedPolicyFilter) dismissStalePRReviews(ctx context.Context, installationToken int64, repo models.Repo, prNum int) error {
 	shouldAllocate, err := p.allocator.ShouldAllocate(feature.LegacyDeprecation, feature.FeatureContext{
 		RepoName: repo.FullName,
+		Org:      repo.Owner,
 	})
 	if err != nil {
 		return errors.Wrap(err, "unable to allocate legacy deprecation feature flag")
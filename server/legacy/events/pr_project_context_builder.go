@@ -38,7 +38,11 @@ func (p *PlatformModeProjectContextBuilder) BuildProjectContext(
 	repoDir string,
 	contextFlags *command.ContextFlags,
 ) []command.ProjectContext {
-	shouldAllocate, err := p.allocator.ShouldAllocate(feature.PlatformMode, feature.FeatureContext{RepoName: ctx.HeadRepo.FullName})
+	shouldAllocate, err := p.allocator.ShouldAllocate(feature.PlatformMode, feature.FeatureContext{
+		RepoName:       ctx.HeadRepo.FullName,
+		Org:            ctx.HeadRepo.Owner,
+		InitiatingUser: ctx.User.Username,
+	})
 	if err != nil {
 		p.Logger.ErrorContext(ctx.RequestCtx, fmt.Sprintf("unable to allocate for feature: %s, error: %s", feature.PlatformMode, err))
 	}
@@ -495,6 +495,7 @@ func (g *GithubClient) GetRepoStatuses(repo models.Repo, pull models.PullRequest
 func (g *GithubClient) UpdateStatus(ctx context.Context, request types.UpdateStatusRequest) (string, error) {
 	shouldAllocate, err := g.allocator.ShouldAllocate(feature.LegacyDeprecation, feature.FeatureContext{
 		RepoName: request.Repo.FullName,
+		Org:      request.Repo.Owner,
 	})
 	if err != nil {
 		return "", errors.Wrap(err, "unable to allocate legacy deprecation feature flag")
@@ -33,7 +33,11 @@ func (a *PlatformModeRunner) Run(ctx *command.Context, cmd *command.Comment) {
 		return
 	}
 
-	shouldAllocate, err := a.Allocator.ShouldAllocate(feature.PlatformMode, feature.FeatureContext{RepoName: ctx.HeadRepo.FullName})
+	shouldAllocate, err := a.Allocator.ShouldAllocate(feature.PlatformMode, feature.FeatureContext{
+		RepoName:       ctx.HeadRepo.FullName,
+		Org:            ctx.HeadRepo.Owner,
+		InitiatingUser: ctx.User.Username,
+	})
 	if err != nil {
 		a.Logger.ErrorContext(ctx.RequestCtx, fmt.Sprintf("unable to allocate for feature: %s, error: %s", feature.PlatformMode, err))
 	}
@@ -74,7 +78,11 @@ type PlatformModeProjectRunner struct { //create object and test
 
 // Plan runs terraform plan for the project described by ctx.
 func (p *PlatformModeProjectRunner) Plan(ctx command.ProjectContext) command.ProjectResult {
-	shouldAllocate, err := p.Allocator.ShouldAllocate(feature.PlatformMode, feature.FeatureContext{RepoName: ctx.HeadRepo.FullName})
+	shouldAllocate, err := p.Allocator.ShouldAllocate(feature.PlatformMode, feature.FeatureContext{
+		RepoName:       ctx.HeadRepo.FullName,
+		Org:            ctx.HeadRepo.Owner,
+		InitiatingUser: ctx.User.Username,
+	})
 	if err != nil {
 		p.Logger.ErrorContext(ctx.RequestCtx, fmt.Sprintf("unable to allocate for feature: %s, error: %s", feature.PlatformMode, err))
 	}
@@ -87,7 +95,11 @@ func (p *PlatformModeProjectRunner) Plan(ctx command.ProjectContext) command.Pro
 
 // PolicyCheck evaluates policies defined with Rego for the project described by ctx.
 func (p *PlatformModeProjectRunner) PolicyCheck(ctx command.ProjectContext) command.ProjectResult {
-	shouldAllocate, err := p.Allocator.ShouldAllocate(feature.PlatformMode, feature.FeatureContext{RepoName: ctx.HeadRepo.FullName})
+	shouldAllocate, err := p.Allocator.ShouldAllocate(feature.PlatformMode, feature.FeatureContext{
+		RepoName:       ctx.HeadRepo.FullName,
+		Org:            ctx.HeadRepo.Owner,
+		InitiatingUser: ctx.User.Username,
+	})
 	if err != nil {
 		p.Logger.ErrorContext(ctx.RequestCtx, fmt.Sprintf("unable to allocate for feature: %s, error: %s", feature.PlatformMode, err))
 	}
@@ -100,7 +112,11 @@ func (p *PlatformModeProjectRunner) PolicyCheck(ctx command.ProjectContext) comm
 
 // Apply runs terraform apply for the project described by ctx.
 func (p *PlatformModeProjectRunner) Apply(ctx command.ProjectContext) command.ProjectResult {
-	shouldAllocate, err := p.Allocator.ShouldAllocate(feature.PlatformMode, feature.FeatureContext{RepoName: ctx.HeadRepo.FullName})
+	shouldAllocate, err := p.Allocator.ShouldAllocate(feature.PlatformMode, feature.FeatureContext{
+		RepoName:       ctx.HeadRepo.FullName,
+		Org:            ctx.HeadRepo.Owner,
+		InitiatingUser: ctx.User.Username,
+	})
 	if err != nil {
 		p.Logger.ErrorContext(ctx.RequestCtx, fmt.Sprintf("unable to allocate for feature: %s, error: %s", feature.PlatformMode, err))
 	}
@@ -119,7 +135,11 @@ func (p *PlatformModeProjectRunner) Apply(ctx command.ProjectContext) command.Pr
 }
 
 func (p *PlatformModeProjectRunner) Version(ctx command.ProjectContext) command.ProjectResult {
-	shouldAllocate, err := p.Allocator.ShouldAllocate(feature.PlatformMode, feature.FeatureContext{RepoName: ctx.HeadRepo.FullName})
+	shouldAllocate, err := p.Allocator.ShouldAllocate(feature.PlatformMode, feature.FeatureContext{
+		RepoName:       ctx.HeadRepo.FullName,
+		Org:            ctx.HeadRepo.Owner,
+		InitiatingUser: ctx.User.Username,
+	})
 	if err != nil {
 		p.Logger.ErrorContext(ctx.RequestCtx, fmt.Sprintf("unable to allocate for feature: %s, error: %s", feature.PlatformMode, err))
 	}
@@ -18,12 +18,15 @@ import (
 	"github.com/runatlantis/atlantis/server/legacy/events/vcs"
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/comment"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/config"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/deploy"
 	gateway_handlers "github.com/runatlantis/atlantis/server/neptune/gateway/event"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/ingestion"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/requirement"
 	"github.com/runatlantis/atlantis/server/neptune/lyft/feature"
 	"github.com/runatlantis/atlantis/server/neptune/sync"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
 	converters "github.com/runatlantis/atlantis/server/vcs/provider/github/converter"
 	"github.com/runatlantis/atlantis/server/vcs/provider/github/request"
 	"github.com/uber-go/tally/v4"
@@ -38,7 +41,7 @@ func NewVCSEventsController(
 	scope tally.Scope,
 	webhookSecret []byte,
 	allowDraftPRs bool,
-	commentParser events.CommentParsing,
+	githubAppSlug string,
 	repoAllowlistChecker *events.RepoAllowlistChecker,
 	vcsClient vcs.Client,
 	logger logging.Logger,
@@ -59,6 +62,8 @@ func NewVCSEventsController(
 	commentCreator *github.CommentCreator,
 	clientCreator githubapp.ClientCreator,
 	defaultTFVersion string,
+	auditSink plugins.AuditSink,
+	ingester *ingestion.Ingester,
 ) *VCSEventsController {
 	legacyHandler := &gateway_handlers.LegacyPullHandler{
 		Logger:           logger,
@@ -106,8 +111,12 @@ func NewVCSEventsController(
 	}
 
 	requirementChecker := requirement.NewDeployAggregate(globalCfg, teamMemberFetcher, reviewFetcher, checkRunFetcher, logger)
+	commentRegistry := comment.NewRegistry(
+		githubAppSlug,
+		comment.DefaultCommands(comment.NewPolicyOwnerAuthorizer(globalCfg.PolicySets, teamMemberFetcher))...,
+	)
 	commentHandler := handlers.NewCommentEventWithCommandHandler(
-		commentParser,
+		commentRegistry,
 		repoAllowlistChecker,
 		vcsClient,
 		gateway_handlers.NewCommentEventWorkerProxy(
@@ -121,7 +130,9 @@ func NewVCSEventsController(
 			rootConfigBuilder,
 			legacyErrorHandler,
 			neptuneErrorHandler,
-			requirementChecker),
+			requirementChecker,
+			commentRegistry,
+			defaultTFVersion),
 		logger,
 	)
 
@@ -131,12 +142,22 @@ func NewVCSEventsController(
 		RootDeployer: rootDeployer,
 	}
 
+	// ingestion is optional, leave the handler fields unset rather than assigning a typed nil
+	if ingester != nil {
+		pushHandler.Ingester = ingester
+		modifiedPullHandler.Ingester = ingester
+	}
+
 	checkRunHandler := &gateway_handlers.CheckRunHandler{
 		Logger:         logger,
 		RootDeployer:   rootDeployer,
 		SyncScheduler:  syncScheduler,
 		AsyncScheduler: asyncScheduler,
 		DeploySignaler: deploySignaler,
+		AuditSink:      auditSink,
+		// plan reviews are authorized the same way as deploys, against the apply team of the root
+		RootConfigBuilder: rootConfigBuilder,
+		Requirement:       requirement.NewTeam(globalCfg, teamMemberFetcher, logger),
 	}
 
 	checkSuiteHandler := &gateway_handlers.CheckSuiteHandler{
@@ -154,6 +175,12 @@ func NewVCSEventsController(
 		RootConfigBuilder: rootConfigBuilder,
 		GlobalCfg:         globalCfg,
 	}
+	teamMembershipHandler := &gateway_handlers.TeamMembershipHandler{
+		Logger:           logger,
+		Scheduler:        asyncScheduler,
+		WorkflowSignaler: &pr.TeamMembershipSignaler{TemporalClient: temporalClient},
+		Org:              globalCfg.PolicySets.Organization,
+	}
 	pullFetcher := &github.PRFetcher{
 		ClientCreator: clientCreator,
 	}
@@ -173,6 +200,7 @@ func NewVCSEventsController(
 				pullRequestReviewHandler,
 				checkRunHandler,
 				checkSuiteHandler,
+				teamMembershipHandler,
 				allowDraftPRs,
 				repoConverter,
 				pullConverter,
@@ -26,6 +26,7 @@ type UserConfig struct {
 	DisableApply             bool   `mapstructure:"disable-apply"`
 	DisableAutoplan          bool   `mapstructure:"disable-autoplan"`
 	DisableMarkdownFolding   bool   `mapstructure:"disable-markdown-folding"`
+	DurableEventIngestion    bool   `mapstructure:"durable-event-ingestion"`
 	EnablePolicyChecks       bool   `mapstructure:"enable-policy-checks"`
 	EnableRegExpCmd          bool   `mapstructure:"enable-regexp-cmd"`
 	EnableDiffMarkdownFormat bool   `mapstructure:"enable-diff-markdown-format"`
@@ -33,6 +34,8 @@ type UserConfig struct {
 	FFRepo                   string `mapstructure:"ff-repo"`
 	FFBranch                 string `mapstructure:"ff-branch"`
 	FFPath                   string `mapstructure:"ff-path"`
+	FFSource                 string `mapstructure:"ff-source"`
+	FFURL                    string `mapstructure:"ff-url"`
 	GithubHostname           string `mapstructure:"gh-hostname"`
 	GithubToken              string `mapstructure:"gh-token"`
 	GithubUser               string `mapstructure:"gh-user"`
@@ -52,6 +55,7 @@ type UserConfig struct {
 	RepoConfig               string `mapstructure:"repo-config"`
 	RepoConfigJSON           string `mapstructure:"repo-config-json"`
 	RepoAllowlist            string `mapstructure:"repo-allowlist"`
+	RepoMirrorCacheMB        int    `mapstructure:"repo-mirror-cache-mb"`
 	// RepoWhitelist is deprecated in favour of RepoAllowlist.
 	RepoWhitelist string `mapstructure:"repo-whitelist"`
 
@@ -62,6 +66,8 @@ type UserConfig struct {
 	// terraform apply.
 	RequireSQUnlocked        bool            `mapstructure:"require-unlocked"`
 	SlackToken               string          `mapstructure:"slack-token"`
+	SlackSigningSecret       string          `mapstructure:"slack-signing-secret"`
+	SlackGithubUserField     string          `mapstructure:"slack-github-user-field"`
 	SSLCertFile              string          `mapstructure:"ssl-cert-file"`
 	SSLKeyFile               string          `mapstructure:"ssl-key-file"`
 	TFDownloadURL            string          `mapstructure:"tf-download-url"`
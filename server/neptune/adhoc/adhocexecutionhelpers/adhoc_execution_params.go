@@ -20,19 +20,18 @@ type AdhocTerraformWorkflowExecutionParams struct {
 
 func ConstructAdhocExecParams(
 	ctx context.Context,
+	orgName string,
 	repoName string,
 	PRNum int,
 	pullFetcher *internal_gh.PRFetcher,
 	pullConverter converter.PullConverter,
 	installationRetriever *internal_gh.InstallationRetriever,
 	rootCfgBuilder *root_config.Builder) (AdhocTerraformWorkflowExecutionParams, error) {
-	orgName := "lyft"
 	installationToken, err := installationRetriever.FindOrganizationInstallation(ctx, orgName)
 	if err != nil {
 		return AdhocTerraformWorkflowExecutionParams{}, errors.Wrap(err, "finding organization installation")
 	}
 
-	// TODO: in the future, could potentially pass in the owner instead of hardcoding lyft
 	ghCommit, err := pullFetcher.Fetch(ctx, installationToken.Token, orgName, repoName, PRNum)
 	if err != nil {
 		return AdhocTerraformWorkflowExecutionParams{}, errors.Wrap(err, "fetching commit")
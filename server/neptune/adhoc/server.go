@@ -14,7 +14,6 @@ import (
 	"time"
 
 	"github.com/palantir/go-githubapp/githubapp"
-	"github.com/runatlantis/atlantis/server/legacy/events/vcs"
 	"github.com/runatlantis/atlantis/server/neptune/sync/crons"
 	ghClient "github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
@@ -46,6 +45,9 @@ import (
 	"go.temporal.io/sdk/worker"
 )
 
+// defaultOwner is used when adhoc mode isn't configured with a repo owner
+const defaultOwner = "lyft"
+
 type Server struct {
 	Logger                logging.Logger
 	CronScheduler         *internalSync.CronScheduler
@@ -59,6 +61,7 @@ type Server struct {
 	GithubActivities      *activities.Github
 	TerraformTaskQueue    string
 	RootConfigBuilder     *root_config.Builder
+	Owner                 string
 	Repo                  string
 	PRNum                 int
 	InstallationRetriever *github.InstallationRetriever
@@ -125,6 +128,7 @@ func NewServer(config *adhocconfig.Config) (*Server, error) {
 		config.TemporalCfg.TerraformTaskQueue,
 		config.GithubCfg.TemporalAppInstallationID,
 		jobStreamHandler,
+		activities.TerraformOptions{GithubHostname: config.GithubHostname},
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "initializing terraform activities")
@@ -157,16 +161,20 @@ func NewServer(config *adhocconfig.Config) (*Server, error) {
 	if err != nil {
 		return nil, err
 	}
-	githubCredentials := &vcs.GithubAppCredentials{
-		AppID:    config.GithubAppID,
-		Key:      privateKey,
-		Hostname: config.GithubHostname,
-		AppSlug:  config.GithubAppSlug,
+
+	owner := config.GlobalCfg.AdhocMode.Owner
+	if owner == "" {
+		owner = defaultOwner
 	}
 
+	installationTokens := &github.InstallationTokens{
+		AppID:      config.GithubAppID,
+		PrivateKey: privateKey,
+		V3APIURL:   config.App.V3APIURL,
+	}
 	repoFetcher := &github.RepoFetcher{
 		DataDir:           config.DataDir,
-		GithubCredentials: githubCredentials,
+		GithubCredentials: installationTokens,
 		GithubHostname:    config.GithubHostname,
 		Logger:            config.CtxLogger,
 		Scope:             scope.SubScope("repo.fetch"),
@@ -177,6 +185,8 @@ func NewServer(config *adhocconfig.Config) (*Server, error) {
 		HookExecutor: &preworkflow.HookExecutor{
 			Logger: config.CtxLogger,
 		},
+		GithubCredentials: installationTokens,
+		GithubHostname:    config.GithubHostname,
 	}
 
 	rootConfigBuilder := &root_config.Builder{
@@ -223,6 +233,7 @@ func NewServer(config *adhocconfig.Config) (*Server, error) {
 		TerraformTaskQueue:    config.TemporalCfg.TerraformTaskQueue,
 		GithubActivities:      githubActivities,
 		RootConfigBuilder:     rootConfigBuilder,
+		Owner:                 owner,
 		Repo:                  config.GlobalCfg.AdhocMode.Repo,
 		PRNum:                 config.GlobalCfg.AdhocMode.PRNum,
 		InstallationRetriever: installationFetcher,
@@ -288,7 +299,7 @@ func (s Server) Start() error {
 	go func() {
 		defer wg.Done()
 
-		adhocExecutionParams, err := adhoc.ConstructAdhocExecParams(ctx, s.Repo, s.PRNum, s.PullFetcher, s.PullConverter, s.InstallationRetriever, s.RootConfigBuilder)
+		adhocExecutionParams, err := adhoc.ConstructAdhocExecParams(ctx, s.Owner, s.Repo, s.PRNum, s.PullFetcher, s.PullConverter, s.InstallationRetriever, s.RootConfigBuilder)
 		if err != nil {
 			s.Logger.Error(err.Error())
 			return
@@ -11,6 +11,7 @@ func (c Key) String() string {
 const (
 	InstallationIDKey = Key("gh-installation-id")
 	RequestIDKey      = Key("gh-request-id")
+	DeliveryIDKey     = Key("gh-delivery-id")
 	RepositoryKey     = Key("repository")
 	SHAKey            = Key("sha")
 	BranchKey         = Key("branch")
@@ -22,7 +23,7 @@ const (
 	Trigger           = Key("workflow-trigger")
 )
 
-var Keys = []Key{RequestIDKey, RepositoryKey, PullNumKey, ProjectKey, SHAKey, BranchKey, InstallationIDKey, DeploymentIDKey, ErrKey}
+var Keys = []Key{RequestIDKey, DeliveryIDKey, RepositoryKey, PullNumKey, ProjectKey, SHAKey, BranchKey, InstallationIDKey, DeploymentIDKey, ErrKey}
 
 type KVStore interface {
 	Value(key interface{}) interface{}
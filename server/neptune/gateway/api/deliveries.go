@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/delivery"
+)
+
+// DeliveriesController exposes the webhook delivery log so that failed deliveries can be
+// inspected and replayed.
+type DeliveriesController struct {
+	Log      *delivery.Log
+	Replayer *delivery.Replayer
+	Logger   logging.Logger
+}
+
+// List returns recent deliveries, optionally filtered with the status query param.
+func (c *DeliveriesController) List(w http.ResponseWriter, r *http.Request) {
+	status := delivery.Status(r.URL.Query().Get("status"))
+	c.respond(w, r, http.StatusOK, c.Log.List(status))
+}
+
+// Replay sends a failed delivery back through the webhook handler and returns its new state.
+func (c *DeliveriesController) Replay(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	d, err := c.Replayer.Replay(r.Context(), id)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintln(w, err)
+		return
+	}
+
+	c.Logger.InfoContext(r.Context(), fmt.Sprintf("replayed delivery %s with status %s", id, d.Status))
+	c.respond(w, r, http.StatusOK, d)
+}
+
+func (c *DeliveriesController) respond(w http.ResponseWriter, r *http.Request, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		c.Logger.ErrorContext(r.Context(), fmt.Sprintf("writing response: %s", err))
+	}
+}
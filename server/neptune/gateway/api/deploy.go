@@ -55,6 +55,7 @@ func (c *DeployHandler) Handle(ctx context.Context, r request.Deploy) error {
 				TriggerInfo: workflows.DeployTriggerInfo{
 					Type: workflows.ManualTrigger,
 				},
+				EnforceTwoPersonRule: true,
 			})
 		},
 	)
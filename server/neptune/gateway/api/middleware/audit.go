@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
+	"github.com/urfave/negroni"
+)
+
+// Audit records an event for every admin API request once it has been handled.
+// This must be used after AdminAuth since it relies on the authenticated username.
+type Audit struct {
+	Sink   plugins.AuditSink
+	Logger logging.Logger
+}
+
+func (m *Audit) Middleware(next http.Handler) http.Handler {
+	return &auditHandler{
+		next:   next,
+		sink:   m.Sink,
+		logger: m.Logger,
+	}
+}
+
+type auditHandler struct {
+	next   http.Handler
+	sink   plugins.AuditSink
+	logger logging.Logger
+}
+
+func (h *auditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wrappedRW := negroni.NewResponseWriter(w)
+	h.next.ServeHTTP(wrappedRW, r)
+
+	ctx := r.Context()
+	username, _ := ctx.Value(UsernameContextKey).(string)
+
+	event := plugins.AuditEvent{
+		Version:   plugins.AuditEventSchemaVersion,
+		ID:        uuid.NewString(),
+		Type:      plugins.AdminAPIAuditEvent,
+		Status:    strconv.Itoa(wrappedRW.Status()),
+		Timestamp: time.Now(),
+		User:      username,
+		Metadata: map[string]string{
+			"method": r.Method,
+			"path":   r.URL.Path,
+		},
+	}
+
+	if err := h.sink.Write(ctx, event); err != nil {
+		h.logger.ErrorContext(ctx, fmt.Sprintf("writing audit event for %s %s: %s", r.Method, r.URL.Path, err))
+	}
+}
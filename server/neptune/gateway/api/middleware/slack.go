@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/slack-go/slack"
+)
+
+// SlackSignature verifies that requests were sent by slack using the app's signing secret.
+// Requests with a stale timestamp are rejected as well to protect against replays.
+type SlackSignature struct {
+	SigningSecret string
+}
+
+func (m *SlackSignature) Middleware(next http.Handler) http.Handler {
+	return &slackSignatureHandler{
+		next:          next,
+		signingSecret: m.SigningSecret,
+	}
+}
+
+type slackSignatureHandler struct {
+	next          http.Handler
+	signingSecret string
+}
+
+func (m *slackSignatureHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	verifier, err := slack.NewSecretsVerifier(r.Header, m.signingSecret)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintln(w, errors.Wrap(err, "initializing slack signature verifier"))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintln(w, errors.Wrap(err, "reading request body"))
+		return
+	}
+
+	if _, err := verifier.Write(body); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintln(w, errors.Wrap(err, "hashing request body"))
+		return
+	}
+
+	if err := verifier.Ensure(); err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintln(w, errors.Wrap(err, "verifying slack signature"))
+		return
+	}
+
+	// the body has been consumed so we need to reset it for downstream handlers
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	m.next.ServeHTTP(w, r)
+}
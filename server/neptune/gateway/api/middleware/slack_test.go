@@ -0,0 +1,94 @@
+package middleware_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/neptune/gateway/api/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+const signingSecret = "secret"
+
+func sign(secret string, timestamp string, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("v0:%s:%s", timestamp, body)))
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func buildRequest(secret string, timestamp time.Time, body string) *http.Request {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	r := httptest.NewRequest(http.MethodPost, "/slack/interactivity", strings.NewReader(body))
+	r.Header.Set("X-Slack-Request-Timestamp", ts)
+	r.Header.Set("X-Slack-Signature", sign(secret, ts, body))
+	return r
+}
+
+type recordingHandler struct {
+	called bool
+	body   string
+}
+
+func (h *recordingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.called = true
+	b, _ := io.ReadAll(r.Body)
+	h.body = string(b)
+}
+
+func TestSlackSignature(t *testing.T) {
+	body := "payload=%7B%7D"
+
+	cases := []struct {
+		description    string
+		request        *http.Request
+		expectedCalled bool
+		expectedStatus int
+	}{
+		{
+			description:    "valid signature",
+			request:        buildRequest(signingSecret, time.Now(), body),
+			expectedCalled: true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "invalid signature",
+			request:        buildRequest("wrong", time.Now(), body),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			description:    "stale timestamp",
+			request:        buildRequest(signingSecret, time.Now().Add(-time.Hour), body),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			description:    "missing headers",
+			request:        httptest.NewRequest(http.MethodPost, "/slack/interactivity", strings.NewReader(body)),
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			next := &recordingHandler{}
+			subject := &middleware.SlackSignature{SigningSecret: signingSecret}
+
+			w := httptest.NewRecorder()
+			subject.Middleware(next).ServeHTTP(w, c.request)
+
+			assert.Equal(t, c.expectedStatus, w.Code)
+			assert.Equal(t, c.expectedCalled, next.called)
+			if c.expectedCalled {
+				assert.Equal(t, body, next.body)
+			}
+		})
+	}
+}
@@ -14,7 +14,7 @@ import (
 func NewDeployConverter(
 	repoRetriever *internal.RepoRetriever,
 	branchRetriever *internal.BranchRetriever,
-	InstallationRetriever *internal.InstallationRetriever,
+	InstallationRetriever installationRetriever,
 ) *JSONRequestValidationProxy[external.DeployRequest, Deploy] {
 	return &JSONRequestValidationProxy[external.DeployRequest, Deploy]{
 		Delegate: &DeployConverter{
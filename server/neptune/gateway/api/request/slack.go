@@ -0,0 +1,140 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/neptune/workflows"
+	"github.com/slack-go/slack"
+)
+
+const (
+	slackPayloadFormKey = "payload"
+
+	// action names mirror the github check run actions
+	slackConfirmAction = "Confirm"
+	slackRejectAction  = "Reject"
+)
+
+type slackUserResolver interface {
+	GithubUsername(ctx context.Context, slackUserID string) (string, error)
+}
+
+// PlanReview contains everything needed to authorize a reviewer and signal
+// the terraform workflow awaiting review.
+type PlanReview struct {
+	WorkflowID        string
+	Status            workflows.TerraformPlanReviewStatus
+	Repo              models.Repo
+	RootName          string
+	InstallationToken int64
+	User              models.User
+}
+
+// SlackPlanReviewConverter converts slack interactive message payloads from plan review
+// buttons into a PlanReview.  Requests are expected to have been signature verified already.
+type SlackPlanReviewConverter struct {
+	InstallationRetriever installationRetriever
+	UserResolver          slackUserResolver
+	GithubHostname        string
+}
+
+func (c *SlackPlanReviewConverter) Convert(from *http.Request) (PlanReview, error) {
+	ctx := from.Context()
+
+	var callback slack.InteractionCallback
+	if err := json.Unmarshal([]byte(from.FormValue(slackPayloadFormKey)), &callback); err != nil {
+		return PlanReview{}, errors.Wrap(err, "decoding interaction payload")
+	}
+
+	if callback.CallbackID != workflows.TerraformSlackPlanReviewCallbackID {
+		return PlanReview{}, fmt.Errorf("unsupported callback id %s", callback.CallbackID)
+	}
+
+	if len(callback.ActionCallback.AttachmentActions) != 1 {
+		return PlanReview{}, fmt.Errorf("expected a single action, got %d", len(callback.ActionCallback.AttachmentActions))
+	}
+	action := callback.ActionCallback.AttachmentActions[0]
+
+	var status workflows.TerraformPlanReviewStatus
+	switch action.Name {
+	case slackConfirmAction:
+		status = workflows.ApprovedPlanReviewStatus
+	case slackRejectAction:
+		status = workflows.RejectedPlanReviewStatus
+	default:
+		return PlanReview{}, fmt.Errorf("unknown action %s", action.Name)
+	}
+
+	value, err := workflows.DecodeTerraformSlackPlanReviewValue(action.Value)
+	if err != nil {
+		return PlanReview{}, errors.Wrap(err, "decoding action value")
+	}
+
+	owner, name, found := strings.Cut(value.Repo, "/")
+	if !found {
+		return PlanReview{}, fmt.Errorf("invalid repo %s", value.Repo)
+	}
+
+	username, err := c.UserResolver.GithubUsername(ctx, callback.User.ID)
+	if err != nil {
+		return PlanReview{}, errors.Wrapf(err, "resolving github username for slack user %s", callback.User.ID)
+	}
+
+	// In order to authenticate as our GH App we need to get the organization's installation token.
+	installation, err := c.InstallationRetriever.FindOrganizationInstallation(ctx, owner)
+	if err != nil {
+		return PlanReview{}, errors.Wrap(err, "finding installation")
+	}
+
+	return PlanReview{
+		WorkflowID: value.WorkflowID,
+		Status:     status,
+		Repo: models.Repo{
+			FullName: value.Repo,
+			Owner:    owner,
+			Name:     name,
+			VCSHost: models.VCSHost{
+				Hostname: c.GithubHostname,
+				Type:     models.Github,
+			},
+		},
+		RootName:          value.Root,
+		InstallationToken: installation.Token,
+		User: models.User{
+			Username: username,
+		},
+	}, nil
+}
+
+type slackProfileClient interface {
+	GetUserProfileContext(ctx context.Context, params *slack.GetUserProfileParameters) (*slack.UserProfile, error)
+}
+
+// SlackUserResolver maps slack users to github usernames using a custom profile field.
+// Display names are freely editable by users so they can't be trusted for authorization,
+// the configured field should be one that only workspace admins can modify.
+type SlackUserResolver struct {
+	Client        slackProfileClient
+	GithubFieldID string
+}
+
+func (r *SlackUserResolver) GithubUsername(ctx context.Context, slackUserID string) (string, error) {
+	profile, err := r.Client.GetUserProfileContext(ctx, &slack.GetUserProfileParameters{
+		UserID: slackUserID,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "getting user profile")
+	}
+
+	field, ok := profile.Fields.ToMap()[r.GithubFieldID]
+	if !ok || field.Value == "" {
+		return "", fmt.Errorf("profile field %s is not set", r.GithubFieldID)
+	}
+	return field.Value, nil
+}
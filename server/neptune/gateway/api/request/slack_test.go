@@ -0,0 +1,140 @@
+package request_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/api/request"
+	"github.com/runatlantis/atlantis/server/neptune/workflows"
+	"github.com/runatlantis/atlantis/server/vcs/provider/github"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+type userResolver struct {
+	expectedT      *testing.T
+	expectedUserID string
+
+	resultUsername string
+	resultErr      error
+}
+
+func (r *userResolver) GithubUsername(ctx context.Context, slackUserID string) (string, error) {
+	assert.Equal(r.expectedT, r.expectedUserID, slackUserID)
+	return r.resultUsername, r.resultErr
+}
+
+func buildSlackRequest(t *testing.T, callbackID string, actionName string, value string) *http.Request {
+	callback := slack.InteractionCallback{
+		Type:       slack.InteractionTypeInteractionMessage,
+		CallbackID: callbackID,
+		User:       slack.User{ID: "U123"},
+		ActionCallback: slack.ActionCallbacks{
+			AttachmentActions: []*slack.AttachmentAction{
+				{Name: actionName, Value: value},
+			},
+		},
+	}
+	payload, err := json.Marshal(callback)
+	assert.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/slack/interactivity", strings.NewReader(url.Values{
+		"payload": {string(payload)},
+	}.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func TestSlackPlanReviewConverter(t *testing.T) {
+	value := workflows.TerraformSlackPlanReviewValue{
+		WorkflowID: "1234",
+		Repo:       "owner/repo",
+		Root:       "root",
+	}.Encode()
+
+	subject := &request.SlackPlanReviewConverter{
+		InstallationRetriever: &installationRetriever{
+			expectedT:   t,
+			expectedOrg: "owner",
+			resultInstallation: github.Installation{
+				Token: 1,
+			},
+		},
+		UserResolver: &userResolver{
+			expectedT:      t,
+			expectedUserID: "U123",
+			resultUsername: "nish",
+		},
+		GithubHostname: "github.com",
+	}
+
+	expectedRepo := models.Repo{
+		FullName: "owner/repo",
+		Owner:    "owner",
+		Name:     "repo",
+		VCSHost: models.VCSHost{
+			Hostname: "github.com",
+			Type:     models.Github,
+		},
+	}
+
+	t.Run("confirm", func(t *testing.T) {
+		result, err := subject.Convert(buildSlackRequest(t, workflows.TerraformSlackPlanReviewCallbackID, "Confirm", value))
+		assert.NoError(t, err)
+		assert.Equal(t, request.PlanReview{
+			WorkflowID:        "1234",
+			Status:            workflows.ApprovedPlanReviewStatus,
+			Repo:              expectedRepo,
+			RootName:          "root",
+			InstallationToken: 1,
+			User:              models.User{Username: "nish"},
+		}, result)
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		result, err := subject.Convert(buildSlackRequest(t, workflows.TerraformSlackPlanReviewCallbackID, "Reject", value))
+		assert.NoError(t, err)
+		assert.Equal(t, workflows.RejectedPlanReviewStatus, result.Status)
+	})
+
+	t.Run("unknown callback", func(t *testing.T) {
+		_, err := subject.Convert(buildSlackRequest(t, "other", "Confirm", value))
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		_, err := subject.Convert(buildSlackRequest(t, workflows.TerraformSlackPlanReviewCallbackID, "Unlock", value))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := subject.Convert(buildSlackRequest(t, workflows.TerraformSlackPlanReviewCallbackID, "Confirm", "1234"))
+		assert.Error(t, err)
+	})
+}
+
+func TestSlackPlanReviewConverter_UnresolvedUser(t *testing.T) {
+	subject := &request.SlackPlanReviewConverter{
+		UserResolver: &userResolver{
+			expectedT:      t,
+			expectedUserID: "U123",
+			resultErr:      fmt.Errorf("profile field not set"),
+		},
+	}
+
+	value := workflows.TerraformSlackPlanReviewValue{
+		WorkflowID: "1234",
+		Repo:       "owner/repo",
+		Root:       "root",
+	}.Encode()
+
+	_, err := subject.Convert(buildSlackRequest(t, workflows.TerraformSlackPlanReviewCallbackID, "Confirm", value))
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/api/request"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/requirement"
+	"github.com/runatlantis/atlantis/server/neptune/workflows"
+	"github.com/slack-go/slack"
+)
+
+type workflowSignaler interface {
+	SignalWorkflow(ctx context.Context, workflowID string, runID string, signalName string, arg interface{}) error
+}
+
+// defaultBranchRootBuilder builds roots from the default branch's repo config
+type defaultBranchRootBuilder interface {
+	BuildDefaultBranchRoot(ctx context.Context, repo models.Repo, installationToken int64, rootName string) (*valid.MergedProjectCfg, error)
+}
+
+// PlanReviewHandler signals the terraform workflow awaiting review once the reviewer has
+// been authorized the same way as deploys, ie. against the apply team of the root.
+type PlanReviewHandler struct {
+	Requirement       requirement.Requirement
+	RootConfigBuilder defaultBranchRootBuilder
+	Signaler          workflowSignaler
+	Logger            logging.Logger
+}
+
+func (h *PlanReviewHandler) Handle(ctx context.Context, r request.PlanReview) error {
+	// the root is read from the default branch since that's what is deployed, repos
+	// settings apply to roots which aren't configured there
+	rootCfg, err := h.RootConfigBuilder.BuildDefaultBranchRoot(ctx, r.Repo, r.InstallationToken, r.RootName)
+	if err != nil {
+		return errors.Wrap(err, "building root")
+	}
+	criteria := requirement.Criteria{
+		User:              r.User,
+		Repo:              r.Repo,
+		InstallationToken: r.InstallationToken,
+	}
+	if rootCfg != nil {
+		criteria.Roots = []*valid.MergedProjectCfg{rootCfg}
+	}
+	if err := h.Requirement.Check(ctx, criteria); err != nil {
+		return errors.Wrap(err, "authorizing reviewer")
+	}
+
+	err = h.Signaler.SignalWorkflow(
+		ctx,
+		r.WorkflowID,
+		// keeping this empty is fine since temporal will find the currently running workflow
+		"",
+		workflows.TerraformPlanReviewSignalName,
+		workflows.TerraformPlanReviewSignalRequest{
+			Status: r.Status,
+			User:   r.User.Username,
+		})
+	if err != nil {
+		return errors.Wrapf(err, "signaling workflow with id: %s", r.WorkflowID)
+	}
+
+	h.Logger.InfoContext(ctx, fmt.Sprintf("Signaled workflow with id %s from slack, review status, %d", r.WorkflowID, r.Status))
+	return nil
+}
+
+// SlackController handles slack interactive message requests.  Unlike Controller, slack
+// expects a 200 with a message body even on failure in order to surface anything to the user.
+type SlackController struct {
+	RequestConverter RequestConverter[request.PlanReview]
+	Handler          Handler[request.PlanReview]
+	Logger           logging.Logger
+}
+
+func (c *SlackController) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var text string
+	planReview, err := c.RequestConverter.Convert(r)
+	if err == nil {
+		err = c.Handler.Handle(ctx, planReview)
+	}
+
+	if err != nil {
+		c.Logger.WarnContext(ctx, fmt.Sprintf("handling slack plan review: %s", err))
+		text = fmt.Sprintf("Unable to review plan: %s", err)
+	} else if planReview.Status == workflows.ApprovedPlanReviewStatus {
+		text = fmt.Sprintf("Plan for %s confirmed by %s", planReview.RootName, planReview.User.Username)
+	} else {
+		text = fmt.Sprintf("Plan for %s rejected by %s", planReview.RootName, planReview.User.Username)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(slack.Msg{
+		Text:            text,
+		ResponseType:    slack.ResponseTypeEphemeral,
+		ReplaceOriginal: false,
+	}); err != nil {
+		c.Logger.ErrorContext(ctx, fmt.Sprintf("writing slack response: %s", err))
+	}
+}
@@ -0,0 +1,51 @@
+package comment
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/models"
+)
+
+type teamMemberFetcher interface {
+	ListTeamMembers(ctx context.Context, installationToken int64, teamSlug string) ([]string, error)
+}
+
+// TeamAuthorizer authorizes members of any of its teams
+type TeamAuthorizer struct {
+	Teams             []string
+	TeamMemberFetcher teamMemberFetcher
+}
+
+// NewPolicyOwnerAuthorizer authorizes members of the owner team of any policy set
+func NewPolicyOwnerAuthorizer(policySets valid.PolicySets, fetcher teamMemberFetcher) *TeamAuthorizer {
+	seen := make(map[string]bool)
+	var teams []string
+	for _, p := range policySets.PolicySets {
+		if p.Owner == "" || seen[p.Owner] {
+			continue
+		}
+		seen[p.Owner] = true
+		teams = append(teams, p.Owner)
+	}
+	return &TeamAuthorizer{
+		Teams:             teams,
+		TeamMemberFetcher: fetcher,
+	}
+}
+
+func (a *TeamAuthorizer) Authorize(ctx context.Context, user models.User, installationToken int64) (bool, error) {
+	for _, team := range a.Teams {
+		members, err := a.TeamMemberFetcher.ListTeamMembers(ctx, installationToken, team)
+		if err != nil {
+			return false, errors.Wrapf(err, "listing members of %s", team)
+		}
+		for _, member := range members {
+			if member == user.Username {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,70 @@
+package comment
+
+import (
+	"github.com/runatlantis/atlantis/server/legacy/events/command"
+	"github.com/spf13/pflag"
+)
+
+const (
+	projectFlagLong  = "project"
+	projectFlagShort = "p"
+	forceFlagLong    = "force"
+	forceFlagShort   = "f"
+)
+
+// planExtraArgs are the terraform plan flags users can pass through comments.  Plans run with extra
+// args are partial, see terraform.Root, so flags which narrow the plan like -target are still unsafe
+// to allow since the plan is shown to reviewers.
+var planExtraArgs = []string{"-var", "-parallelism", "-compact-warnings"}
+
+// DefaultCommands are the comment commands of the PR workflow, approving policies is restricted to policy owners.
+// Unlock is intentionally open to anyone who can comment, it only cancels in progress plans and discards plans
+// which any commenter can already replace by running plan.
+func DefaultCommands(policyOwners authorizer) []Command {
+	return []Command{
+		{
+			Keyword:     command.Plan.String(),
+			Name:        command.Plan,
+			Description: "Runs 'terraform plan' for the roots modified by this pull request.\nTo plan a specific root, use the -p flag.",
+			Flags: func(flags *pflag.FlagSet, cmd *command.Comment) {
+				flags.StringVarP(&cmd.ProjectName, projectFlagLong, projectFlagShort, "", "Which root to run plan for.")
+			},
+			ExtraArgs: planExtraArgs,
+		},
+		{
+			Keyword:     command.Apply.String(),
+			Name:        command.Apply,
+			Description: "Deploys the roots modified by this pull request without merging it.\nTo apply a specific root, use the -p flag.",
+			Flags: func(flags *pflag.FlagSet, cmd *command.Comment) {
+				flags.StringVarP(&cmd.ProjectName, projectFlagLong, projectFlagShort, "", "Which root to apply.")
+				flags.BoolVarP(&cmd.ForceApply, forceFlagLong, forceFlagShort, false, "Ignore apply requirements, this should only be used in an emergency.")
+			},
+		},
+		{
+			Keyword:     "policy-check",
+			Aliases:     []string{command.PolicyCheck.String()},
+			Name:        command.PolicyCheck,
+			Description: "Re-evaluates the failing policies of the latest revision against current approvals.",
+		},
+		{
+			Keyword:     "approve-policies",
+			Aliases:     []string{command.ApprovePolicies.String()},
+			Name:        command.ApprovePolicies,
+			Description: "Approves the failing policies you own for the latest revision.",
+			Authorizer:  policyOwners,
+		},
+		{
+			Keyword:     command.Unlock.String(),
+			Name:        command.Unlock,
+			Description: "Cancels in progress plans and discards all plans of this pull request.",
+		},
+		{
+			Keyword:     command.Version.String(),
+			Name:        command.Version,
+			Description: "Prints the Terraform version of the roots modified by this pull request.\nTo print the version of a specific root, use the -p flag.",
+			Flags: func(flags *pflag.FlagSet, cmd *command.Comment) {
+				flags.StringVarP(&cmd.ProjectName, projectFlagLong, projectFlagShort, "", "Which root to print the version of.")
+			},
+		},
+	}
+}
@@ -0,0 +1,253 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/flynn-archive/go-shlex"
+	"github.com/runatlantis/atlantis/server/legacy/events"
+	"github.com/runatlantis/atlantis/server/legacy/events/command"
+	"github.com/runatlantis/atlantis/server/models"
+	"github.com/spf13/pflag"
+)
+
+const (
+	executable = "atlantis"
+	helpCmd    = "help"
+	usagesCols = 90
+)
+
+// multiLineRegex ignores multi-line comments while still allowing a trailing newline, see events.CommentParser
+var multiLineRegex = regexp.MustCompile(`.*\r?\n[^\r\n]+`)
+
+type authorizer interface {
+	Authorize(ctx context.Context, user models.User, installationToken int64) (bool, error)
+}
+
+// Command is a comment command supported by the PR workflow
+type Command struct {
+	// Keyword invokes the command, ex. atlantis policy-check
+	Keyword string
+	// Aliases also invoke the command, ex. the spellings supported by events.CommentParser
+	Aliases []string
+	Name    command.Name
+	// Description is rendered in the help comment
+	Description string
+	// Flags registers the command's flags, which populate the parsed comment
+	Flags func(flags *pflag.FlagSet, cmd *command.Comment)
+	// ExtraArgs are the terraform flags allowed after --, they're passed through to terraform
+	ExtraArgs []string
+	// Authorizer restricts who can run the command, anyone can if nil
+	Authorizer authorizer
+}
+
+func (c Command) flagSet(cmd *command.Comment) *pflag.FlagSet {
+	flags := pflag.NewFlagSet(c.Keyword, pflag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	if c.Flags != nil {
+		c.Flags(flags, cmd)
+	}
+	return flags
+}
+
+// allowsExtraArg only accepts allowed flags in the -flag or -flag=value form, values can't be
+// passed as a separate argument since they'd be indistinguishable from positional arguments
+func (c Command) allowsExtraArg(arg string) bool {
+	name := strings.SplitN(arg, "=", 2)[0]
+	for _, allowed := range c.ExtraArgs {
+		if name == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// legacyFlags select projects by dir and workspace in events.CommentParser, roots are only selected by name
+var legacyFlags = []string{"-d", "--dir", "-w", "--workspace"}
+
+// legacyFlag returns the first flag of args which is only supported by events.CommentParser, if any
+func legacyFlag(args []string) (string, bool) {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name := strings.SplitN(arg, "=", 2)[0]
+		for _, flag := range legacyFlags {
+			if name == flag {
+				return arg, true
+			}
+		}
+	}
+	return "", false
+}
+
+// UnauthorizedError is returned when a user isn't allowed to run a command
+type UnauthorizedError struct {
+	User    string
+	Keyword string
+}
+
+func (e *UnauthorizedError) Error() string {
+	return fmt.Sprintf("@%s is not authorized to run `%s %s`", e.User, executable, e.Keyword)
+}
+
+// Registry parses PR comments into the commands registered with it, it replaces events.CommentParser
+// for PRs handled by the PR workflow.
+type Registry struct {
+	GithubUser string
+
+	commands []Command
+}
+
+func NewRegistry(githubUser string, commands ...Command) *Registry {
+	return &Registry{
+		GithubUser: githubUser,
+		commands:   commands,
+	}
+}
+
+// Parse parses the comment as one of the registered commands, see events.CommentParsing
+func (r *Registry) Parse(comment string, vcsHost models.VCSHostType) events.CommentParseResult {
+	if multiLineRegex.MatchString(comment) {
+		return events.CommentParseResult{Ignore: true}
+	}
+
+	args := strings.Fields(comment)
+	if len(args) < 1 {
+		return events.CommentParseResult{Ignore: true}
+	}
+	if args[0] == "terraform" {
+		return events.CommentParseResult{CommentResponse: events.DidYouMeanAtlantisComment}
+	}
+	if !r.isExecutable(args[0], vcsHost) {
+		return events.CommentParseResult{Ignore: true}
+	}
+
+	// re-parse using a shell-style parser now that we know atlantis is being invoked
+	args, err := shlex.Split(comment)
+	if err != nil {
+		return events.CommentParseResult{CommentResponse: fmt.Sprintf("```\nError parsing command: %s\n```", err)}
+	}
+	if len(args) < 2 {
+		return events.CommentParseResult{CommentResponse: r.Help()}
+	}
+
+	keyword := args[1]
+	if keyword == helpCmd || keyword == "-h" || keyword == "--help" {
+		return events.CommentParseResult{CommentResponse: r.Help()}
+	}
+	c, ok := r.lookup(keyword)
+	if !ok {
+		return events.CommentParseResult{CommentResponse: fmt.Sprintf("```\nError: unknown command %q.\nRun '%s %s' for usage.\n```", keyword, executable, helpCmd)}
+	}
+
+	cmd := &command.Comment{Name: c.Name}
+	flags := c.flagSet(cmd)
+	if flag, ok := legacyFlag(args[2:]); ok {
+		return events.CommentParseResult{CommentResponse: errMarkdown(fmt.Sprintf("%s isn't supported, roots are no longer selected by dir or workspace, use -%s/--%s with the name of the root instead", flag, projectFlagShort, projectFlagLong), keyword, flags)}
+	}
+	err = flags.Parse(args[2:])
+	if err == pflag.ErrHelp {
+		return events.CommentParseResult{CommentResponse: fmt.Sprintf("```\nUsage of %s:\n%s\n```", keyword, flags.FlagUsagesWrapped(usagesCols))}
+	}
+	if err != nil {
+		return events.CommentParseResult{CommentResponse: errMarkdown(err.Error(), keyword, flags)}
+	}
+
+	unusedArgs := flags.Args()
+	var extraArgs []string
+	if dash := flags.ArgsLenAtDash(); dash != -1 {
+		unusedArgs = flags.Args()[:dash]
+		extraArgs = flags.Args()[dash:]
+	}
+	if len(unusedArgs) > 0 {
+		return events.CommentParseResult{CommentResponse: errMarkdown(fmt.Sprintf("unknown argument(s) – %s", strings.Join(unusedArgs, " ")), keyword, flags)}
+	}
+	if len(extraArgs) > 0 && len(c.ExtraArgs) == 0 {
+		return events.CommentParseResult{CommentResponse: errMarkdown("terraform arguments aren't supported", keyword, flags)}
+	}
+	for _, arg := range extraArgs {
+		if !c.allowsExtraArg(arg) {
+			return events.CommentParseResult{CommentResponse: errMarkdown(fmt.Sprintf("terraform argument %q isn't supported, supported arguments are %s in the -flag=value form", arg, strings.Join(c.ExtraArgs, ", ")), keyword, flags)}
+		}
+	}
+	cmd.Flags = extraArgs
+
+	return events.CommentParseResult{Command: cmd}
+}
+
+// Authorize returns an UnauthorizedError if the user isn't allowed to run the command
+func (r *Registry) Authorize(ctx context.Context, cmd *command.Comment, user models.User, installationToken int64) error {
+	for _, c := range r.commands {
+		if c.Name != cmd.Name || c.Authorizer == nil {
+			continue
+		}
+		ok, err := c.Authorizer.Authorize(ctx, user, installationToken)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return &UnauthorizedError{User: user.Username, Keyword: c.Keyword}
+		}
+	}
+	return nil
+}
+
+// Help renders the usage of every registered command
+func (r *Registry) Help() string {
+	var b strings.Builder
+	b.WriteString("```cmake\n")
+	fmt.Fprintf(&b, "%s\nTerraform Pull Request Automation\n\n", executable)
+	fmt.Fprintf(&b, "Usage:\n  %s <command> [options] -- [terraform options]\n\n", executable)
+	b.WriteString("Commands:\n")
+	for _, c := range r.commands {
+		writeCommandUsage(&b, c.Keyword, c.Description)
+		if usages := c.flagSet(&command.Comment{}).FlagUsagesWrapped(usagesCols); usages != "" {
+			for _, line := range strings.Split(strings.TrimRight(usages, "\n"), "\n") {
+				fmt.Fprintf(&b, "%-20s%s\n", "", line)
+			}
+		}
+	}
+	writeCommandUsage(&b, helpCmd, "View help.")
+	fmt.Fprintf(&b, "\nUse \"%s [command] --help\" for more information about a command.\n", executable)
+	b.WriteString("```")
+	return b.String()
+}
+
+func writeCommandUsage(b *strings.Builder, keyword string, description string) {
+	for i, line := range strings.Split(description, "\n") {
+		if i == 0 {
+			fmt.Fprintf(b, "  %-18s%s\n", keyword, line)
+			continue
+		}
+		fmt.Fprintf(b, "%-20s%s\n", "", line)
+	}
+}
+
+func (r *Registry) lookup(keyword string) (Command, bool) {
+	for _, c := range r.commands {
+		if c.Keyword == keyword {
+			return c, true
+		}
+		for _, alias := range c.Aliases {
+			if alias == keyword {
+				return c, true
+			}
+		}
+	}
+	return Command{}, false
+}
+
+func (r *Registry) isExecutable(arg string, vcsHost models.VCSHostType) bool {
+	if arg == executable || arg == "run" {
+		return true
+	}
+	return vcsHost == models.Github && r.GithubUser != "" && arg == "@"+r.GithubUser
+}
+
+func errMarkdown(errMsg string, keyword string, flags *pflag.FlagSet) string {
+	return fmt.Sprintf("```\nError: %s.\nUsage of %s:\n%s```", errMsg, keyword, flags.FlagUsagesWrapped(usagesCols))
+}
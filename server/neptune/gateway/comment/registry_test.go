@@ -0,0 +1,208 @@
+package comment_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/legacy/events"
+	"github.com/runatlantis/atlantis/server/legacy/events/command"
+	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/comment"
+	"github.com/stretchr/testify/assert"
+)
+
+type testFetcher struct {
+	members map[string][]string
+}
+
+func (f testFetcher) ListTeamMembers(_ context.Context, _ int64, teamSlug string) ([]string, error) {
+	return f.members[teamSlug], nil
+}
+
+func testRegistry() *comment.Registry {
+	policySets := valid.PolicySets{
+		PolicySets: []valid.PolicySet{
+			{Name: "a", Owner: "owners"},
+			{Name: "b", Owner: "owners"},
+		},
+	}
+	fetcher := testFetcher{members: map[string][]string{"owners": {"owner"}}}
+	return comment.NewRegistry("atlantis-bot", comment.DefaultCommands(comment.NewPolicyOwnerAuthorizer(policySets, fetcher))...)
+}
+
+func TestRegistry_Parse(t *testing.T) {
+	registry := testRegistry()
+
+	cases := []struct {
+		description string
+		comment     string
+		expected    events.CommentParseResult
+	}{
+		{
+			description: "not a command",
+			comment:     "lgtm",
+			expected:    events.CommentParseResult{Ignore: true},
+		},
+		{
+			description: "multi-line",
+			comment:     "atlantis plan\nplease",
+			expected:    events.CommentParseResult{Ignore: true},
+		},
+		{
+			description: "terraform",
+			comment:     "terraform plan",
+			expected:    events.CommentParseResult{CommentResponse: events.DidYouMeanAtlantisComment},
+		},
+		{
+			description: "plan with extra args",
+			comment:     "atlantis plan -p root -- -var=foo=bar -compact-warnings",
+			expected: events.CommentParseResult{Command: &command.Comment{
+				Name:        command.Plan,
+				ProjectName: "root",
+				Flags:       []string{"-var=foo=bar", "-compact-warnings"},
+			}},
+		},
+		{
+			description: "force apply",
+			comment:     "@atlantis-bot apply -f",
+			expected: events.CommentParseResult{Command: &command.Comment{
+				Name:       command.Apply,
+				ForceApply: true,
+			}},
+		},
+		{
+			description: "policy check",
+			comment:     "atlantis policy-check",
+			expected:    events.CommentParseResult{Command: &command.Comment{Name: command.PolicyCheck}},
+		},
+		{
+			description: "approve policies",
+			comment:     "run approve-policies",
+			expected:    events.CommentParseResult{Command: &command.Comment{Name: command.ApprovePolicies}},
+		},
+		{
+			description: "policy check alias",
+			comment:     "atlantis policy_check",
+			expected:    events.CommentParseResult{Command: &command.Comment{Name: command.PolicyCheck}},
+		},
+		{
+			description: "approve policies alias",
+			comment:     "atlantis approve_policies",
+			expected:    events.CommentParseResult{Command: &command.Comment{Name: command.ApprovePolicies}},
+		},
+		{
+			description: "version",
+			comment:     "atlantis version -p root",
+			expected: events.CommentParseResult{Command: &command.Comment{
+				Name:        command.Version,
+				ProjectName: "root",
+			}},
+		},
+		{
+			description: "unlock",
+			comment:     "atlantis unlock",
+			expected:    events.CommentParseResult{Command: &command.Comment{Name: command.Unlock}},
+		},
+		{
+			description: "help",
+			comment:     "atlantis help",
+			expected:    events.CommentParseResult{CommentResponse: registry.Help()},
+		},
+		{
+			description: "no command",
+			comment:     "atlantis",
+			expected:    events.CommentParseResult{CommentResponse: registry.Help()},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			assert.Equal(t, c.expected, registry.Parse(c.comment, models.Github))
+		})
+	}
+}
+
+func TestRegistry_Parse_Errors(t *testing.T) {
+	registry := testRegistry()
+
+	cases := []struct {
+		description string
+		comment     string
+		contains    string
+	}{
+		{
+			description: "unknown command",
+			comment:     "atlantis import",
+			contains:    `Error: unknown command "import".`,
+		},
+		{
+			description: "unknown flag",
+			comment:     "atlantis unlock -p root",
+			contains:    "Error: unknown shorthand flag: 'p' in -p.",
+		},
+		{
+			description: "unused args",
+			comment:     "atlantis policy-check now",
+			contains:    "Error: unknown argument(s) – now.",
+		},
+		{
+			description: "extra arg not allowed",
+			comment:     "atlantis plan -- -target=foo",
+			contains:    `Error: terraform argument "-target=foo" isn't supported`,
+		},
+		{
+			description: "extra arg value not in flag=value form",
+			comment:     "atlantis plan -- -var foo=bar",
+			contains:    `Error: terraform argument "foo=bar" isn't supported`,
+		},
+		{
+			description: "dir flag",
+			comment:     "atlantis plan -d dir",
+			contains:    "Error: -d isn't supported, roots are no longer selected by dir or workspace, use -p/--project with the name of the root instead.",
+		},
+		{
+			description: "workspace flag",
+			comment:     "atlantis apply --workspace=staging",
+			contains:    "Error: --workspace=staging isn't supported",
+		},
+		{
+			description: "extra args not supported",
+			comment:     "atlantis apply -- -target=foo",
+			contains:    "Error: terraform arguments aren't supported.",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			result := registry.Parse(c.comment, models.Github)
+			assert.Nil(t, result.Command)
+			assert.Contains(t, result.CommentResponse, c.contains)
+		})
+	}
+}
+
+func TestRegistry_Help(t *testing.T) {
+	help := testRegistry().Help()
+	for _, keyword := range []string{"plan", "apply", "policy-check", "approve-policies", "unlock", "version", "help"} {
+		assert.Contains(t, help, "  "+keyword+" ")
+	}
+	assert.Contains(t, help, "--force")
+}
+
+func TestRegistry_Authorize(t *testing.T) {
+	registry := testRegistry()
+
+	t.Run("unrestricted command", func(t *testing.T) {
+		err := registry.Authorize(context.Background(), &command.Comment{Name: command.Unlock}, models.User{Username: "someone"}, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("policy owner", func(t *testing.T) {
+		err := registry.Authorize(context.Background(), &command.Comment{Name: command.ApprovePolicies}, models.User{Username: "owner"}, 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("not a policy owner", func(t *testing.T) {
+		err := registry.Authorize(context.Background(), &command.Comment{Name: command.ApprovePolicies}, models.User{Username: "someone"}, 1)
+		assert.Equal(t, &comment.UnauthorizedError{User: "someone", Keyword: "approve-policies"}, err)
+	})
+}
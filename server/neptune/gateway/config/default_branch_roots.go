@@ -0,0 +1,36 @@
+package config
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/models"
+)
+
+// DefaultBranchRoots reads roots from the repo config on the default branch.  Attributes which
+// grant or restrict access (ie. owners, policy scopes) are read from the default branch rather than
+// the PR since its author controls the PR's repo config, the same as CODEOWNERS.
+type DefaultBranchRoots struct {
+	ContentsFetcher contentsFetcher
+	ParserValidator parserValidator
+}
+
+// FetchRepoCfg returns the repo config of the default branch, it has no roots if there is none.
+// The default branch is used when the repo's isn't known.
+func (f *DefaultBranchRoots) FetchRepoCfg(ctx context.Context, repo models.Repo, installationToken int64) (valid.RepoCfg, error) {
+	found, data, err := f.ContentsFetcher.GetFileContents(ctx, repo, installationToken, repo.DefaultBranch, config.AtlantisYAMLFilename)
+	if err != nil {
+		return valid.RepoCfg{}, errors.Wrapf(err, "fetching %s", config.AtlantisYAMLFilename)
+	}
+	if !found {
+		return valid.RepoCfg{}, nil
+	}
+
+	repoCfg, err := f.ParserValidator.ParseRepoCfgData(data, repo.ID())
+	if err != nil {
+		return valid.RepoCfg{}, errors.Wrapf(err, "parsing %s", config.AtlantisYAMLFilename)
+	}
+	return repoCfg, nil
+}
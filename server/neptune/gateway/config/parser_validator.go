@@ -36,7 +36,13 @@ func (p *ParserValidator) ParseRepoCfg(absRepoDir string, repoID string) (valid.
 	if err != nil {
 		return valid.RepoCfg{}, errors.Wrapf(err, "unable to read %s file", AtlantisYAMLFilename)
 	}
-	return p.parseRepoCfgData(configData, repoID)
+	return p.ParseRepoCfgData(configData, repoID)
+}
+
+// ParseRepoCfgData returns the parsed and validated atlantis.yaml config from its contents,
+// this is used when the config is fetched remotely instead of from a clone.
+func (p *ParserValidator) ParseRepoCfgData(repoCfgData []byte, repoID string) (valid.RepoCfg, error) {
+	return p.parseRepoCfgData(repoCfgData, repoID)
 }
 
 // hasRepoCfg returns true if there is a repo config (atlantis.yaml) file for the repo at absRepoDir.
@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -15,14 +16,19 @@ import (
 	"github.com/uber-go/tally/v4"
 )
 
+const (
+	remoteBuildMetric = "remote"
+	cloneBuildMetric  = "clone"
+)
+
 // repoFetcher manages a cloned repo's workspace on disk for running commands.
 type repoFetcher interface {
-	Fetch(ctx context.Context, baseRepo models.Repo, branch string, sha string, options github.RepoFetcherOptions) (string, func(ctx context.Context, filePath string), error)
+	Fetch(ctx context.Context, baseRepo models.Repo, installationToken int64, branch string, sha string, options github.RepoFetcherOptions) (string, func(ctx context.Context, filePath string), error)
 }
 
 // hooksRunner runs preworkflow hooks for a given repository/commit
 type hooksRunner interface {
-	Run(ctx context.Context, repo models.Repo, repoDir string) error
+	Run(ctx context.Context, repo models.Repo, repoDir string, installationToken int64) error
 }
 
 // fileFetcher handles being able to identify and fetch the changed files per individual commit
@@ -40,6 +46,33 @@ type rootFinder interface {
 // parserValidator config builds repo specific configurations
 type parserValidator interface {
 	ParseRepoCfg(absRepoDir string, repoID string) (valid.RepoCfg, error)
+	ParseRepoCfgData(repoCfgData []byte, repoID string) (valid.RepoCfg, error)
+}
+
+// contentsFetcher reads files from a commit without cloning the repo.
+type contentsFetcher interface {
+	GetFileContents(ctx context.Context, repo models.Repo, installationToken int64, ref string, path string) (bool, []byte, error)
+}
+
+// remoteRootFinder determines which roots were modified without a clone of the repo.
+type remoteRootFinder interface {
+	FindRoots(ctx context.Context, config valid.RepoCfg, repo models.Repo, installationToken int64, sha string, modifiedFiles []string) ([]valid.Project, error)
+}
+
+// rootFingerprinter computes content fingerprints keyed by root name.
+type rootFingerprinter interface {
+	FingerprintLocal(ctx context.Context, absRepoDir string, roots []valid.Project) (map[string]string, error)
+	FingerprintRemote(ctx context.Context, repo models.Repo, installationToken int64, sha string, roots []valid.Project) (map[string]string, error)
+}
+
+// codeOwnersFetcher reads a repo's CODEOWNERS file.
+type codeOwnersFetcher interface {
+	FetchCodeOwners(ctx context.Context, repo models.Repo, installationToken int64) (github.CodeOwners, error)
+}
+
+// defaultBranchRootsFetcher reads a trusted copy of the repo config.
+type defaultBranchRootsFetcher interface {
+	FetchRepoCfg(ctx context.Context, repo models.Repo, installationToken int64) (valid.RepoCfg, error)
 }
 
 type ModifiedRootsStrategy struct {
@@ -66,6 +99,45 @@ func (s *ModifiedRootsStrategy) FindMatches(ctx context.Context, config valid.Re
 	return matchingRoots, nil
 }
 
+// RemoteRootsStrategy determines the repo config and modified roots through the github API
+// which avoids cloning the repo.
+type RemoteRootsStrategy struct {
+	ContentsFetcher contentsFetcher
+	FileFetcher     fileFetcher
+	RootFinder      remoteRootFinder
+}
+
+// FetchRepoCfg returns the contents of the repo config, if there was no config file it will
+// return an os.ErrNotExist error, consistent with parsing from a clone.
+func (s *RemoteRootsStrategy) FetchRepoCfg(ctx context.Context, commit *RepoCommit, installationToken int64) ([]byte, error) {
+	found, data, err := s.ContentsFetcher.GetFileContents(ctx, commit.Repo, installationToken, commit.Sha, config.AtlantisYAMLFilename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching %s", config.AtlantisYAMLFilename)
+	}
+	if !found {
+		return nil, os.ErrNotExist
+	}
+	return data, nil
+}
+
+func (s *RemoteRootsStrategy) FindMatches(ctx context.Context, config valid.RepoCfg, commit *RepoCommit, installationToken int64) ([]valid.Project, error) {
+	modifiedFiles, err := s.FileFetcher.GetModifiedFiles(ctx, commit.Repo, installationToken, github.FileFetcherOptions{
+		PRNum: commit.OptionalPRNum,
+		Sha:   commit.Sha,
+	})
+	if err != nil {
+		debugStr := fmt.Sprintf("sha: %s, prNum: %d", commit.Sha, commit.OptionalPRNum)
+		return nil, errors.Wrapf(err, "finding modified files, debug str: %s", debugStr)
+	}
+
+	matchingRoots, err := s.RootFinder.FindRoots(ctx, config, commit.Repo, installationToken, commit.Sha, modifiedFiles)
+	if err != nil {
+		return nil, errors.Wrap(err, "determining roots")
+	}
+
+	return matchingRoots, nil
+}
+
 type RepoCommit struct {
 	Repo          models.Repo
 	Branch        string
@@ -86,11 +158,29 @@ type Builder struct {
 	GlobalCfg       valid.GlobalCfg
 	Logger          logging.Logger
 	Scope           tally.Scope
+
+	// RemoteStrategy is optional, when set roots are built without cloning the repo unless
+	// pre-workflow hooks need to run against its tree (ie. to generate the repo config).
+	RemoteStrategy *RemoteRootsStrategy
+
+	// CodeOwners is optional, when set it is used to determine the owners of roots which
+	// don't define their own for repos with the owners requirement.
+	CodeOwners codeOwnersFetcher
+
+	// DefaultBranchRoots is optional, when set it provides the apply settings overrides and explicit
+	// owners of roots and the attributes policy sets are scoped on. These are never read from the config
+	// being built since it can come from an untrusted PR.
+	DefaultBranchRoots defaultBranchRootsFetcher
+
+	// Fingerprinter is optional, when set roots are fingerprinted if requested through the builder options.
+	Fingerprinter rootFingerprinter
 }
 
 type BuilderOptions struct {
 	RepoFetcherOptions *github.RepoFetcherOptions
 	RootNames          []string
+	// Fingerprint populates the fingerprint of each root so unchanged roots can be skipped when re-planning
+	Fingerprint bool
 }
 
 func (b *Builder) Build(ctx context.Context, commit *RepoCommit, installationToken int64, opts ...BuilderOptions) ([]*valid.MergedProjectCfg, error) {
@@ -110,6 +200,7 @@ func (b *Builder) Build(ctx context.Context, commit *RepoCommit, installationTok
 func (b *Builder) build(ctx context.Context, commit *RepoCommit, installationToken int64, opts ...BuilderOptions) ([]*valid.MergedProjectCfg, error) {
 	var repoOptions github.RepoFetcherOptions
 	var rootNames []string
+	var fingerprint bool
 	for _, o := range opts {
 		if o.RepoFetcherOptions != nil {
 			repoOptions = *o.RepoFetcherOptions
@@ -118,10 +209,21 @@ func (b *Builder) build(ctx context.Context, commit *RepoCommit, installationTok
 		if len(o.RootNames) > 0 {
 			rootNames = o.RootNames
 		}
+
+		if o.Fingerprint {
+			fingerprint = true
+		}
 	}
+	fingerprint = fingerprint && b.Fingerprinter != nil
+
+	if b.RemoteStrategy != nil && !b.hasPreWorkflowHooks(commit.Repo) {
+		b.Scope.Counter(remoteBuildMetric).Inc(1)
+		return b.buildRemote(ctx, commit, installationToken, rootNames, fingerprint)
+	}
+	b.Scope.Counter(cloneBuildMetric).Inc(1)
 
 	// Generate a new filepath location and clone repo into it
-	repoDir, cleanup, err := b.RepoFetcher.Fetch(ctx, commit.Repo, commit.Branch, commit.Sha, repoOptions)
+	repoDir, cleanup, err := b.RepoFetcher.Fetch(ctx, commit.Repo, installationToken, commit.Branch, commit.Sha, repoOptions)
 	if err != nil {
 		return nil, errors.Wrap(err, fmt.Sprintf("creating temporary clone at path: %s", repoDir))
 	}
@@ -135,15 +237,12 @@ func (b *Builder) build(ctx context.Context, commit *RepoCommit, installationTok
 	b.Logger.Info(fmt.Sprintf("localRepo is: full repo name: %s, commit sha: %s, commit branch: %s, commit repo name: %s, repodir: %s", commit.Repo.FullName, commit.Sha, commit.Branch, commit.Repo.Name, repoDir))
 
 	// Run pre-workflow hooks
-	err = b.HooksRunner.Run(ctx, localRepo.Repo, localRepo.Dir)
+	err = b.HooksRunner.Run(ctx, localRepo.Repo, localRepo.Dir, installationToken)
 	if err != nil {
 		return nil, errors.Wrap(err, "running pre-workflow hooks")
 	}
 
 	// Parse repo configs into specific root configs (i.e. roots)
-	// TODO: rename project to roots
-	var mergedRootCfgs []*valid.MergedProjectCfg
-
 	repoCfg, err := b.ParserValidator.ParseRepoCfg(localRepo.Dir, localRepo.Repo.ID())
 	if err != nil {
 		return nil, errors.Wrapf(err, "parsing %s", config.AtlantisYAMLFilename)
@@ -154,12 +253,216 @@ func (b *Builder) build(ctx context.Context, commit *RepoCommit, installationTok
 		return nil, errors.Wrap(err, "getting matching roots")
 	}
 
-	b.Logger.Info(fmt.Sprintf("merging roots for %s", localRepo.Repo.FullName))
+	mergedRootCfgs := b.mergeRoots(localRepo.Repo, matchingRoots, repoCfg)
+	if fingerprint && len(matchingRoots) > 0 {
+		fingerprints, err := b.Fingerprinter.FingerprintLocal(ctx, localRepo.Dir, matchingRoots)
+		if err != nil {
+			// not fatal since roots without a fingerprint are always planned
+			b.Logger.WarnContext(ctx, "unable to fingerprint roots", map[string]interface{}{
+				"err": err,
+			})
+		}
+		applyFingerprints(mergedRootCfgs, fingerprints)
+	}
+
+	return b.resolveDefaultBranchAttributes(ctx, localRepo.Repo, installationToken, mergedRootCfgs)
+}
+
+func (b *Builder) buildRemote(ctx context.Context, commit *RepoCommit, installationToken int64, rootNames []string, fingerprint bool) ([]*valid.MergedProjectCfg, error) {
+	b.Logger.Info(fmt.Sprintf("building roots without a clone: full repo name: %s, commit sha: %s, commit branch: %s", commit.Repo.FullName, commit.Sha, commit.Branch))
+
+	repoCfgData, err := b.RemoteStrategy.FetchRepoCfg(ctx, commit, installationToken)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", config.AtlantisYAMLFilename)
+	}
+
+	repoCfg, err := b.ParserValidator.ParseRepoCfgData(repoCfgData, commit.Repo.ID())
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", config.AtlantisYAMLFilename)
+	}
+
+	var matchingRoots []valid.Project
+	if len(rootNames) > 0 {
+		matchingRoots, err = b.validateAndGetRoots(repoCfg, rootNames)
+	} else {
+		matchingRoots, err = b.RemoteStrategy.FindMatches(ctx, repoCfg, commit, installationToken)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "getting matching roots")
+	}
+
+	mergedRootCfgs := b.mergeRoots(commit.Repo, matchingRoots, repoCfg)
+	if fingerprint && len(matchingRoots) > 0 {
+		fingerprints, err := b.Fingerprinter.FingerprintRemote(ctx, commit.Repo, installationToken, commit.Sha, matchingRoots)
+		if err != nil {
+			// not fatal since roots without a fingerprint are always planned
+			b.Logger.WarnContext(ctx, "unable to fingerprint roots", map[string]interface{}{
+				"err": err,
+			})
+		}
+		applyFingerprints(mergedRootCfgs, fingerprints)
+	}
+
+	return b.resolveDefaultBranchAttributes(ctx, commit.Repo, installationToken, mergedRootCfgs)
+}
+
+func applyFingerprints(rootCfgs []*valid.MergedProjectCfg, fingerprints map[string]string) {
+	for _, rootCfg := range rootCfgs {
+		rootCfg.Fingerprint = fingerprints[rootCfg.Name]
+	}
+}
+
+func (b *Builder) mergeRoots(repo models.Repo, matchingRoots []valid.Project, repoCfg valid.RepoCfg) []*valid.MergedProjectCfg {
+	// TODO: rename project to roots
+	var mergedRootCfgs []*valid.MergedProjectCfg
+
+	b.Logger.Info(fmt.Sprintf("merging roots for %s", repo.FullName))
 	for _, mr := range matchingRoots {
-		mergedRootCfg := b.GlobalCfg.MergeProjectCfg(localRepo.Repo.ID(), mr, repoCfg)
+		mergedRootCfg := b.GlobalCfg.MergeProjectCfg(repo.ID(), mr, repoCfg)
 		mergedRootCfgs = append(mergedRootCfgs, &mergedRootCfg)
 	}
-	return mergedRootCfgs, nil
+	return mergedRootCfgs
+}
+
+// resolveDefaultBranchAttributes sets the apply settings, owners and policy targets of roots from the
+// default branch's repo config.  It is only fetched if the apply settings can be overridden, or if the
+// owners requirement, scoped policy sets or policy exceptions need it.
+func (b *Builder) resolveDefaultBranchAttributes(ctx context.Context, repo models.Repo, installationToken int64, rootCfgs []*valid.MergedProjectCfg) ([]*valid.MergedProjectCfg, error) {
+	if len(rootCfgs) == 0 {
+		return rootCfgs, nil
+	}
+
+	var defaultBranchRoots map[string]valid.Project
+	fetch := func() error {
+		if defaultBranchRoots != nil {
+			return nil
+		}
+		defaultBranchRoots = make(map[string]valid.Project)
+		if b.DefaultBranchRoots == nil {
+			return nil
+		}
+		repoCfg, err := b.DefaultBranchRoots.FetchRepoCfg(ctx, repo, installationToken)
+		if err != nil {
+			return errors.Wrap(err, "fetching default branch roots")
+		}
+		for _, p := range repoCfg.Projects {
+			defaultBranchRoots[p.GetName()] = p
+		}
+		return nil
+	}
+
+	if b.GlobalCfg.AllowsOverride(repo.ID(), valid.ApplySettingsKey) {
+		if err := fetch(); err != nil {
+			return nil, err
+		}
+		resolveApplySettings(b.GlobalCfg, repo, rootCfgs, defaultBranchRoots)
+	}
+
+	var ownedRoots []*valid.MergedProjectCfg
+	for _, rootCfg := range rootCfgs {
+		if rootCfg.ApplySettings.ContainsPRRequirement(valid.OwnersApplyReq) {
+			ownedRoots = append(ownedRoots, rootCfg)
+		}
+	}
+	// exceptions are matched on the root so they need policy targets as much as scoped policy sets do
+	targeted := b.GlobalCfg.PolicySets.HasScopedPolicies() || len(b.GlobalCfg.PolicySets.Exceptions) > 0
+	if len(ownedRoots) == 0 && !targeted {
+		return rootCfgs, nil
+	}
+	if err := fetch(); err != nil {
+		return nil, err
+	}
+
+	if targeted {
+		resolvePolicyTargets(rootCfgs, defaultBranchRoots)
+	}
+	if err := b.resolveOwners(ctx, repo, installationToken, ownedRoots, defaultBranchRoots); err != nil {
+		return nil, err
+	}
+	return rootCfgs, nil
+}
+
+// resolveApplySettings replaces the apply settings of roots with the overrides of the root on the default
+// branch, roots which don't exist there use the repo's settings.
+func resolveApplySettings(globalCfg valid.GlobalCfg, repo models.Repo, rootCfgs []*valid.MergedProjectCfg, defaultBranchRoots map[string]valid.Project) {
+	for _, rootCfg := range rootCfgs {
+		var root *valid.Project
+		if r, ok := defaultBranchRoots[rootCfg.Name]; ok {
+			root = &r
+		}
+		rootCfg.ApplySettings = globalCfg.RootApplySettings(repo.ID(), root)
+	}
+}
+
+// BuildDefaultBranchRoot builds the root as configured on the default branch, nil if it isn't
+// configured there.  It's meant for authorizing requests which aren't tied to a trusted commit,
+// ie. plan reviews.
+func (b *Builder) BuildDefaultBranchRoot(ctx context.Context, repo models.Repo, installationToken int64, rootName string) (*valid.MergedProjectCfg, error) {
+	if b.DefaultBranchRoots == nil {
+		return nil, nil
+	}
+	repoCfg, err := b.DefaultBranchRoots.FetchRepoCfg(ctx, repo, installationToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching default branch roots")
+	}
+	for _, p := range repoCfg.Projects {
+		if p.GetName() == rootName {
+			rootCfg := b.GlobalCfg.MergeProjectCfg(repo.ID(), p, repoCfg)
+			return &rootCfg, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolvePolicyTargets sets the policy target of roots which exist on the default branch, roots
+// without one are matched by every policy set scope.
+func resolvePolicyTargets(rootCfgs []*valid.MergedProjectCfg, defaultBranchRoots map[string]valid.Project) {
+	for _, rootCfg := range rootCfgs {
+		root, ok := defaultBranchRoots[rootCfg.Name]
+		if !ok {
+			continue
+		}
+		rootCfg.PolicyTarget = &valid.PolicyTarget{
+			Name: root.GetName(),
+			Path: root.Dir,
+			Tags: root.Tags,
+		}
+	}
+}
+
+// resolveOwners sets the owners of roots from the default branch's repo config, falling back to the
+// repo's CODEOWNERS for roots without explicit owners.
+func (b *Builder) resolveOwners(ctx context.Context, repo models.Repo, installationToken int64, ownedRoots []*valid.MergedProjectCfg, defaultBranchRoots map[string]valid.Project) error {
+	var unowned []*valid.MergedProjectCfg
+	for _, rootCfg := range ownedRoots {
+		rootCfg.Owners = defaultBranchRoots[rootCfg.Name].Owners
+		if len(rootCfg.Owners) == 0 {
+			unowned = append(unowned, rootCfg)
+		}
+	}
+	if len(unowned) == 0 || b.CodeOwners == nil {
+		return nil
+	}
+
+	codeOwners, err := b.CodeOwners.FetchCodeOwners(ctx, repo, installationToken)
+	if err != nil {
+		return errors.Wrap(err, "fetching code owners")
+	}
+	for _, rootCfg := range unowned {
+		rootCfg.Owners = codeOwners.Owners(rootCfg.RepoRelDir)
+	}
+	return nil
+}
+
+// hasPreWorkflowHooks mirrors how hooks are selected when they are run, since they can
+// generate or modify the repo config we need a clone whenever any are configured.
+func (b *Builder) hasPreWorkflowHooks(repo models.Repo) bool {
+	for _, r := range b.GlobalCfg.Repos {
+		if r.IDMatches(repo.ID()) && len(r.PreWorkflowHooks) > 0 {
+			return true
+		}
+	}
+	return false
 }
 
 func (b *Builder) getMatchingRoots(ctx context.Context, config valid.RepoCfg, repo *LocalRepo, installationToken int64, rootNames []string) ([]valid.Project, error) {
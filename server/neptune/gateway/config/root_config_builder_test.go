@@ -3,6 +3,7 @@ package config_test
 import (
 	"context"
 	"errors"
+	"os"
 	"testing"
 
 	"github.com/runatlantis/atlantis/server/vcs/provider/github"
@@ -230,13 +231,471 @@ func TestRootConfigBuilder_HooksRunnerError(t *testing.T) {
 	assert.Empty(t, projectConfigs)
 }
 
+func TestRootConfigBuilder_Remote(t *testing.T) {
+	setupTesting(t)
+	commit := &config.RepoCommit{
+		Repo: models.Repo{
+			FullName: "nish/repo",
+		},
+		Sha: "1234",
+	}
+	root := testRoot
+	projects := []valid.Project{
+		{
+			Name: &root,
+		},
+	}
+
+	repoFetcher := &mockRepoFetcher{}
+	parserValidator := &mockParserValidator{
+		repoCfg: valid.RepoCfg{
+			Projects: projects,
+		},
+	}
+	rootFinder := &mockRemoteRootFinder{
+		ConfigProjects: projects,
+	}
+	rcb.RepoFetcher = repoFetcher
+	rcb.ParserValidator = parserValidator
+	rcb.RemoteStrategy = &config.RemoteRootsStrategy{
+		ContentsFetcher: &mockContentsFetcher{
+			found: true,
+			data:  []byte("version: 3"),
+		},
+		FileFetcher: &mockFileFetcher{},
+		RootFinder:  rootFinder,
+	}
+
+	projCfg := globalCfg.MergeProjectCfg(commit.Repo.ID(), projects[0], parserValidator.repoCfg)
+
+	projectConfigs, err := rcb.Build(context.Background(), commit, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []*valid.MergedProjectCfg{&projCfg}, projectConfigs)
+	assert.Equal(t, []byte("version: 3"), parserValidator.data)
+	assert.True(t, rootFinder.called)
+	assert.False(t, repoFetcher.called)
+}
+
+func TestRootConfigBuilder_Remote_MissingRepoCfg(t *testing.T) {
+	setupTesting(t)
+	commit := &config.RepoCommit{
+		Repo: models.Repo{
+			FullName: "nish/repo",
+		},
+		Sha: "1234",
+	}
+	rcb.RemoteStrategy = &config.RemoteRootsStrategy{
+		ContentsFetcher: &mockContentsFetcher{},
+		FileFetcher:     &mockFileFetcher{},
+		RootFinder:      &mockRemoteRootFinder{},
+	}
+
+	_, err := rcb.Build(context.Background(), commit, 2)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRootConfigBuilder_Remote_PreWorkflowHooksClone(t *testing.T) {
+	setupTesting(t)
+	commit := &config.RepoCommit{
+		Repo: models.Repo{
+			FullName: "nish/repo",
+			VCSHost: models.VCSHost{
+				Hostname: "github.com",
+			},
+		},
+		Sha: "1234",
+	}
+
+	rcb.GlobalCfg.Repos = append(rcb.GlobalCfg.Repos, valid.Repo{
+		ID: "github.com/nish/repo",
+		PreWorkflowHooks: []*valid.PreWorkflowHook{
+			{StepName: "generate", RunCommand: "generate-config"},
+		},
+	})
+	repoFetcher := &mockRepoFetcher{}
+	rootFinder := &mockRemoteRootFinder{}
+	rcb.RepoFetcher = repoFetcher
+	rcb.RemoteStrategy = &config.RemoteRootsStrategy{
+		ContentsFetcher: &mockContentsFetcher{},
+		FileFetcher:     &mockFileFetcher{},
+		RootFinder:      rootFinder,
+	}
+
+	_, err := rcb.Build(context.Background(), commit, 2)
+	assert.NoError(t, err)
+	assert.True(t, repoFetcher.called)
+	assert.False(t, rootFinder.called)
+}
+
 // Mock implementations
 
+func TestRootConfigBuilder_Owners(t *testing.T) {
+	setupTesting(t)
+	commit := &config.RepoCommit{
+		Repo: models.Repo{
+			FullName: "nish/repo",
+			VCSHost:  models.VCSHost{Hostname: "github.com"},
+		},
+		Sha: "1234",
+	}
+	explicitRoot := "explicit"
+	codeOwnedRoot := "codeowned"
+	projects := []valid.Project{
+		{
+			Name: &explicitRoot,
+			Dir:  "explicit",
+		},
+		{
+			Name: &codeOwnedRoot,
+			Dir:  "terraform/codeowned",
+			// owners from the PR are ignored
+			Owners: []string{"mallory"},
+		},
+	}
+	rcb.Strategy.RootFinder = &mockRootFinder{
+		ConfigProjects: projects,
+	}
+	rcb.DefaultBranchRoots = &mockDefaultBranchRootsFetcher{
+		repoCfg: valid.RepoCfg{
+			Projects: []valid.Project{
+				{Name: &explicitRoot, Dir: "explicit", Owners: []string{"alice"}},
+			},
+		},
+	}
+	rcb.CodeOwners = &mockCodeOwnersFetcher{
+		codeOwners: github.ParseCodeOwners([]byte("/terraform/ @org/team")),
+	}
+
+	t.Run("requirement disabled", func(t *testing.T) {
+		projectConfigs, err := rcb.Build(context.Background(), commit, 2)
+		assert.NoError(t, err)
+		assert.Empty(t, projectConfigs[0].Owners)
+		assert.Empty(t, projectConfigs[1].Owners)
+	})
+
+	t.Run("requirement enabled", func(t *testing.T) {
+		rcb.GlobalCfg.Repos[0].ApplySettings.PRRequirements = []string{valid.OwnersApplyReq}
+
+		projectConfigs, err := rcb.Build(context.Background(), commit, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"alice"}, projectConfigs[0].Owners)
+		assert.Equal(t, []string{"org/team"}, projectConfigs[1].Owners)
+	})
+
+	t.Run("root owners error", func(t *testing.T) {
+		rcb.DefaultBranchRoots = &mockDefaultBranchRootsFetcher{err: assert.AnError}
+
+		_, err := rcb.Build(context.Background(), commit, 2)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("code owners error", func(t *testing.T) {
+		rcb.DefaultBranchRoots = &mockDefaultBranchRootsFetcher{}
+		rcb.CodeOwners = &mockCodeOwnersFetcher{err: assert.AnError}
+
+		_, err := rcb.Build(context.Background(), commit, 2)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestRootConfigBuilder_PolicyTargets(t *testing.T) {
+	setupTesting(t)
+	commit := &config.RepoCommit{
+		Repo: models.Repo{
+			FullName: "nish/repo",
+			VCSHost:  models.VCSHost{Hostname: "github.com"},
+		},
+		Sha: "1234",
+	}
+	existingRoot := "existing"
+	newRoot := "new"
+	rcb.Strategy.RootFinder = &mockRootFinder{
+		ConfigProjects: []valid.Project{
+			{
+				Name: &existingRoot,
+				Dir:  "moved",
+				// tags from the PR are ignored
+				Tags: map[string]string{"env": "dev"},
+			},
+			{
+				Name: &newRoot,
+				Dir:  "new",
+			},
+		},
+	}
+	rcb.DefaultBranchRoots = &mockDefaultBranchRootsFetcher{
+		repoCfg: valid.RepoCfg{
+			Projects: []valid.Project{
+				{Name: &existingRoot, Dir: "existing", Tags: map[string]string{"env": "prod"}},
+			},
+		},
+	}
+
+	t.Run("no scoped policies", func(t *testing.T) {
+		projectConfigs, err := rcb.Build(context.Background(), commit, 2)
+		assert.NoError(t, err)
+		assert.Nil(t, projectConfigs[0].PolicyTarget)
+		assert.Nil(t, projectConfigs[1].PolicyTarget)
+	})
+
+	t.Run("policy exceptions", func(t *testing.T) {
+		rcb.GlobalCfg.PolicySets = valid.PolicySets{
+			Exceptions: []valid.PolicyException{
+				{PolicySet: "prod", Repo: "nish/*", Root: existingRoot},
+			},
+		}
+
+		projectConfigs, err := rcb.Build(context.Background(), commit, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, existingRoot, projectConfigs[0].PolicyTarget.Name)
+		assert.Nil(t, projectConfigs[1].PolicyTarget)
+	})
+
+	t.Run("scoped policies", func(t *testing.T) {
+		rcb.GlobalCfg.PolicySets = valid.PolicySets{
+			PolicySets: []valid.PolicySet{
+				{Name: "prod", AppliesTo: valid.PolicySetScope{Tags: map[string]string{"env": "prod"}}},
+			},
+		}
+
+		projectConfigs, err := rcb.Build(context.Background(), commit, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, &valid.PolicyTarget{
+			Name: existingRoot,
+			Path: "existing",
+			Tags: map[string]string{"env": "prod"},
+		}, projectConfigs[0].PolicyTarget)
+		assert.Nil(t, projectConfigs[1].PolicyTarget)
+	})
+
+	t.Run("default branch roots error", func(t *testing.T) {
+		rcb.DefaultBranchRoots = &mockDefaultBranchRootsFetcher{err: assert.AnError}
+
+		_, err := rcb.Build(context.Background(), commit, 2)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestRootConfigBuilder_ApplySettings(t *testing.T) {
+	setupTesting(t)
+	commit := &config.RepoCommit{
+		Repo: models.Repo{
+			FullName: "nish/repo",
+			VCSHost:  models.VCSHost{Hostname: "github.com"},
+		},
+		Sha: "1234",
+	}
+	existingRoot := "existing"
+	newRoot := "new"
+	// the PR attempts to loosen the requirements of its own roots
+	prOverride := &valid.ApplySettings{
+		PRRequirements: []string{},
+		Team:           "mine",
+		MinApprovals:   1,
+	}
+	rcb.Strategy.RootFinder = &mockRootFinder{
+		ConfigProjects: []valid.Project{
+			{Name: &existingRoot, Dir: "existing", ApplySettings: prOverride},
+			{Name: &newRoot, Dir: "new", ApplySettings: prOverride},
+		},
+	}
+	rcb.DefaultBranchRoots = &mockDefaultBranchRootsFetcher{
+		repoCfg: valid.RepoCfg{
+			Projects: []valid.Project{
+				{Name: &existingRoot, Dir: "existing", ApplySettings: &valid.ApplySettings{MinApprovals: 3}},
+			},
+		},
+	}
+	repoSettings := valid.ApplySettings{
+		PRRequirements: []string{valid.ApprovedApplyReq, valid.PoliciesPassedApplyReq},
+		Team:           "platform",
+		MinApprovals:   2,
+	}
+	rcb.GlobalCfg.Repos[0].ApplySettings = repoSettings
+	rcb.GlobalCfg.Repos[0].AllowedOverrides = []string{valid.ApplySettingsKey}
+
+	projectConfigs, err := rcb.Build(context.Background(), commit, 2)
+	assert.NoError(t, err)
+
+	// the default branch's override applies to roots which exist there
+	expected := repoSettings
+	expected.MinApprovals = 3
+	assert.Equal(t, expected, projectConfigs[0].ApplySettings)
+	assert.Equal(t, repoSettings, projectConfigs[1].ApplySettings)
+
+	t.Run("default branch roots error", func(t *testing.T) {
+		rcb.DefaultBranchRoots = &mockDefaultBranchRootsFetcher{err: assert.AnError}
+
+		_, err := rcb.Build(context.Background(), commit, 2)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestRootConfigBuilder_BuildDefaultBranchRoot(t *testing.T) {
+	setupTesting(t)
+	repo := models.Repo{
+		FullName: "nish/repo",
+		VCSHost:  models.VCSHost{Hostname: "github.com"},
+	}
+	rootName := "root"
+	rcb.GlobalCfg.Repos[0].AllowedOverrides = []string{valid.ApplySettingsKey}
+	rcb.DefaultBranchRoots = &mockDefaultBranchRootsFetcher{
+		repoCfg: valid.RepoCfg{
+			Projects: []valid.Project{
+				{
+					Name:          &rootName,
+					Dir:           "root",
+					ApplySettings: &valid.ApplySettings{Team: "root-team"},
+				},
+			},
+		},
+	}
+
+	t.Run("configured root", func(t *testing.T) {
+		rootCfg, err := rcb.BuildDefaultBranchRoot(context.Background(), repo, 2, rootName)
+		assert.NoError(t, err)
+		assert.Equal(t, rootName, rootCfg.Name)
+		assert.Equal(t, "root-team", rootCfg.ApplySettings.Team)
+	})
+
+	t.Run("unknown root", func(t *testing.T) {
+		rootCfg, err := rcb.BuildDefaultBranchRoot(context.Background(), repo, 2, "unknown")
+		assert.NoError(t, err)
+		assert.Nil(t, rootCfg)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		rcb.DefaultBranchRoots = &mockDefaultBranchRootsFetcher{err: assert.AnError}
+
+		_, err := rcb.BuildDefaultBranchRoot(context.Background(), repo, 2, rootName)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestDefaultBranchRoots_FetchRepoCfg(t *testing.T) {
+	repo := models.Repo{FullName: "nish/repo", DefaultBranch: "main"}
+	explicitRoot := "explicit"
+	repoCfg := valid.RepoCfg{
+		Projects: []valid.Project{
+			{Name: &explicitRoot, Dir: "explicit", Owners: []string{"alice"}},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		fetcher := &config.DefaultBranchRoots{
+			ContentsFetcher: &mockContentsFetcher{found: true, data: []byte("data")},
+			ParserValidator: &mockParserValidator{repoCfg: repoCfg},
+		}
+		result, err := fetcher.FetchRepoCfg(context.Background(), repo, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, repoCfg, result)
+	})
+
+	t.Run("no config", func(t *testing.T) {
+		fetcher := &config.DefaultBranchRoots{
+			ContentsFetcher: &mockContentsFetcher{},
+			ParserValidator: &mockParserValidator{repoCfg: repoCfg},
+		}
+		result, err := fetcher.FetchRepoCfg(context.Background(), repo, 2)
+		assert.NoError(t, err)
+		assert.Empty(t, result.Projects)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		fetcher := &config.DefaultBranchRoots{
+			ContentsFetcher: &mockContentsFetcher{found: true, data: []byte("data")},
+			ParserValidator: &mockParserValidator{error: assert.AnError},
+		}
+		_, err := fetcher.FetchRepoCfg(context.Background(), repo, 2)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestRootConfigBuilder_Fingerprint(t *testing.T) {
+	setupTesting(t)
+	commit := &config.RepoCommit{
+		Repo: models.Repo{
+			FullName: "nish/repo",
+		},
+		Sha: "1234",
+	}
+	root := testRoot
+	rcb.Strategy.RootFinder = &mockRootFinder{
+		ConfigProjects: []valid.Project{
+			{Name: &root},
+		},
+	}
+
+	t.Run("not requested", func(t *testing.T) {
+		fingerprinter := &mockFingerprinter{fingerprints: map[string]string{testRoot: "abc"}}
+		rcb.Fingerprinter = fingerprinter
+
+		projectConfigs, err := rcb.Build(context.Background(), commit, 2)
+		assert.NoError(t, err)
+		assert.Empty(t, projectConfigs[0].Fingerprint)
+		assert.False(t, fingerprinter.called)
+	})
+
+	t.Run("requested", func(t *testing.T) {
+		fingerprinter := &mockFingerprinter{fingerprints: map[string]string{testRoot: "abc"}}
+		rcb.Fingerprinter = fingerprinter
+
+		projectConfigs, err := rcb.Build(context.Background(), commit, 2, config.BuilderOptions{Fingerprint: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", projectConfigs[0].Fingerprint)
+		assert.True(t, fingerprinter.called)
+	})
+
+	t.Run("fingerprint error", func(t *testing.T) {
+		rcb.Fingerprinter = &mockFingerprinter{err: assert.AnError}
+
+		projectConfigs, err := rcb.Build(context.Background(), commit, 2, config.BuilderOptions{Fingerprint: true})
+		assert.NoError(t, err)
+		assert.Empty(t, projectConfigs[0].Fingerprint)
+	})
+}
+
+type mockFingerprinter struct {
+	fingerprints map[string]string
+	err          error
+	called       bool
+}
+
+func (f *mockFingerprinter) FingerprintLocal(_ context.Context, _ string, _ []valid.Project) (map[string]string, error) {
+	f.called = true
+	return f.fingerprints, f.err
+}
+
+func (f *mockFingerprinter) FingerprintRemote(_ context.Context, _ models.Repo, _ int64, _ string, _ []valid.Project) (map[string]string, error) {
+	f.called = true
+	return f.fingerprints, f.err
+}
+
+type mockCodeOwnersFetcher struct {
+	codeOwners github.CodeOwners
+	err        error
+}
+
+func (f *mockCodeOwnersFetcher) FetchCodeOwners(_ context.Context, _ models.Repo, _ int64) (github.CodeOwners, error) {
+	return f.codeOwners, f.err
+}
+
+type mockDefaultBranchRootsFetcher struct {
+	repoCfg valid.RepoCfg
+	err     error
+}
+
+func (f *mockDefaultBranchRootsFetcher) FetchRepoCfg(_ context.Context, _ models.Repo, _ int64) (valid.RepoCfg, error) {
+	return f.repoCfg, f.err
+}
+
 type mockRepoFetcher struct {
+	called     bool
 	cloneError error
 }
 
-func (r *mockRepoFetcher) Fetch(_ context.Context, _ models.Repo, _ string, _ string, _ github.RepoFetcherOptions) (string, func(ctx context.Context, filePath string), error) {
+func (r *mockRepoFetcher) Fetch(_ context.Context, _ models.Repo, _ int64, _ string, _ string, _ github.RepoFetcherOptions) (string, func(ctx context.Context, filePath string), error) {
+	r.called = true
 	return "", func(ctx context.Context, filePath string) {}, r.cloneError
 }
 
@@ -244,7 +703,7 @@ type mockHooksRunner struct {
 	error error
 }
 
-func (h *mockHooksRunner) Run(_ context.Context, _ models.Repo, _ string) error {
+func (h *mockHooksRunner) Run(_ context.Context, _ models.Repo, _ string, _ int64) error {
 	return h.error
 }
 
@@ -271,9 +730,36 @@ func (m *mockRootFinder) FindRoots(_ context.Context, _ valid.RepoCfg, _ string,
 
 type mockParserValidator struct {
 	repoCfg valid.RepoCfg
+	data    []byte
 	error   error
 }
 
 func (v *mockParserValidator) ParseRepoCfg(_ string, _ string) (valid.RepoCfg, error) {
 	return v.repoCfg, v.error
 }
+
+func (v *mockParserValidator) ParseRepoCfgData(data []byte, _ string) (valid.RepoCfg, error) {
+	v.data = data
+	return v.repoCfg, v.error
+}
+
+type mockContentsFetcher struct {
+	found bool
+	data  []byte
+	error error
+}
+
+func (f *mockContentsFetcher) GetFileContents(_ context.Context, _ models.Repo, _ int64, _ string, _ string) (bool, []byte, error) {
+	return f.found, f.data, f.error
+}
+
+type mockRemoteRootFinder struct {
+	called         bool
+	ConfigProjects []valid.Project
+	error          error
+}
+
+func (m *mockRemoteRootFinder) FindRoots(_ context.Context, _ valid.RepoCfg, _ models.Repo, _ int64, _ string, _ []string) ([]valid.Project, error) {
+	m.called = true
+	return m.ConfigProjects, m.error
+}
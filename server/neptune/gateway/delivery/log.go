@@ -0,0 +1,163 @@
+package delivery
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultDedupeWindow = time.Hour
+	DefaultMaxEntries   = 1000
+)
+
+type Status string
+
+const (
+	PendingStatus   Status = "pending"
+	SucceededStatus Status = "succeeded"
+	FailedStatus    Status = "failed"
+)
+
+// Delivery is the record of a single webhook delivery and its outcome across both
+// synchronous and asynchronous handling.
+type Delivery struct {
+	ID         string    `json:"id"`
+	Event      string    `json:"event"`
+	ReceivedAt time.Time `json:"received_at"`
+	Status     Status    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	Replays    int       `json:"replays"`
+
+	header http.Header
+	body   []byte
+
+	// number of handlers, sync or async, which haven't completed yet
+	pending int
+}
+
+// Log is an in memory record of recent webhook deliveries.  It is used to drop redeliveries
+// of events we've already handled and to keep the original request around so that failed
+// deliveries can be replayed.
+// Only the most recent MaxEntries deliveries are retained.
+type Log struct {
+	DedupeWindow time.Duration
+	MaxEntries   int
+
+	clock      func() time.Time
+	mu         sync.Mutex
+	deliveries map[string]*Delivery
+	order      []string
+}
+
+func NewLog(dedupeWindow time.Duration, maxEntries int) *Log {
+	return &Log{
+		DedupeWindow: dedupeWindow,
+		MaxEntries:   maxEntries,
+		clock:        time.Now,
+		deliveries:   make(map[string]*Delivery),
+	}
+}
+
+// Start records the receipt of a delivery and returns false if it is a duplicate of one
+// received within the dedupe window.  Deliveries which previously failed are never considered
+// duplicates so that redeliveries from github act as retries.
+func (l *Log) Start(id string, event string, header http.Header, body []byte, replay bool) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock()
+	d, ok := l.deliveries[id]
+	if ok && !replay && d.Status != FailedStatus && now.Sub(d.ReceivedAt) < l.DedupeWindow {
+		return false
+	}
+
+	if !ok {
+		d = &Delivery{ID: id}
+		l.deliveries[id] = d
+		l.order = append(l.order, id)
+		l.evict()
+	}
+
+	if replay {
+		d.Replays++
+	}
+	d.Event = event
+	d.ReceivedAt = now
+	d.Status = PendingStatus
+	d.Error = ""
+	d.header = header
+	d.body = body
+	d.pending = 1
+	return true
+}
+
+// Track registers additional work for a delivery which will be completed through Done.
+func (l *Log) Track(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if d, ok := l.deliveries[id]; ok {
+		d.pending++
+	}
+}
+
+// Done completes a unit of work for the delivery.  A delivery fails if any of its work fails
+// and only succeeds once all of it has completed.
+func (l *Log) Done(id string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	d, ok := l.deliveries[id]
+	if !ok {
+		return
+	}
+
+	d.pending--
+	if err != nil {
+		d.Status = FailedStatus
+		d.Error = err.Error()
+	}
+	if d.pending <= 0 && d.Status == PendingStatus {
+		d.Status = SucceededStatus
+	}
+}
+
+func (l *Log) Get(id string) (Delivery, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	d, ok := l.deliveries[id]
+	if !ok {
+		return Delivery{}, false
+	}
+	return *d, true
+}
+
+// List returns deliveries with the given status, or all deliveries if status is empty,
+// ordered from most to least recent.
+func (l *Log) List(status Status) []Delivery {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	deliveries := []Delivery{}
+	for _, d := range l.deliveries {
+		if status == "" || d.Status == status {
+			deliveries = append(deliveries, *d)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].ReceivedAt.After(deliveries[j].ReceivedAt)
+	})
+	return deliveries
+}
+
+// evict drops the oldest deliveries once we're over capacity, callers must hold the lock.
+func (l *Log) evict() {
+	for l.MaxEntries > 0 && len(l.order) > l.MaxEntries {
+		delete(l.deliveries, l.order[0])
+		l.order = l.order[1:]
+	}
+}
@@ -0,0 +1,96 @@
+package delivery
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLog_Start(t *testing.T) {
+	now := time.Now()
+	l := NewLog(time.Hour, 10)
+	l.clock = func() time.Time { return now }
+
+	assert.True(t, l.Start("1", "push", nil, nil, false))
+	l.Done("1", nil)
+
+	t.Run("duplicate within window", func(t *testing.T) {
+		assert.False(t, l.Start("1", "push", nil, nil, false))
+	})
+
+	t.Run("duplicate outside window", func(t *testing.T) {
+		now = now.Add(2 * time.Hour)
+		assert.True(t, l.Start("1", "push", nil, nil, false))
+		l.Done("1", nil)
+	})
+
+	t.Run("redelivery of failure", func(t *testing.T) {
+		assert.True(t, l.Start("2", "push", nil, nil, false))
+		l.Done("2", errors.New("error"))
+		assert.True(t, l.Start("2", "push", nil, nil, false))
+
+		d, ok := l.Get("2")
+		assert.True(t, ok)
+		assert.Equal(t, PendingStatus, d.Status)
+		assert.Empty(t, d.Error)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		assert.True(t, l.Start("1", "push", nil, nil, true))
+
+		d, _ := l.Get("1")
+		assert.Equal(t, 1, d.Replays)
+	})
+}
+
+func TestLog_Done(t *testing.T) {
+	l := NewLog(time.Hour, 10)
+
+	t.Run("waits on tracked work", func(t *testing.T) {
+		l.Start("1", "push", nil, nil, false)
+		l.Track("1")
+		l.Done("1", nil)
+
+		d, _ := l.Get("1")
+		assert.Equal(t, PendingStatus, d.Status)
+
+		l.Done("1", nil)
+		d, _ = l.Get("1")
+		assert.Equal(t, SucceededStatus, d.Status)
+	})
+
+	t.Run("async failure", func(t *testing.T) {
+		l.Start("2", "push", nil, nil, false)
+		l.Track("2")
+		l.Done("2", nil)
+		l.Done("2", errors.New("error"))
+
+		d, _ := l.Get("2")
+		assert.Equal(t, FailedStatus, d.Status)
+		assert.Equal(t, "error", d.Error)
+	})
+
+	t.Run("list by status", func(t *testing.T) {
+		var ids []string
+		for _, d := range l.List(FailedStatus) {
+			ids = append(ids, d.ID)
+		}
+		assert.Equal(t, []string{"2"}, ids)
+		assert.Len(t, l.List(""), 2)
+	})
+}
+
+func TestLog_Evict(t *testing.T) {
+	l := NewLog(time.Hour, 2)
+
+	l.Start("1", "push", nil, nil, false)
+	l.Start("2", "push", nil, nil, false)
+	l.Start("3", "push", nil, nil, false)
+
+	_, ok := l.Get("1")
+	assert.False(t, ok)
+	_, ok = l.Get("3")
+	assert.True(t, ok)
+}
@@ -0,0 +1,88 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/runatlantis/atlantis/server/logging"
+	key "github.com/runatlantis/atlantis/server/neptune/context"
+)
+
+const (
+	deliveryHeader = "X-Github-Delivery"
+	eventHeader    = "X-Github-Event"
+)
+
+type replayKey struct{}
+
+// Middleware records each webhook delivery in the log and short circuits redeliveries of
+// events which have already been handled.
+type Middleware struct {
+	Log    *Log
+	Logger logging.Logger
+}
+
+func (m *Middleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(deliveryHeader)
+		if id == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintln(w, "reading request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := context.WithValue(r.Context(), key.DeliveryIDKey, id)
+		_, replay := ctx.Value(replayKey{}).(bool)
+
+		if !m.Log.Start(id, r.Header.Get(eventHeader), r.Header.Clone(), body, replay) {
+			m.Logger.InfoContext(ctx, fmt.Sprintf("skipping duplicate delivery %s", id))
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, "Delivery %s has already been processed\n", id)
+			return
+		}
+
+		rw := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rw, r.WithContext(ctx))
+
+		var handleErr error
+		if rw.status >= http.StatusBadRequest {
+			handleErr = fmt.Errorf("responded with status %d: %s", rw.status, strings.TrimSpace(rw.body.String()))
+		}
+		m.Log.Done(id, handleErr)
+	})
+}
+
+// responseRecorder captures the status and body of a response so it can be recorded for
+// the delivery.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// IDFromContext returns the id of the delivery being handled, if any.
+func IDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(key.DeliveryIDKey).(string)
+	return id, ok && id != ""
+}
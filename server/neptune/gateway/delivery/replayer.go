@@ -0,0 +1,41 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/pkg/errors"
+)
+
+// Replayer sends a previously failed delivery back through the handler chain it was
+// originally received by.
+type Replayer struct {
+	Log *Log
+
+	// Handler should be the same handler the webhook endpoint is served by, including Middleware
+	Handler http.Handler
+}
+
+func (r *Replayer) Replay(ctx context.Context, id string) (Delivery, error) {
+	d, ok := r.Log.Get(id)
+	if !ok {
+		return Delivery{}, fmt.Errorf("delivery %s not found", id)
+	}
+	if d.Status != FailedStatus {
+		return Delivery{}, fmt.Errorf("delivery %s has status %s, only failed deliveries can be replayed", id, d.Status)
+	}
+
+	req, err := http.NewRequestWithContext(context.WithValue(ctx, replayKey{}, true), http.MethodPost, "/events", bytes.NewReader(d.body))
+	if err != nil {
+		return Delivery{}, errors.Wrap(err, "building request")
+	}
+	req.Header = d.header.Clone()
+
+	r.Handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	d, _ = r.Log.Get(id)
+	return d, nil
+}
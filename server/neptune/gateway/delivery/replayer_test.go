@@ -0,0 +1,81 @@
+package delivery_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/delivery"
+	"github.com/runatlantis/atlantis/server/neptune/sync"
+	"github.com/stretchr/testify/assert"
+)
+
+type testScheduler struct{}
+
+func (s testScheduler) Schedule(ctx context.Context, f sync.Executor) error {
+	return f(ctx)
+}
+
+func TestReplay(t *testing.T) {
+	log := delivery.NewLog(time.Hour, 10)
+	scheduler := &delivery.Scheduler{Delegate: testScheduler{}, Log: log}
+
+	var bodies []string
+	asyncErr := errors.New("async error")
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		bodies = append(bodies, string(b))
+
+		_ = scheduler.Schedule(r.Context(), func(ctx context.Context) error {
+			return asyncErr
+		})
+		w.WriteHeader(http.StatusOK)
+	})
+
+	middleware := &delivery.Middleware{Log: log, Logger: logging.NewNoopCtxLogger(t)}
+	eventsHandler := middleware.Middleware(handler)
+
+	send := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewBufferString("payload"))
+		req.Header.Set("X-Github-Delivery", "1234")
+		req.Header.Set("X-Github-Event", "push")
+		rw := httptest.NewRecorder()
+		eventsHandler.ServeHTTP(rw, req)
+		return rw.Code
+	}
+
+	assert.Equal(t, http.StatusOK, send())
+
+	d, ok := log.Get("1234")
+	assert.True(t, ok)
+	assert.Equal(t, delivery.FailedStatus, d.Status)
+	assert.Equal(t, "push", d.Event)
+	assert.Equal(t, "async error", d.Error)
+
+	replayer := &delivery.Replayer{Log: log, Handler: eventsHandler}
+
+	// replays run through the same handler with the original request
+	asyncErr = nil
+	d, err := replayer.Replay(context.Background(), "1234")
+	assert.NoError(t, err)
+	assert.Equal(t, delivery.SucceededStatus, d.Status)
+	assert.Equal(t, 1, d.Replays)
+	assert.Equal(t, []string{"payload", "payload"}, bodies)
+
+	// successful deliveries are deduped and can't be replayed
+	assert.Equal(t, http.StatusOK, send())
+	assert.Len(t, bodies, 2)
+
+	_, err = replayer.Replay(context.Background(), "1234")
+	assert.Error(t, err)
+
+	_, err = replayer.Replay(context.Background(), "unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,32 @@
+package delivery
+
+import (
+	"context"
+
+	"github.com/runatlantis/atlantis/server/neptune/sync"
+)
+
+type scheduler interface {
+	Schedule(ctx context.Context, f sync.Executor) error
+}
+
+// Scheduler attributes the outcome of scheduled work back to the delivery which triggered it
+// so that failures in asynchronous handling aren't lost once the webhook has been responded to.
+type Scheduler struct {
+	Delegate scheduler
+	Log      *Log
+}
+
+func (s *Scheduler) Schedule(ctx context.Context, f sync.Executor) error {
+	id, ok := IDFromContext(ctx)
+	if !ok {
+		return s.Delegate.Schedule(ctx, f)
+	}
+
+	s.Log.Track(id)
+	return s.Delegate.Schedule(ctx, func(ctx context.Context) error {
+		err := f(ctx)
+		s.Log.Done(id, err)
+		return err
+	})
+}
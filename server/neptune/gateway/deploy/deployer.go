@@ -10,6 +10,7 @@ import (
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/models"
 	contextInternal "github.com/runatlantis/atlantis/server/neptune/context"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/requirement"
 	"github.com/runatlantis/atlantis/server/neptune/workflows"
 	"github.com/runatlantis/atlantis/server/vcs/provider/github"
 	"go.temporal.io/sdk/client"
@@ -24,10 +25,18 @@ type rootConfigBuilder interface {
 	Build(ctx context.Context, commit *config.RepoCommit, installationToken int64, opts ...config.BuilderOptions) ([]*valid.MergedProjectCfg, error)
 }
 
+type requirementChecker interface {
+	Check(ctx context.Context, criteria requirement.Criteria) error
+}
+
 type RootDeployer struct {
 	Logger            logging.Logger
 	RootConfigBuilder rootConfigBuilder
 	DeploySignaler    deploySignaler
+
+	// ManualDeployRequirement is optional, when set manually triggered deploys are only
+	// signaled once their roots satisfy it.
+	ManualDeployRequirement requirementChecker
 }
 
 // RootDeployOptions is basically a modeled request for RootDeployer, options isn't really the right word here
@@ -52,6 +61,10 @@ type RootDeployOptions struct {
 	// instead we should just inject implementations of RepoFetcher to handle different scenarios
 	RepoFetcherOptions *github.RepoFetcherOptions
 	TriggerInfo        workflows.DeployTriggerInfo
+
+	// EnforceTwoPersonRule is set for deploys subject to the two person rule of each root's
+	// apply settings, ie. force applies and manual API deploys.
+	EnforceTwoPersonRule bool
 }
 
 func (d *RootDeployer) Deploy(ctx context.Context, deployOptions RootDeployOptions) error {
@@ -71,6 +84,21 @@ func (d *RootDeployer) Deploy(ctx context.Context, deployOptions RootDeployOptio
 	if err != nil {
 		return errors.Wrap(err, "generating roots")
 	}
+
+	if d.ManualDeployRequirement != nil && deployOptions.TriggerInfo.Type == workflows.ManualTrigger {
+		if err := d.ManualDeployRequirement.Check(ctx, requirement.Criteria{
+			User:              deployOptions.Sender,
+			Branch:            deployOptions.Branch,
+			Repo:              deployOptions.Repo,
+			InstallationToken: deployOptions.InstallationToken,
+			TriggerInfo:       deployOptions.TriggerInfo,
+			Roots:             rootCfgs,
+			Revision:          deployOptions.Revision,
+		}); err != nil {
+			return errors.Wrap(err, "checking manual deploy requirements")
+		}
+	}
+
 	for _, rootCfg := range rootCfgs {
 		c := context.WithValue(ctx, contextInternal.ProjectKey, rootCfg.Name)
 		run, err := d.DeploySignaler.SignalWithStartWorkflow(c, rootCfg, deployOptions)
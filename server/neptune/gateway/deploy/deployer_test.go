@@ -10,6 +10,8 @@ import (
 	"github.com/runatlantis/atlantis/server/models"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/config"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/deploy"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/requirement"
+	"github.com/runatlantis/atlantis/server/neptune/workflows"
 	"github.com/runatlantis/atlantis/server/vcs/provider/github"
 	"github.com/stretchr/testify/assert"
 	"go.temporal.io/sdk/client"
@@ -103,6 +105,56 @@ func TestDeploy(t *testing.T) {
 		assert.NoError(t, err)
 		assert.True(t, signaler.called)
 	})
+
+	t.Run("manual deploy requirement failure", func(t *testing.T) {
+		ctx := context.Background()
+		signaler := &mockDeploySignaler{run: testRun{}}
+		rootCfgs := []*valid.MergedProjectCfg{
+			{Name: testRoot},
+		}
+		checker := &mockRequirementChecker{err: assert.AnError}
+		deployer := deploy.RootDeployer{
+			DeploySignaler: signaler,
+			Logger:         logger,
+			RootConfigBuilder: &mockRootConfigBuilder{
+				expectedT:      t,
+				expectedCommit: commit,
+				expectedToken:  deployOptions.InstallationToken,
+				expectedOptions: []config.BuilderOptions{
+					{
+						RootNames:          deployOptions.RootNames,
+						RepoFetcherOptions: deployOptions.RepoFetcherOptions,
+					},
+				},
+				rootConfigs: rootCfgs,
+			},
+			ManualDeployRequirement: checker,
+		}
+
+		manualDeployOptions := deployOptions
+		manualDeployOptions.TriggerInfo = workflows.DeployTriggerInfo{Type: workflows.ManualTrigger}
+
+		err := deployer.Deploy(ctx, manualDeployOptions)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.False(t, signaler.called)
+		assert.Equal(t, rootCfgs, checker.criteria.Roots)
+		assert.Equal(t, deployOptions.Revision, checker.criteria.Revision)
+
+		// merges aren't subject to the requirement
+		err = deployer.Deploy(ctx, deployOptions)
+		assert.NoError(t, err)
+		assert.True(t, signaler.called)
+	})
+}
+
+type mockRequirementChecker struct {
+	criteria requirement.Criteria
+	err      error
+}
+
+func (c *mockRequirementChecker) Check(_ context.Context, criteria requirement.Criteria) error {
+	c.criteria = criteria
+	return c.err
 }
 
 type mockRootConfigBuilder struct {
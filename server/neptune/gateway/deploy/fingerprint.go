@@ -0,0 +1,271 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/models"
+	subprocess_exec "github.com/runatlantis/atlantis/server/neptune/exec"
+)
+
+type treeFetcher interface {
+	GetTree(ctx context.Context, repo models.Repo, installationToken int64, ref string) (map[string]string, error)
+}
+
+type fileContentsFetcher interface {
+	GetFileContents(ctx context.Context, repo models.Repo, installationToken int64, ref string, path string) (bool, []byte, error)
+}
+
+// RootFingerprinter computes a fingerprint for each root from the blob hashes of the files
+// matching its when_modified patterns and of the local modules it calls. Roots with the same
+// fingerprint across revisions of a PR don't need to be planned again.
+type RootFingerprinter struct {
+	TreeFetcher     treeFetcher
+	ContentsFetcher fileContentsFetcher
+	Logger          logging.Logger
+}
+
+// FingerprintLocal fingerprints roots of a cloned repo, the result is keyed by root name.
+func (f *RootFingerprinter) FingerprintLocal(ctx context.Context, absRepoDir string, roots []valid.Project) (map[string]string, error) {
+	tree, err := f.localTree(ctx, absRepoDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing repo tree")
+	}
+	return fingerprints(roots, tree, &localFS{dir: absRepoDir})
+}
+
+// FingerprintRemote fingerprints roots through the github API without a clone, the result is
+// keyed by root name.
+func (f *RootFingerprinter) FingerprintRemote(ctx context.Context, repo models.Repo, installationToken int64, sha string, roots []valid.Project) (map[string]string, error) {
+	tree, err := f.TreeFetcher.GetTree(ctx, repo, installationToken, sha)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing repo tree")
+	}
+	fs := &remoteFS{
+		ctx:               ctx,
+		repo:              repo,
+		installationToken: installationToken,
+		sha:               sha,
+		tree:              tree,
+		fetcher:           f.ContentsFetcher,
+		files:             make(map[string][]byte),
+	}
+	return fingerprints(roots, tree, fs)
+}
+
+func fingerprints(roots []valid.Project, tree map[string]string, fs tfconfig.FS) (map[string]string, error) {
+	paths := make([]string, 0, len(tree))
+	for p := range tree {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	result := make(map[string]string)
+	for _, root := range roots {
+		fingerprint, err := fingerprintRoot(root, paths, tree, fs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fingerprinting root %s", root.GetName())
+		}
+		result[root.GetName()] = fingerprint
+	}
+	return result, nil
+}
+
+// fingerprintRoot hashes the path and blob hash of every file tracked by the root, paths are expected to be sorted
+func fingerprintRoot(root valid.Project, paths []string, tree map[string]string, fs tfconfig.FS) (string, error) {
+	pm, err := whenModifiedMatcher(root)
+	if err != nil {
+		return "", err
+	}
+	moduleDirs := localModuleDirs(fs, path.Clean(root.Dir))
+
+	h := sha256.New()
+	for _, p := range paths {
+		match, err := pm.Matches(p)
+		if err != nil {
+			continue
+		}
+		if !match && !inAnyDir(p, moduleDirs) {
+			continue
+		}
+		fmt.Fprintf(h, "%s %s\n", p, tree[p])
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// localModuleDirs returns the directories of all local modules called by the module in dir, including
+// the ones called transitively. Modules which fail to parse are skipped since terraform will surface the
+// error itself when planning.
+func localModuleDirs(fs tfconfig.FS, dir string) []string {
+	visited := map[string]bool{dir: true}
+	var dirs []string
+
+	var walk func(dir string)
+	walk = func(dir string) {
+		module, _ := tfconfig.LoadModuleFromFilesystem(fs, dir)
+		if module == nil {
+			return
+		}
+
+		// sort calls to keep the result stable
+		var sources []string
+		for _, call := range module.ModuleCalls {
+			sources = append(sources, call.Source)
+		}
+		sort.Strings(sources)
+
+		for _, source := range sources {
+			if !strings.HasPrefix(source, "./") && !strings.HasPrefix(source, "../") {
+				continue
+			}
+			moduleDir := path.Join(dir, source)
+			if visited[moduleDir] {
+				continue
+			}
+			visited[moduleDir] = true
+			dirs = append(dirs, moduleDir)
+			walk(moduleDir)
+		}
+	}
+	walk(dir)
+	return dirs
+}
+
+func inAnyDir(p string, dirs []string) bool {
+	for _, dir := range dirs {
+		if dir == "." || strings.HasPrefix(p, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// localTree lists the blob hashes of the files committed at HEAD of the clone
+func (f *RootFingerprinter) localTree(ctx context.Context, absRepoDir string) (map[string]string, error) {
+	var out bytes.Buffer
+	cmd := subprocess_exec.Command(f.Logger, "git", "ls-tree", "-r", "-z", "--full-tree", "HEAD")
+	cmd.Dir = absRepoDir
+	cmd.Stdout = &out
+	if err := cmd.RunWithNewProcessGroup(ctx); err != nil {
+		return nil, errors.Wrap(err, "running git ls-tree")
+	}
+
+	tree := make(map[string]string)
+	for _, entry := range bytes.Split(out.Bytes(), []byte{0}) {
+		// each entry is formatted as "<mode> <type> <object>\t<path>"
+		meta, filePath, found := strings.Cut(string(entry), "\t")
+		if !found {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		tree[filePath] = fields[2]
+	}
+	return tree, nil
+}
+
+// localFS resolves paths relative to the root of a cloned repo
+type localFS struct {
+	dir string
+}
+
+func (fs *localFS) Open(name string) (tfconfig.File, error) {
+	return os.Open(filepath.Join(fs.dir, name))
+}
+
+func (fs *localFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(fs.dir, name))
+}
+
+func (fs *localFS) ReadDir(dirname string) ([]os.FileInfo, error) {
+	entries, err := os.ReadDir(filepath.Join(fs.dir, dirname))
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]os.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// remoteFS reads directories from the repo tree and files through the github API, file contents
+// are cached since modules are usually shared by many roots.
+type remoteFS struct {
+	ctx               context.Context
+	repo              models.Repo
+	installationToken int64
+	sha               string
+	tree              map[string]string
+	fetcher           fileContentsFetcher
+	files             map[string][]byte
+}
+
+func (fs *remoteFS) Open(name string) (tfconfig.File, error) {
+	return nil, errors.Errorf("opening %s is not supported", name)
+}
+
+func (fs *remoteFS) ReadFile(name string) ([]byte, error) {
+	name = path.Clean(name)
+	if contents, ok := fs.files[name]; ok {
+		return contents, nil
+	}
+	found, contents, err := fs.fetcher.GetFileContents(fs.ctx, fs.repo, fs.installationToken, fs.sha, name)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, os.ErrNotExist
+	}
+	fs.files[name] = contents
+	return contents, nil
+}
+
+// ReadDir only lists the files directly within dirname, which is all the module loader needs
+func (fs *remoteFS) ReadDir(dirname string) ([]os.FileInfo, error) {
+	dirname = path.Clean(dirname)
+	var infos []os.FileInfo
+	for p := range fs.tree {
+		if path.Dir(p) != dirname {
+			continue
+		}
+		infos = append(infos, remoteFileInfo{name: path.Base(p)})
+	}
+	if len(infos) == 0 {
+		return nil, os.ErrNotExist
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name() < infos[j].Name()
+	})
+	return infos, nil
+}
+
+type remoteFileInfo struct {
+	name string
+}
+
+func (i remoteFileInfo) Name() string       { return i.name }
+func (i remoteFileInfo) Size() int64        { return 0 }
+func (i remoteFileInfo) Mode() os.FileMode  { return 0 }
+func (i remoteFileInfo) ModTime() time.Time { return time.Time{} }
+func (i remoteFileInfo) IsDir() bool        { return false }
+func (i remoteFileInfo) Sys() interface{}   { return nil }
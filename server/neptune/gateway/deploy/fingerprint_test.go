@@ -0,0 +1,102 @@
+package deploy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/deploy"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTreeFetcher struct {
+	tree map[string]string
+}
+
+func (f *testTreeFetcher) GetTree(_ context.Context, _ models.Repo, _ int64, _ string) (map[string]string, error) {
+	return f.tree, nil
+}
+
+type testContentsFetcher struct {
+	files   map[string]string
+	fetched []string
+}
+
+func (f *testContentsFetcher) GetFileContents(_ context.Context, _ models.Repo, _ int64, _ string, path string) (bool, []byte, error) {
+	f.fetched = append(f.fetched, path)
+	contents, ok := f.files[path]
+	return ok, []byte(contents), nil
+}
+
+func TestRootFingerprinter_FingerprintRemote(t *testing.T) {
+	autoplan := valid.Autoplan{
+		Enabled:      true,
+		WhenModified: []string{"**/*.tf*"},
+	}
+	app := "app"
+	network := "network"
+	roots := []valid.Project{
+		{Name: &app, Dir: "roots/app", Autoplan: autoplan},
+		{Name: &network, Dir: "roots/network", Autoplan: autoplan},
+	}
+	files := map[string]string{
+		"roots/app/main.tf":       `module "service" { source = "../../modules/service" }`,
+		"roots/network/main.tf":   `resource "null_resource" "this" {}`,
+		"modules/service/main.tf": `module "dns" { source = "../dns" }`,
+		"modules/dns/main.tf":     `resource "null_resource" "this" {}`,
+	}
+	baseTree := map[string]string{
+		"roots/app/main.tf":       "1",
+		"roots/network/main.tf":   "2",
+		"modules/service/main.tf": "3",
+		"modules/dns/main.tf":     "4",
+		"modules/unused/main.tf":  "5",
+		"README.md":               "6",
+	}
+
+	fingerprint := func(changes map[string]string) map[string]string {
+		tree := make(map[string]string)
+		for p, blob := range baseTree {
+			tree[p] = blob
+		}
+		for p, blob := range changes {
+			tree[p] = blob
+		}
+		contentsFetcher := &testContentsFetcher{files: files}
+		fingerprinter := deploy.RootFingerprinter{
+			TreeFetcher:     &testTreeFetcher{tree: tree},
+			ContentsFetcher: contentsFetcher,
+		}
+		result, err := fingerprinter.FingerprintRemote(context.Background(), models.Repo{}, 1, "sha", roots)
+		assert.NoError(t, err)
+		return result
+	}
+
+	base := fingerprint(nil)
+	assert.Len(t, base, 2)
+	assert.NotEqual(t, base["app"], base["network"])
+
+	t.Run("unrelated changes", func(t *testing.T) {
+		assert.Equal(t, base, fingerprint(map[string]string{
+			"README.md":              "7",
+			"modules/unused/main.tf": "7",
+		}))
+	})
+
+	t.Run("root change", func(t *testing.T) {
+		result := fingerprint(map[string]string{
+			"roots/network/main.tf": "7",
+		})
+		assert.Equal(t, base["app"], result["app"])
+		assert.NotEqual(t, base["network"], result["network"])
+	})
+
+	t.Run("transitive local module change", func(t *testing.T) {
+		result := fingerprint(map[string]string{
+			"modules/dns/main.tf": "7",
+		})
+		assert.NotEqual(t, base["app"], result["app"])
+		assert.Equal(t, base["network"], result["network"])
+	})
+}
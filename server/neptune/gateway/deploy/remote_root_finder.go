@@ -0,0 +1,60 @@
+package deploy
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/models"
+)
+
+type pathChecker interface {
+	PathExists(ctx context.Context, repo models.Repo, installationToken int64, ref string, path string) (bool, error)
+}
+
+// RemoteRootFinder determines modified roots the same way as RepoRootFinder except root
+// directories are checked through the github API instead of against a local clone.
+type RemoteRootFinder struct {
+	Logger      logging.Logger
+	PathChecker pathChecker
+}
+
+func (f *RemoteRootFinder) FindRoots(ctx context.Context, config valid.RepoCfg, repo models.Repo, installationToken int64, sha string, modifiedFiles []string) ([]valid.Project, error) {
+	var roots []valid.Project
+	for _, root := range config.Projects {
+		modified, err := rootModified(root, modifiedFiles)
+		if err != nil {
+			return nil, err
+		}
+		if !modified {
+			continue
+		}
+
+		exists, err := f.dirExists(ctx, repo, installationToken, sha, root.Dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "checking directory for root %s", root.Dir)
+		}
+		if !exists {
+			f.Logger.WarnContext(ctx, "unable to find directory for root", map[string]interface{}{
+				"dir": root.Dir,
+			})
+			continue
+		}
+
+		roots = append(roots, root)
+	}
+	return roots, nil
+}
+
+// dirExists is only called for modified roots to keep API calls to a minimum
+func (f *RemoteRootFinder) dirExists(ctx context.Context, repo models.Repo, installationToken int64, sha string, dir string) (bool, error) {
+	dir = path.Clean(dir)
+
+	// the repository root always exists
+	if dir == "." || dir == "/" {
+		return true, nil
+	}
+	return f.PathChecker.PathExists(ctx, repo, installationToken, sha, dir)
+}
@@ -0,0 +1,64 @@
+package deploy_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/deploy"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPathChecker struct {
+	existing map[string]bool
+	checked  []string
+}
+
+func (c *testPathChecker) PathExists(_ context.Context, _ models.Repo, _ int64, _ string, path string) (bool, error) {
+	c.checked = append(c.checked, path)
+	return c.existing[path], nil
+}
+
+func TestRemoteRootFinder_FindRoots(t *testing.T) {
+	autoplan := valid.Autoplan{
+		Enabled:      true,
+		WhenModified: []string{"**/*.tf*"},
+	}
+	cfg := valid.RepoCfg{
+		Projects: []valid.Project{
+			{Dir: ".", Autoplan: autoplan},
+			{Dir: "project1", Autoplan: autoplan},
+			{Dir: "project2", Autoplan: autoplan},
+			{Dir: "deleted", Autoplan: autoplan},
+		},
+	}
+
+	checker := &testPathChecker{
+		existing: map[string]bool{
+			"project1": true,
+			"project2": true,
+		},
+	}
+	rf := deploy.RemoteRootFinder{
+		Logger:      logging.NewNoopCtxLogger(t),
+		PathChecker: checker,
+	}
+
+	roots, err := rf.FindRoots(context.Background(), cfg, models.Repo{}, 1, "sha", []string{
+		"project1/main.tf",
+		"deleted/main.tf",
+		"README.md",
+	})
+	assert.NoError(t, err)
+
+	var dirs []string
+	for _, r := range roots {
+		dirs = append(dirs, r.Dir)
+	}
+	assert.Equal(t, []string{".", "project1"}, dirs)
+
+	// unmodified roots and the repo root are never checked remotely
+	assert.Equal(t, []string{"project1", "deleted"}, checker.checked)
+}
@@ -34,43 +34,63 @@ func (f *RepoRootFinder) FindRoots(ctx context.Context, config valid.RepoCfg, ab
 			continue
 		}
 
-		var whenModifiedRelToRepoRoot []string
-		for _, wm := range root.Autoplan.WhenModified {
-			wm = strings.TrimSpace(wm)
-			// An exclusion uses a '!' at the beginning. If it's there, we need
-			// to remove it, then add in the root path, then add it back.
-			exclusion := false
-			if wm != "" && wm[0] == '!' {
-				wm = wm[1:]
-				exclusion = true
-			}
-
-			// Prepend root dir to when modified patterns because the patterns
-			// are relative to the root dirs but our list of modified files is
-			// relative to the repo root.
-			wmRelPath := filepath.Join(root.Dir, wm)
-			if exclusion {
-				wmRelPath = "!" + wmRelPath
-			}
-			whenModifiedRelToRepoRoot = append(whenModifiedRelToRepoRoot, wmRelPath)
+		modified, err := rootModified(root, modifiedFiles)
+		if err != nil {
+			return nil, err
+		}
+		if modified {
+			roots = append(roots, root)
 		}
-		pm, err := fileutils.NewPatternMatcher(whenModifiedRelToRepoRoot)
+	}
+	return roots, nil
+}
+
+// rootModified returns true if any of the modified files match the root's when_modified patterns.
+func rootModified(root valid.Project, modifiedFiles []string) (bool, error) {
+	pm, err := whenModifiedMatcher(root)
+	if err != nil {
+		return false, err
+	}
+
+	// If any of the modified files matches the pattern then this root is
+	// considered modified.
+	for _, file := range modifiedFiles {
+		match, err := pm.Matches(file)
 		if err != nil {
-			return nil, errors.Wrapf(err, "matching modified files with patterns: %v", root.Autoplan.WhenModified)
+			continue
+		}
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// whenModifiedMatcher returns a matcher for the root's when_modified patterns relative to the repo root.
+func whenModifiedMatcher(root valid.Project) (*fileutils.PatternMatcher, error) {
+	var whenModifiedRelToRepoRoot []string
+	for _, wm := range root.Autoplan.WhenModified {
+		wm = strings.TrimSpace(wm)
+		// An exclusion uses a '!' at the beginning. If it's there, we need
+		// to remove it, then add in the root path, then add it back.
+		exclusion := false
+		if wm != "" && wm[0] == '!' {
+			wm = wm[1:]
+			exclusion = true
 		}
 
-		// If any of the modified files matches the pattern then this root is
-		// considered modified.
-		for _, file := range modifiedFiles {
-			match, err := pm.Matches(file)
-			if err != nil {
-				continue
-			}
-			if match {
-				roots = append(roots, root)
-				break
-			}
+		// Prepend root dir to when modified patterns because the patterns
+		// are relative to the root dirs but our list of modified files is
+		// relative to the repo root.
+		wmRelPath := filepath.Join(root.Dir, wm)
+		if exclusion {
+			wmRelPath = "!" + wmRelPath
 		}
+		whenModifiedRelToRepoRoot = append(whenModifiedRelToRepoRoot, wmRelPath)
 	}
-	return roots, nil
+	pm, err := fileutils.NewPatternMatcher(whenModifiedRelToRepoRoot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "matching modified files with patterns: %v", root.Autoplan.WhenModified)
+	}
+	return pm, nil
 }
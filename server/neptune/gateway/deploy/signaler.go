@@ -64,6 +64,8 @@ func (d *WorkflowSignaler) SignalWithStartWorkflow(ctx context.Context, rootCfg
 				TfVersion:    tfVersion,
 				PlanMode:     d.generatePlanMode(rootCfg),
 				TriggerInfo:  rootDeployOptions.TriggerInfo,
+
+				TwoPersonApproval: rootDeployOptions.EnforceTwoPersonRule && rootCfg.ApplySettings.TwoPersonRule,
 			},
 			Repo: workflows.Repo{
 				URL:      repo.CloneURL,
@@ -75,8 +77,10 @@ func (d *WorkflowSignaler) SignalWithStartWorkflow(ctx context.Context, rootCfg
 				},
 				RebaseEnabled: true,
 				DefaultBranch: repo.DefaultBranch,
+				Hostname:      repo.VCSHost.Hostname,
 			},
-			Tags: rootCfg.Tags,
+			Tags:          rootCfg.Tags,
+			Notifications: d.generateNotifications(rootCfg),
 		},
 		options,
 		workflows.Deploy,
@@ -125,6 +129,24 @@ func (d *WorkflowSignaler) generateSteps(steps []valid.Step) []workflows.Step {
 	return workflowSteps
 }
 
+func (d *WorkflowSignaler) generateNotifications(cfg *valid.MergedProjectCfg) workflows.DeployNotifications {
+	var slack []workflows.DeploySlackNotification
+	for _, n := range cfg.SlackNotifications {
+		var events []string
+		for _, e := range n.Events {
+			events = append(events, string(e))
+		}
+		slack = append(slack, workflows.DeploySlackNotification{
+			ChannelID: n.ChannelID,
+			Events:    events,
+		})
+	}
+
+	return workflows.DeployNotifications{
+		Slack: slack,
+	}
+}
+
 func (d *WorkflowSignaler) generatePlanMode(cfg *valid.MergedProjectCfg) workflows.PlanMode {
 	t, ok := cfg.Tags[Deprecated]
 	if ok && t == Destroy {
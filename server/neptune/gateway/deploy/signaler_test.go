@@ -252,6 +252,102 @@ func TestSignalWithStartWorkflow_Success(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, testRun{}, run)
 	})
+
+	t.Run("success w/slack notifications", func(t *testing.T) {
+		rootCfg := valid.MergedProjectCfg{
+			Name: testRoot,
+			DeploymentWorkflow: valid.Workflow{
+				Plan:  valid.DefaultPlanStage,
+				Apply: valid.DefaultApplyStage,
+			},
+			TerraformVersion: version,
+			SlackNotifications: []valid.SlackNotification{
+				{
+					ChannelID: "C123",
+					Events:    []valid.DeploySlackEvent{valid.FailedDeploySlackEvent},
+				},
+				{
+					ChannelID: "C456",
+				},
+			},
+		}
+
+		testSignaler := &testSignaler{
+			t:                  t,
+			expectedWorkflowID: fmt.Sprintf("%s||%s", repoFullName, testRoot),
+			expectedSignalName: workflows.DeployNewRevisionSignalID,
+			expectedSignalArg: workflows.DeployNewRevisionSignalRequest{
+				Revision: sha,
+				Branch:   branch,
+				Root: workflows.Root{
+					Name: testRoot,
+					Plan: workflows.Job{
+						Steps: convertTestSteps(valid.DefaultPlanStage.Steps),
+					},
+					Apply: workflows.Job{
+						Steps: convertTestSteps(valid.DefaultApplyStage.Steps),
+					},
+					TfVersion: version.String(),
+					PlanMode:  workflows.NormalPlanMode,
+					TriggerInfo: workflows.DeployTriggerInfo{
+						Type: workflows.MergeTrigger,
+					},
+				},
+				InitiatingUser: workflows.User{
+					Name: user.Username,
+				},
+				Repo: workflows.Repo{
+					FullName:      repoFullName,
+					Name:          repoName,
+					Owner:         repoOwner,
+					URL:           repoURL,
+					RebaseEnabled: true,
+				},
+				Notifications: workflows.DeployNotifications{
+					Slack: []workflows.DeploySlackNotification{
+						{
+							ChannelID: "C123",
+							Events:    []string{"failed"},
+						},
+						{
+							ChannelID: "C456",
+						},
+					},
+				},
+			},
+			expectedWorkflow: workflows.Deploy,
+			expectedOptions: client.StartWorkflowOptions{
+				TaskQueue: workflows.DeployTaskQueue,
+				SearchAttributes: map[string]interface{}{
+					"atlantis_repository": repo.FullName,
+					"atlantis_root":       rootCfg.Name,
+				},
+			},
+			expectedWorkflowArgs: workflows.DeployRequest{
+				Repo: workflows.DeployRequestRepo{
+					FullName: repoFullName,
+				},
+				Root: workflows.DeployRequestRoot{
+					Name: rootCfg.Name,
+				},
+			},
+		}
+		deploySignaler := deploy.WorkflowSignaler{
+			TemporalClient: testSignaler,
+		}
+		rootDeployOptions := deploy.RootDeployOptions{
+			Repo:     repo,
+			Revision: sha,
+			Branch:   branch,
+			Sender:   user,
+			TriggerInfo: workflows.DeployTriggerInfo{
+				Type: workflows.MergeTrigger,
+			},
+		}
+		run, err := deploySignaler.SignalWithStartWorkflow(context.Background(), &rootCfg, rootDeployOptions)
+		assert.NoError(t, err)
+		assert.Equal(t, testRun{}, run)
+	})
 }
 
 func TestSignalWithStartWorkflow_Failure(t *testing.T) {
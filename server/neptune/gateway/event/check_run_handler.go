@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/config/valid"
 	contextInternal "github.com/runatlantis/atlantis/server/neptune/context"
@@ -14,7 +15,9 @@ import (
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/models"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/deploy"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/requirement"
 	"github.com/runatlantis/atlantis/server/neptune/workflows"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
 )
 
 const (
@@ -27,6 +30,11 @@ type deploySignaler interface {
 	SignalWorkflow(ctx context.Context, workflowID string, runID string, signalName string, arg interface{}) error
 }
 
+// defaultBranchRootBuilder builds roots from the default branch's repo config
+type defaultBranchRootBuilder interface {
+	BuildDefaultBranchRoot(ctx context.Context, repo models.Repo, installationToken int64, rootName string) (*valid.MergedProjectCfg, error)
+}
+
 var checkRunRegex = regexp.MustCompile("atlantis/deploy: (?P<name>.+)")
 
 type CheckRunAction interface {
@@ -64,6 +72,10 @@ type CheckRunHandler struct {
 	SyncScheduler  scheduler
 	AsyncScheduler scheduler
 	DeploySignaler deploySignaler
+	AuditSink      plugins.AuditSink
+	// RootConfigBuilder and Requirement authorize plan reviews against the apply team of the root
+	RootConfigBuilder defaultBranchRootBuilder
+	Requirement       requirementChecker
 }
 
 func (h *CheckRunHandler) Handle(ctx context.Context, event CheckRun) error {
@@ -114,14 +126,18 @@ func (h *CheckRunHandler) handleRequestedAction(ctx context.Context, event Check
 	case "Unlock":
 		return h.signalUnlockWorkflowChannel(ctx, event, rootName)
 	case "Confirm":
-		return h.signalPlanReviewWorkflowChannel(ctx, event, workflows.ApprovedPlanReviewStatus)
+		return h.signalPlanReviewWorkflowChannel(ctx, event, rootName, workflows.ApprovedPlanReviewStatus)
 	case "Reject":
-		return h.signalPlanReviewWorkflowChannel(ctx, event, workflows.RejectedPlanReviewStatus)
+		return h.signalPlanReviewWorkflowChannel(ctx, event, rootName, workflows.RejectedPlanReviewStatus)
 	}
 	return fmt.Errorf("unknown action id %s", action.Identifier)
 }
 
-func (h *CheckRunHandler) signalPlanReviewWorkflowChannel(ctx context.Context, event CheckRun, status workflows.TerraformPlanReviewStatus) error {
+func (h *CheckRunHandler) signalPlanReviewWorkflowChannel(ctx context.Context, event CheckRun, rootName string, status workflows.TerraformPlanReviewStatus) error {
+	if err := h.authorizePlanReview(ctx, event, rootName); err != nil {
+		return errors.Wrap(err, "authorizing reviewer")
+	}
+
 	err := h.DeploySignaler.SignalWorkflow(
 		ctx,
 		// assumed that we're using the check run external id as our workflow id
@@ -140,6 +156,25 @@ func (h *CheckRunHandler) signalPlanReviewWorkflowChannel(ctx context.Context, e
 	return nil
 }
 
+// authorizePlanReview requires the reviewer to be a member of the root's apply team, the root is read
+// from the default branch since that's what is deployed.
+func (h *CheckRunHandler) authorizePlanReview(ctx context.Context, event CheckRun, rootName string) error {
+	rootCfg, err := h.RootConfigBuilder.BuildDefaultBranchRoot(ctx, event.Repo, event.InstallationToken, rootName)
+	if err != nil {
+		return errors.Wrap(err, "building root")
+	}
+
+	criteria := requirement.Criteria{
+		User:              event.User,
+		Repo:              event.Repo,
+		InstallationToken: event.InstallationToken,
+	}
+	if rootCfg != nil {
+		criteria.Roots = []*valid.MergedProjectCfg{rootCfg}
+	}
+	return h.Requirement.Check(ctx, criteria)
+}
+
 func (h *CheckRunHandler) signalUnlockWorkflowChannel(ctx context.Context, event CheckRun, rootName string) error {
 	workflowID := deploy.BuildDeployWorkflowID(event.Repo.FullName, rootName)
 	err := h.DeploySignaler.SignalWorkflow(
@@ -156,6 +191,21 @@ func (h *CheckRunHandler) signalUnlockWorkflowChannel(ctx context.Context, event
 		return errors.Wrapf(err, "signaling workflow with id: %s", workflowID)
 	}
 	h.Logger.InfoContext(ctx, fmt.Sprintf("Signaled workflow with id %s to unlock", workflowID))
+
+	// the unlock has already gone through at this point so we don't fail the request
+	if err := h.AuditSink.Write(ctx, plugins.AuditEvent{
+		Version:    plugins.AuditEventSchemaVersion,
+		ID:         workflowID,
+		Type:       plugins.UnlockAuditEvent,
+		Status:     "unlocked",
+		Timestamp:  time.Now(),
+		Repository: event.Repo.FullName,
+		RootName:   rootName,
+		Revision:   event.HeadSha,
+		User:       event.User.Username,
+	}); err != nil {
+		h.Logger.ErrorContext(ctx, fmt.Sprintf("writing unlock audit event: %s", err))
+	}
 	return nil
 }
 
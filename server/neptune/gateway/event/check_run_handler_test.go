@@ -12,6 +12,8 @@ import (
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/deploy"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/event"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/requirement"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -169,13 +171,17 @@ func TestCheckRunHandler(t *testing.T) {
 		user := models.User{Username: "nish"}
 		workflowID := "wfid"
 		logger := logging.NewNoopCtxLogger(t)
+		rootCfg := &valid.MergedProjectCfg{Name: "testroot"}
+		reviewerRequirement := &testReviewerRequirement{}
 		subject := event.CheckRunHandler{
 			Logger:       logging.NewNoopCtxLogger(t),
 			RootDeployer: &testRootDeployer{},
 			// both are synchronous to keep our tests predictable
-			SyncScheduler:  &sync.SynchronousScheduler{Logger: logger},
-			AsyncScheduler: &sync.SynchronousScheduler{Logger: logger},
-			DeploySignaler: signaler,
+			SyncScheduler:     &sync.SynchronousScheduler{Logger: logger},
+			AsyncScheduler:    &sync.SynchronousScheduler{Logger: logger},
+			DeploySignaler:    signaler,
+			RootConfigBuilder: &testDefaultBranchRootBuilder{t: t, expectedRoot: "testroot", rootCfg: rootCfg},
+			Requirement:       reviewerRequirement,
 		}
 		e := event.CheckRun{
 			Action: event.RequestedActionChecksAction{
@@ -188,12 +194,41 @@ func TestCheckRunHandler(t *testing.T) {
 		err := subject.Handle(context.Background(), e)
 		assert.NoError(t, err)
 		assert.True(t, signaler.called)
+		assert.Equal(t, []*valid.MergedProjectCfg{rootCfg}, reviewerRequirement.criteria.Roots)
+		assert.Equal(t, user, reviewerRequirement.criteria.User)
+	})
+
+	t.Run("plan signal unauthorized", func(t *testing.T) {
+		signaler := &mockDeploySignaler{}
+		logger := logging.NewNoopCtxLogger(t)
+		subject := event.CheckRunHandler{
+			Logger:       logging.NewNoopCtxLogger(t),
+			RootDeployer: &testRootDeployer{},
+			// both are synchronous to keep our tests predictable
+			SyncScheduler:     &sync.SynchronousScheduler{Logger: logger},
+			AsyncScheduler:    &sync.SynchronousScheduler{Logger: logger},
+			DeploySignaler:    signaler,
+			RootConfigBuilder: &testDefaultBranchRootBuilder{t: t, expectedRoot: "testroot"},
+			Requirement:       &testReviewerRequirement{err: assert.AnError},
+		}
+		e := event.CheckRun{
+			Action: event.RequestedActionChecksAction{
+				Identifier: "Confirm",
+			},
+			ExternalID: "wfid",
+			User:       models.User{Username: "mallory"},
+			Name:       "atlantis/deploy: testroot",
+		}
+		err := subject.Handle(context.Background(), e)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.False(t, signaler.called)
 	})
 
 	t.Run("unlock signal success", func(t *testing.T) {
 		user := models.User{Username: "nish"}
 		workflowID := "testrepo||testroot"
 		signaler := &mockDeploySignaler{}
+		auditSink := &testAuditSink{}
 		logger := logging.NewNoopCtxLogger(t)
 		subject := event.CheckRunHandler{
 			Logger:       logging.NewNoopCtxLogger(t),
@@ -202,6 +237,7 @@ func TestCheckRunHandler(t *testing.T) {
 			SyncScheduler:  &sync.SynchronousScheduler{Logger: logger},
 			AsyncScheduler: &sync.SynchronousScheduler{Logger: logger},
 			DeploySignaler: signaler,
+			AuditSink:      auditSink,
 		}
 		e := event.CheckRun{
 			Action: event.RequestedActionChecksAction{
@@ -215,6 +251,11 @@ func TestCheckRunHandler(t *testing.T) {
 		err := subject.Handle(context.Background(), e)
 		assert.NoError(t, err)
 		assert.True(t, signaler.called)
+		assert.Len(t, auditSink.events, 1)
+		assert.Equal(t, plugins.UnlockAuditEvent, auditSink.events[0].Type)
+		assert.Equal(t, "nish", auditSink.events[0].User)
+		assert.Equal(t, "testroot", auditSink.events[0].RootName)
+		assert.Equal(t, "testrepo", auditSink.events[0].Repository)
 	})
 
 	t.Run("non-deploy atlantis check run", func(t *testing.T) {
@@ -245,9 +286,11 @@ func TestCheckRunHandler(t *testing.T) {
 			Logger:       logging.NewNoopCtxLogger(t),
 			RootDeployer: &testRootDeployer{},
 			// both are synchronous to keep our tests predictable
-			SyncScheduler:  &sync.SynchronousScheduler{Logger: logger},
-			AsyncScheduler: &sync.SynchronousScheduler{Logger: logger},
-			DeploySignaler: signaler,
+			SyncScheduler:     &sync.SynchronousScheduler{Logger: logger},
+			AsyncScheduler:    &sync.SynchronousScheduler{Logger: logger},
+			DeploySignaler:    signaler,
+			RootConfigBuilder: &testDefaultBranchRootBuilder{t: t, expectedRoot: "testroot"},
+			Requirement:       &testReviewerRequirement{},
 		}
 		e := event.CheckRun{
 			Action: event.RequestedActionChecksAction{
@@ -276,6 +319,36 @@ func (m *testRootDeployer) Deploy(_ context.Context, options deploy.RootDeployOp
 	return m.error
 }
 
+type testDefaultBranchRootBuilder struct {
+	t            *testing.T
+	expectedRoot string
+	rootCfg      *valid.MergedProjectCfg
+}
+
+func (b *testDefaultBranchRootBuilder) BuildDefaultBranchRoot(_ context.Context, _ models.Repo, _ int64, rootName string) (*valid.MergedProjectCfg, error) {
+	assert.Equal(b.t, b.expectedRoot, rootName)
+	return b.rootCfg, nil
+}
+
+type testReviewerRequirement struct {
+	criteria requirement.Criteria
+	err      error
+}
+
+func (r *testReviewerRequirement) Check(_ context.Context, criteria requirement.Criteria) error {
+	r.criteria = criteria
+	return r.err
+}
+
+type testAuditSink struct {
+	events []plugins.AuditEvent
+}
+
+func (s *testAuditSink) Write(_ context.Context, event plugins.AuditEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
 type mockDeploySignaler struct {
 	run    client.WorkflowRun
 	error  error
@@ -3,6 +3,7 @@ package event
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -12,6 +13,7 @@ import (
 	"github.com/runatlantis/atlantis/server/legacy/http"
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/comment"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/config"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/deploy"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/pr"
@@ -22,6 +24,12 @@ import (
 
 const warningMessage = "⚠️ WARNING ⚠️\n\n You are force applying changes from your PR instead of merging into your default branch 🚀. This can have unpredictable consequences 🙏🏽 and should only be used in an emergency 🆘.\n\n To confirm behavior, review and confirm the plan within the generated atlantis/deploy GH check below.\n\n 𝐓𝐡𝐢𝐬 𝐚𝐜𝐭𝐢𝐨𝐧 𝐰𝐢𝐥𝐥 𝐛𝐞 𝐚𝐮𝐝𝐢𝐭𝐞𝐝.\n"
 
+const selfApprovalMessage = "@%s PR authors can't approve the policies of their own pull request, please ask another policy owner to approve."
+
+const versionMessageHeader = "Terraform versions of the roots modified by this pull request:\n\n| Root | Version |\n| - | - |\n"
+
+const twoPersonRuleMessage = "\n 👥 Some of these roots follow the two person rule, their plans must be confirmed by an authorized user other than yourself.\n"
+
 type statusUpdater interface {
 	UpdateCombined(ctx context.Context, repo models.Repo, pull models.PullRequest, status models.VCSStatus, cmdName fmt.Stringer, statusID string, output string) (string, error)
 }
@@ -38,6 +46,17 @@ type requirementChecker interface {
 	Check(ctx context.Context, criteria requirement.Criteria) error
 }
 
+type prCommandSignaler interface {
+	prSignaler
+	SendPolicyCheckSignal(ctx context.Context, repoName string, pullNum int, revision string) error
+	SendApprovePoliciesSignal(ctx context.Context, repoName string, pullNum int, revision string, user string) error
+	SendUnlockSignal(ctx context.Context, repoName string, pullNum int, user string) error
+}
+
+type commandAuthorizer interface {
+	Authorize(ctx context.Context, cmd *command.Comment, user models.User, installationToken int64) error
+}
+
 type errorHandler interface {
 	WrapWithHandling(ctx context.Context, event PREvent, commandName string, executor sync.Executor) sync.Executor
 }
@@ -67,7 +86,7 @@ func (c Comment) GetRepo() models.Repo {
 	return c.BaseRepo
 }
 
-func NewCommentEventWorkerProxy(logger logging.Logger, scheduler scheduler, prSignaler prSignaler, deploySignaler deploySignaler, commentCreator commentCreator, vcsStatusUpdater statusUpdater, globalCfg valid.GlobalCfg, rootConfigBuilder rootConfigBuilder, legacyErrorHandler errorHandler, neptuneErrorHandler errorHandler, requirementChecker requirementChecker) *CommentEventWorkerProxy {
+func NewCommentEventWorkerProxy(logger logging.Logger, scheduler scheduler, prSignaler prCommandSignaler, deploySignaler deploySignaler, commentCreator commentCreator, vcsStatusUpdater statusUpdater, globalCfg valid.GlobalCfg, rootConfigBuilder rootConfigBuilder, legacyErrorHandler errorHandler, neptuneErrorHandler errorHandler, requirementChecker requirementChecker, commandAuthorizer commandAuthorizer, defaultTFVersion string) *CommentEventWorkerProxy {
 	return &CommentEventWorkerProxy{
 		logger:    logger,
 		scheduler: scheduler,
@@ -77,6 +96,8 @@ func NewCommentEventWorkerProxy(logger logging.Logger, scheduler scheduler, prSi
 			commentCreator:     commentCreator,
 			requirementChecker: requirementChecker,
 			prSignaler:         prSignaler,
+			commandAuthorizer:  commandAuthorizer,
+			defaultTFVersion:   defaultTFVersion,
 		},
 		vcsStatusUpdater:    vcsStatusUpdater,
 		rootConfigBuilder:   rootConfigBuilder,
@@ -90,17 +111,47 @@ type NeptuneWorkerProxy struct {
 	deploySignaler     deploySignaler
 	commentCreator     commentCreator
 	requirementChecker requirementChecker
-	prSignaler         prSignaler
+	prSignaler         prCommandSignaler
+	commandAuthorizer  commandAuthorizer
+	defaultTFVersion   string
 }
 
 func (p *NeptuneWorkerProxy) Handle(ctx context.Context, event Comment, cmd *command.Comment, roots []*valid.MergedProjectCfg, request *http.BufferedRequest) error {
+	if err := p.commandAuthorizer.Authorize(ctx, cmd, event.User, event.InstallationToken); err != nil {
+		var unauthorizedErr *comment.UnauthorizedError
+		if !errors.As(err, &unauthorizedErr) {
+			return errors.Wrap(err, "authorizing command")
+		}
+		if err := p.commentCreator.CreateComment(event.BaseRepo, event.PullNum, unauthorizedErr.Error(), cmd.CommandName().String()); err != nil {
+			p.logger.ErrorContext(ctx, err.Error())
+		}
+		return nil
+	}
+
 	if cmd.IsForSpecificProject() {
 		roots = partitionRootsByProject(cmd.ProjectName, roots)
 	}
 
-	if cmd.Name == command.Apply {
+	switch cmd.Name {
+	case command.Apply:
 		return p.handleApplies(ctx, event, cmd, roots)
+	case command.PolicyCheck:
+		return errors.Wrap(p.prSignaler.SendPolicyCheckSignal(ctx, event.Pull.HeadRepo.FullName, event.Pull.Num, event.Pull.HeadCommit), "signaling policy check")
+	case command.ApprovePolicies:
+		// consistent with github reviews, authors can't approve their own PRs
+		if event.User.Username == event.Pull.Author {
+			if err := p.commentCreator.CreateComment(event.BaseRepo, event.PullNum, fmt.Sprintf(selfApprovalMessage, event.User.Username), cmd.CommandName().String()); err != nil {
+				p.logger.ErrorContext(ctx, err.Error())
+			}
+			return nil
+		}
+		return errors.Wrap(p.prSignaler.SendApprovePoliciesSignal(ctx, event.Pull.HeadRepo.FullName, event.Pull.Num, event.Pull.HeadCommit, event.User.Username), "signaling policy approval")
+	case command.Unlock:
+		return errors.Wrap(p.prSignaler.SendUnlockSignal(ctx, event.Pull.HeadRepo.FullName, event.Pull.Num, event.User.Username), "signaling unlock")
+	case command.Version:
+		return errors.Wrap(p.commentCreator.CreateComment(event.BaseRepo, event.PullNum, p.buildVersionComment(roots), cmd.CommandName().String()), "commenting versions")
 	}
+
 	prRequest := pr.Request{
 		Number:            event.Pull.Num,
 		Revision:          event.Pull.HeadCommit,
@@ -108,6 +159,9 @@ func (p *NeptuneWorkerProxy) Handle(ctx context.Context, event Comment, cmd *com
 		InstallationToken: event.InstallationToken,
 		Branch:            event.Pull.HeadBranch,
 		ValidateEnvs:      buildValidateEnvsFromComment(event),
+		// plans requested through comments always re-plan every root
+		ForceReplan:   true,
+		PlanExtraArgs: cmd.Flags,
 	}
 	run, err := p.prSignaler.SignalWithStartWorkflow(ctx, roots, prRequest)
 	if err != nil {
@@ -143,7 +197,7 @@ func (p *NeptuneWorkerProxy) handleApplies(ctx context.Context, event Comment, c
 	}
 
 	if cmd.ForceApply {
-		if err := p.commentCreator.CreateComment(event.BaseRepo, event.PullNum, warningMessage, ""); err != nil {
+		if err := p.commentCreator.CreateComment(event.BaseRepo, event.PullNum, buildForceApplyWarning(roots), ""); err != nil {
 			p.logger.ErrorContext(ctx, err.Error())
 		}
 	}
@@ -156,6 +210,8 @@ func (p *NeptuneWorkerProxy) handleApplies(ctx context.Context, event Comment, c
 		Sender:            event.User,
 		InstallationToken: event.InstallationToken,
 		TriggerInfo:       triggerInfo,
+
+		EnforceTwoPersonRule: cmd.ForceApply,
 	}
 
 	for _, r := range roots {
@@ -167,6 +223,29 @@ func (p *NeptuneWorkerProxy) handleApplies(ctx context.Context, event Comment, c
 	return nil
 }
 
+// buildVersionComment lists the terraform version each root is planned and applied with
+func (p *NeptuneWorkerProxy) buildVersionComment(roots []*valid.MergedProjectCfg) string {
+	var b strings.Builder
+	b.WriteString(versionMessageHeader)
+	for _, r := range roots {
+		tfVersion := p.defaultTFVersion
+		if r.TerraformVersion != nil {
+			tfVersion = r.TerraformVersion.String()
+		}
+		fmt.Fprintf(&b, "| `%s` | `%s` |\n", r.Name, tfVersion)
+	}
+	return b.String()
+}
+
+func buildForceApplyWarning(roots []*valid.MergedProjectCfg) string {
+	for _, r := range roots {
+		if r.ApplySettings.TwoPersonRule {
+			return warningMessage + twoPersonRuleMessage
+		}
+	}
+	return warningMessage
+}
+
 type CommentEventWorkerProxy struct {
 	logger              logging.Logger
 	scheduler           scheduler
@@ -185,12 +264,21 @@ func (p *CommentEventWorkerProxy) Handle(ctx context.Context, request *http.Buff
 }
 
 func (p *CommentEventWorkerProxy) handle(ctx context.Context, request *http.BufferedRequest, event Comment, cmd *command.Comment) error {
+	// these commands signal the running PR workflow directly and don't operate on roots
+	if isPRWorkflowSignal(cmd) {
+		return p.schedule(ctx, event, cmd, nil, request)
+	}
+
+	var opts []config.BuilderOptions
+	if cmd.Name == command.Plan {
+		opts = append(opts, config.BuilderOptions{Fingerprint: true})
+	}
 	roots, err := p.rootConfigBuilder.Build(ctx, &config.RepoCommit{
 		Repo:          event.BaseRepo,
 		Branch:        event.Pull.HeadBranch,
 		Sha:           event.Pull.HeadCommit,
 		OptionalPRNum: event.PullNum,
-	}, event.InstallationToken)
+	}, event.InstallationToken, opts...)
 
 	if err != nil {
 		return errors.Wrap(err, "getting project commands")
@@ -201,7 +289,10 @@ func (p *CommentEventWorkerProxy) handle(ctx context.Context, request *http.Buff
 		p.markSuccessStatuses(ctx, event, cmd)
 		return nil
 	}
+	return p.schedule(ctx, event, cmd, roots, request)
+}
 
+func (p *CommentEventWorkerProxy) schedule(ctx context.Context, event Comment, cmd *command.Comment, roots []*valid.MergedProjectCfg, request *http.BufferedRequest) error {
 	fxns := []sync.Executor{
 		p.neptuneErrorHandler.WrapWithHandling(ctx, event, cmd.CommandName().String(), func(ctx context.Context) error {
 			return p.neptuneWorkerProxy.Handle(ctx, event, cmd, roots, request)
@@ -225,6 +316,14 @@ func (p *CommentEventWorkerProxy) markSuccessStatuses(ctx context.Context, event
 	}
 }
 
+func isPRWorkflowSignal(cmd *command.Comment) bool {
+	switch cmd.Name {
+	case command.PolicyCheck, command.ApprovePolicies, command.Unlock:
+		return true
+	}
+	return false
+}
+
 func partitionRootsByProject(name string, cmds []*valid.MergedProjectCfg) []*valid.MergedProjectCfg {
 	var cfgs []*valid.MergedProjectCfg
 	for _, cmd := range cmds {
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/go-version"
 	"github.com/runatlantis/atlantis/server/config/valid"
 	"github.com/runatlantis/atlantis/server/legacy/events/command"
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/comment"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/config"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/deploy"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/event"
@@ -127,6 +129,7 @@ func TestCommentEventWorkerProxy_HandleForceApply(t *testing.T) {
 			Type:  workflows.ManualTrigger,
 			Force: true,
 		},
+		EnforceTwoPersonRule: true,
 	}
 	rootConfigBuilder := &mockRootConfigBuilder{
 		expectedT: t,
@@ -139,7 +142,8 @@ func TestCommentEventWorkerProxy_HandleForceApply(t *testing.T) {
 		expectedToken: 123,
 		rootConfigs: []*valid.MergedProjectCfg{
 			{
-				Name: "root1",
+				Name:          "root1",
+				ApplySettings: valid.ApplySettings{TwoPersonRule: true},
 			},
 			{
 				Name: "root2",
@@ -165,14 +169,14 @@ func TestCommentEventWorkerProxy_HandleForceApply(t *testing.T) {
 		expectedT:       t,
 		expectedRepo:    testRepo,
 		expectedPull:    testPull.Num,
-		expectedMessage: "⚠️ WARNING ⚠️\n\n You are force applying changes from your PR instead of merging into your default branch 🚀. This can have unpredictable consequences 🙏🏽 and should only be used in an emergency 🆘.\n\n To confirm behavior, review and confirm the plan within the generated atlantis/deploy GH check below.\n\n 𝐓𝐡𝐢𝐬 𝐚𝐜𝐭𝐢𝐨𝐧 𝐰𝐢𝐥𝐥 𝐛𝐞 𝐚𝐮𝐝𝐢𝐭𝐞𝐝.\n",
+		expectedMessage: "⚠️ WARNING ⚠️\n\n You are force applying changes from your PR instead of merging into your default branch 🚀. This can have unpredictable consequences 🙏🏽 and should only be used in an emergency 🆘.\n\n To confirm behavior, review and confirm the plan within the generated atlantis/deploy GH check below.\n\n 𝐓𝐡𝐢𝐬 𝐚𝐜𝐭𝐢𝐨𝐧 𝐰𝐢𝐥𝐥 𝐛𝐞 𝐚𝐮𝐝𝐢𝐭𝐞𝐝.\n\n 👥 Some of these roots follow the two person rule, their plans must be confirmed by an authorized user other than yourself.\n",
 	}
 	statusUpdater := &mockStatusUpdater{}
 	cfg := valid.NewGlobalCfg("somedir")
 	prSignaler := &mockPRSignaler{
 		expectedT: t,
 	}
-	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, testSignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{})
+	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, testSignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{}, &mockCommandAuthorizer{}, "1.0.0")
 	bufReq := buildRequest(t)
 	cmd := &command.Comment{
 		Name:       command.Apply,
@@ -226,7 +230,7 @@ func TestCommentEventWorkerProxy_HandleApplyComment_RequirementsFailed(t *testin
 	cfg := valid.NewGlobalCfg("somedir")
 	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, testSignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{
 		err: assert.AnError,
-	})
+	}, &mockCommandAuthorizer{}, "1.0.0")
 	bufReq := buildRequest(t)
 	cmd := &command.Comment{
 		Name: command.Apply,
@@ -300,7 +304,7 @@ func TestCommentEventWorkerProxy_HandleApplyComment(t *testing.T) {
 	prSignaler := &mockPRSignaler{
 		expectedT: t,
 	}
-	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, testSignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{})
+	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, testSignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{}, &mockCommandAuthorizer{}, "1.0.0")
 	bufReq := buildRequest(t)
 	cmd := &command.Comment{
 		Name: command.Apply,
@@ -322,7 +326,8 @@ func TestCommentEventWorkerProxy_HandlePlanComment_NoCmds(t *testing.T) {
 			Sha:           testPull.HeadCommit,
 			OptionalPRNum: testPull.Num,
 		},
-		expectedToken: 123,
+		expectedToken:   123,
+		expectedOptions: []config.BuilderOptions{{Fingerprint: true}},
 	}
 	testSignaler := &testDeploySignaler{}
 	commentEvent := event.Comment{
@@ -369,7 +374,7 @@ func TestCommentEventWorkerProxy_HandlePlanComment_NoCmds(t *testing.T) {
 	prSignaler := &mockPRSignaler{
 		expectedT: t,
 	}
-	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, testSignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{})
+	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, testSignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{}, &mockCommandAuthorizer{}, "1.0.0")
 	bufReq := buildRequest(t)
 	cmd := &command.Comment{
 		Name: command.Plan,
@@ -422,7 +427,7 @@ func TestCommentEventWorkerProxy_HandleApplyComment_NoCmds(t *testing.T) {
 	prSignaler := &mockPRSignaler{
 		expectedT: t,
 	}
-	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, testSignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{})
+	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, testSignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{}, &mockCommandAuthorizer{}, "1.0.0")
 	bufReq := buildRequest(t)
 	cmd := &command.Comment{
 		Name: command.Apply,
@@ -456,6 +461,7 @@ func TestCommentEventWorkerProxy_HandlePlanComment(t *testing.T) {
 				Username:   "someuser",
 			},
 		},
+		ForceReplan: true,
 	}
 	rootConfigBuilder := &mockRootConfigBuilder{
 		expectedT: t,
@@ -465,8 +471,9 @@ func TestCommentEventWorkerProxy_HandlePlanComment(t *testing.T) {
 			Sha:           testPull.HeadCommit,
 			OptionalPRNum: testPull.Num,
 		},
-		expectedToken: 123,
-		rootConfigs:   roots,
+		expectedToken:   123,
+		expectedOptions: []config.BuilderOptions{{Fingerprint: true}},
+		rootConfigs:     roots,
 	}
 	deploySignaler := &testDeploySignaler{}
 	commentEvent := event.Comment{
@@ -488,7 +495,7 @@ func TestCommentEventWorkerProxy_HandlePlanComment(t *testing.T) {
 		expectedRoots:     roots,
 		expectedPRRequest: prRequest,
 	}
-	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, deploySignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{})
+	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, deploySignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{}, &mockCommandAuthorizer{}, "1.0.0")
 	bufReq := buildRequest(t)
 	cmd := &command.Comment{
 		Name: command.Plan,
@@ -523,6 +530,7 @@ func TestCommentEventWorkerProxy_HandlePlanCommentAllocatorEnabled(t *testing.T)
 				Username:   "someuser",
 			},
 		},
+		ForceReplan: true,
 	}
 	rootConfigBuilder := &mockRootConfigBuilder{
 		expectedT: t,
@@ -532,8 +540,9 @@ func TestCommentEventWorkerProxy_HandlePlanCommentAllocatorEnabled(t *testing.T)
 			Sha:           testPull.HeadCommit,
 			OptionalPRNum: testPull.Num,
 		},
-		expectedToken: token,
-		rootConfigs:   roots,
+		expectedToken:   token,
+		expectedOptions: []config.BuilderOptions{{Fingerprint: true}},
+		rootConfigs:     roots,
 	}
 	testSignaler := &testDeploySignaler{}
 	commentEvent := event.Comment{
@@ -555,7 +564,7 @@ func TestCommentEventWorkerProxy_HandlePlanCommentAllocatorEnabled(t *testing.T)
 		expectedRoots:     roots,
 		expectedPRRequest: prRequest,
 	}
-	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, testSignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{})
+	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, testSignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{}, &mockCommandAuthorizer{}, "1.0.0")
 	bufReq := buildRequest(t)
 	cmd := &command.Comment{
 		Name: command.Plan,
@@ -568,6 +577,190 @@ func TestCommentEventWorkerProxy_HandlePlanCommentAllocatorEnabled(t *testing.T)
 	assert.True(t, prSignaler.called)
 }
 
+func TestCommentEventWorkerProxy_HandleVersion(t *testing.T) {
+	logger := logging.NewNoopCtxLogger(t)
+	pinned, err := version.NewVersion("1.2.3")
+	assert.NoError(t, err)
+	roots := []*valid.MergedProjectCfg{
+		{Name: "pinned", TerraformVersion: pinned},
+		{Name: "unpinned"},
+	}
+	rootConfigBuilder := &mockRootConfigBuilder{
+		expectedT: t,
+		expectedCommit: &config.RepoCommit{
+			Repo:          testRepo,
+			Branch:        testPull.HeadBranch,
+			Sha:           testPull.HeadCommit,
+			OptionalPRNum: testPull.Num,
+		},
+		expectedToken: 123,
+		rootConfigs:   roots,
+	}
+	commentEvent := event.Comment{
+		Pull:     testPull,
+		PullNum:  testPull.Num,
+		BaseRepo: testRepo,
+		HeadRepo: testRepo,
+		User: models.User{
+			Username: "someuser",
+		},
+		InstallationToken: 123,
+	}
+	commentCreator := &mockCommentCreator{
+		expectedT:       t,
+		expectedRepo:    testRepo,
+		expectedPull:    testPull.Num,
+		expectedMessage: "Terraform versions of the roots modified by this pull request:\n\n| Root | Version |\n| - | - |\n| `pinned` | `1.2.3` |\n| `unpinned` | `1.0.0` |\n",
+	}
+	prSignaler := &mockPRSignaler{expectedT: t}
+	scheduler := &sync.SynchronousScheduler{Logger: logger}
+	cfg := valid.NewGlobalCfg("somedir")
+	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, &testDeploySignaler{}, commentCreator, &mockStatusUpdater{}, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{}, &mockCommandAuthorizer{}, "1.0.0")
+	err = commentEventWorkerProxy.Handle(context.Background(), buildRequest(t), commentEvent, &command.Comment{Name: command.Version})
+	assert.NoError(t, err)
+	assert.True(t, commentCreator.isCalled)
+	assert.False(t, prSignaler.called)
+}
+
+func TestCommentEventWorkerProxy_HandlePRWorkflowSignals(t *testing.T) {
+	pull := testPull
+	pull.HeadRepo = testRepo
+	commentEvent := event.Comment{
+		Pull:     pull,
+		PullNum:  pull.Num,
+		BaseRepo: testRepo,
+		HeadRepo: testRepo,
+		User: models.User{
+			Username: "someuser",
+		},
+		InstallationToken: 123,
+	}
+
+	cases := []struct {
+		description string
+		cmdName     command.Name
+		called      func(s *mockPRSignaler) bool
+	}{
+		{
+			description: "policy check",
+			cmdName:     command.PolicyCheck,
+			called:      func(s *mockPRSignaler) bool { return s.policyCheckCalled },
+		},
+		{
+			description: "approve policies",
+			cmdName:     command.ApprovePolicies,
+			called:      func(s *mockPRSignaler) bool { return s.approvePoliciesCalled },
+		},
+		{
+			description: "unlock",
+			cmdName:     command.Unlock,
+			called:      func(s *mockPRSignaler) bool { return s.unlockCalled },
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			logger := logging.NewNoopCtxLogger(t)
+			prSignaler := &mockPRSignaler{
+				expectedT:        t,
+				expectedRepoName: repoFullName,
+				expectedPullNum:  pull.Num,
+				expectedRevision: pull.HeadCommit,
+				expectedUser:     "someuser",
+			}
+			rootConfigBuilder := &mockRootConfigBuilder{
+				expectedT: t,
+			}
+			scheduler := &sync.SynchronousScheduler{Logger: logger}
+			commentCreator := &mockCommentCreator{}
+			statusUpdater := &mockStatusUpdater{}
+			testSignaler := &testDeploySignaler{}
+			cfg := valid.NewGlobalCfg("somedir")
+			commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, testSignaler, commentCreator, statusUpdater, cfg, rootConfigBuilder, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{}, &mockCommandAuthorizer{}, "1.0.0")
+			err := commentEventWorkerProxy.Handle(context.Background(), buildRequest(t), commentEvent, &command.Comment{Name: c.cmdName})
+			assert.NoError(t, err)
+			assert.True(t, c.called(prSignaler))
+			assert.False(t, prSignaler.called)
+			assert.False(t, testSignaler.called)
+			assert.False(t, commentCreator.isCalled)
+		})
+	}
+}
+
+func TestCommentEventWorkerProxy_HandleUnauthorized(t *testing.T) {
+	logger := logging.NewNoopCtxLogger(t)
+	commentEvent := event.Comment{
+		Pull:     testPull,
+		PullNum:  testPull.Num,
+		BaseRepo: testRepo,
+		HeadRepo: testRepo,
+		User: models.User{
+			Username: "someuser",
+		},
+		InstallationToken: 123,
+	}
+	prSignaler := &mockPRSignaler{
+		expectedT: t,
+	}
+	scheduler := &sync.SynchronousScheduler{Logger: logger}
+	commentCreator := &mockCommentCreator{
+		expectedT:       t,
+		expectedRepo:    testRepo,
+		expectedPull:    testPull.Num,
+		expectedMessage: "@someuser is not authorized to run `atlantis approve-policies`",
+	}
+	statusUpdater := &mockStatusUpdater{}
+	testSignaler := &testDeploySignaler{}
+	cfg := valid.NewGlobalCfg("somedir")
+	authorizer := &mockCommandAuthorizer{
+		err: &comment.UnauthorizedError{User: "someuser", Keyword: "approve-policies"},
+	}
+	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, testSignaler, commentCreator, statusUpdater, cfg, &mockRootConfigBuilder{expectedT: t}, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{}, authorizer, "1.0.0")
+	err := commentEventWorkerProxy.Handle(context.Background(), buildRequest(t), commentEvent, &command.Comment{Name: command.ApprovePolicies})
+	assert.NoError(t, err)
+	assert.True(t, commentCreator.isCalled)
+	assert.False(t, prSignaler.approvePoliciesCalled)
+}
+
+func TestCommentEventWorkerProxy_HandleApprovePoliciesByAuthor(t *testing.T) {
+	logger := logging.NewNoopCtxLogger(t)
+	pull := testPull
+	pull.Author = "someuser"
+	commentEvent := event.Comment{
+		Pull:     pull,
+		PullNum:  pull.Num,
+		BaseRepo: testRepo,
+		HeadRepo: testRepo,
+		User: models.User{
+			Username: "someuser",
+		},
+		InstallationToken: 123,
+	}
+	prSignaler := &mockPRSignaler{
+		expectedT: t,
+	}
+	scheduler := &sync.SynchronousScheduler{Logger: logger}
+	commentCreator := &mockCommentCreator{
+		expectedT:       t,
+		expectedRepo:    testRepo,
+		expectedPull:    pull.Num,
+		expectedMessage: "@someuser PR authors can't approve the policies of their own pull request, please ask another policy owner to approve.",
+	}
+	cfg := valid.NewGlobalCfg("somedir")
+	commentEventWorkerProxy := event.NewCommentEventWorkerProxy(logger, scheduler, prSignaler, &testDeploySignaler{}, commentCreator, &mockStatusUpdater{}, cfg, &mockRootConfigBuilder{expectedT: t}, noopErrorHandler{}, noopErrorHandler{}, &requirementsChecker{}, &mockCommandAuthorizer{}, "1.0.0")
+	err := commentEventWorkerProxy.Handle(context.Background(), buildRequest(t), commentEvent, &command.Comment{Name: command.ApprovePolicies})
+	assert.NoError(t, err)
+	assert.True(t, commentCreator.isCalled)
+	assert.False(t, prSignaler.approvePoliciesCalled)
+}
+
+type mockCommandAuthorizer struct {
+	err error
+}
+
+func (a *mockCommandAuthorizer) Authorize(_ context.Context, _ *command.Comment, _ models.User, _ int64) error {
+	return a.err
+}
+
 type mockCommentCreator struct {
 	isCalled        bool
 	expectedT       *testing.T
@@ -27,6 +27,10 @@ type prSignaler interface {
 	SignalWithStartWorkflow(ctx context.Context, rootCfgs []*valid.MergedProjectCfg, prRequest pr.Request) (client.WorkflowRun, error)
 }
 
+type pullIngester interface {
+	IngestPullRequest(ctx context.Context, event PullRequest) error
+}
+
 type ModifiedPullHandler struct {
 	Logger             logging.Logger
 	Scheduler          scheduler
@@ -35,6 +39,10 @@ type ModifiedPullHandler struct {
 	RequirementChecker requirementChecker
 	LegacyHandler      legacyHandler
 	PRSignaler         prSignaler
+
+	// Ingester is optional and, when set, hands accepted events off to a durable
+	// ingestion workflow instead of processing them in the gateway.
+	Ingester pullIngester
 }
 
 // PullRequest is our internal representation of a vcs based pr event
@@ -59,11 +67,24 @@ func NewModifiedPullHandler(logger logging.Logger, scheduler scheduler, rootConf
 }
 
 func (p *ModifiedPullHandler) Handle(ctx context.Context, request *http.BufferedRequest, event PullRequest) error {
+	if p.Ingester != nil {
+		return p.Ingester.IngestPullRequest(ctx, event)
+	}
+
 	return p.Scheduler.Schedule(ctx, func(ctx context.Context) error {
 		return p.handle(ctx, request, event)
 	})
 }
 
+// Process builds the roots for an already accepted pull request event and signals them.
+// The raw request isn't persisted alongside the event so it is not available here, this is
+// fine since none of our downstream handlers depend on it.
+// Handlers used for processing should be configured with a synchronous scheduler so that
+// failures are surfaced to the caller.
+func (p *ModifiedPullHandler) Process(ctx context.Context, event PullRequest) error {
+	return p.handle(ctx, nil, event)
+}
+
 func (p *ModifiedPullHandler) handle(ctx context.Context, request *http.BufferedRequest, event PullRequest) error {
 	criteria := requirement.Criteria{
 		User:              event.User,
@@ -95,6 +116,7 @@ func (p *ModifiedPullHandler) handle(ctx context.Context, request *http.Buffered
 		RepoFetcherOptions: &github.RepoFetcherOptions{
 			CloneDepth: cloneDepth,
 		},
+		Fingerprint: true,
 	}
 
 	rootCfgs, err := p.RootConfigBuilder.Build(ctx, commit, event.InstallationToken, builderOptions)
@@ -76,6 +76,53 @@ func TestModifiedPullHandler_Handle_SignalerFailure(t *testing.T) {
 	assert.ErrorContains(t, err, assert.AnError.Error())
 }
 
+func TestModifiedPullHandler_Handle_Ingester(t *testing.T) {
+	logger := logging.NewNoopCtxLogger(t)
+	ingester := &mockIngester{}
+	pullHandler := event.ModifiedPullHandler{
+		Logger:             logger,
+		Scheduler:          &sync.SynchronousScheduler{Logger: logger},
+		GlobalCfg:          valid.GlobalCfg{},
+		RequirementChecker: &requirementsChecker{err: assert.AnError},
+		Ingester:           ingester,
+	}
+	pull := event.PullRequest{Pull: models.PullRequest{Num: 1}}
+	err := pullHandler.Handle(context.Background(), &http.BufferedRequest{}, pull)
+	assert.NoError(t, err)
+	assert.Equal(t, []event.PullRequest{pull}, ingester.pulls)
+}
+
+func TestModifiedPullHandler_Process_SignalerFailure(t *testing.T) {
+	logger := logging.NewNoopCtxLogger(t)
+	root := &valid.MergedProjectCfg{
+		Name: "platform",
+	}
+	prRequest := pr.Request{ValidateEnvs: []pr.ValidateEnvs{{}}}
+	pullHandler := event.ModifiedPullHandler{
+		Logger:             logger,
+		Scheduler:          &sync.SynchronousScheduler{Logger: logger},
+		GlobalCfg:          valid.GlobalCfg{},
+		RequirementChecker: &requirementsChecker{},
+		RootConfigBuilder: &mockConfigBuilder{
+			expectedCommit: &config.RepoCommit{},
+			expectedT:      t,
+			rootConfigs:    []*valid.MergedProjectCfg{root},
+		},
+		LegacyHandler: &mockLegacyHandler{
+			expectedAllRoots: []*valid.MergedProjectCfg{root},
+			expectedT:        t,
+		},
+		PRSignaler: &mockPRSignaler{
+			error:             assert.AnError,
+			expectedRoots:     []*valid.MergedProjectCfg{root},
+			expectedT:         t,
+			expectedPRRequest: prRequest,
+		},
+	}
+	err := pullHandler.Process(context.Background(), event.PullRequest{})
+	assert.ErrorContains(t, err, assert.AnError.Error())
+}
+
 func TestModifiedPullHandler_Handle_BranchStrategy(t *testing.T) {
 	testRepo := models.Repo{
 		FullName: "owner/repo",
@@ -258,6 +305,14 @@ type mockPRSignaler struct {
 	expectedPRRequest pr.Request
 	expectedRoots     []*valid.MergedProjectCfg
 	expectedT         *testing.T
+
+	policyCheckCalled     bool
+	approvePoliciesCalled bool
+	unlockCalled          bool
+	expectedRepoName      string
+	expectedPullNum       int
+	expectedRevision      string
+	expectedUser          string
 }
 
 func (s *mockPRSignaler) SignalWithStartWorkflow(_ context.Context, rootCfgs []*valid.MergedProjectCfg, prRequest pr.Request) (client.WorkflowRun, error) {
@@ -266,3 +321,28 @@ func (s *mockPRSignaler) SignalWithStartWorkflow(_ context.Context, rootCfgs []*
 	assert.Equal(s.expectedT, s.expectedRoots, rootCfgs)
 	return testRun{}, s.error
 }
+
+func (s *mockPRSignaler) SendPolicyCheckSignal(_ context.Context, repoName string, pullNum int, revision string) error {
+	s.policyCheckCalled = true
+	assert.Equal(s.expectedT, s.expectedRepoName, repoName)
+	assert.Equal(s.expectedT, s.expectedPullNum, pullNum)
+	assert.Equal(s.expectedT, s.expectedRevision, revision)
+	return s.error
+}
+
+func (s *mockPRSignaler) SendApprovePoliciesSignal(_ context.Context, repoName string, pullNum int, revision string, user string) error {
+	s.approvePoliciesCalled = true
+	assert.Equal(s.expectedT, s.expectedRepoName, repoName)
+	assert.Equal(s.expectedT, s.expectedPullNum, pullNum)
+	assert.Equal(s.expectedT, s.expectedRevision, revision)
+	assert.Equal(s.expectedT, s.expectedUser, user)
+	return s.error
+}
+
+func (s *mockPRSignaler) SendUnlockSignal(_ context.Context, repoName string, pullNum int, user string) error {
+	s.unlockCalled = true
+	assert.Equal(s.expectedT, s.expectedRepoName, repoName)
+	assert.Equal(s.expectedT, s.expectedPullNum, pullNum)
+	assert.Equal(s.expectedT, s.expectedUser, user)
+	return s.error
+}
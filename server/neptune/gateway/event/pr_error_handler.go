@@ -55,6 +55,7 @@ type LegacyPREventErrorHandler struct {
 func (p *LegacyPREventErrorHandler) WrapWithHandling(ctx context.Context, event PREvent, commandName string, executor sync.Executor) sync.Executor {
 	allocation, err := p.allocator.ShouldAllocate(feature.LegacyDeprecation, feature.FeatureContext{
 		RepoName: event.GetRepo().FullName,
+		Org:      event.GetRepo().Owner,
 	})
 
 	if err != nil {
@@ -76,6 +77,7 @@ type NeptunePREventErrorHandler struct {
 func (p *NeptunePREventErrorHandler) WrapWithHandling(ctx context.Context, event PREvent, commandName string, executor sync.Executor) sync.Executor {
 	allocation, err := p.allocator.ShouldAllocate(feature.LegacyDeprecation, feature.FeatureContext{
 		RepoName: event.GetRepo().FullName,
+		Org:      event.GetRepo().Owner,
 	})
 
 	if err != nil {
@@ -16,7 +16,7 @@ type HookExecutor struct {
 	Logger logging.Logger
 }
 
-func (e *HookExecutor) Execute(ctx context.Context, hook *valid.PreWorkflowHook, repo models.Repo, path string) error {
+func (e *HookExecutor) Execute(ctx context.Context, hook *valid.PreWorkflowHook, repo models.Repo, path string, envs map[string]string) error {
 	cmd := subprocess_exec.Command(e.Logger, "sh", "-c", hook.RunCommand)
 	cmd.Dir = path
 	baseEnvVars := os.Environ()
@@ -31,6 +31,9 @@ func (e *HookExecutor) Execute(ctx context.Context, hook *valid.PreWorkflowHook,
 	for key, val := range customEnvVars {
 		finalEnvVars = append(finalEnvVars, fmt.Sprintf("%s=%s", key, val))
 	}
+	for key, val := range envs {
+		finalEnvVars = append(finalEnvVars, fmt.Sprintf("%s=%s", key, val))
+	}
 	cmd.Env = finalEnvVars
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
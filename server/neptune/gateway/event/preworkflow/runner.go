@@ -6,18 +6,27 @@ import (
 	"github.com/pkg/errors"
 	"github.com/runatlantis/atlantis/server/config/valid"
 	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/vcs/provider/github"
 )
 
 type executor interface {
-	Execute(ctx context.Context, hook *valid.PreWorkflowHook, repo models.Repo, path string) error
+	Execute(ctx context.Context, hook *valid.PreWorkflowHook, repo models.Repo, path string, envs map[string]string) error
+}
+
+type tokenGetter interface {
+	GetToken(ctx context.Context, installationID int64) (string, error)
 }
 
 type HooksRunner struct {
-	GlobalCfg    valid.GlobalCfg
-	HookExecutor executor
+	GlobalCfg         valid.GlobalCfg
+	HookExecutor      executor
+	GithubCredentials tokenGetter
+	GithubHostname    string
 }
 
-func (r *HooksRunner) Run(ctx context.Context, baseRepo models.Repo, repoDir string) error {
+// Run runs the repo's preworkflow hooks in repoDir, hooks are authenticated as the installation so
+// that they can run git commands against the repo's host.
+func (r *HooksRunner) Run(ctx context.Context, baseRepo models.Repo, repoDir string, installationToken int64) error {
 	preWorkflowHooks := make([]*valid.PreWorkflowHook, 0)
 	for _, repo := range r.GlobalCfg.Repos {
 		if repo.IDMatches(baseRepo.ID()) && len(repo.PreWorkflowHooks) > 0 {
@@ -30,9 +39,15 @@ func (r *HooksRunner) Run(ctx context.Context, baseRepo models.Repo, repoDir str
 		return nil
 	}
 
+	ghToken, err := r.GithubCredentials.GetToken(ctx, installationToken)
+	if err != nil {
+		return errors.Wrap(err, "fetching github token")
+	}
+	envs := github.GitCredentialEnvs(r.GithubHostname, ghToken)
+
 	// uses default zero values for some field in PreWorkflowHookCommandContext struct since they aren't relevant to fxn
 	for _, hook := range preWorkflowHooks {
-		err := r.HookExecutor.Execute(ctx, hook, baseRepo, repoDir)
+		err := r.HookExecutor.Execute(ctx, hook, baseRepo, repoDir, envs)
 		if err != nil {
 			return errors.Wrap(err, "running pre workflow hooks")
 		}
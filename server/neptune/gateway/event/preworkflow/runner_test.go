@@ -9,6 +9,7 @@ import (
 	"github.com/runatlantis/atlantis/server/models"
 	"github.com/runatlantis/atlantis/server/models/fixtures"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/event/preworkflow"
+	"github.com/runatlantis/atlantis/server/vcs/provider/github"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,6 +21,7 @@ func TestRunPreHooks_Clone(t *testing.T) {
 	repoDir := "path/to/repo"
 
 	t.Run("success hooks in cfg", func(t *testing.T) {
+		executor := &mockPreWorkflowHookExecutor{}
 		globalCfg := valid.GlobalCfg{
 			Repos: []valid.Repo{
 				{
@@ -31,11 +33,14 @@ func TestRunPreHooks_Clone(t *testing.T) {
 			},
 		}
 		wh := preworkflow.HooksRunner{
-			HookExecutor: &mockPreWorkflowHookExecutor{},
-			GlobalCfg:    globalCfg,
+			HookExecutor:      executor,
+			GlobalCfg:         globalCfg,
+			GithubCredentials: &testTokenGetter{token: "token"},
+			GithubHostname:    "github.com",
 		}
-		err := wh.Run(context.Background(), fixtures.GithubRepo, repoDir)
+		err := wh.Run(context.Background(), fixtures.GithubRepo, repoDir, 1)
 		assert.NoError(t, err)
+		assert.Equal(t, github.GitCredentialEnvs("github.com", "token"), executor.envs)
 	})
 	t.Run("success hooks not in cfg", func(t *testing.T) {
 		globalCfg := valid.GlobalCfg{
@@ -58,7 +63,7 @@ func TestRunPreHooks_Clone(t *testing.T) {
 			HookExecutor: &mockPreWorkflowHookExecutor{},
 			GlobalCfg:    globalCfg,
 		}
-		err := wh.Run(context.Background(), fixtures.GithubRepo, repoDir)
+		err := wh.Run(context.Background(), fixtures.GithubRepo, repoDir, 1)
 		assert.NoError(t, err)
 	})
 	t.Run("error running pre hook", func(t *testing.T) {
@@ -76,17 +81,50 @@ func TestRunPreHooks_Clone(t *testing.T) {
 			HookExecutor: &mockPreWorkflowHookExecutor{
 				error: errors.New("some error"),
 			},
-			GlobalCfg: globalCfg,
+			GlobalCfg:         globalCfg,
+			GithubCredentials: &testTokenGetter{token: "token"},
+		}
+		err := wh.Run(context.Background(), fixtures.GithubRepo, repoDir, 1)
+		assert.Error(t, err, "error not nil")
+	})
+	t.Run("error fetching token", func(t *testing.T) {
+		globalCfg := valid.GlobalCfg{
+			Repos: []valid.Repo{
+				{
+					ID: fixtures.GithubRepo.ID(),
+					PreWorkflowHooks: []*valid.PreWorkflowHook{
+						testHook,
+					},
+				},
+			},
+		}
+		executor := &mockPreWorkflowHookExecutor{}
+		wh := preworkflow.HooksRunner{
+			HookExecutor:      executor,
+			GlobalCfg:         globalCfg,
+			GithubCredentials: &testTokenGetter{err: errors.New("some error")},
 		}
-		err := wh.Run(context.Background(), fixtures.GithubRepo, repoDir)
+		err := wh.Run(context.Background(), fixtures.GithubRepo, repoDir, 1)
 		assert.Error(t, err, "error not nil")
+		assert.Nil(t, executor.envs)
 	})
 }
 
 type mockPreWorkflowHookExecutor struct {
 	error error
+	envs  map[string]string
 }
 
-func (m *mockPreWorkflowHookExecutor) Execute(_ context.Context, _ *valid.PreWorkflowHook, _ models.Repo, _ string) error {
+func (m *mockPreWorkflowHookExecutor) Execute(_ context.Context, _ *valid.PreWorkflowHook, _ models.Repo, _ string, envs map[string]string) error {
+	m.envs = envs
 	return m.error
 }
+
+type testTokenGetter struct {
+	token string
+	err   error
+}
+
+func (g *testTokenGetter) GetToken(_ context.Context, _ int64) (string, error) {
+	return g.token, g.err
+}
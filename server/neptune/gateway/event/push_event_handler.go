@@ -38,10 +38,18 @@ type rootDeployer interface {
 	Deploy(ctx context.Context, deployOptions deploy.RootDeployOptions) error
 }
 
+type pushIngester interface {
+	IngestPush(ctx context.Context, event Push) error
+}
+
 type PushHandler struct {
 	Scheduler    scheduler
 	Logger       logging.Logger
 	RootDeployer rootDeployer
+
+	// Ingester is optional and, when set, hands accepted events off to a durable
+	// ingestion workflow instead of processing them in the gateway.
+	Ingester pushIngester
 }
 
 func (p *PushHandler) Handle(ctx context.Context, event Push) error {
@@ -55,11 +63,21 @@ func (p *PushHandler) Handle(ctx context.Context, event Push) error {
 		return nil
 	}
 
+	if p.Ingester != nil {
+		return p.Ingester.IngestPush(ctx, event)
+	}
+
 	return p.Scheduler.Schedule(ctx, func(ctx context.Context) error {
 		return p.handle(ctx, event)
 	})
 }
 
+// Process deploys the roots modified by an already accepted push event and blocks until
+// each of them has been signaled.
+func (p *PushHandler) Process(ctx context.Context, event Push) error {
+	return p.handle(ctx, event)
+}
+
 func (p *PushHandler) handle(ctx context.Context, event Push) error {
 	rootDeployOptions := deploy.RootDeployOptions{
 		Repo:              event.Repo,
@@ -170,6 +170,52 @@ func TestHandlePushEvent(t *testing.T) {
 		err := handler.Handle(ctx, e)
 		assert.Error(t, err)
 	})
+
+	t.Run("ingested", func(t *testing.T) {
+		ctx := context.Background()
+		rootDeployer := &mockRootDeployer{}
+		ingester := &mockIngester{}
+		handler := event.PushHandler{
+			Scheduler:    &sync.SynchronousScheduler{Logger: logger},
+			Logger:       logger,
+			RootDeployer: rootDeployer,
+			Ingester:     ingester,
+		}
+
+		err := handler.Handle(ctx, e)
+		assert.NoError(t, err)
+		assert.Equal(t, []event.Push{e}, ingester.pushes)
+		assert.False(t, rootDeployer.isCalled)
+	})
+
+	t.Run("process", func(t *testing.T) {
+		ctx := context.Background()
+		rootDeployer := &mockRootDeployer{}
+		handler := event.PushHandler{
+			Logger:       logger,
+			RootDeployer: rootDeployer,
+		}
+
+		err := handler.Process(ctx, e)
+		assert.NoError(t, err)
+		assert.True(t, rootDeployer.isCalled)
+	})
+}
+
+type mockIngester struct {
+	pushes []event.Push
+	pulls  []event.PullRequest
+	err    error
+}
+
+func (m *mockIngester) IngestPush(_ context.Context, e event.Push) error {
+	m.pushes = append(m.pushes, e)
+	return m.err
+}
+
+func (m *mockIngester) IngestPullRequest(_ context.Context, e event.PullRequest) error {
+	m.pulls = append(m.pulls, e)
+	return m.err
 }
 
 type mockRootDeployer struct {
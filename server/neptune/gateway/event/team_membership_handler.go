@@ -0,0 +1,52 @@
+package event
+
+import (
+	"context"
+
+	"github.com/runatlantis/atlantis/server/logging"
+)
+
+const (
+	TeamAddedToRepositoryAction     = "added_to_repository"
+	TeamRemovedFromRepositoryAction = "removed_from_repository"
+)
+
+// TeamMembership is a change to the members of a team, or to the team itself
+type TeamMembership struct {
+	InstallationToken int64
+	Action            string
+	Org               string
+	TeamSlug          string
+}
+
+type teamMembershipSignaler interface {
+	SendTeamMembershipSignal(ctx context.Context, org string, teamSlug string) error
+}
+
+// TeamMembershipHandler forwards team changes to PR workflows so that approvals from
+// policy owners are re-evaluated without polling.
+type TeamMembershipHandler struct {
+	Logger           logging.Logger
+	Scheduler        scheduler
+	WorkflowSignaler teamMembershipSignaler
+
+	// Org owning the policy teams, teams of other orgs are ignored
+	Org string
+}
+
+func (h *TeamMembershipHandler) Handle(ctx context.Context, event TeamMembership) error {
+	if event.Org != h.Org {
+		h.Logger.DebugContext(ctx, "ignoring team event outside of the policy org")
+		return nil
+	}
+
+	// repository access doesn't affect who can approve policies
+	if event.Action == TeamAddedToRepositoryAction || event.Action == TeamRemovedFromRepositoryAction {
+		h.Logger.DebugContext(ctx, "ignoring team repository event")
+		return nil
+	}
+
+	return h.Scheduler.Schedule(ctx, func(ctx context.Context) error {
+		return h.WorkflowSignaler.SendTeamMembershipSignal(ctx, event.Org, event.TeamSlug)
+	})
+}
@@ -0,0 +1,69 @@
+package event_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/event"
+	"github.com/runatlantis/atlantis/server/neptune/sync"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTeamMembershipSignaler struct {
+	calls []string
+	err   error
+}
+
+func (m *mockTeamMembershipSignaler) SendTeamMembershipSignal(ctx context.Context, org string, teamSlug string) error {
+	m.calls = append(m.calls, org+"/"+teamSlug)
+	return m.err
+}
+
+func TestTeamMembershipHandler(t *testing.T) {
+	cases := []struct {
+		description   string
+		event         event.TeamMembership
+		signalErr     error
+		expectedCalls []string
+		expectedErr   error
+	}{
+		{
+			description:   "membership change",
+			event:         event.TeamMembership{Action: "added", Org: "org", TeamSlug: "team"},
+			expectedCalls: []string{"org/team"},
+		},
+		{
+			description: "other org",
+			event:       event.TeamMembership{Action: "added", Org: "other", TeamSlug: "team"},
+		},
+		{
+			description: "repository access change",
+			event:       event.TeamMembership{Action: event.TeamAddedToRepositoryAction, Org: "org", TeamSlug: "team"},
+		},
+		{
+			description:   "signal error",
+			event:         event.TeamMembership{Action: "deleted", Org: "org", TeamSlug: "team"},
+			signalErr:     assert.AnError,
+			expectedCalls: []string{"org/team"},
+			expectedErr:   assert.AnError,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			logger := logging.NewNoopCtxLogger(t)
+			signaler := &mockTeamMembershipSignaler{err: c.signalErr}
+			subject := event.TeamMembershipHandler{
+				Logger:           logger,
+				Scheduler:        &sync.SynchronousScheduler{Logger: logger},
+				WorkflowSignaler: signaler,
+				Org:              "org",
+			}
+
+			err := subject.Handle(context.Background(), c.event)
+			assert.ErrorIs(t, err, c.expectedErr)
+			assert.Equal(t, c.expectedCalls, signaler.calls)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package ingestion
+
+import (
+	"context"
+
+	"github.com/runatlantis/atlantis/server/neptune/gateway/event"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/temporal"
+)
+
+type pushProcessor interface {
+	Process(ctx context.Context, event event.Push) error
+}
+
+type pullRequestProcessor interface {
+	Process(ctx context.Context, event event.PullRequest) error
+}
+
+type Activities struct {
+	PushProcessor        pushProcessor
+	PullRequestProcessor pullRequestProcessor
+}
+
+func (a *Activities) ProcessPush(ctx context.Context, event event.Push) error {
+	cancel := temporal.StartHeartbeat(ctx, temporal.HeartbeatTimeout)
+	defer cancel()
+
+	return a.PushProcessor.Process(ctx, event)
+}
+
+func (a *Activities) ProcessPullRequest(ctx context.Context, event event.PullRequest) error {
+	cancel := temporal.StartHeartbeat(ctx, temporal.HeartbeatTimeout)
+	defer cancel()
+
+	return a.PullRequestProcessor.Process(ctx, event)
+}
@@ -0,0 +1,64 @@
+package ingestion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/delivery"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/event"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+type workflowStarter interface {
+	ExecuteWorkflow(ctx context.Context, options client.StartWorkflowOptions, workflow interface{}, args ...interface{}) (client.WorkflowRun, error)
+}
+
+// Ingester hands accepted events off to the ingestion workflow.  Workflows are keyed by
+// webhook delivery so that redeliveries of events which were already ingested are dropped
+// even across gateway restarts.
+type Ingester struct {
+	TemporalClient workflowStarter
+	Logger         logging.Logger
+}
+
+func (i *Ingester) IngestPush(ctx context.Context, event event.Push) error {
+	return i.ingest(ctx, "push", Request{Push: &event})
+}
+
+func (i *Ingester) IngestPullRequest(ctx context.Context, event event.PullRequest) error {
+	return i.ingest(ctx, "pull_request", Request{PullRequest: &event})
+}
+
+func (i *Ingester) ingest(ctx context.Context, eventType string, request Request) error {
+	id, ok := delivery.IDFromContext(ctx)
+	if !ok {
+		id = uuid.NewString()
+	}
+
+	options := client.StartWorkflowOptions{
+		ID:                    fmt.Sprintf("ingest/%s/%s", eventType, id),
+		TaskQueue:             TaskQueue,
+		WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE_FAILED_ONLY,
+	}
+
+	run, err := i.TemporalClient.ExecuteWorkflow(ctx, options, Workflow, request)
+
+	var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+	if errors.As(err, &alreadyStarted) {
+		i.Logger.InfoContext(ctx, fmt.Sprintf("event was already ingested by workflow %s", options.ID))
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "starting ingestion workflow")
+	}
+
+	i.Logger.InfoContext(ctx, "Started ingestion workflow.", map[string]interface{}{
+		"workflow-id": run.GetID(), "run-id": run.GetRunID(),
+	})
+	return nil
+}
@@ -0,0 +1,93 @@
+package ingestion_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/logging"
+	key "github.com/runatlantis/atlantis/server/neptune/context"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/event"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/ingestion"
+	"github.com/stretchr/testify/assert"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+type testRun struct {
+	client.WorkflowRun
+	id string
+}
+
+func (r testRun) GetID() string    { return r.id }
+func (r testRun) GetRunID() string { return "run" }
+
+type testStarter struct {
+	options []client.StartWorkflowOptions
+	args    []interface{}
+	err     error
+}
+
+func (s *testStarter) ExecuteWorkflow(ctx context.Context, options client.StartWorkflowOptions, workflow interface{}, args ...interface{}) (client.WorkflowRun, error) {
+	s.options = append(s.options, options)
+	s.args = append(s.args, args...)
+	return testRun{id: options.ID}, s.err
+}
+
+func TestIngester_IngestPush(t *testing.T) {
+	ctx := context.WithValue(context.Background(), key.DeliveryIDKey, "1234")
+	starter := &testStarter{}
+	ingester := &ingestion.Ingester{
+		TemporalClient: starter,
+		Logger:         logging.NewNoopCtxLogger(t),
+	}
+
+	push := event.Push{Sha: "sha"}
+	err := ingester.IngestPush(ctx, push)
+	assert.NoError(t, err)
+	assert.Equal(t, []client.StartWorkflowOptions{
+		{
+			ID:                    "ingest/push/1234",
+			TaskQueue:             ingestion.TaskQueue,
+			WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE_FAILED_ONLY,
+		},
+	}, starter.options)
+	assert.Equal(t, []interface{}{ingestion.Request{Push: &push}}, starter.args)
+}
+
+func TestIngester_IngestPullRequest(t *testing.T) {
+	t.Run("without delivery id", func(t *testing.T) {
+		starter := &testStarter{}
+		ingester := &ingestion.Ingester{
+			TemporalClient: starter,
+			Logger:         logging.NewNoopCtxLogger(t),
+		}
+
+		pull := event.PullRequest{InstallationToken: 1}
+		err := ingester.IngestPullRequest(context.Background(), pull)
+		assert.NoError(t, err)
+		assert.Len(t, starter.options, 1)
+		assert.Regexp(t, "^ingest/pull_request/.+", starter.options[0].ID)
+		assert.Equal(t, []interface{}{ingestion.Request{PullRequest: &pull}}, starter.args)
+	})
+
+	t.Run("already ingested", func(t *testing.T) {
+		ingester := &ingestion.Ingester{
+			TemporalClient: &testStarter{err: serviceerror.NewWorkflowExecutionAlreadyStarted("started", "", "")},
+			Logger:         logging.NewNoopCtxLogger(t),
+		}
+
+		err := ingester.IngestPullRequest(context.Background(), event.PullRequest{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ingester := &ingestion.Ingester{
+			TemporalClient: &testStarter{err: assert.AnError},
+			Logger:         logging.NewNoopCtxLogger(t),
+		}
+
+		err := ingester.IngestPullRequest(context.Background(), event.PullRequest{})
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,50 @@
+package ingestion
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/event"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	TaskQueue = "event_ingestion"
+
+	// activities heartbeat while running so that work on a gateway which went away is
+	// retried well before the start to close timeout elapses.
+	HeartbeatTimeout = 1 * time.Minute
+)
+
+// Request is the input to the ingestion workflow, exactly one event is expected to be set.
+type Request struct {
+	Push        *event.Push
+	PullRequest *event.PullRequest
+}
+
+// Workflow processes a single accepted webhook event.  Processing involves cloning the repo,
+// detecting modified roots and signaling their workflows which can take a while for larger repos,
+// running it in an activity means it survives gateway restarts and is retried on failure.
+func Workflow(ctx workflow.Context, request Request) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Minute,
+		HeartbeatTimeout:    HeartbeatTimeout,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    5 * time.Minute,
+			MaximumAttempts:    10,
+		},
+	})
+
+	var a *Activities
+	switch {
+	case request.Push != nil:
+		return workflow.ExecuteActivity(ctx, a.ProcessPush, *request.Push).Get(ctx, nil)
+	case request.PullRequest != nil:
+		return workflow.ExecuteActivity(ctx, a.ProcessPullRequest, *request.PullRequest).Get(ctx, nil)
+	default:
+		return temporal.NewNonRetryableApplicationError("request is missing an event", "InvalidRequest", errors.New("no event set"))
+	}
+}
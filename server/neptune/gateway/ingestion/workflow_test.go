@@ -0,0 +1,85 @@
+package ingestion_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/neptune/gateway/event"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/ingestion"
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/sdk/testsuite"
+)
+
+type testPushProcessor struct {
+	failures int
+	events   []event.Push
+}
+
+func (p *testPushProcessor) Process(ctx context.Context, e event.Push) error {
+	p.events = append(p.events, e)
+	if len(p.events) <= p.failures {
+		return assert.AnError
+	}
+	return nil
+}
+
+type testPullRequestProcessor struct {
+	events []event.PullRequest
+}
+
+func (p *testPullRequestProcessor) Process(ctx context.Context, e event.PullRequest) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+func TestWorkflow_Push(t *testing.T) {
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+
+	pushProcessor := &testPushProcessor{failures: 1}
+	pullProcessor := &testPullRequestProcessor{}
+	env.RegisterActivity(&ingestion.Activities{
+		PushProcessor:        pushProcessor,
+		PullRequestProcessor: pullProcessor,
+	})
+
+	push := event.Push{Sha: "1234"}
+	env.ExecuteWorkflow(ingestion.Workflow, ingestion.Request{Push: &push})
+
+	// failures are retried
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+	assert.Equal(t, []event.Push{push, push}, pushProcessor.events)
+	assert.Empty(t, pullProcessor.events)
+}
+
+func TestWorkflow_PullRequest(t *testing.T) {
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+
+	pushProcessor := &testPushProcessor{}
+	pullProcessor := &testPullRequestProcessor{}
+	env.RegisterActivity(&ingestion.Activities{
+		PushProcessor:        pushProcessor,
+		PullRequestProcessor: pullProcessor,
+	})
+
+	pull := event.PullRequest{InstallationToken: 1}
+	env.ExecuteWorkflow(ingestion.Workflow, ingestion.Request{PullRequest: &pull})
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+	assert.Equal(t, []event.PullRequest{pull}, pullProcessor.events)
+	assert.Empty(t, pushProcessor.events)
+}
+
+func TestWorkflow_NoEvent(t *testing.T) {
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	env.RegisterActivity(&ingestion.Activities{})
+
+	env.ExecuteWorkflow(ingestion.Workflow, ingestion.Request{})
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.Error(t, env.GetWorkflowError())
+}
@@ -2,9 +2,13 @@ package pr
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
+	"github.com/pkg/errors"
 	"github.com/runatlantis/atlantis/server/config/valid"
 	"github.com/runatlantis/atlantis/server/models"
 	"github.com/runatlantis/atlantis/server/neptune/workflows"
@@ -45,9 +49,16 @@ type Request struct {
 	InstallationToken int64
 	Branch            string
 	ValidateEnvs      []ValidateEnvs
+	// ForceReplan plans all roots even if they haven't changed since their last plan
+	ForceReplan bool
+	// PlanExtraArgs are appended to the terraform plan of every root, ex. atlantis plan -- -target=resource
+	PlanExtraArgs []string
 }
 
 func (s *WorkflowSignaler) SignalWithStartWorkflow(ctx context.Context, rootCfgs []*valid.MergedProjectCfg, request Request) (client.WorkflowRun, error) {
+	if err := s.signalPlanLimiter(ctx, rootCfgs, request); err != nil {
+		return nil, err
+	}
 	options := client.StartWorkflowOptions{
 		TaskQueue: workflows.PRTaskQueue,
 		SearchAttributes: map[string]interface{}{
@@ -59,8 +70,11 @@ func (s *WorkflowSignaler) SignalWithStartWorkflow(ctx context.Context, rootCfgs
 		BuildPRWorkflowID(request.Repo.FullName, request.Number),
 		workflows.PRTerraformRevisionSignalID,
 		workflows.PRNewRevisionSignalRequest{
-			Revision: request.Revision,
-			Roots:    s.buildRoots(rootCfgs, request.ValidateEnvs...),
+			Revision:               request.Revision,
+			Roots:                  s.buildRequestRoots(rootCfgs, request),
+			MaxConcurrentRoots:     maxConcurrentRoots(rootCfgs),
+			MaxConcurrentRepoRoots: maxConcurrentRepoRoots(rootCfgs),
+			ForceReplan:            request.ForceReplan,
 			Repo: workflows.PRRepo{
 				URL:           request.Repo.CloneURL,
 				FullName:      request.Repo.FullName,
@@ -84,6 +98,9 @@ func (s *WorkflowSignaler) SignalWithStartWorkflow(ctx context.Context, rootCfgs
 }
 
 func (s *WorkflowSignaler) SendRevisionSignal(ctx context.Context, rootCfgs []*valid.MergedProjectCfg, request Request) error {
+	if err := s.signalPlanLimiter(ctx, rootCfgs, request); err != nil {
+		return err
+	}
 	return s.TemporalClient.SignalWorkflow(
 		ctx,
 		BuildPRWorkflowID(request.Repo.FullName, request.Number),
@@ -91,8 +108,11 @@ func (s *WorkflowSignaler) SendRevisionSignal(ctx context.Context, rootCfgs []*v
 		"",
 		workflows.PRTerraformRevisionSignalID,
 		workflows.PRNewRevisionSignalRequest{
-			Revision: request.Revision,
-			Roots:    s.buildRoots(rootCfgs, request.ValidateEnvs...),
+			Revision:               request.Revision,
+			Roots:                  s.buildRequestRoots(rootCfgs, request),
+			MaxConcurrentRoots:     maxConcurrentRoots(rootCfgs),
+			MaxConcurrentRepoRoots: maxConcurrentRepoRoots(rootCfgs),
+			ForceReplan:            request.ForceReplan,
 			Repo: workflows.PRRepo{
 				URL:           request.Repo.CloneURL,
 				FullName:      request.Repo.FullName,
@@ -129,6 +149,65 @@ func (s *WorkflowSignaler) SendReviewSignal(ctx context.Context, repoName string
 	)
 }
 
+// SendPolicyCheckSignal re-evaluates the failing policies of the revision
+func (s *WorkflowSignaler) SendPolicyCheckSignal(ctx context.Context, repoName string, pullNum int, revision string) error {
+	return s.TemporalClient.SignalWorkflow(
+		ctx,
+		BuildPRWorkflowID(repoName, pullNum),
+		"",
+		workflows.PRPolicyCheckSignalName,
+		workflows.PRPolicyCheckRequest{Revision: revision},
+	)
+}
+
+// SendApprovePoliciesSignal approves the failing policies of the revision on behalf of the user,
+// the workflow only bypasses policies the user owns
+func (s *WorkflowSignaler) SendApprovePoliciesSignal(ctx context.Context, repoName string, pullNum int, revision string, user string) error {
+	return s.TemporalClient.SignalWorkflow(
+		ctx,
+		BuildPRWorkflowID(repoName, pullNum),
+		"",
+		workflows.PRApprovePoliciesSignalName,
+		workflows.PRApprovePoliciesRequest{Revision: revision, User: user},
+	)
+}
+
+// SendUnlockSignal discards the plans of the PR
+func (s *WorkflowSignaler) SendUnlockSignal(ctx context.Context, repoName string, pullNum int, user string) error {
+	return s.TemporalClient.SignalWorkflow(
+		ctx,
+		BuildPRWorkflowID(repoName, pullNum),
+		"",
+		workflows.PRUnlockSignalName,
+		workflows.PRUnlockRequest{User: user},
+	)
+}
+
+// signalPlanLimiter makes sure the repo's plan limiter is running with the configured limit before
+// the revision is signaled, since PR workflows can't start it themselves.
+func (s *WorkflowSignaler) signalPlanLimiter(ctx context.Context, rootCfgs []*valid.MergedProjectCfg, request Request) error {
+	limit := maxConcurrentRepoRoots(rootCfgs)
+	if limit <= 0 {
+		return nil
+	}
+	options := client.StartWorkflowOptions{
+		TaskQueue: workflows.PRTaskQueue,
+		SearchAttributes: map[string]interface{}{
+			"atlantis_repository": request.Repo.FullName,
+		},
+	}
+	_, err := s.TemporalClient.SignalWithStartWorkflow(
+		ctx,
+		workflows.BuildPRPlanLimiterWorkflowID(request.Repo.FullName),
+		workflows.PRPlanLimitSignalName,
+		workflows.PRPlanLimitRequest{Limit: limit},
+		options,
+		workflows.PRPlanLimiter,
+		workflows.PRPlanLimiterRequest{Limit: limit},
+	)
+	return errors.Wrap(err, "signaling plan limiter")
+}
+
 func BuildPRWorkflowID(repoName string, prNum int) string {
 	return fmt.Sprintf("%s||%d", repoName, prNum)
 }
@@ -141,17 +220,102 @@ func (s *WorkflowSignaler) buildRoots(rootCfgs []*valid.MergedProjectCfg, valida
 			tfVersion = rootCfg.TerraformVersion.String()
 		}
 		roots = append(roots, workflows.PRRoot{
-			Name:        rootCfg.Name,
-			RepoRelPath: rootCfg.RepoRelDir,
-			TfVersion:   tfVersion,
-			PlanMode:    generatePlanMode(rootCfg),
-			Plan:        workflows.PRJob{Steps: s.prependPlanEnvSteps(rootCfg)},
-			Validate:    workflows.PRJob{Steps: s.prependValidateEnvSteps(rootCfg, validateEnvOpts...)},
+			Name:         rootCfg.Name,
+			RepoRelPath:  rootCfg.RepoRelDir,
+			TfVersion:    tfVersion,
+			PlanMode:     generatePlanMode(rootCfg),
+			Plan:         workflows.PRJob{Steps: s.prependPlanEnvSteps(rootCfg)},
+			Validate:     workflows.PRJob{Steps: s.prependValidateEnvSteps(rootCfg, validateEnvOpts...)},
+			Owners:       rootCfg.Owners,
+			PolicyTarget: policyTarget(rootCfg.PolicyTarget),
 		})
 	}
 	return roots
 }
 
+// buildRequestRoots builds the roots of the revision signal, they are fingerprinted last so that
+// every field is final.
+func (s *WorkflowSignaler) buildRequestRoots(rootCfgs []*valid.MergedProjectCfg, request Request) []workflows.PRRoot {
+	roots := withPlanExtraArgs(s.buildRoots(rootCfgs, request.ValidateEnvs...), request.PlanExtraArgs)
+	for i := range roots {
+		roots[i].Fingerprint = s.fingerprint(rootCfgs[i], roots[i])
+	}
+	return roots
+}
+
+// fingerprint combines the fingerprint of the root's files with the config it's planned and validated
+// with, so that config changes (ie. the terraform version) replan the root.  The env steps prepended to
+// validate are left out since they change with every revision.
+func (s *WorkflowSignaler) fingerprint(rootCfg *valid.MergedProjectCfg, root workflows.PRRoot) string {
+	if rootCfg.Fingerprint == "" {
+		return ""
+	}
+	tfVersion := root.TfVersion
+	if tfVersion == "" {
+		tfVersion = s.DefaultTFVersion
+	}
+	cfg, err := json.Marshal(struct {
+		RepoRelPath  string
+		TfVersion    string
+		PlanMode     workflows.PRPlanMode
+		Plan         workflows.PRJob
+		Validate     []workflows.PRStep
+		PolicyTarget *workflows.PRPolicyTarget
+		PartialPlan  bool
+	}{
+		RepoRelPath:  root.RepoRelPath,
+		TfVersion:    tfVersion,
+		PlanMode:     root.PlanMode,
+		Plan:         root.Plan,
+		Validate:     generateSteps(rootCfg.PullRequestWorkflow.PolicyCheck.Steps),
+		PolicyTarget: root.PolicyTarget,
+		PartialPlan:  root.PartialPlan,
+	})
+	if err != nil {
+		// roots without a fingerprint are always planned
+		return ""
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n", rootCfg.Fingerprint)
+	h.Write(cfg)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// withPlanExtraArgs appends the args to the plan step of each root, which marks their plans as partial
+func withPlanExtraArgs(roots []workflows.PRRoot, args []string) []workflows.PRRoot {
+	if len(args) == 0 {
+		return roots
+	}
+	for i := range roots {
+		roots[i].PartialPlan = true
+		steps := roots[i].Plan.Steps
+		for j := range steps {
+			if steps[j].StepName == "plan" {
+				steps[j].ExtraArgs = append(append([]string{}, steps[j].ExtraArgs...), args...)
+			}
+		}
+	}
+	return roots
+}
+
+// maxConcurrentRoots returns the plan concurrency limit of the repo, all roots share
+// the same repo so the first one is used.
+func maxConcurrentRoots(rootCfgs []*valid.MergedProjectCfg) int {
+	if len(rootCfgs) == 0 {
+		return 0
+	}
+	return rootCfgs[0].MaxConcurrentPlans
+}
+
+// maxConcurrentRepoRoots returns the plan concurrency limit across all PRs of the repo, all roots
+// share the same repo so the first one is used.
+func maxConcurrentRepoRoots(rootCfgs []*valid.MergedProjectCfg) int {
+	if len(rootCfgs) == 0 {
+		return 0
+	}
+	return rootCfgs[0].MaxConcurrentRepoPlans
+}
+
 func (s *WorkflowSignaler) prependPlanEnvSteps(cfg *valid.MergedProjectCfg) []workflows.PRStep {
 	var steps []workflows.PRStep
 	if t, ok := cfg.Tags[Manifest]; ok {
@@ -191,6 +355,17 @@ func generateSteps(steps []valid.Step) []workflows.PRStep {
 	return workflowSteps
 }
 
+func policyTarget(target *valid.PolicyTarget) *workflows.PRPolicyTarget {
+	if target == nil {
+		return nil
+	}
+	return &workflows.PRPolicyTarget{
+		Name: target.Name,
+		Path: target.Path,
+		Tags: target.Tags,
+	}
+}
+
 func generatePlanMode(cfg *valid.MergedProjectCfg) workflows.PRPlanMode {
 	t, ok := cfg.Tags[Deprecated]
 	if ok && t == Destroy {
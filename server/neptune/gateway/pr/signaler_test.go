@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/go-version"
 	"github.com/runatlantis/atlantis/server/config/valid"
 	"github.com/runatlantis/atlantis/server/models"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/pr"
@@ -24,12 +25,19 @@ func TestWorkflowSignaler_SignalWithStartWorkflow_Success(t *testing.T) {
 			RepoRelDir:          "some/path/`",
 			Tags:                make(map[string]string),
 			PullRequestWorkflow: testWorkflow,
+			MaxConcurrentPlans:  5,
+			PolicyTarget: &valid.PolicyTarget{
+				Name: "root1",
+				Path: "some/path",
+				Tags: map[string]string{"env": "prod"},
+			},
 		},
 		{
 			Name:                "root2",
 			RepoRelDir:          "some/path/1",
 			Tags:                make(map[string]string),
 			PullRequestWorkflow: testWorkflow,
+			MaxConcurrentPlans:  5,
 		},
 	}
 	testRepo := models.Repo{
@@ -50,8 +58,9 @@ func TestWorkflowSignaler_SignalWithStartWorkflow_Success(t *testing.T) {
 		expectedRunID:      "456",
 		expectedSignalName: workflows.PRTerraformRevisionSignalID,
 		expectedSignalArg: workflows.PRNewRevisionSignalRequest{
-			Revision: "abc",
-			Roots:    buildRoots(rootCfgs),
+			Revision:           "abc",
+			Roots:              buildRoots(rootCfgs),
+			MaxConcurrentRoots: 5,
 			Repo: workflows.PRRepo{
 				URL:           testRepo.CloneURL,
 				FullName:      testRepo.FullName,
@@ -84,6 +93,148 @@ func TestWorkflowSignaler_SignalWithStartWorkflow_Success(t *testing.T) {
 	assert.Equal(t, "456", run.GetRunID())
 }
 
+func TestWorkflowSignaler_SignalWithStartWorkflow_PlanExtraArgs(t *testing.T) {
+	rootCfgs := []*valid.MergedProjectCfg{
+		{
+			Name: "root1",
+			Tags: make(map[string]string),
+			PullRequestWorkflow: valid.Workflow{
+				Plan:        valid.DefaultPlanStage,
+				PolicyCheck: valid.DefaultPolicyCheckStage,
+			},
+		},
+	}
+	testRepo := models.Repo{
+		FullName: "some/test",
+	}
+	prRequest := pr.Request{
+		Number:        1,
+		Revision:      "abc",
+		Repo:          testRepo,
+		PlanExtraArgs: []string{"-var=foo=bar"},
+	}
+	roots := buildRoots(rootCfgs)
+	roots[0].PartialPlan = true
+	for i, step := range roots[0].Plan.Steps {
+		if step.StepName == "plan" {
+			roots[0].Plan.Steps[i].ExtraArgs = []string{"-var=foo=bar"}
+		}
+	}
+	mockTemporalClient := &mockTemporalClient{
+		t:                  t,
+		expectedWorkflowID: "some/test||1",
+		expectedSignalName: workflows.PRTerraformRevisionSignalID,
+		expectedSignalArg: workflows.PRNewRevisionSignalRequest{
+			Revision: "abc",
+			Roots:    roots,
+			Repo: workflows.PRRepo{
+				FullName: testRepo.FullName,
+			},
+		},
+		expectedOptions: client.StartWorkflowOptions{
+			TaskQueue: workflows.PRTaskQueue,
+			SearchAttributes: map[string]interface{}{
+				"atlantis_repository": prRequest.Repo.FullName,
+			},
+		},
+		expectedWorkflow: workflows.PR,
+		expectedWorkflowArgs: workflows.PRRequest{
+			RepoFullName: "some/test",
+			PRNum:        1,
+		},
+	}
+	workflowSignaler := pr.WorkflowSignaler{TemporalClient: mockTemporalClient}
+	_, err := workflowSignaler.SignalWithStartWorkflow(context.Background(), rootCfgs, prRequest)
+	assert.NoError(t, err)
+	assert.True(t, mockTemporalClient.called)
+	// the plan step of the repo's workflow isn't modified
+	for _, step := range rootCfgs[0].PullRequestWorkflow.Plan.Steps {
+		assert.Empty(t, step.ExtraArgs)
+	}
+}
+
+func TestWorkflowSignaler_SendRevisionSignal_Fingerprint(t *testing.T) {
+	tfVersion, err := version.NewVersion("1.5.0")
+	assert.NoError(t, err)
+	rootCfg := &valid.MergedProjectCfg{
+		Name:        "root1",
+		Tags:        make(map[string]string),
+		Fingerprint: "files",
+		PullRequestWorkflow: valid.Workflow{
+			Plan:        valid.DefaultPlanStage,
+			PolicyCheck: valid.DefaultPolicyCheckStage,
+		},
+	}
+	upgradedRootCfg := *rootCfg
+	upgradedRootCfg.TerraformVersion = tfVersion
+
+	fingerprint := func(rootCfg *valid.MergedProjectCfg, request pr.Request) string {
+		temporalClient := &recordingTemporalClient{}
+		workflowSignaler := pr.WorkflowSignaler{TemporalClient: temporalClient, DefaultTFVersion: "1.4.0"}
+		assert.NoError(t, workflowSignaler.SendRevisionSignal(context.Background(), []*valid.MergedProjectCfg{rootCfg}, request))
+		return temporalClient.signalArg.(workflows.PRNewRevisionSignalRequest).Roots[0].Fingerprint
+	}
+	request := pr.Request{
+		Number:       1,
+		Revision:     "abc",
+		ValidateEnvs: []pr.ValidateEnvs{{HeadCommit: "abc"}},
+	}
+	base := fingerprint(rootCfg, request)
+	assert.NotEmpty(t, base)
+	assert.NotEqual(t, "files", base)
+
+	t.Run("revision specific envs", func(t *testing.T) {
+		next := request
+		next.Revision = "def"
+		next.ValidateEnvs = []pr.ValidateEnvs{{HeadCommit: "def"}}
+		assert.Equal(t, base, fingerprint(rootCfg, next))
+	})
+
+	t.Run("terraform version", func(t *testing.T) {
+		assert.NotEqual(t, base, fingerprint(&upgradedRootCfg, request))
+	})
+
+	t.Run("plan extra args", func(t *testing.T) {
+		partial := request
+		partial.PlanExtraArgs = []string{"-var=foo=bar"}
+		assert.NotEqual(t, base, fingerprint(rootCfg, partial))
+	})
+
+	t.Run("unknown files", func(t *testing.T) {
+		unfingerprinted := *rootCfg
+		unfingerprinted.Fingerprint = ""
+		assert.Empty(t, fingerprint(&unfingerprinted, request))
+	})
+}
+
+func TestWorkflowSignaler_SignalWithStartWorkflow_RepoPlanLimit(t *testing.T) {
+	rootCfgs := []*valid.MergedProjectCfg{
+		{
+			Name: "root1",
+			Tags: make(map[string]string),
+			PullRequestWorkflow: valid.Workflow{
+				Plan:        valid.DefaultPlanStage,
+				PolicyCheck: valid.DefaultPolicyCheckStage,
+			},
+			MaxConcurrentRepoPlans: 10,
+		},
+	}
+	prRequest := pr.Request{
+		Number:   1,
+		Revision: "abc",
+		Repo:     models.Repo{FullName: "some/test"},
+	}
+	temporalClient := &recordingTemporalClient{}
+	workflowSignaler := pr.WorkflowSignaler{TemporalClient: temporalClient}
+	_, err := workflowSignaler.SignalWithStartWorkflow(context.Background(), rootCfgs, prRequest)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"some/test||plan-limiter", "some/test||1"}, temporalClient.workflowIDs)
+	assert.Equal(t, workflows.PRPlanLimitRequest{Limit: 10}, temporalClient.signalArgs[0])
+	revisionRequest := temporalClient.signalArgs[1].(workflows.PRNewRevisionSignalRequest)
+	assert.Equal(t, 10, revisionRequest.MaxConcurrentRepoRoots)
+}
+
 func TestWorkflowSignaler_SignalWithStartWorkflow_Failure(t *testing.T) {
 	testRepo := models.Repo{
 		FullName:      "some/test",
@@ -145,17 +296,30 @@ func buildRoots(rootCfgs []*valid.MergedProjectCfg) []workflows.PRRoot {
 			tfVersion = rootCfg.TerraformVersion.String()
 		}
 		roots = append(roots, workflows.PRRoot{
-			Name:        rootCfg.Name,
-			RepoRelPath: rootCfg.RepoRelDir,
-			TfVersion:   tfVersion,
-			PlanMode:    workflows.PRNormalPlanMode,
-			Plan:        workflows.PRJob{Steps: generateSteps(rootCfg.PullRequestWorkflow.Plan.Steps)},
-			Validate:    workflows.PRJob{Steps: generateSteps(rootCfg.PullRequestWorkflow.PolicyCheck.Steps)},
+			Name:         rootCfg.Name,
+			RepoRelPath:  rootCfg.RepoRelDir,
+			TfVersion:    tfVersion,
+			PlanMode:     workflows.PRNormalPlanMode,
+			Plan:         workflows.PRJob{Steps: generateSteps(rootCfg.PullRequestWorkflow.Plan.Steps)},
+			Validate:     workflows.PRJob{Steps: generateSteps(rootCfg.PullRequestWorkflow.PolicyCheck.Steps)},
+			Owners:       rootCfg.Owners,
+			PolicyTarget: policyTarget(rootCfg.PolicyTarget),
 		})
 	}
 	return roots
 }
 
+func policyTarget(target *valid.PolicyTarget) *workflows.PRPolicyTarget {
+	if target == nil {
+		return nil
+	}
+	return &workflows.PRPolicyTarget{
+		Name: target.Name,
+		Path: target.Path,
+		Tags: target.Tags,
+	}
+}
+
 func generateSteps(steps []valid.Step) []workflows.PRStep {
 	// NOTE: for deployment workflows, we won't support command level user requests for log level output verbosity
 	var workflowSteps []workflows.PRStep
@@ -189,6 +353,29 @@ func (r testRun) GetWithOptions(ctx context.Context, valuePtr interface{}, optio
 	return nil
 }
 
+type recordingTemporalClient struct {
+	// signalArg is the last signal sent
+	signalArg   interface{}
+	workflowIDs []string
+	signalArgs  []interface{}
+}
+
+func (c *recordingTemporalClient) SignalWorkflow(_ context.Context, workflowID string, _ string, _ string, arg interface{}) error {
+	c.record(workflowID, arg)
+	return nil
+}
+
+func (c *recordingTemporalClient) SignalWithStartWorkflow(_ context.Context, workflowID string, _ string, signalArg interface{}, _ client.StartWorkflowOptions, _ interface{}, _ ...interface{}) (client.WorkflowRun, error) {
+	c.record(workflowID, signalArg)
+	return testRun{}, nil
+}
+
+func (c *recordingTemporalClient) record(workflowID string, signalArg interface{}) {
+	c.signalArg = signalArg
+	c.workflowIDs = append(c.workflowIDs, workflowID)
+	c.signalArgs = append(c.signalArgs, signalArg)
+}
+
 type mockTemporalClient struct {
 	t                    *testing.T
 	expectedWorkflowID   string
@@ -0,0 +1,64 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/neptune/workflows"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+type workflowLister interface {
+	ListWorkflow(ctx context.Context, request *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error)
+	SignalWorkflow(ctx context.Context, workflowID string, runID string, signalName string, arg interface{}) error
+}
+
+// TeamMembershipSignaler signals every running PR workflow when a team changes since the gateway
+// doesn't know which PRs are awaiting approval from the team.  Team changes are infrequent
+// enough for this fan out to be cheap.
+type TeamMembershipSignaler struct {
+	TemporalClient workflowLister
+}
+
+func (s *TeamMembershipSignaler) SendTeamMembershipSignal(ctx context.Context, org string, teamSlug string) error {
+	query := fmt.Sprintf("WorkflowType = '%s' AND ExecutionStatus = 'Running'", workflows.PRWorkflowName)
+
+	var combinedErrors *multierror.Error
+	var nextPageToken []byte
+	for {
+		resp, err := s.TemporalClient.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         query,
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return errors.Wrap(err, "listing running pr workflows")
+		}
+
+		for _, info := range resp.GetExecutions() {
+			err := s.TemporalClient.SignalWorkflow(
+				ctx,
+				info.GetExecution().GetWorkflowId(),
+				info.GetExecution().GetRunId(),
+				workflows.PRTeamMembershipSignalName,
+				workflows.PRTeamMembershipRequest{
+					Org:      org,
+					TeamSlug: teamSlug,
+				},
+			)
+
+			// workflows can complete between listing and signaling them
+			var workflowNotFoundErr *serviceerror.NotFound
+			if err != nil && !errors.As(err, &workflowNotFoundErr) {
+				combinedErrors = multierror.Append(combinedErrors, errors.Wrapf(err, "signaling %s", info.GetExecution().GetWorkflowId()))
+			}
+		}
+
+		nextPageToken = resp.GetNextPageToken()
+		if len(nextPageToken) == 0 {
+			return combinedErrors.ErrorOrNil()
+		}
+	}
+}
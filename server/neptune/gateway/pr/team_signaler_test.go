@@ -0,0 +1,71 @@
+package pr_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/neptune/gateway/pr"
+	"github.com/runatlantis/atlantis/server/neptune/workflows"
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+type mockWorkflowLister struct {
+	t        *testing.T
+	pages    [][]string
+	notFound map[string]bool
+
+	signaled []string
+}
+
+func (m *mockWorkflowLister) ListWorkflow(ctx context.Context, request *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+	assert.Equal(m.t, "WorkflowType = 'PR' AND ExecutionStatus = 'Running'", request.GetQuery())
+
+	page := 0
+	if len(request.GetNextPageToken()) > 0 {
+		page = int(request.GetNextPageToken()[0])
+	}
+
+	var executions []*workflow.WorkflowExecutionInfo
+	for _, id := range m.pages[page] {
+		executions = append(executions, &workflow.WorkflowExecutionInfo{
+			Execution: &common.WorkflowExecution{WorkflowId: id, RunId: "run"},
+		})
+	}
+
+	var nextPageToken []byte
+	if page+1 < len(m.pages) {
+		nextPageToken = []byte{byte(page + 1)}
+	}
+	return &workflowservice.ListWorkflowExecutionsResponse{
+		Executions:    executions,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (m *mockWorkflowLister) SignalWorkflow(ctx context.Context, workflowID string, runID string, signalName string, arg interface{}) error {
+	assert.Equal(m.t, "run", runID)
+	assert.Equal(m.t, workflows.PRTeamMembershipSignalName, signalName)
+	assert.Equal(m.t, workflows.PRTeamMembershipRequest{Org: "org", TeamSlug: "team"}, arg)
+	if m.notFound[workflowID] {
+		return serviceerror.NewNotFound("workflow completed")
+	}
+	m.signaled = append(m.signaled, workflowID)
+	return nil
+}
+
+func TestTeamMembershipSignaler_SendTeamMembershipSignal(t *testing.T) {
+	client := &mockWorkflowLister{
+		t:        t,
+		pages:    [][]string{{"owner/repo||1", "owner/repo||2"}, {"owner/other||3"}},
+		notFound: map[string]bool{"owner/repo||2": true},
+	}
+	subject := pr.TeamMembershipSignaler{TemporalClient: client}
+
+	err := subject.SendTeamMembershipSignal(context.Background(), "org", "team")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"owner/repo||1", "owner/other||3"}, client.signaled)
+}
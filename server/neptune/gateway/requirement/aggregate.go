@@ -38,14 +38,7 @@ func NewDeployAggregate(cfg valid.GlobalCfg, teamFetcher *github.TeamMemberFetch
 					loader: template.Loader[template.BranchForbiddenData]{GlobalCfg: cfg},
 				},
 			},
-			&team{
-				cfg:     cfg,
-				fetcher: teamFetcher,
-				errorGenerator: errorGenerator[template.UserForbiddenData]{
-					logger: logger,
-					loader: template.Loader[template.UserForbiddenData]{GlobalCfg: cfg},
-				},
-			},
+			NewTeam(cfg, teamFetcher, logger),
 			&approval{
 				cfg:     cfg,
 				fetcher: reviewFetcher,
@@ -55,7 +48,6 @@ func NewDeployAggregate(cfg valid.GlobalCfg, teamFetcher *github.TeamMemberFetch
 				},
 			},
 			&planValidationResult{
-				cfg: cfg,
 				errorGenerator: errorGenerator[template.PlanValidationSuccessData]{
 					logger: logger,
 					loader: template.Loader[template.PlanValidationSuccessData]{GlobalCfg: cfg},
@@ -67,6 +59,20 @@ func NewDeployAggregate(cfg valid.GlobalCfg, teamFetcher *github.TeamMemberFetch
 		// non-overrideable
 		[]Requirement{
 			pull{},
+
+			// ci must be green regardless of whether we are forcing the deployment
+			NewStatusChecks(cfg, checkRunFetcher, logger),
+
+			// owners must sign off on force applies as well
+			owners{
+				org:             teamFetcher.Org,
+				approverFetcher: reviewFetcher,
+				teamFetcher:     teamFetcher,
+				errorGenerator: errorGenerator[template.OwnersApprovalRequiredData]{
+					logger: logger,
+					loader: template.Loader[template.OwnersApprovalRequiredData]{GlobalCfg: cfg},
+				},
+			},
 		},
 	)
 }
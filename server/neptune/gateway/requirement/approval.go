@@ -21,25 +21,54 @@ type approval struct {
 }
 
 func (a approval) Check(ctx context.Context, criteria Criteria) error {
-	match := a.cfg.MatchingRepo(criteria.Repo.ID())
-
-	shouldCheckApproval := match.ApplySettings.ContainsPRRequirement(valid.ApprovedApplyReq)
-
-	if !shouldCheckApproval || criteria.OptionalPull == nil {
+	if criteria.OptionalPull == nil {
 		return nil
 	}
 
-	reviews, err := a.fetcher.ListApprovalReviews(ctx, criteria.InstallationToken, criteria.Repo, criteria.OptionalPull.Num)
+	var reviews []*github.PullRequestReview
+	var fetched bool
+	for _, s := range applySettingsFor(a.cfg, criteria) {
+		if !s.settings.ContainsPRRequirement(valid.ApprovedApplyReq) {
+			continue
+		}
 
-	if err != nil {
-		return errors.Wrap(err, "fetching approval reviews")
-	}
+		// only fetch reviews once we know a root requires them
+		if !fetched {
+			var err error
+			reviews, err = a.fetcher.ListApprovalReviews(ctx, criteria.InstallationToken, criteria.Repo, criteria.OptionalPull.Num)
+			if err != nil {
+				return errors.Wrap(err, "fetching approval reviews")
+			}
+			fetched = true
+		}
 
-	if len(reviews) > 0 {
-		return nil
+		required := s.settings.RequiredApprovals()
+		if countApprovals(reviews, criteria.OptionalPull.HeadCommit, s.settings.DismissStaleApprovals) >= required {
+			continue
+		}
+
+		if required == 1 {
+			return a.errorGenerator.GenerateForbiddenError(ctx, template.ApprovalRequired, criteria.Repo, template.Input{},
+				"PR approval is required",
+			)
+		}
+		return a.errorGenerator.GenerateForbiddenError(ctx, template.ApprovalRequired, criteria.Repo, template.Input{},
+			"%d PR approvals are required for root %s", required, s.root,
+		)
 	}
 
-	return a.errorGenerator.GenerateForbiddenError(ctx, template.ApprovalRequired, criteria.Repo, template.Input{},
-		"PR approval is required",
-	)
+	return nil
+}
+
+// countApprovals returns the number of distinct users who approved, when dismissing stale approvals
+// only those of the head commit count since anything pushed afterwards hasn't been reviewed.
+func countApprovals(reviews []*github.PullRequestReview, headCommit string, dismissStale bool) int {
+	approvers := make(map[string]bool)
+	for _, r := range reviews {
+		if dismissStale && r.GetCommitID() != headCommit {
+			continue
+		}
+		approvers[r.GetUser().GetLogin()] = true
+	}
+	return len(approvers)
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/go-github/v45/github"
 	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/models"
 	"github.com/runatlantis/atlantis/server/neptune/template"
 	"github.com/stretchr/testify/assert"
@@ -107,4 +108,73 @@ func TestApproval(t *testing.T) {
 		err := subject.Check(context.Background(), expectedCriteria)
 		assert.NoError(t, err)
 	})
+
+	t.Run("root approval counts", func(t *testing.T) {
+		expectedCriteria := Criteria{
+			Repo:         models.Repo{Name: "hi", DefaultBranch: "main"},
+			Branch:       "main",
+			OptionalPull: &models.PullRequest{Num: 1, HeadCommit: "head"},
+			Roots: []*valid.MergedProjectCfg{
+				{
+					Name: "dev",
+					ApplySettings: valid.ApplySettings{
+						PRRequirements: []string{valid.ApprovedApplyReq},
+					},
+				},
+				{
+					Name: "prod",
+					ApplySettings: valid.ApplySettings{
+						PRRequirements:        []string{valid.ApprovedApplyReq},
+						MinApprovals:          2,
+						DismissStaleApprovals: true,
+					},
+				},
+			},
+		}
+
+		review := func(user string, commit string) *github.PullRequestReview {
+			return &github.PullRequestReview{
+				User:     &github.User{Login: github.String(user)},
+				CommitID: github.String(commit),
+			}
+		}
+
+		cases := []struct {
+			description string
+			reviews     []*github.PullRequestReview
+			expectedErr string
+		}{
+			{
+				description: "enough approvals",
+				reviews:     []*github.PullRequestReview{review("a", "head"), review("b", "head")},
+			},
+			{
+				description: "duplicate approvals",
+				reviews:     []*github.PullRequestReview{review("a", "head"), review("a", "head")},
+				expectedErr: "2 PR approvals are required for root prod",
+			},
+			{
+				description: "stale approvals",
+				reviews:     []*github.PullRequestReview{review("a", "head"), review("b", "old")},
+				expectedErr: "2 PR approvals are required for root prod",
+			},
+		}
+
+		for _, c := range cases {
+			t.Run(c.description, func(t *testing.T) {
+				subject := &approval{
+					cfg:            valid.NewGlobalCfg(""),
+					errorGenerator: errorGenerator[template.Input]{logger: logging.NewNoopCtxLogger(t)},
+					fetcher:        testReviewFetcher{reviews: c.reviews},
+				}
+
+				err := subject.Check(context.Background(), expectedCriteria)
+				if c.expectedErr == "" {
+					assert.NoError(t, err)
+					return
+				}
+				assert.EqualError(t, err, c.expectedErr)
+			})
+		}
+	})
 }
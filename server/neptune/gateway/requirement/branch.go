@@ -61,17 +61,21 @@ type branchRestriction struct {
 }
 
 func (r *branchRestriction) Check(ctx context.Context, criteria Criteria) error {
-	match := r.cfg.MatchingRepo(criteria.Repo.ID())
+	if criteria.Repo.DefaultBranch == criteria.Branch {
+		return nil
+	}
 
-	if match.ApplySettings.BranchRestriction == valid.DefaultBranchRestriction && criteria.Repo.DefaultBranch != criteria.Branch {
-		return r.errorGenerator.GenerateForbiddenError(
-			ctx,
-			template.BranchForbidden, criteria.Repo,
-			template.BranchForbiddenData{
-				DefaultBranch: criteria.Repo.DefaultBranch,
-			},
-			"deploys are forbidden on %s branch", criteria.Branch,
-		)
+	for _, s := range applySettingsFor(r.cfg, criteria) {
+		if s.settings.BranchRestriction == valid.DefaultBranchRestriction {
+			return r.errorGenerator.GenerateForbiddenError(
+				ctx,
+				template.BranchForbidden, criteria.Repo,
+				template.BranchForbiddenData{
+					DefaultBranch: criteria.Repo.DefaultBranch,
+				},
+				"deploys are forbidden on %s branch", criteria.Branch,
+			)
+		}
 	}
 
 	return nil
@@ -0,0 +1,89 @@
+package requirement
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/neptune/template"
+	"github.com/runatlantis/atlantis/server/vcs/provider/github"
+)
+
+type checkResultsFetcher interface {
+	ListCheckResults(ctx context.Context, installationToken int64, repo models.Repo, ref string) (map[string]github.CheckResult, error)
+}
+
+// statusChecks requires the check runs and commit statuses listed in the apply settings of
+// each root to be successful on the deployed revision.
+type statusChecks struct {
+	cfg            valid.GlobalCfg
+	fetcher        checkResultsFetcher
+	errorGenerator errGenerator[template.RequiredChecksFailedData]
+}
+
+// NewStatusChecks returns a requirement on the required checks of each root.  This is exposed
+// for manual deploys, which aren't checked against the deploy aggregate.
+func NewStatusChecks(cfg valid.GlobalCfg, checkRunFetcher *github.CheckRunsFetcher, logger logging.Logger) Requirement {
+	return &statusChecks{
+		cfg:     cfg,
+		fetcher: checkRunFetcher,
+		errorGenerator: errorGenerator[template.RequiredChecksFailedData]{
+			logger: logger,
+			loader: template.Loader[template.RequiredChecksFailedData]{GlobalCfg: cfg},
+		},
+	}
+}
+
+func (r *statusChecks) Check(ctx context.Context, criteria Criteria) error {
+	// roots commonly share required checks so dedupe them while preserving their order
+	var required []string
+	seen := make(map[string]bool)
+	for _, s := range applySettingsFor(r.cfg, criteria) {
+		for _, c := range s.settings.RequiredChecks {
+			if !seen[c] {
+				seen[c] = true
+				required = append(required, c)
+			}
+		}
+	}
+
+	revision := criteria.revision()
+	if len(required) == 0 || revision == "" {
+		return nil
+	}
+
+	results, err := r.fetcher.ListCheckResults(ctx, criteria.InstallationToken, criteria.Repo, revision)
+	if err != nil {
+		return errors.Wrap(err, "listing check results")
+	}
+
+	var unsuccessful []template.RequiredCheck
+	var names []string
+	for _, name := range required {
+		result, ok := results[name]
+		if ok && result.IsSuccessful() {
+			continue
+		}
+		unsuccessful = append(unsuccessful, template.RequiredCheck{
+			Name:   name,
+			URL:    result.URL,
+			Status: result.Status,
+		})
+		names = append(names, name)
+	}
+
+	if len(unsuccessful) == 0 {
+		return nil
+	}
+
+	return r.errorGenerator.GenerateForbiddenError(
+		ctx,
+		template.RequiredChecksFailed,
+		criteria.Repo,
+		template.RequiredChecksFailedData{Checks: unsuccessful},
+		"required checks are not successful: %s", strings.Join(names, ", "),
+	)
+}
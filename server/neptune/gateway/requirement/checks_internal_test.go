@@ -0,0 +1,115 @@
+package requirement
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/neptune/template"
+	"github.com/runatlantis/atlantis/server/vcs/provider/github"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCheckResultsFetcher struct {
+	ref     string
+	results map[string]github.CheckResult
+	err     error
+}
+
+func (f *testCheckResultsFetcher) ListCheckResults(ctx context.Context, installationToken int64, repo models.Repo, ref string) (map[string]github.CheckResult, error) {
+	f.ref = ref
+	return f.results, f.err
+}
+
+type testChecksErrGenerator struct {
+	data template.RequiredChecksFailedData
+}
+
+func (g *testChecksErrGenerator) GenerateForbiddenError(ctx context.Context, key template.Key, repo models.Repo, data template.RequiredChecksFailedData, msg string, format ...any) ForbiddenError {
+	g.data = data
+	return NewForbiddenError(msg, format...)
+}
+
+func TestStatusChecks(t *testing.T) {
+	criteria := Criteria{
+		Repo:         models.Repo{Name: "hi", DefaultBranch: "main"},
+		OptionalPull: &models.PullRequest{Num: 1, HeadCommit: "head"},
+		Roots: []*valid.MergedProjectCfg{
+			{Name: "dev", ApplySettings: valid.ApplySettings{RequiredChecks: []string{"ci/build"}}},
+			{Name: "prod", ApplySettings: valid.ApplySettings{RequiredChecks: []string{"ci/build", "lint"}}},
+		},
+	}
+
+	t.Run("no required checks", func(t *testing.T) {
+		subject := &statusChecks{
+			cfg:     valid.NewGlobalCfg(""),
+			fetcher: &testCheckResultsFetcher{err: assert.AnError},
+		}
+
+		err := subject.Check(context.Background(), Criteria{
+			Repo:         criteria.Repo,
+			OptionalPull: criteria.OptionalPull,
+			Roots:        []*valid.MergedProjectCfg{{Name: "dev"}},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("successful", func(t *testing.T) {
+		fetcher := &testCheckResultsFetcher{
+			results: map[string]github.CheckResult{
+				"ci/build": {Name: "ci/build", Status: "success"},
+				"lint":     {Name: "lint", Status: "skipped"},
+			},
+		}
+		subject := &statusChecks{
+			cfg:     valid.NewGlobalCfg(""),
+			fetcher: fetcher,
+		}
+
+		err := subject.Check(context.Background(), criteria)
+		assert.NoError(t, err)
+		assert.Equal(t, "head", fetcher.ref)
+	})
+
+	t.Run("unsuccessful", func(t *testing.T) {
+		errGenerator := &testChecksErrGenerator{}
+		subject := &statusChecks{
+			cfg: valid.NewGlobalCfg(""),
+			fetcher: &testCheckResultsFetcher{
+				results: map[string]github.CheckResult{
+					"ci/build": {Name: "ci/build", Status: "failure", URL: "www.ci.com"},
+				},
+			},
+			errorGenerator: errGenerator,
+		}
+
+		err := subject.Check(context.Background(), criteria)
+
+		var target ForbiddenError
+		assert.ErrorAs(t, err, &target)
+		assert.Equal(t, "required checks are not successful: ci/build, lint", err.Error())
+		assert.Equal(t, template.RequiredChecksFailedData{
+			Checks: []template.RequiredCheck{
+				{Name: "ci/build", URL: "www.ci.com", Status: "failure"},
+				{Name: "lint"},
+			},
+		}, errGenerator.data)
+	})
+
+	t.Run("manual deploy revision", func(t *testing.T) {
+		fetcher := &testCheckResultsFetcher{err: assert.AnError}
+		subject := &statusChecks{
+			cfg:     valid.NewGlobalCfg(""),
+			fetcher: fetcher,
+		}
+
+		err := subject.Check(context.Background(), Criteria{
+			Repo:     criteria.Repo,
+			Roots:    criteria.Roots,
+			Revision: "sha",
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, "sha", fetcher.ref)
+	})
+}
@@ -0,0 +1,110 @@
+package requirement
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/neptune/template"
+)
+
+type approverFetcher interface {
+	ListLatestApprovalUsernames(ctx context.Context, installationToken int64, repo models.Repo, prNum int) ([]string, error)
+}
+
+// owners requires an approval from an owner of every root being applied from a PR.  Owners are
+// either github users or org/team slugs and are resolved when building the roots from the root
+// config or the repo's CODEOWNERS.  Teams can only be resolved within org, so teams of other orgs
+// never satisfy the requirement.
+type owners struct {
+	org             string
+	approverFetcher approverFetcher
+	teamFetcher     fetcher
+	errorGenerator  errGenerator[template.OwnersApprovalRequiredData]
+}
+
+func (o owners) Check(ctx context.Context, criteria Criteria) error {
+	if criteria.OptionalPull == nil {
+		return nil
+	}
+
+	var ownedRoots []*valid.MergedProjectCfg
+	for _, root := range criteria.Roots {
+		if root.ApplySettings.ContainsPRRequirement(valid.OwnersApplyReq) && len(root.Owners) > 0 {
+			ownedRoots = append(ownedRoots, root)
+		}
+	}
+	if len(ownedRoots) == 0 {
+		return nil
+	}
+
+	approvers, err := o.approverFetcher.ListLatestApprovalUsernames(ctx, criteria.InstallationToken, criteria.Repo, criteria.OptionalPull.Num)
+	if err != nil {
+		return errors.Wrap(err, "fetching approvals")
+	}
+
+	approved := make(map[string]bool)
+	for _, a := range approvers {
+		approved[a] = true
+	}
+
+	// memoize team lookups since roots commonly share owners
+	teamMembers := make(map[string][]string)
+	isApprovedBy := func(owner string) (bool, error) {
+		org, slug, isTeam := strings.Cut(owner, "/")
+		if !isTeam {
+			return approved[owner], nil
+		}
+		if org != o.org {
+			return false, nil
+		}
+
+		members, ok := teamMembers[slug]
+		if !ok {
+			members, err = o.teamFetcher.ListTeamMembers(ctx, criteria.InstallationToken, slug)
+			if err != nil {
+				return false, errors.Wrapf(err, "fetching members of %s", owner)
+			}
+			teamMembers[slug] = members
+		}
+
+		for _, m := range members {
+			if approved[m] {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	var missing []template.RootOwners
+	var missingNames []string
+	for _, root := range ownedRoots {
+		rootApproved := false
+		for _, owner := range root.Owners {
+			ok, err := isApprovedBy(owner)
+			if err != nil {
+				return err
+			}
+			if ok {
+				rootApproved = true
+				break
+			}
+		}
+
+		if !rootApproved {
+			missing = append(missing, template.RootOwners{Root: root.Name, Owners: root.Owners})
+			missingNames = append(missingNames, root.Name)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return o.errorGenerator.GenerateForbiddenError(ctx, template.OwnersApprovalRequired, criteria.Repo,
+		template.OwnersApprovalRequiredData{Roots: missing},
+		"missing approvals from owners of roots: %s", strings.Join(missingNames, ", "),
+	)
+}
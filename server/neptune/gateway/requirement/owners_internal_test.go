@@ -0,0 +1,127 @@
+package requirement
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/neptune/template"
+	"github.com/stretchr/testify/assert"
+)
+
+type testApproverFetcher struct {
+	approvers []string
+	err       error
+}
+
+func (f testApproverFetcher) ListLatestApprovalUsernames(ctx context.Context, installationToken int64, repo models.Repo, prNum int) ([]string, error) {
+	return f.approvers, f.err
+}
+
+type testOwnersErrGenerator struct {
+	data template.OwnersApprovalRequiredData
+}
+
+func (g *testOwnersErrGenerator) GenerateForbiddenError(ctx context.Context, key template.Key, repo models.Repo, data template.OwnersApprovalRequiredData, msg string, format ...any) ForbiddenError {
+	g.data = data
+	return NewForbiddenError(msg, format...)
+}
+
+func TestOwners(t *testing.T) {
+	applySettings := valid.ApplySettings{PRRequirements: []string{valid.OwnersApplyReq}}
+
+	criteria := Criteria{
+		Repo:         models.Repo{Name: "hi", DefaultBranch: "main"},
+		OptionalPull: &models.PullRequest{Num: 1},
+		Roots: []*valid.MergedProjectCfg{
+			{Name: "user-owned", Owners: []string{"alice", "bob"}, ApplySettings: applySettings},
+			{Name: "team-owned", Owners: []string{"org/team"}, ApplySettings: applySettings},
+			{Name: "unowned", ApplySettings: applySettings},
+		},
+	}
+
+	t.Run("requirement not specified", func(t *testing.T) {
+		subject := owners{
+			approverFetcher: testApproverFetcher{err: assert.AnError},
+		}
+
+		err := subject.Check(context.Background(), Criteria{
+			Repo:         criteria.Repo,
+			OptionalPull: criteria.OptionalPull,
+			Roots: []*valid.MergedProjectCfg{
+				{Name: "user-owned", Owners: []string{"alice", "bob"}},
+			},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("pull not specified", func(t *testing.T) {
+		subject := owners{
+			approverFetcher: testApproverFetcher{err: assert.AnError},
+		}
+
+		err := subject.Check(context.Background(), Criteria{Repo: criteria.Repo, Roots: criteria.Roots})
+		assert.NoError(t, err)
+	})
+
+	t.Run("approved by owners of each root", func(t *testing.T) {
+		subject := owners{
+			org:             "org",
+			approverFetcher: testApproverFetcher{approvers: []string{"bob", "carol"}},
+			teamFetcher:     testFetcher{users: []string{"carol"}},
+		}
+
+		err := subject.Check(context.Background(), criteria)
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing owner approvals", func(t *testing.T) {
+		errGenerator := &testOwnersErrGenerator{}
+		subject := owners{
+			org:             "org",
+			approverFetcher: testApproverFetcher{approvers: []string{"dave"}},
+			teamFetcher:     testFetcher{users: []string{"carol"}},
+			errorGenerator:  errGenerator,
+		}
+
+		err := subject.Check(context.Background(), criteria)
+
+		var target ForbiddenError
+		assert.ErrorAs(t, err, &target)
+		assert.Equal(t, "missing approvals from owners of roots: user-owned, team-owned", err.Error())
+		assert.Equal(t, template.OwnersApprovalRequiredData{
+			Roots: []template.RootOwners{
+				{Root: "user-owned", Owners: []string{"alice", "bob"}},
+				{Root: "team-owned", Owners: []string{"org/team"}},
+			},
+		}, errGenerator.data)
+	})
+
+	t.Run("team from another org", func(t *testing.T) {
+		errGenerator := &testOwnersErrGenerator{}
+		subject := owners{
+			org:             "other-org",
+			approverFetcher: testApproverFetcher{approvers: []string{"bob", "carol"}},
+			teamFetcher:     testFetcher{users: []string{"carol"}},
+			errorGenerator:  errGenerator,
+		}
+
+		err := subject.Check(context.Background(), criteria)
+
+		var target ForbiddenError
+		assert.ErrorAs(t, err, &target)
+		assert.Equal(t, "missing approvals from owners of roots: team-owned", err.Error())
+	})
+
+	t.Run("team fetch error", func(t *testing.T) {
+		subject := owners{
+			org:             "org",
+			approverFetcher: testApproverFetcher{approvers: []string{"bob"}},
+			teamFetcher:     testFetcher{err: assert.AnError},
+		}
+
+		err := subject.Check(context.Background(), criteria)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -19,17 +19,24 @@ type checkRunFetcher interface {
 }
 
 type planValidationResult struct {
-	cfg            valid.GlobalCfg
 	errorGenerator errGenerator[template.PlanValidationSuccessData]
 	fetcher        checkRunFetcher
 }
 
 func (a planValidationResult) Check(ctx context.Context, criteria Criteria) error {
-	match := a.cfg.MatchingRepo(criteria.Repo.ID())
+	if criteria.OptionalPull == nil {
+		return nil
+	}
 
-	shouldCheck := match.ApplySettings.ContainsPRRequirement(valid.PoliciesPassedApplyReq)
+	// only roots which require passing policies are forbidden by their check runs
+	rootsByName := make(map[string]*valid.MergedProjectCfg)
+	for _, r := range criteria.Roots {
+		if r.ApplySettings.ContainsPRRequirement(valid.PoliciesPassedApplyReq) {
+			rootsByName[r.Name] = r
+		}
+	}
 
-	if !shouldCheck || criteria.OptionalPull == nil {
+	if len(rootsByName) == 0 {
 		return nil
 	}
 
@@ -38,11 +45,6 @@ func (a planValidationResult) Check(ctx context.Context, criteria Criteria) erro
 		return errors.Wrap(err, "listing failed policy check runs")
 	}
 
-	rootsByName := make(map[string]*valid.MergedProjectCfg)
-	for _, r := range criteria.Roots {
-		rootsByName[r.Name] = r
-	}
-
 	var forbiddenCheckRuns []template.CheckRun
 	// if failing check run is a root that we are intending to apply, let's forbid it.
 	for _, c := range checkRuns {
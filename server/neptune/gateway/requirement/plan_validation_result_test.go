@@ -29,9 +29,7 @@ func TestPlanValidationResult(t *testing.T) {
 			OptionalPull: &models.PullRequest{},
 		}
 
-		globalCfg := valid.NewGlobalCfg("")
 		subject := &planValidationResult{
-			cfg:            globalCfg,
 			errorGenerator: testErrGenerator[template.PlanValidationSuccessData]{},
 		}
 
@@ -45,10 +43,7 @@ func TestPlanValidationResult(t *testing.T) {
 			Branch: "main",
 		}
 
-		globalCfg := valid.NewGlobalCfg("")
-		globalCfg.Repos[0].ApplySettings.PRRequirements = []string{valid.PoliciesPassedApplyReq}
 		subject := &planValidationResult{
-			cfg:            globalCfg,
 			errorGenerator: testErrGenerator[template.PlanValidationSuccessData]{},
 		}
 
@@ -66,17 +61,16 @@ func TestPlanValidationResult(t *testing.T) {
 			Roots: []*valid.MergedProjectCfg{
 				{
 					Name: root,
+					ApplySettings: valid.ApplySettings{
+						PRRequirements: []string{valid.PoliciesPassedApplyReq},
+					},
 				},
 			},
 		}
 
-		globalCfg := valid.NewGlobalCfg("")
-		globalCfg.Repos[0].ApplySettings.PRRequirements = []string{valid.PoliciesPassedApplyReq}
-
 		expectedError := ForbiddenError{details: "hi"}
 
 		subject := &planValidationResult{
-			cfg:            globalCfg,
 			errorGenerator: testErrGenerator[template.PlanValidationSuccessData]{err: expectedError},
 			fetcher: &testCheckRunFetcher{
 				checks: []*github.CheckRun{
@@ -105,15 +99,14 @@ func TestPlanValidationResult(t *testing.T) {
 			Roots: []*valid.MergedProjectCfg{
 				{
 					Name: root,
+					ApplySettings: valid.ApplySettings{
+						PRRequirements: []string{valid.PoliciesPassedApplyReq},
+					},
 				},
 			},
 		}
 
-		globalCfg := valid.NewGlobalCfg("")
-		globalCfg.Repos[0].ApplySettings.PRRequirements = []string{valid.PoliciesPassedApplyReq}
-
 		subject := &planValidationResult{
-			cfg:            globalCfg,
 			errorGenerator: testErrGenerator[template.PlanValidationSuccessData]{},
 			fetcher: &testCheckRunFetcher{
 				checks: []*github.CheckRun{
@@ -0,0 +1,29 @@
+package requirement
+
+import (
+	"github.com/runatlantis/atlantis/server/config/valid"
+)
+
+type rootApplySettings struct {
+	// root is empty when the settings are the repo's
+	root     string
+	settings valid.ApplySettings
+}
+
+// applySettingsFor returns the apply settings of each root in the criteria.  Requests which
+// aren't scoped to roots, such as plan reviews, fall back to the settings of the matching repo.
+func applySettingsFor(cfg valid.GlobalCfg, criteria Criteria) []rootApplySettings {
+	if len(criteria.Roots) == 0 {
+		match := cfg.MatchingRepo(criteria.Repo.ID())
+		if match == nil {
+			return nil
+		}
+		return []rootApplySettings{{settings: match.ApplySettings}}
+	}
+
+	var result []rootApplySettings
+	for _, r := range criteria.Roots {
+		result = append(result, rootApplySettings{root: r.Name, settings: r.ApplySettings})
+	}
+	return result
+}
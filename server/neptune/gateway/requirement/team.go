@@ -5,9 +5,11 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/models"
 	"github.com/runatlantis/atlantis/server/neptune/template"
 	"github.com/runatlantis/atlantis/server/neptune/workflows"
+	"github.com/runatlantis/atlantis/server/vcs/provider/github"
 )
 
 type Criteria struct {
@@ -18,6 +20,16 @@ type Criteria struct {
 	InstallationToken int64
 	TriggerInfo       workflows.DeployTriggerInfo
 	Roots             []*valid.MergedProjectCfg
+
+	// Revision is the commit being deployed, the head of OptionalPull is used if it's empty.
+	Revision string
+}
+
+func (c Criteria) revision() string {
+	if c.Revision == "" && c.OptionalPull != nil {
+		return c.OptionalPull.HeadCommit
+	}
+	return c.Revision
 }
 
 type fetcher interface {
@@ -30,14 +42,40 @@ type team struct {
 	errorGenerator errGenerator[template.UserForbiddenData]
 }
 
+// NewTeam returns a requirement which restricts the user to members of the apply team
+// of the matching repo.  This is exposed for entrypoints outside of the deploy aggregate,
+// such as slack plan reviews, which need to authorize users the same way.
+func NewTeam(cfg valid.GlobalCfg, teamFetcher *github.TeamMemberFetcher, logger logging.Logger) Requirement {
+	return &team{
+		cfg:     cfg,
+		fetcher: teamFetcher,
+		errorGenerator: errorGenerator[template.UserForbiddenData]{
+			logger: logger,
+			loader: template.Loader[template.UserForbiddenData]{GlobalCfg: cfg},
+		},
+	}
+}
+
+// Check requires the user to be a member of the apply team of every root, roots
+// commonly share a team so each one is only checked once.
 func (r *team) Check(ctx context.Context, criteria Criteria) error {
-	match := r.cfg.MatchingRepo(criteria.Repo.ID())
+	checked := make(map[string]bool)
+	for _, s := range applySettingsFor(r.cfg, criteria) {
+		team := s.settings.Team
+		if len(team) == 0 || checked[team] {
+			continue
+		}
+		checked[team] = true
 
-	if len(match.ApplySettings.Team) == 0 {
-		return nil
+		if err := r.checkMembership(ctx, criteria, team); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	teamMembers, err := r.fetcher.ListTeamMembers(ctx, criteria.InstallationToken, match.ApplySettings.Team)
+func (r *team) checkMembership(ctx context.Context, criteria Criteria, team string) error {
+	teamMembers, err := r.fetcher.ListTeamMembers(ctx, criteria.InstallationToken, team)
 	if err != nil {
 		return errors.Wrap(err, "fetching team members")
 	}
@@ -53,7 +91,7 @@ func (r *team) Check(ctx context.Context, criteria Criteria) error {
 		template.UserForbidden, criteria.Repo,
 		template.UserForbiddenData{
 			User: criteria.User.Username,
-			Team: match.ApplySettings.Team,
+			Team: team,
 			Org:  r.cfg.PolicySets.Organization,
 		},
 		"User: %s is forbidden from executing a deploy", criteria.User.Username,
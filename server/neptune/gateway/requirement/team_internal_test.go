@@ -84,4 +84,47 @@ func TestTeam(t *testing.T) {
 		err := subject.Check(context.Background(), expectedCriteria)
 		assert.EqualError(t, err, expectedErr.details)
 	})
+
+	t.Run("root teams", func(t *testing.T) {
+		expectedCriteria := Criteria{
+			Repo: models.Repo{Name: "hi", DefaultBranch: "main"},
+			User: models.User{Username: "nish"},
+			Roots: []*valid.MergedProjectCfg{
+				{Name: "dev", ApplySettings: valid.ApplySettings{Team: "eng"}},
+				{Name: "prod", ApplySettings: valid.ApplySettings{Team: "sre"}},
+			},
+		}
+
+		globalCfg := valid.NewGlobalCfg("")
+		globalCfg.Repos[0].ApplySettings.Team = "some-team"
+
+		expectedErr := ForbiddenError{details: "hi"}
+		subject := team{
+			cfg: globalCfg,
+			fetcher: testTeamsFetcher{
+				members: map[string][]string{
+					"eng": {"nish"},
+					"sre": {"test"},
+				},
+			},
+			errorGenerator: testErrGenerator[template.UserForbiddenData]{
+				err: expectedErr,
+			},
+		}
+
+		err := subject.Check(context.Background(), expectedCriteria)
+		assert.EqualError(t, err, expectedErr.details)
+
+		expectedCriteria.Roots = expectedCriteria.Roots[:1]
+		err = subject.Check(context.Background(), expectedCriteria)
+		assert.NoError(t, err)
+	})
+}
+
+type testTeamsFetcher struct {
+	members map[string][]string
+}
+
+func (f testTeamsFetcher) ListTeamMembers(ctx context.Context, installationToken int64, teamSlug string) ([]string, error) {
+	return f.members[teamSlug], nil
 }
@@ -7,20 +7,24 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/runatlantis/atlantis/server/config/valid"
 	"github.com/runatlantis/atlantis/server/legacy/controllers"
-	lyft_gateway "github.com/runatlantis/atlantis/server/legacy/lyft/gateway"
 	"github.com/runatlantis/atlantis/server/logging"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/api"
 	apiMiddleware "github.com/runatlantis/atlantis/server/neptune/gateway/api/middleware"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/api/request"
 	commonMiddleware "github.com/runatlantis/atlantis/server/neptune/gateway/middleware"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
 )
 
 func newRouter(
 	logger logging.Logger,
-	eventsController *lyft_gateway.VCSEventsController,
+	eventsHandler http.Handler,
 	statusController *controllers.StatusController,
 	deployController *api.Controller[request.Deploy],
+	deliveriesController *api.DeliveriesController,
+	slackController *api.SlackController,
+	slackSigningSecret string,
 	globalCfg valid.GlobalCfg,
+	auditSink plugins.AuditSink,
 ) *mux.Router {
 	recovery := &commonMiddleware.Recovery{
 		Logger: logger,
@@ -34,16 +38,30 @@ func newRouter(
 	router.Use(requestID.Middleware, logging.Middleware, recovery.Middleware)
 	router.HandleFunc("/healthz", Healthz).Methods(http.MethodGet)
 	router.HandleFunc("/status", statusController.Get).Methods(http.MethodGet)
-	router.HandleFunc("/events", eventsController.Post).Methods(http.MethodPost)
+	router.Handle("/events", eventsHandler).Methods(http.MethodPost)
 	router.HandleFunc("/debug/pprof/profile", pprof.Profile)
 
+	if slackController != nil {
+		slackSubrouter := router.PathPrefix("/slack").Subrouter()
+		slackSubrouter.Use((&apiMiddleware.SlackSignature{SigningSecret: slackSigningSecret}).Middleware)
+		slackSubrouter.HandleFunc("/interactivity", slackController.Handle).Methods(http.MethodPost)
+	}
+
 	apiSubrouter := router.PathPrefix("/api/admin").Subrouter()
 	auth := &apiMiddleware.AdminAuth{
 		Admin: globalCfg.Admin,
 	}
 
-	apiSubrouter.Use(auth.Middleware)
+	audit := &apiMiddleware.Audit{
+		Sink:   auditSink,
+		Logger: logger,
+	}
+
+	// audit must come after auth so that the authenticated user is recorded
+	apiSubrouter.Use(auth.Middleware, audit.Middleware)
 	apiSubrouter.HandleFunc("/deploy", deployController.Handle).Methods(http.MethodPost)
+	apiSubrouter.HandleFunc("/deliveries", deliveriesController.List).Methods(http.MethodGet)
+	apiSubrouter.HandleFunc("/deliveries/{id}/replay", deliveriesController.Replay).Methods(http.MethodPost)
 
 	return router
 }
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
@@ -26,18 +27,27 @@ import (
 	"github.com/runatlantis/atlantis/server/neptune/gateway/api"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/api/request"
 	root_config "github.com/runatlantis/atlantis/server/neptune/gateway/config"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/delivery"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/deploy"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/event"
 	"github.com/runatlantis/atlantis/server/neptune/gateway/event/preworkflow"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/ingestion"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/pr"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/requirement"
 	httpInternal "github.com/runatlantis/atlantis/server/neptune/http"
 	"github.com/runatlantis/atlantis/server/neptune/lyft/feature"
 	internalSync "github.com/runatlantis/atlantis/server/neptune/sync"
 	"github.com/runatlantis/atlantis/server/neptune/sync/crons"
 	"github.com/runatlantis/atlantis/server/neptune/temporal"
 	ghClient "github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins/audit"
 	"github.com/runatlantis/atlantis/server/vcs/provider/github"
 	github_converter "github.com/runatlantis/atlantis/server/vcs/provider/github/converter"
+	"github.com/slack-go/slack"
 	"github.com/urfave/cli"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/worker"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -47,11 +57,15 @@ type Config struct {
 	AutoplanFileList          string
 	AppCfg                    githubapp.Config
 	RepoAllowList             string
+	RepoMirrorCacheMB         int
+	DurableEventIngestion     bool
 	MaxProjectsPerPR          int
 	FFOwner                   string
 	FFRepo                    string
 	FFBranch                  string
 	FFPath                    string
+	FFSource                  string
+	FFURL                     string
 	GithubHostname            string
 	GithubWebhookSecret       string
 	GithubAppID               int64
@@ -68,6 +82,9 @@ type Config struct {
 	SSLCertFile               string
 	DefaultCheckrunDetailsURL string
 	DefaultTFVersion          string
+	SlackToken                string
+	SlackSigningSecret        string
+	SlackGithubUserField      string
 }
 
 type Server struct {
@@ -81,6 +98,10 @@ type Server struct {
 	Server         httpInternal.ServerProxy
 	TemporalClient client.Client
 	CronScheduler  *internalSync.CronScheduler
+
+	// IngestionWorker processes events handed off by the gateway, only set when durable
+	// event ingestion is enabled.
+	IngestionWorker worker.Worker
 }
 
 // NewServer injects all dependencies nothing should "start" here
@@ -138,24 +159,21 @@ func NewServer(config Config) (*Server, error) {
 		return nil, errors.Wrap(err, "creating github client creator")
 	}
 
-	repoConfig := feature.RepoConfig{
-		Owner:  config.FFOwner,
-		Repo:   config.FFRepo,
-		Branch: config.FFBranch,
-		Path:   config.FFPath,
-	}
-	installationFetcher := &github.InstallationRetriever{
-		ClientCreator: clientCreator,
-	}
-	fileFetcher := &github.SingleFileContentsFetcher{
-		ClientCreator: clientCreator,
-	}
-	retriever := &feature.CustomGithubInstallationRetriever{
-		InstallationFetcher: installationFetcher,
-		FileContentsFetcher: fileFetcher,
-		Cfg:                 repoConfig,
+	retriever, err := feature.NewRetriever(feature.SourceConfig{
+		Type: feature.SourceType(config.FFSource),
+		Github: feature.RepoConfig{
+			Owner:  config.FFOwner,
+			Repo:   config.FFRepo,
+			Branch: config.FFBranch,
+			Path:   config.FFPath,
+		},
+		Path: config.FFPath,
+		URL:  config.FFURL,
+	}, clientCreator)
+	if err != nil {
+		return nil, errors.Wrap(err, "initializing feature retriever")
 	}
-	featureAllocator, err := feature.NewGHSourcedAllocator(retriever, ctxLogger)
+	featureAllocator, err := feature.NewAllocator(retriever, ctxLogger)
 	if err != nil {
 		return nil, errors.Wrap(err, "initializing feature allocator")
 	}
@@ -175,16 +193,17 @@ func NewServer(config Config) (*Server, error) {
 		return nil, errors.Wrap(err, "initializing new aws session")
 	}
 
+	auditSink, err := audit.NewSink(globalCfg.Audit)
+	if err != nil {
+		return nil, errors.Wrap(err, "initializing audit sink")
+	}
+
 	drainer := &events.Drainer{}
 	statusController := &controllers.StatusController{
 		Logger:  ctxLogger,
 		Drainer: drainer,
 	}
 
-	commentParser := &events.CommentParser{
-		GithubUser: config.GithubAppSlug,
-	}
-
 	syncScheduler := &internalSync.SynchronousScheduler{
 		Logger:               ctxLogger,
 		PanicRecoveryEnabled: true,
@@ -208,28 +227,73 @@ func NewServer(config Config) (*Server, error) {
 		return nil, errors.Wrap(err, "initializing temporal client")
 	}
 
+	var mirrorCache *github.MirrorCache
+	if config.RepoMirrorCacheMB > 0 {
+		mirrorCache, err = github.NewMirrorCache(
+			filepath.Join(config.DataDir, "mirrors"),
+			int64(config.RepoMirrorCacheMB)<<20,
+			ctxLogger,
+			statsScope.SubScope("repo.mirror"),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "initializing repo mirror cache")
+		}
+	}
+
+	installationTokens := &github.InstallationTokens{
+		AppID:      config.GithubAppID,
+		PrivateKey: privateKey,
+		V3APIURL:   config.AppCfg.V3APIURL,
+	}
 	repoFetcher := &github.RepoFetcher{
 		DataDir:           config.DataDir,
-		GithubCredentials: githubCredentials,
+		GithubCredentials: installationTokens,
 		GithubHostname:    config.GithubHostname,
 		Logger:            ctxLogger,
 		Scope:             statsScope.SubScope("repo.fetch"),
+		MirrorCache:       mirrorCache,
 	}
 	hooksRunner := &preworkflow.HooksRunner{
 		GlobalCfg: globalCfg,
 		HookExecutor: &preworkflow.HookExecutor{
 			Logger: ctxLogger,
 		},
+		GithubCredentials: installationTokens,
+		GithubHostname:    config.GithubHostname,
 	}
 
+	contentsFetcher := &github.ContentsFetcher{
+		ClientCreator: clientCreator,
+	}
+	parserValidator := &root_config.ParserValidator{GlobalCfg: globalCfg}
 	rootConfigBuilder := &root_config.Builder{
 		RepoFetcher:     repoFetcher,
 		HooksRunner:     hooksRunner,
-		ParserValidator: &root_config.ParserValidator{GlobalCfg: globalCfg},
+		ParserValidator: parserValidator,
 		Strategy: &root_config.ModifiedRootsStrategy{
 			RootFinder:  &deploy.RepoRootFinder{Logger: ctxLogger},
 			FileFetcher: &github.RemoteFileFetcher{ClientCreator: clientCreator},
 		},
+		RemoteStrategy: &root_config.RemoteRootsStrategy{
+			ContentsFetcher: contentsFetcher,
+			FileFetcher:     &github.RemoteFileFetcher{ClientCreator: clientCreator},
+			RootFinder: &deploy.RemoteRootFinder{
+				Logger:      ctxLogger,
+				PathChecker: contentsFetcher,
+			},
+		},
+		CodeOwners: &github.CodeOwnersFetcher{
+			ContentsFetcher: contentsFetcher,
+		},
+		DefaultBranchRoots: &root_config.DefaultBranchRoots{
+			ContentsFetcher: contentsFetcher,
+			ParserValidator: parserValidator,
+		},
+		Fingerprinter: &deploy.RootFingerprinter{
+			TreeFetcher:     contentsFetcher,
+			ContentsFetcher: contentsFetcher,
+			Logger:          ctxLogger,
+		},
 		GlobalCfg: globalCfg,
 		Logger:    ctxLogger,
 		Scope:     statsScope.SubScope("event.filters.root"),
@@ -238,25 +302,65 @@ func NewServer(config Config) (*Server, error) {
 	deploySignaler := &deploy.WorkflowSignaler{
 		TemporalClient: temporalClient,
 	}
-	rootDeployer := &deploy.RootDeployer{
-		Logger:            ctxLogger,
-		RootConfigBuilder: rootConfigBuilder,
-		DeploySignaler:    deploySignaler,
-	}
-
 	checkRunFetcher := &github.CheckRunsFetcher{
 		AppID:         config.GithubAppID,
 		ClientCreator: clientCreator,
 	}
+	rootDeployer := &deploy.RootDeployer{
+		Logger:                  ctxLogger,
+		RootConfigBuilder:       rootConfigBuilder,
+		DeploySignaler:          deploySignaler,
+		ManualDeployRequirement: requirement.NewStatusChecks(globalCfg, checkRunFetcher, ctxLogger),
+	}
 
 	commentCreator := &github.CommentCreator{
 		ClientCreator: clientCreator,
 	}
+	// records webhook deliveries so that redeliveries are dropped and failures can be replayed
+	deliveryLog := delivery.NewLog(delivery.DefaultDedupeWindow, delivery.DefaultMaxEntries)
+
+	// with durable ingestion, push and pull request events are processed by a temporal workflow
+	// instead of in async goroutines so they survive restarts and are retried on failure.
+	var ingester *ingestion.Ingester
+	var ingestionWorker worker.Worker
+	if config.DurableEventIngestion {
+		ingester = &ingestion.Ingester{
+			TemporalClient: temporalClient,
+			Logger:         ctxLogger,
+		}
+
+		ingestionWorker = worker.New(temporalClient.Client, ingestion.TaskQueue, worker.Options{
+			WorkerStopTimeout: 30 * time.Second,
+			Interceptors: []interceptor.WorkerInterceptor{
+				temporal.NewWorkerInterceptor(),
+			},
+		})
+		ingestionWorker.RegisterWorkflow(ingestion.Workflow)
+
+		// processors run synchronously within the activity so that failures are retried
+		ingestionWorker.RegisterActivity(&ingestion.Activities{
+			PushProcessor: &event.PushHandler{
+				Scheduler:    syncScheduler,
+				Logger:       ctxLogger,
+				RootDeployer: rootDeployer,
+			},
+			PullRequestProcessor: event.NewModifiedPullHandler(
+				ctxLogger,
+				syncScheduler,
+				rootConfigBuilder,
+				globalCfg,
+				requirement.NewPRAggregate(globalCfg),
+				&pr.WorkflowSignaler{TemporalClient: temporalClient, DefaultTFVersion: config.DefaultTFVersion},
+				&event.LegacyPullHandler{Logger: ctxLogger, VCSStatusUpdater: vcsStatusUpdater},
+			),
+		})
+	}
+
 	gatewayEventsController := lyft_gateway.NewVCSEventsController(
 		statsScope,
 		[]byte(config.GithubWebhookSecret),
 		false,
-		commentParser,
+		config.GithubAppSlug,
 		repoAllowlist,
 		vcsClient,
 		ctxLogger,
@@ -266,7 +370,7 @@ func NewServer(config Config) (*Server, error) {
 		vcsClient,
 		featureAllocator,
 		syncScheduler,
-		asyncScheduler,
+		&delivery.Scheduler{Delegate: asyncScheduler, Log: deliveryLog},
 		temporalClient,
 		rootDeployer,
 		rootConfigBuilder,
@@ -277,6 +381,8 @@ func NewServer(config Config) (*Server, error) {
 		commentCreator,
 		clientCreator,
 		config.DefaultTFVersion,
+		auditSink,
+		ingester,
 	)
 
 	repoRetriever := &github.RepoRetriever{
@@ -288,8 +394,10 @@ func NewServer(config Config) (*Server, error) {
 		ClientCreator: clientCreator,
 	}
 
-	installationRetriever := &github.InstallationRetriever{
-		ClientCreator: clientCreator,
+	installationRetriever := &github.InstallationCache{
+		Retriever: &github.InstallationRetriever{
+			ClientCreator: clientCreator,
+		},
 	}
 
 	deployController := &api.Controller[request.Deploy]{
@@ -303,12 +411,55 @@ func NewServer(config Config) (*Server, error) {
 		},
 	}
 
+	// slack interactivity is opt-in since it requires a dedicated slack app
+	var slackController *api.SlackController
+	if config.SlackSigningSecret != "" {
+		slackController = &api.SlackController{
+			RequestConverter: &request.SlackPlanReviewConverter{
+				InstallationRetriever: installationRetriever,
+				UserResolver: &request.SlackUserResolver{
+					Client:        slack.New(config.SlackToken),
+					GithubFieldID: config.SlackGithubUserField,
+				},
+				GithubHostname: config.GithubHostname,
+			},
+			Handler: &api.PlanReviewHandler{
+				Requirement: requirement.NewTeam(globalCfg, &github.TeamMemberFetcher{
+					ClientCreator: clientCreator,
+					Org:           globalCfg.PolicySets.Organization,
+				}, ctxLogger),
+				RootConfigBuilder: rootConfigBuilder,
+				Signaler:          deploySignaler,
+				Logger:            ctxLogger,
+			},
+			Logger: ctxLogger,
+		}
+	}
+
+	deliveryMiddleware := &delivery.Middleware{
+		Log:    deliveryLog,
+		Logger: ctxLogger,
+	}
+	eventsHandler := deliveryMiddleware.Middleware(http.HandlerFunc(gatewayEventsController.Post))
+	deliveriesController := &api.DeliveriesController{
+		Log: deliveryLog,
+		Replayer: &delivery.Replayer{
+			Log:     deliveryLog,
+			Handler: eventsHandler,
+		},
+		Logger: ctxLogger,
+	}
+
 	router := newRouter(
 		ctxLogger,
-		gatewayEventsController,
+		eventsHandler,
 		statusController,
 		deployController,
+		deliveriesController,
+		slackController,
+		config.SlackSigningSecret,
 		globalCfg,
+		auditSink,
 	)
 
 	s := httpInternal.ServerProxy{
@@ -334,14 +485,15 @@ func NewServer(config Config) (*Server, error) {
 				Frequency: 1 * time.Minute,
 			},
 		},
-		StatsCloser:    closer,
-		Scheduler:      asyncScheduler,
-		Logger:         ctxLogger,
-		Port:           config.Port,
-		Drainer:        drainer,
-		TemporalClient: temporalClient,
-		Server:         s,
-		CronScheduler:  cronScheduler,
+		StatsCloser:     closer,
+		Scheduler:       asyncScheduler,
+		Logger:          ctxLogger,
+		Port:            config.Port,
+		Drainer:         drainer,
+		TemporalClient:  temporalClient,
+		Server:          s,
+		CronScheduler:   cronScheduler,
+		IngestionWorker: ingestionWorker,
 	}, nil
 }
 
@@ -370,6 +522,12 @@ func (s *Server) Start() error {
 		s.CronScheduler.Schedule(c)
 	}
 
+	if s.IngestionWorker != nil {
+		if err := s.IngestionWorker.Start(); err != nil {
+			return errors.Wrap(err, "starting ingestion worker")
+		}
+	}
+
 	<-gCtx.Done()
 	s.Logger.Warn("Received interrupt. Waiting for in-progress operations to complete")
 
@@ -395,6 +553,11 @@ func (s *Server) Shutdown() error {
 	// block on async work for 30 seconds max
 	s.Scheduler.Shutdown(30 * time.Second)
 
+	// in progress ingestion activities which don't complete in time are retried by another gateway
+	if s.IngestionWorker != nil {
+		s.IngestionWorker.Stop()
+	}
+
 	// flush stats before shutdown
 	if err := s.StatsCloser.Close(); err != nil {
 		s.Logger.Error(err.Error())
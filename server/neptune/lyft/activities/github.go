@@ -13,10 +13,19 @@ import (
 )
 
 type Github struct {
-	ClientCreator  githubapp.ClientCreator
+	ClientCreator githubapp.ClientCreator
+
+	// InstallationID is used for repos which don't carry their own installation credentials
 	InstallationID int64
 }
 
+func (a *Github) installationID(repo internal.Repo) int64 {
+	if repo.Credentials.InstallationToken != 0 {
+		return repo.Credentials.InstallationToken
+	}
+	return a.InstallationID
+}
+
 type ListPRsRequest struct {
 	Repo    internal.Repo
 	State   internal.PullRequestState
@@ -30,7 +39,7 @@ type ListPRsResponse struct {
 
 func (a *Github) GithubListPRs(ctx context.Context, request ListPRsRequest) (ListPRsResponse, error) {
 	prs, err := a.listPullRequests(
-		ctx, a.InstallationID,
+		ctx, a.installationID(request.Repo),
 		request.Repo.Owner,
 		request.Repo.Name,
 		request.Repo.DefaultBranch,
@@ -80,7 +89,7 @@ type ListModifiedFilesResponse struct {
 
 func (a *Github) GithubListModifiedFiles(ctx context.Context, request ListModifiedFilesRequest) (ListModifiedFilesResponse, error) {
 	files, err := a.listModifiedFiles(
-		ctx, a.InstallationID,
+		ctx, a.installationID(request.Repo),
 		request.Repo.Owner,
 		request.Repo.Name,
 		request.PullRequest.Number,
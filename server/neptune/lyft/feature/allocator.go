@@ -14,6 +14,13 @@ import (
 type FeatureContext struct { //nolint:revive // avoiding refactor while adding linter action
 	RepoName         string
 	PullCreationTime time.Time
+
+	// Optional targeting attributes, these are only added to the evaluation
+	// context when set.  Rollouts are always bucketed by repo.
+	Org            string
+	RootName       string
+	WorkflowMode   string
+	InitiatingUser string
 }
 
 // If keys are missing, the default is respected, so we don't need to have
@@ -47,6 +54,11 @@ type retriever interface {
 }
 
 func NewGHSourcedAllocator(retriever retriever, logger logging.Logger) (Allocator, error) {
+	return NewAllocator(retriever, logger)
+}
+
+// NewAllocator returns an allocator backed by any of the supported sources, see NewRetriever.
+func NewAllocator(retriever retriever, logger logging.Logger) (Allocator, error) {
 	ff, err := ffclient.New(
 		ffclient.Config{
 			Context:   context.Background(),
@@ -95,16 +107,29 @@ type PercentageBasedAllocator struct {
 func (r *PercentageBasedAllocator) ShouldAllocate(featureID Name, featureContext FeatureContext) (bool, error) {
 	// rule defintion used by this ff definition is not smart enough to understand different time formats
 	// so we use the Unix() time in seconds to evaluate if this feature should be allocated
-	repo := ffuser.NewUserBuilder(featureContext.RepoName).
-		AddCustom("prCreationTime", featureContext.PullCreationTime.Unix()).
-		Build()
+	builder := ffuser.NewUserBuilder(featureContext.RepoName).
+		AddCustom("repo", featureContext.RepoName).
+		AddCustom("prCreationTime", featureContext.PullCreationTime.Unix())
+
+	for attribute, value := range map[string]string{
+		"org":            featureContext.Org,
+		"rootName":       featureContext.RootName,
+		"workflowMode":   featureContext.WorkflowMode,
+		"initiatingUser": featureContext.InitiatingUser,
+	} {
+		if value != "" {
+			builder = builder.AddCustom(attribute, value)
+		}
+	}
 
-	shouldAllocate, err := r.featureFlag.BoolVariation(string(featureID), repo, false)
+	defaultValue := DefaultFor(featureID)
+	shouldAllocate, err := r.featureFlag.BoolVariation(string(featureID), builder.Build(), defaultValue)
 
-	// if we error out we shouldn't enable the feature, could be risky
-	// Note: if the feature doesn't exist, the library returns the default value.
+	// if we error out we fall back to the catalog default which is false unless a
+	// flag has been explicitly marked as safe to enable.
+	// Note: if the feature doesn't exist, the library returns an error as well.
 	if err != nil {
-		return false, errors.Wrapf(err, "getting feature %s", featureID)
+		return defaultValue, errors.Wrapf(err, "getting feature %s", featureID)
 	}
 
 	return shouldAllocate, nil
@@ -0,0 +1,84 @@
+package feature_test
+
+import (
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/neptune/lyft/feature"
+	"github.com/stretchr/testify/assert"
+)
+
+const targetedConfiguration feature.StringRetriever = `platform-mode:
+  rule: rootName eq "root" and org eq "lyft"
+  percentage: 100
+  true: true
+  false: false
+  default: false
+legacy-deprecation:
+  rule: workflowMode eq "deploy" and initiatingUser eq "nish"
+  percentage: 100
+  true: true
+  false: false
+  default: false`
+
+func TestPercentageBasedAllocator_Targeting(t *testing.T) {
+	subject, err := feature.NewStringSourcedAllocatorWithRetriever(logging.NewNoopCtxLogger(t), targetedConfiguration)
+	assert.NoError(t, err)
+	defer subject.Close()
+
+	cases := []struct {
+		description string
+		feature     feature.Name
+		ctx         feature.FeatureContext
+		expected    bool
+		expectedErr bool
+	}{
+		{
+			description: "matching root and org",
+			feature:     feature.PlatformMode,
+			ctx:         feature.FeatureContext{RepoName: "lyft/repo", Org: "lyft", RootName: "root"},
+			expected:    true,
+		},
+		{
+			description: "different root",
+			feature:     feature.PlatformMode,
+			ctx:         feature.FeatureContext{RepoName: "lyft/repo", Org: "lyft", RootName: "other"},
+		},
+		{
+			description: "missing targeting attributes",
+			feature:     feature.PlatformMode,
+			ctx:         feature.FeatureContext{RepoName: "lyft/repo"},
+		},
+		{
+			description: "matching workflow mode and user",
+			feature:     feature.LegacyDeprecation,
+			ctx:         feature.FeatureContext{RepoName: "lyft/repo", WorkflowMode: "deploy", InitiatingUser: "nish"},
+			expected:    true,
+		},
+		{
+			description: "unknown flag uses catalog default",
+			feature:     feature.Name("unknown"),
+			ctx:         feature.FeatureContext{RepoName: "lyft/repo"},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			allocated, err := subject.ShouldAllocate(c.feature, c.ctx)
+			if c.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, c.expected, allocated)
+		})
+	}
+}
+
+func TestCatalog_Names(t *testing.T) {
+	for name, flag := range feature.Catalog {
+		assert.Equal(t, name, flag.Name)
+		assert.NotEmpty(t, flag.Description)
+	}
+}
@@ -7,3 +7,31 @@ const (
 	PlatformMode      Name = "platform-mode"
 	LegacyDeprecation Name = "legacy-deprecation"
 )
+
+// Flag describes a feature flag known to the code base.
+type Flag struct {
+	Name        Name
+	Description string
+
+	// Default is used when the flag is missing from the external configuration
+	// or when the configuration cannot be evaluated.
+	Default bool
+}
+
+// Catalog contains every flag used in the code base, new flags should be added
+// here so that their defaults are explicit and discoverable.
+var Catalog = map[Name]Flag{
+	PlatformMode: {
+		Name:        PlatformMode,
+		Description: "Runs PR operations in platform mode instead of legacy mode.",
+	},
+	LegacyDeprecation: {
+		Name:        LegacyDeprecation,
+		Description: "Routes PR operations to Neptune and deprecates legacy workflows.",
+	},
+}
+
+// DefaultFor returns the catalog default for the given flag, unknown flags default to false.
+func DefaultFor(name Name) bool {
+	return Catalog[name].Default
+}
@@ -0,0 +1,62 @@
+package feature
+
+import (
+	"fmt"
+
+	"github.com/palantir/go-githubapp/githubapp"
+	ffclient "github.com/thomaspoignant/go-feature-flag"
+
+	gh "github.com/runatlantis/atlantis/server/vcs/provider/github"
+)
+
+type SourceType string
+
+const (
+	GithubSource SourceType = "github"
+	FileSource   SourceType = "file"
+	HTTPSource   SourceType = "http"
+)
+
+// SourceConfig determines where feature flag configuration is retrieved from.
+// Only the fields relevant to the configured Type are used.
+type SourceConfig struct {
+	Type SourceType
+
+	// Github is used by the github source
+	Github RepoConfig
+
+	// Path is used by the file source
+	Path string
+
+	// URL is used by the http source
+	URL string
+}
+
+// NewRetriever returns a retriever for the configured source, an empty source type
+// defaults to github for backwards compatibility.
+func NewRetriever(cfg SourceConfig, clientCreator githubapp.ClientCreator) (ffclient.Retriever, error) {
+	switch cfg.Type {
+	case GithubSource, "":
+		return &CustomGithubInstallationRetriever{
+			InstallationFetcher: &gh.InstallationRetriever{
+				ClientCreator: clientCreator,
+			},
+			FileContentsFetcher: &gh.SingleFileContentsFetcher{
+				ClientCreator: clientCreator,
+			},
+			Cfg: cfg.Github,
+		}, nil
+	case FileSource:
+		if cfg.Path == "" {
+			return nil, fmt.Errorf("path is required for the %s feature flag source", FileSource)
+		}
+		return &ffclient.FileRetriever{Path: cfg.Path}, nil
+	case HTTPSource:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("url is required for the %s feature flag source", HTTPSource)
+		}
+		return &ffclient.HTTPRetriever{URL: cfg.URL}, nil
+	default:
+		return nil, fmt.Errorf("unsupported feature flag source %q", cfg.Type)
+	}
+}
@@ -0,0 +1,60 @@
+package feature_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/neptune/lyft/feature"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRetriever_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("contents"), 0600))
+
+	subject, err := feature.NewRetriever(feature.SourceConfig{Type: feature.FileSource, Path: path}, nil)
+	assert.NoError(t, err)
+
+	contents, err := subject.Retrieve(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("contents"), contents)
+}
+
+func TestNewRetriever_HTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("contents"))
+	}))
+	defer server.Close()
+
+	subject, err := feature.NewRetriever(feature.SourceConfig{Type: feature.HTTPSource, URL: server.URL}, nil)
+	assert.NoError(t, err)
+
+	contents, err := subject.Retrieve(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("contents"), contents)
+}
+
+func TestNewRetriever_Github(t *testing.T) {
+	for _, sourceType := range []feature.SourceType{feature.GithubSource, ""} {
+		subject, err := feature.NewRetriever(feature.SourceConfig{Type: sourceType}, nil)
+		assert.NoError(t, err)
+		assert.IsType(t, &feature.CustomGithubInstallationRetriever{}, subject)
+	}
+}
+
+func TestNewRetriever_Invalid(t *testing.T) {
+	cases := []feature.SourceConfig{
+		{Type: feature.FileSource},
+		{Type: feature.HTTPSource},
+		{Type: "s3"},
+	}
+
+	for _, c := range cases {
+		_, err := feature.NewRetriever(c, nil)
+		assert.Error(t, err)
+	}
+}
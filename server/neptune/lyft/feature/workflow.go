@@ -0,0 +1,33 @@
+package feature
+
+import (
+	"go.temporal.io/sdk/workflow"
+)
+
+// WorkflowAllocator allocates features from within workflow code.  Each allocation is
+// recorded as a side effect so that replays are deterministic even if the external
+// flag configuration changes while the workflow is running.
+type WorkflowAllocator struct {
+	Allocator Allocator
+}
+
+// ShouldAllocate returns the allocation for the given feature, errors are logged and
+// treated as the feature being disabled.
+func (a WorkflowAllocator) ShouldAllocate(ctx workflow.Context, featureID Name, featureCtx FeatureContext) bool {
+	var shouldAllocate bool
+	err := workflow.SideEffect(ctx, func(ctx workflow.Context) interface{} {
+		allocated, err := a.Allocator.ShouldAllocate(featureID, featureCtx)
+		if err != nil {
+			workflow.GetLogger(ctx).Warn("unable to allocate feature", "feature", featureID, "err", err)
+			return false
+		}
+		return allocated
+	}).Get(&shouldAllocate)
+
+	if err != nil {
+		workflow.GetLogger(ctx).Warn("unable to decode feature allocation", "feature", featureID, "err", err)
+		return false
+	}
+
+	return shouldAllocate
+}
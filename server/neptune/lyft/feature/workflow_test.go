@@ -0,0 +1,61 @@
+package feature_test
+
+import (
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/neptune/lyft/feature"
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+type testAllocator struct {
+	allocated bool
+	err       error
+	calls     int
+}
+
+func (a *testAllocator) ShouldAllocate(featureID feature.Name, featureCtx feature.FeatureContext) (bool, error) {
+	a.calls++
+	return a.allocated, a.err
+}
+
+func TestWorkflowAllocator(t *testing.T) {
+	cases := []struct {
+		description string
+		allocator   *testAllocator
+		expected    bool
+	}{
+		{
+			description: "allocated",
+			allocator:   &testAllocator{allocated: true},
+			expected:    true,
+		},
+		{
+			description: "not allocated",
+			allocator:   &testAllocator{},
+		},
+		{
+			description: "error",
+			allocator:   &testAllocator{allocated: true, err: assert.AnError},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			subject := feature.WorkflowAllocator{Allocator: c.allocator}
+
+			var suite testsuite.WorkflowTestSuite
+			env := suite.NewTestWorkflowEnvironment()
+			env.ExecuteWorkflow(func(ctx workflow.Context) (bool, error) {
+				return subject.ShouldAllocate(ctx, feature.PlatformMode, feature.FeatureContext{RepoName: "owner/repo", RootName: "root"}), nil
+			})
+			assert.NoError(t, env.GetWorkflowError())
+
+			var result bool
+			assert.NoError(t, env.GetWorkflowResult(&result))
+			assert.Equal(t, c.expected, result)
+			assert.Equal(t, 1, c.allocator.calls)
+		})
+	}
+}
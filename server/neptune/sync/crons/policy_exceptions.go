@@ -0,0 +1,67 @@
+package crons
+
+import (
+	"context"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/uber-go/tally/v4"
+)
+
+const (
+	policyExceptionsSubScope = "policy.exceptions"
+	expiredExceptions        = "expired"
+	expiringExceptions       = "expiring"
+
+	// DefaultExceptionExpiryWindow is how far ahead expiring policy exceptions are reported
+	DefaultExceptionExpiryWindow = 14 * 24 * time.Hour
+)
+
+// PolicyExceptionReporter reports policy exceptions which expired or will expire within the window
+// so that their owners can renew them before failures are no longer bypassed.
+type PolicyExceptionReporter struct {
+	Exceptions []valid.PolicyException
+	Window     time.Duration
+	Logger     logging.Logger
+	Scope      tally.Scope
+	now        func() time.Time
+}
+
+func NewPolicyExceptionReporter(scope tally.Scope, logger logging.Logger, exceptions []valid.PolicyException) *PolicyExceptionReporter {
+	return &PolicyExceptionReporter{
+		Exceptions: exceptions,
+		Window:     DefaultExceptionExpiryWindow,
+		Logger:     logger,
+		Scope:      scope.SubScope(policyExceptionsSubScope),
+		now:        time.Now,
+	}
+}
+
+func (r *PolicyExceptionReporter) Run(ctx context.Context) error {
+	now := r.now()
+
+	var expired, expiring int
+	for _, e := range r.Exceptions {
+		fields := map[string]interface{}{
+			"policy_set": e.PolicySet,
+			"repo":       e.Repo,
+			"root":       e.Root,
+			"owner":      e.Owner,
+			"expiry":     e.Expiry.Format(valid.PolicyExceptionExpiryFormat),
+		}
+
+		switch {
+		case e.IsExpired(now):
+			expired++
+			r.Logger.WarnContext(ctx, "policy exception has expired", fields)
+		case e.IsExpired(now.Add(r.Window)):
+			expiring++
+			r.Logger.WarnContext(ctx, "policy exception is expiring soon", fields)
+		}
+	}
+
+	r.Scope.Gauge(expiredExceptions).Update(float64(expired))
+	r.Scope.Gauge(expiringExceptions).Update(float64(expiring))
+	return nil
+}
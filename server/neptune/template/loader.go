@@ -22,19 +22,23 @@ type Input struct {
 
 // list of all valid template ids
 const (
-	PRComment             = Key("pr_comment")
-	BranchForbidden       = Key("branch_forbidden")
-	UserForbidden         = Key("user_forbidden")
-	ApprovalRequired      = Key("approval_required")
-	PlanValidationSuccess = Key("plan_validation_success")
+	PRComment              = Key("pr_comment")
+	BranchForbidden        = Key("branch_forbidden")
+	UserForbidden          = Key("user_forbidden")
+	ApprovalRequired       = Key("approval_required")
+	PlanValidationSuccess  = Key("plan_validation_success")
+	OwnersApprovalRequired = Key("owners_approval_required")
+	RequiredChecksFailed   = Key("required_checks_failed")
 )
 
 var defaultTemplates = map[Key]string{
-	PRComment:             prCommentTemplate,
-	BranchForbidden:       branchForbiddenTemplate,
-	UserForbidden:         userForbiddenTemplate,
-	ApprovalRequired:      approvalRequiredTemplate,
-	PlanValidationSuccess: planValidationSuccessTemplate,
+	PRComment:              prCommentTemplate,
+	BranchForbidden:        branchForbiddenTemplate,
+	UserForbidden:          userForbiddenTemplate,
+	ApprovalRequired:       approvalRequiredTemplate,
+	PlanValidationSuccess:  planValidationSuccessTemplate,
+	OwnersApprovalRequired: ownersApprovalRequiredTemplate,
+	RequiredChecksFailed:   requiredChecksFailedTemplate,
 }
 
 type PRCommentData struct {
@@ -54,6 +58,26 @@ type PlanValidationSuccessData struct {
 	CheckRuns []CheckRun
 }
 
+type RootOwners struct {
+	Root   string
+	Owners []string
+}
+
+type OwnersApprovalRequiredData struct {
+	Roots []RootOwners
+}
+
+type RequiredCheck struct {
+	Name string
+	URL  string
+	// Status is empty when the check hasn't been reported
+	Status string
+}
+
+type RequiredChecksFailedData struct {
+	Checks []RequiredCheck
+}
+
 type BranchForbiddenData struct {
 	DefaultBranch string
 }
@@ -79,6 +103,12 @@ var approvalRequiredTemplate string
 //go:embed templates/plan_validation_success.tmpl
 var planValidationSuccessTemplate string
 
+//go:embed templates/owners_approval_required.tmpl
+var ownersApprovalRequiredTemplate string
+
+//go:embed templates/required_checks_failed.tmpl
+var requiredChecksFailedTemplate string
+
 type Loader[T any] struct {
 	GlobalCfg valid.GlobalCfg
 }
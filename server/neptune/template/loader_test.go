@@ -38,3 +38,29 @@ func TestLoader_TemplateOverride(t *testing.T) {
 
 	assert.Equal(t, output, string(templateContent))
 }
+
+func TestLoader_OwnersApprovalRequired(t *testing.T) {
+	loader := NewLoader[OwnersApprovalRequiredData](valid.GlobalCfg{})
+
+	output, err := loader.Load(OwnersApprovalRequired, testRepo, OwnersApprovalRequiredData{
+		Roots: []RootOwners{
+			{Root: "root", Owners: []string{"alice", "org/team"}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, output, "root: @alice, @org/team")
+}
+
+func TestLoader_RequiredChecksFailed(t *testing.T) {
+	loader := NewLoader[RequiredChecksFailedData](valid.GlobalCfg{})
+
+	output, err := loader.Load(RequiredChecksFailed, testRepo, RequiredChecksFailedData{
+		Checks: []RequiredCheck{
+			{Name: "ci/build", URL: "www.ci.com", Status: "failure"},
+			{Name: "lint"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, output, "[ci/build](www.ci.com): failure")
+	assert.Contains(t, output, "lint: missing")
+}
@@ -37,6 +37,8 @@ type FeatureConfig struct {
 	FFRepo   string
 	FFPath   string
 	FFBranch string
+	FFSource string
+	FFURL    string
 }
 
 // Config is TemporalWorker specific user config
@@ -52,6 +54,11 @@ type Config struct {
 	JobConfig        valid.StoreConfig
 	Metrics          valid.Metrics
 	RevisionSetter   valid.RevisionSetter
+	AuditCfg         valid.Audit
+	// GlobalCfg is used to resolve per repo settings, ie. deploy webhooks.
+	GlobalCfg      valid.GlobalCfg
+	GithubHostname string
+	SlackToken     string
 	//TODO: combine this with above
 	StatsNamespace string
 
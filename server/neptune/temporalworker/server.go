@@ -16,7 +16,6 @@ import (
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/runatlantis/atlantis/server/neptune/lyft/feature"
 	ghClient "github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
-	"github.com/runatlantis/atlantis/server/vcs/provider/github"
 
 	assetfs "github.com/elazarl/go-bindata-assetfs"
 	"github.com/gorilla/mux"
@@ -29,6 +28,7 @@ import (
 	"github.com/runatlantis/atlantis/server/neptune/lyft/executor"
 	"github.com/runatlantis/atlantis/server/neptune/lyft/notifier"
 	lyftWorkflows "github.com/runatlantis/atlantis/server/neptune/lyft/workflows"
+	"github.com/runatlantis/atlantis/server/neptune/storage"
 	internalSync "github.com/runatlantis/atlantis/server/neptune/sync"
 	"github.com/runatlantis/atlantis/server/neptune/sync/crons"
 	"github.com/runatlantis/atlantis/server/neptune/temporal"
@@ -37,7 +37,10 @@ import (
 	"github.com/runatlantis/atlantis/server/neptune/temporalworker/job"
 	"github.com/runatlantis/atlantis/server/neptune/workflows"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/plan"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins/audit"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins/webhook"
 	"github.com/runatlantis/atlantis/server/static"
 	"github.com/uber-go/tally/v4"
 	"github.com/urfave/negroni"
@@ -82,6 +85,8 @@ type Server struct {
 	RevisionSetterActivities *lyftActivities.RevisionSetter
 	// Temporary until we move this into our private code
 	AuditActivity              *lyftActivities.Audit
+	AuditPluginActivities      *audit.Activities
+	WebhookPluginActivities    *webhook.Activities
 	PRRevisionGithubActivities *lyftActivities.Github
 	TerraformTaskQueue         string
 	RevisionSetterConfig       valid.RevisionSetter
@@ -149,11 +154,25 @@ func NewServer(config *config.Config) (*Server, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "initializing lyft activities")
 	}
-	deployActivities, err := activities.NewDeploy(config.DeploymentConfig)
+	auditSink, err := audit.NewSink(config.AuditCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "initializing audit sink")
+	}
+	deployActivities, err := activities.NewDeploy(config.DeploymentConfig, config.SlackToken)
 	if err != nil {
 		return nil, errors.Wrap(err, "initializing deploy activities")
 	}
 
+	// PR plans are persisted next to the deployment info of each root
+	planStorageClient, err := storage.NewClient(config.DeploymentConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "initializing plan storage client")
+	}
+	planStore, err := plan.NewStore(planStorageClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "initializing plan store")
+	}
+
 	terraformActivities, err := activities.NewTerraform(
 		config.TerraformCfg,
 		config.ValidationConfig,
@@ -163,6 +182,10 @@ func NewServer(config *config.Config) (*Server, error) {
 		config.TemporalCfg.TerraformTaskQueue,
 		config.GithubCfg.TemporalAppInstallationID,
 		jobStreamHandler,
+		activities.TerraformOptions{
+			PlanStore:      planStore,
+			GithubHostname: config.GithubHostname,
+		},
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "initializing terraform activities")
@@ -175,24 +198,21 @@ func NewServer(config *config.Config) (*Server, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "client creator")
 	}
-	repoConfig := feature.RepoConfig{
-		Owner:  config.FeatureConfig.FFOwner,
-		Repo:   config.FeatureConfig.FFRepo,
-		Branch: config.FeatureConfig.FFBranch,
-		Path:   config.FeatureConfig.FFPath,
-	}
-	installationFetcher := &github.InstallationRetriever{
-		ClientCreator: clientCreator,
-	}
-	fileFetcher := &github.SingleFileContentsFetcher{
-		ClientCreator: clientCreator,
-	}
-	retriever := &feature.CustomGithubInstallationRetriever{
-		InstallationFetcher: installationFetcher,
-		FileContentsFetcher: fileFetcher,
-		Cfg:                 repoConfig,
+	retriever, err := feature.NewRetriever(feature.SourceConfig{
+		Type: feature.SourceType(config.FeatureConfig.FFSource),
+		Github: feature.RepoConfig{
+			Owner:  config.FeatureConfig.FFOwner,
+			Repo:   config.FeatureConfig.FFRepo,
+			Branch: config.FeatureConfig.FFBranch,
+			Path:   config.FeatureConfig.FFPath,
+		},
+		Path: config.FeatureConfig.FFPath,
+		URL:  config.FeatureConfig.FFURL,
+	}, clientCreator)
+	if err != nil {
+		return nil, errors.Wrap(err, "initializing feature retriever")
 	}
-	featureAllocator, err := feature.NewGHSourcedAllocator(retriever, config.CtxLogger)
+	featureAllocator, err := feature.NewAllocator(retriever, config.CtxLogger)
 	if err != nil {
 		return nil, errors.Wrap(err, "initializing feature allocator")
 	}
@@ -231,20 +251,31 @@ func NewServer(config *config.Config) (*Server, error) {
 				Executor:  crons.NewRateLimitStats(scope, clientCreator, config.GithubCfg.TemporalAppInstallationID).Run,
 				Frequency: 1 * time.Minute,
 			},
+			{
+				Executor:  crons.NewPolicyExceptionReporter(scope, config.CtxLogger, config.ValidationConfig.Policies.Exceptions).Run,
+				Frequency: 24 * time.Hour,
+			},
+		},
+		HTTPServerProxy:          httpServerProxy,
+		Port:                     config.ServerCfg.Port,
+		StatsScope:               scope,
+		StatsCloser:              statsCloser,
+		TemporalClient:           temporalClient,
+		JobStreamHandler:         jobStreamHandler,
+		DeployActivities:         deployActivities,
+		TerraformActivities:      terraformActivities,
+		GithubActivities:         githubActivities,
+		RevisionSetterActivities: revisionSetterActivities,
+		TerraformTaskQueue:       config.TemporalCfg.TerraformTaskQueue,
+		RevisionSetterConfig:     config.RevisionSetter,
+		AuditActivity:            auditActivity,
+		AuditPluginActivities:    &audit.Activities{Sink: auditSink},
+		WebhookPluginActivities: &webhook.Activities{
+			GlobalCfg:      config.GlobalCfg,
+			GithubHostname: config.GithubHostname,
+			Client:         &http.Client{Timeout: 20 * time.Second},
+			Scope:          scope,
 		},
-		HTTPServerProxy:            httpServerProxy,
-		Port:                       config.ServerCfg.Port,
-		StatsScope:                 scope,
-		StatsCloser:                statsCloser,
-		TemporalClient:             temporalClient,
-		JobStreamHandler:           jobStreamHandler,
-		DeployActivities:           deployActivities,
-		TerraformActivities:        terraformActivities,
-		GithubActivities:           githubActivities,
-		RevisionSetterActivities:   revisionSetterActivities,
-		TerraformTaskQueue:         config.TemporalCfg.TerraformTaskQueue,
-		RevisionSetterConfig:       config.RevisionSetter,
-		AuditActivity:              auditActivity,
 		PRRevisionGithubActivities: prRevisionGithubActivities,
 	}
 	return &server, nil
@@ -279,8 +310,22 @@ func (s Server) Start() error {
 		})
 		prWorker.RegisterActivity(s.GithubActivities)
 		prWorker.RegisterActivity(s.TerraformActivities)
-		prWorker.RegisterWorkflow(workflows.PR)
+		prWorker.RegisterActivity(s.AuditPluginActivities)
+		prWorker.RegisterWorkflowWithOptions(workflows.GetPRWithPlugins(
+			func(ctx workflow.Context, request workflows.PRRequest) (plugins.PR, error) {
+				var a *audit.Activities
+
+				return plugins.PR{
+					Notifiers: []plugins.TerraformWorkflowNotifier{
+						audit.NewNotifier(a),
+					},
+				}, nil
+			},
+		), workflow.RegisterOptions{
+			Name: workflows.PRWorkflowName,
+		})
 		prWorker.RegisterWorkflow(workflows.Terraform)
+		prWorker.RegisterWorkflow(workflows.PRPlanLimiter)
 		if err := prWorker.Run(worker.InterruptCh()); err != nil {
 			log.Fatalln("unable to start pr worker", err)
 		}
@@ -408,16 +453,22 @@ func (s Server) buildDeployWorker() worker.Worker {
 	deployWorker.RegisterActivity(s.DeployActivities)
 	deployWorker.RegisterActivity(s.GithubActivities)
 	deployWorker.RegisterActivity(s.AuditActivity)
+	deployWorker.RegisterActivity(s.AuditPluginActivities)
+	deployWorker.RegisterActivity(s.WebhookPluginActivities)
 	deployWorker.RegisterActivity(s.TerraformActivities)
 	deployWorker.RegisterWorkflowWithOptions(workflows.GetDeployWithPlugins(
 		func(ctx workflow.Context, dr workflows.DeployRequest) (plugins.Deploy, error) {
 			var a *lyftActivities.Audit
+			var auditActivities *audit.Activities
+			var webhookActivities *webhook.Activities
 
 			return plugins.Deploy{
 				Notifiers: []plugins.TerraformWorkflowNotifier{
 					&notifier.SNSNotifier{
 						Activity: a,
 					},
+					audit.NewNotifier(auditActivities),
+					webhook.NewNotifier(webhookActivities),
 				},
 				PostDeployExecutors: []plugins.PostDeployExecutor{
 					&executor.PRRevisionWorkflowExecutor{TaskQueue: lyftWorkflows.PRRevisionTaskQueue},
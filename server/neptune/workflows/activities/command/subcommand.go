@@ -19,6 +19,7 @@ const (
 
 	// Conftest operations
 	ConftestTest Operation = "test"
+	ConftestPull Operation = "pull"
 )
 
 // Argument is the key value pair passed into the command
@@ -4,9 +4,17 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
+	"unicode"
 
+	"github.com/docker/docker/pkg/fileutils"
 	"github.com/hashicorp/go-version"
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config/valid"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/command"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/temporal"
 	"go.temporal.io/sdk/activity"
@@ -29,7 +37,9 @@ type conftestActivity struct {
 	ConftestClient         asyncClient
 	StreamHandler          streamer
 	Policies               []PolicySet
+	Exceptions             []PolicyException
 	FileValidator          fileValidator
+	PolicyBundles          policyBundleCache
 }
 
 type ConftestRequest struct {
@@ -38,6 +48,20 @@ type ConftestRequest struct {
 	JobID       string
 	Path        string
 	ShowFile    string
+	// Target is matched against the scope of each policy set to determine which ones run
+	Target PolicyTarget
+}
+
+// PolicyTarget describes the root being validated
+type PolicyTarget struct {
+	Repo string
+	Root string
+	// Path of the root relative to the repo
+	Path string
+	Tags map[string]string
+	// Untrusted is set when the root, path and tags come from the PR rather than the default branch,
+	// every policy set scoped to the repo applies since the PR's author controls them.
+	Untrusted bool
 }
 
 type ValidationStatus int
@@ -45,12 +69,22 @@ type ValidationStatus int
 const (
 	Success ValidationStatus = iota
 	Fail
+	// Skipped policy sets don't apply to the root
+	Skipped
+	// Excepted policy sets failed but are bypassed by a policy exception
+	Excepted
 	//todo: support warn status
 )
 
 type ValidationResult struct {
 	Status    ValidationStatus
 	PolicySet PolicySet
+	// Reason the policy set was skipped
+	Reason string
+	// Exception bypassing the failure of an excepted policy set
+	Exception *PolicyException
+	// PolicyVersion is the version of the remote bundle the policy set was run from, empty for local policy sets
+	PolicyVersion string
 }
 
 type ConftestResponse struct {
@@ -78,15 +112,29 @@ func (c *conftestActivity) Conftest(ctx context.Context, request ConftestRequest
 
 	// run each policy separately to track which pass and fail
 	for _, policy := range c.Policies {
+		if reason, ok := policy.AppliesTo.Match(request.Target); !ok {
+			validationResults = append(validationResults, ValidationResult{
+				Status:    Skipped,
+				PolicySet: policy,
+				Reason:    reason,
+			})
+			continue
+		}
+
+		policyPaths, err := c.resolvePaths(ctx, policy)
+		if err != nil {
+			return ConftestResponse{}, errors.Wrapf(err, "fetching policy bundle for %s", policy.Name)
+		}
+
 		// add paths as arguments
 		var policyArgs []command.Argument
-		for _, path := range policy.Paths {
+		for _, path := range policyPaths {
 			policyArgs = append(policyArgs, command.Argument{
 				Key:   "p",
 				Value: path,
 			})
 		}
-		policyNames = append(policyNames, policy.Name)
+		policyNames = append(policyNames, describePolicy(policy))
 		args := append(policyArgs, request.Args...)
 		conftestRequest := &command.RunCommandRequest{
 			RootPath:          request.Path,
@@ -95,17 +143,28 @@ func (c *conftestActivity) Conftest(ctx context.Context, request ConftestRequest
 			Version:           c.DefaultConftestVersion,
 		}
 		cmdOutput, cmdErr := c.runCommand(ctx, conftestRequest)
+		exception := c.findException(policy, request.Target, cmdOutput, time.Now())
 		// Continue running other policies if one fails since it might not be the only failing one
-		if cmdErr != nil {
+		if cmdErr != nil && exception != nil {
+			validationResults = append(validationResults, ValidationResult{
+				Status:        Excepted,
+				PolicySet:     policy,
+				PolicyVersion: policy.Version(),
+				Exception:     exception,
+			})
+			cmdOutput += fmt.Sprintf("\nFailures are bypassed by an exception owned by %s until %s: %s\n", exception.Owner, exception.Expiry.Format(valid.PolicyExceptionExpiryFormat), exception.Justification)
+		} else if cmdErr != nil {
 			activity.GetLogger(ctx).Error(cmdOutput)
 			validationResults = append(validationResults, ValidationResult{
-				Status:    Fail,
-				PolicySet: policy,
+				Status:        Fail,
+				PolicySet:     policy,
+				PolicyVersion: policy.Version(),
 			})
 		} else {
 			validationResults = append(validationResults, ValidationResult{
-				Status:    Success,
-				PolicySet: policy,
+				Status:        Success,
+				PolicySet:     policy,
+				PolicyVersion: policy.Version(),
 			})
 		}
 		totalCmdOutput = append(totalCmdOutput, c.processOutput(cmdOutput, policy, cmdErr))
@@ -116,6 +175,36 @@ func (c *conftestActivity) Conftest(ctx context.Context, request ConftestRequest
 	return ConftestResponse{ValidationResults: validationResults}, nil
 }
 
+// resolvePaths returns the local paths of the policy set, fetching its bundle if it's remote
+func (c *conftestActivity) resolvePaths(ctx context.Context, policy PolicySet) ([]string, error) {
+	if policy.Source == nil {
+		return policy.Paths, nil
+	}
+
+	bundleDir, err := c.PolicyBundles.Get(ctx, *policy.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(policy.Paths) == 0 {
+		return []string{bundleDir}, nil
+	}
+
+	var paths []string
+	for _, p := range policy.Paths {
+		paths = append(paths, filepath.Join(bundleDir, p))
+	}
+	return paths, nil
+}
+
+// describePolicy names the policy set along with the version of its bundle for auditability
+func describePolicy(policy PolicySet) string {
+	if policy.Source == nil {
+		return policy.Name
+	}
+	return fmt.Sprintf("%s@%s (%s)", policy.Name, policy.Source.Version, policy.Source)
+}
+
 func (c *conftestActivity) runCommand(ctx context.Context, request *command.RunCommandRequest) (string, error) {
 	buf := &bytes.Buffer{}
 	err := c.ConftestClient.RunCommand(ctx, request, command.RunOptions{
@@ -142,9 +231,112 @@ func (c *conftestActivity) sanitizeOutput(inputFile string, output string) strin
 }
 
 func (c *conftestActivity) processOutput(output string, policySet PolicySet, err error) string {
+	name := policySet.Name
+	if v := policySet.Version(); v != "" {
+		name += "@" + v
+	}
 	// errored results need an extra newline
 	if err != nil {
-		return policySet.Name + ":\n" + output
+		return name + ":\n" + output
+	}
+	return name + ":" + output
+}
+
+// Match returns whether the policy set applies to the target, and the reason if it doesn't
+func (s PolicySetScope) Match(target PolicyTarget) (string, bool) {
+	if len(s.Repos) > 0 && !matchesAnyGlob(s.Repos, target.Repo) {
+		return fmt.Sprintf("repo %s is out of scope", target.Repo), false
+	}
+
+	if target.Untrusted {
+		return "", true
+	}
+
+	if len(s.Roots) > 0 && !matchesAnyGlob(s.Roots, target.Root) {
+		return fmt.Sprintf("root %s is out of scope", target.Root), false
+	}
+
+	if len(s.Paths) > 0 {
+		pm, err := fileutils.NewPatternMatcher(s.Paths)
+		if err != nil {
+			return fmt.Sprintf("invalid path patterns: %s", err), false
+		}
+		if match, err := pm.Matches(target.Path); err != nil || !match {
+			return fmt.Sprintf("path %s is out of scope", target.Path), false
+		}
+	}
+
+	// sort keys to keep the reason stable
+	keys := make([]string, 0, len(s.Tags))
+	for k := range s.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if tag, ok := target.Tags[k]; !ok || tag != s.Tags[k] {
+			return fmt.Sprintf("root is not tagged %s=%s", k, s.Tags[k]), false
+		}
+	}
+
+	return "", true
+}
+
+// findException returns the first unexpired exception covering the failures of the policy set for the target, nil if none
+func (c *conftestActivity) findException(policy PolicySet, target PolicyTarget, output string, now time.Time) *PolicyException {
+	// the root of untrusted targets comes from the PR, which could otherwise name it after an exception
+	if target.Untrusted {
+		return nil
+	}
+	for _, e := range c.Exceptions {
+		if e.PolicySet != policy.Name || valid.PolicyExceptionExpired(e.Expiry, now) {
+			continue
+		}
+		if !matchesAnyGlob([]string{e.Repo}, target.Repo) || !matchesAnyGlob([]string{e.Root}, target.Root) {
+			continue
+		}
+		if e.Resource != "" && !failuresMention(output, e.Resource) {
+			continue
+		}
+		exception := e
+		return &exception
+	}
+	return nil
+}
+
+// failuresMention returns true if every failure in the conftest output mentions a resource address
+// matching the pattern, addresses are expected to be separated by whitespace or quotes
+func failuresMention(output string, pattern string) bool {
+	var failures int
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "FAIL") {
+			continue
+		}
+		failures++
+
+		tokens := strings.FieldsFunc(line, func(r rune) bool {
+			return unicode.IsSpace(r) || strings.ContainsRune("\"'`,;()", r)
+		})
+		if !matchesAnyToken(pattern, tokens) {
+			return false
+		}
+	}
+	return failures > 0
+}
+
+func matchesAnyToken(pattern string, tokens []string) bool {
+	for _, token := range tokens {
+		if match, _ := path.Match(pattern, token); match {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyGlob(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if match, _ := path.Match(pattern, name); match {
+			return true
+		}
 	}
-	return policySet.Name + ":" + output
+	return false
 }
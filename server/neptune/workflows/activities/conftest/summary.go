@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/runatlantis/atlantis/server/config/valid"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
 )
 
 type ValidateSummary struct {
 	Failures  []string
 	Successes []string
+	// Skipped policies and why they don't apply to the root
+	Skipped []string
+	// Excepted policies and the exceptions bypassing their failures
+	Excepted []string
 }
 
 func NewValidateSummaryFromResults(results []activities.ValidationResult) ValidateSummary {
@@ -19,11 +24,23 @@ func NewValidateSummaryFromResults(results []activities.ValidationResult) Valida
 
 	var failures []string
 	var successes []string
+	var skipped []string
+	var excepted []string
 	for _, result := range results {
+		// remote policy sets are cited along with the version of the bundle they were run from
 		summary := result.PolicySet.Name
-		if result.Status == activities.Success {
+		if result.PolicyVersion != "" {
+			summary += "@" + result.PolicyVersion
+		}
+		switch result.Status {
+		case activities.Success:
 			successes = append(successes, summary)
-		} else {
+		case activities.Skipped:
+			skipped = append(skipped, fmt.Sprintf("%s (%s)", summary, result.Reason))
+		case activities.Excepted:
+			e := result.Exception
+			excepted = append(excepted, fmt.Sprintf("%s (excepted by %s until %s: %s)", summary, e.Owner, e.Expiry.Format(valid.PolicyExceptionExpiryFormat), e.Justification))
+		default:
 			failures = append(failures, summary)
 		}
 	}
@@ -31,11 +48,13 @@ func NewValidateSummaryFromResults(results []activities.ValidationResult) Valida
 	return ValidateSummary{
 		Failures:  failures,
 		Successes: successes,
+		Skipped:   skipped,
+		Excepted:  excepted,
 	}
 }
 
 func (s ValidateSummary) IsEmpty() bool {
-	return len(s.Successes) == 0 && len(s.Failures) == 0
+	return len(s.Successes) == 0 && len(s.Failures) == 0 && len(s.Skipped) == 0 && len(s.Excepted) == 0
 }
 
 func (s ValidateSummary) String() string {
@@ -51,6 +70,13 @@ func (s ValidateSummary) String() string {
 		failures = "None"
 	}
 
-	return fmt.Sprintf(
+	summary := fmt.Sprintf(
 		"Successful policies: %s`\n\n`Failing policies: %s", successes, failures)
+	if len(s.Skipped) > 0 {
+		summary += fmt.Sprintf("`\n\n`Skipped policies: %s", strings.Join(s.Skipped, ", "))
+	}
+	if len(s.Excepted) > 0 {
+		summary += fmt.Sprintf("`\n\n`Excepted policies: %s", strings.Join(s.Excepted, ", "))
+	}
+	return summary
 }
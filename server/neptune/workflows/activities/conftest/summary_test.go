@@ -2,6 +2,7 @@ package conftest
 
 import (
 	"testing"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
 	"gopkg.in/go-playground/assert.v1"
@@ -19,12 +20,33 @@ func TestNewValidateSummaryFromResults(t *testing.T) {
 			PolicySet: activities.PolicySet{
 				Name: "policy2",
 			},
-			Status: activities.Success,
+			Status:        activities.Success,
+			PolicyVersion: "v1.2.0",
+		},
+		{
+			PolicySet: activities.PolicySet{
+				Name: "policy3",
+			},
+			Status: activities.Skipped,
+			Reason: "root is not tagged env=prod",
+		},
+		{
+			PolicySet: activities.PolicySet{
+				Name: "policy4",
+			},
+			Status: activities.Excepted,
+			Exception: &activities.PolicyException{
+				Owner:         "infra",
+				Justification: "legacy buckets",
+				Expiry:        time.Date(2022, time.June, 15, 0, 0, 0, 0, time.UTC),
+			},
 		},
 	}
 	summary := NewValidateSummaryFromResults(testResults)
 	assert.Equal(t, summary.Failures, []string{"policy1"})
-	assert.Equal(t, summary.Successes, []string{"policy2"})
+	assert.Equal(t, summary.Successes, []string{"policy2@v1.2.0"})
+	assert.Equal(t, summary.Skipped, []string{"policy3 (root is not tagged env=prod)"})
+	assert.Equal(t, summary.Excepted, []string{"policy4 (excepted by infra until 2022-06-15: legacy buckets)"})
 }
 
 func TestValidateSummary_IsEmpty(t *testing.T) {
@@ -50,4 +72,14 @@ func TestValidateSummary_String(t *testing.T) {
 		Successes: []string{"policy2"},
 	}
 	assert.Equal(t, summary.String(), "Successful policies: policy2`\n\n`Failing policies: policy1")
+	summary = ValidateSummary{
+		Successes: []string{"policy2"},
+		Skipped:   []string{"policy3 (root app is out of scope)"},
+	}
+	assert.Equal(t, summary.String(), "Successful policies: policy2`\n\n`Failing policies: None`\n\n`Skipped policies: policy3 (root app is out of scope)")
+	summary = ValidateSummary{
+		Failures: []string{"policy1"},
+		Excepted: []string{"policy4 (excepted by infra until 2022-06-15: legacy buckets)"},
+	}
+	assert.Equal(t, summary.String(), "Successful policies: None`\n\n`Failing policies: policy1`\n\n`Excepted policies: policy4 (excepted by infra until 2022-06-15: legacy buckets)")
 }
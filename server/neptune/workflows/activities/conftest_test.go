@@ -1,8 +1,10 @@
 package activities
 
 import (
+	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/command"
@@ -205,3 +207,299 @@ func (s *mockStat) Stat(name string) error {
 	assert.Equal(s.t, s.expectedName, name)
 	return s.error
 }
+
+func TestConftest_SkipsOutOfScopePolicies(t *testing.T) {
+	version, err := version.NewVersion("0.20.0")
+	assert.Nil(t, err)
+
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestActivityEnvironment()
+
+	path := "some/path"
+	jobID := "1234"
+
+	// only the in scope policy set is run
+	testClient := &testTfClient{
+		t:             t,
+		jobID:         jobID,
+		path:          path,
+		cmd:           command.NewSubCommand(command.ConftestTest).WithArgs(command.Argument{Key: "p", Value: "path/one"}).WithInput("some/path/output.json").WithFlags(NoColorFlag),
+		customEnvVars: map[string]string{},
+		version:       version,
+	}
+
+	activity := conftestActivity{
+		DefaultConftestVersion: version,
+		ConftestClient:         testClient,
+		StreamHandler:          &testStreamHandler{t: t},
+		Policies: []PolicySet{
+			{
+				Name:      "policy1",
+				Paths:     []string{"path/one"},
+				AppliesTo: PolicySetScope{Tags: map[string]string{"env": "prod"}},
+			},
+			{
+				Name:      "policy2",
+				Paths:     []string{"path/two"},
+				AppliesTo: PolicySetScope{Tags: map[string]string{"env": "staging"}},
+			},
+		},
+		FileValidator: &mockStat{t: t, expectedName: "some/path/output.json"},
+	}
+	env.RegisterActivity(activity.Conftest)
+
+	result, err := env.ExecuteActivity(activity.Conftest, ConftestRequest{
+		JobID:    jobID,
+		Path:     path,
+		ShowFile: "some/path/output.json",
+		Target: PolicyTarget{
+			Repo: "owner/repo",
+			Root: "app",
+			Path: path,
+			Tags: map[string]string{"env": "prod"},
+		},
+	})
+	assert.NoError(t, err)
+
+	var resp ConftestResponse
+	assert.NoError(t, result.Get(&resp))
+	assert.Equal(t, []ValidationResult{
+		{
+			Status:    Success,
+			PolicySet: activity.Policies[0],
+		},
+		{
+			Status:    Skipped,
+			PolicySet: activity.Policies[1],
+			Reason:    "root is not tagged env=staging",
+		},
+	}, resp.ValidationResults)
+}
+
+func TestPolicySetScope_Match(t *testing.T) {
+	target := PolicyTarget{
+		Repo: "owner/repo",
+		Root: "aws-app",
+		Path: "infra/aws/app",
+		Tags: map[string]string{"env": "prod"},
+	}
+
+	cases := []struct {
+		description string
+		scope       PolicySetScope
+		reason      string
+	}{
+		{
+			description: "empty scope",
+		},
+		{
+			description: "matching scope",
+			scope: PolicySetScope{
+				Repos: []string{"other/repo", "owner/*"},
+				Roots: []string{"aws-*"},
+				Paths: []string{"infra/aws"},
+				Tags:  map[string]string{"env": "prod"},
+			},
+		},
+		{
+			description: "repo out of scope",
+			scope:       PolicySetScope{Repos: []string{"other/*"}},
+			reason:      "repo owner/repo is out of scope",
+		},
+		{
+			description: "root out of scope",
+			scope:       PolicySetScope{Roots: []string{"gcp-*"}},
+			reason:      "root aws-app is out of scope",
+		},
+		{
+			description: "path out of scope",
+			scope:       PolicySetScope{Paths: []string{"infra/gcp/**"}},
+			reason:      "path infra/aws/app is out of scope",
+		},
+		{
+			description: "missing tag",
+			scope:       PolicySetScope{Tags: map[string]string{"team": "infra"}},
+			reason:      "root is not tagged team=infra",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			reason, ok := c.scope.Match(target)
+			assert.Equal(t, c.reason == "", ok)
+			assert.Equal(t, c.reason, reason)
+		})
+	}
+
+	t.Run("untrusted target", func(t *testing.T) {
+		untrusted := PolicyTarget{
+			Repo:      "owner/repo",
+			Root:      "scratch",
+			Path:      "scratch",
+			Untrusted: true,
+		}
+
+		_, ok := PolicySetScope{Roots: []string{"aws-*"}, Tags: map[string]string{"env": "prod"}}.Match(untrusted)
+		assert.True(t, ok)
+
+		reason, ok := PolicySetScope{Repos: []string{"other/*"}}.Match(untrusted)
+		assert.False(t, ok)
+		assert.Equal(t, "repo owner/repo is out of scope", reason)
+	})
+}
+
+func TestConftest_FindException(t *testing.T) {
+	now := time.Date(2022, time.June, 15, 12, 0, 0, 0, time.UTC)
+	target := PolicyTarget{
+		Repo: "owner/repo",
+		Root: "app",
+	}
+	output := `FAIL - output.json - main - aws_s3_bucket.logs is public
+FAIL - output.json - main - aws_s3_bucket.assets is public
+
+2 tests, 0 passed, 0 warnings, 2 failures, 0 exceptions`
+
+	exception := PolicyException{
+		PolicySet:     "policy1",
+		Repo:          "owner/*",
+		Root:          "*",
+		Justification: "legacy buckets",
+		Owner:         "infra",
+		Expiry:        time.Date(2022, time.June, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	cases := []struct {
+		description string
+		exception   func(e PolicyException) PolicyException
+		untrusted   bool
+		excepted    bool
+	}{
+		{
+			description: "matching exception",
+			exception:   func(e PolicyException) PolicyException { return e },
+			excepted:    true,
+		},
+		{
+			description: "other policy set",
+			exception: func(e PolicyException) PolicyException {
+				e.PolicySet = "policy2"
+				return e
+			},
+		},
+		{
+			description: "other root",
+			exception: func(e PolicyException) PolicyException {
+				e.Root = "other"
+				return e
+			},
+		},
+		{
+			description: "expired",
+			exception: func(e PolicyException) PolicyException {
+				e.Expiry = now.AddDate(0, 0, -1)
+				return e
+			},
+		},
+		{
+			description: "resource matching every failure",
+			exception: func(e PolicyException) PolicyException {
+				e.Resource = "aws_s3_bucket.*"
+				return e
+			},
+			excepted: true,
+		},
+		{
+			description: "untrusted target",
+			exception:   func(e PolicyException) PolicyException { return e },
+			untrusted:   true,
+		},
+		{
+			description: "resource matching some failures",
+			exception: func(e PolicyException) PolicyException {
+				e.Resource = "aws_s3_bucket.logs"
+				return e
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			activity := conftestActivity{
+				Exceptions: []PolicyException{c.exception(exception)},
+			}
+			target := target
+			target.Untrusted = c.untrusted
+			result := activity.findException(PolicySet{Name: "policy1"}, target, output, now)
+			if c.excepted {
+				assert.Equal(t, &activity.Exceptions[0], result)
+			} else {
+				assert.Nil(t, result)
+			}
+		})
+	}
+}
+
+type testBundleCache struct {
+	t      *testing.T
+	source PolicySource
+	dir    string
+}
+
+func (c *testBundleCache) Get(ctx context.Context, source PolicySource) (string, error) {
+	assert.Equal(c.t, c.source, source)
+	return c.dir, nil
+}
+
+func TestConftest_RemotePolicies(t *testing.T) {
+	version, err := version.NewVersion("0.20.0")
+	assert.Nil(t, err)
+
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestActivityEnvironment()
+
+	path := "some/path"
+	jobID := "1234"
+	source := PolicySource{Type: "oci", URL: "ghcr.io/owner/policies", Version: "1.2.0"}
+
+	testClient := &testTfClient{
+		t:             t,
+		jobID:         jobID,
+		path:          path,
+		cmd:           command.NewSubCommand(command.ConftestTest).WithArgs(command.Argument{Key: "p", Value: "/bundles/policies/aws"}).WithInput("some/path/output.json").WithFlags(NoColorFlag),
+		customEnvVars: map[string]string{},
+		version:       version,
+	}
+
+	activity := conftestActivity{
+		DefaultConftestVersion: version,
+		ConftestClient:         testClient,
+		StreamHandler:          &testStreamHandler{t: t},
+		Policies: []PolicySet{
+			{
+				Name:   "policy1",
+				Paths:  []string{"aws"},
+				Source: &source,
+			},
+		},
+		FileValidator: &mockStat{t: t, expectedName: "some/path/output.json"},
+		PolicyBundles: &testBundleCache{t: t, source: source, dir: "/bundles/policies"},
+	}
+	env.RegisterActivity(activity.Conftest)
+
+	result, err := env.ExecuteActivity(activity.Conftest, ConftestRequest{
+		JobID:    jobID,
+		Path:     path,
+		ShowFile: "some/path/output.json",
+	})
+	assert.NoError(t, err)
+
+	var resp ConftestResponse
+	assert.NoError(t, result.Get(&resp))
+	assert.Equal(t, []ValidationResult{
+		{
+			Status:        Success,
+			PolicySet:     activity.Policies[0],
+			PolicyVersion: "1.2.0",
+		},
+	}, resp.ValidationResults)
+}
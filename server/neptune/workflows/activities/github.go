@@ -29,16 +29,18 @@ var HashiGetter = func(ctx context.Context, dst, src string) error {
 type gogetter func(ctx context.Context, dst, src string) error
 
 type githubClient interface { //nolint:interfacebloat
-	CreateCheckRun(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error)
-	UpdateCheckRun(ctx context.Context, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error)
-	GetArchiveLink(ctx context.Context, owner, repo string, archiveformat github.ArchiveFormat, opts *github.RepositoryContentGetOptions, followRedirects bool) (*url.URL, *github.Response, error)
-	CompareCommits(ctx context.Context, owner, repo string, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error)
-	ListReviews(ctx context.Context, owner string, repo string, number int) ([]*github.PullRequestReview, error)
-	GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error)
-	ListCommits(ctx context.Context, owner string, repo string, number int) ([]*github.RepositoryCommit, error)
-	DismissReview(ctx context.Context, owner, repo string, number int, reviewID int64, review *github.PullRequestReviewDismissalRequest) (*github.PullRequestReview, *github.Response, error)
-	ListTeamMembers(ctx context.Context, org string, teamSlug string) ([]*github.User, error)
-	CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	CreateCheckRun(ctx context.Context, installationToken int64, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error)
+	UpdateCheckRun(ctx context.Context, installationToken int64, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error)
+	GetArchiveLink(ctx context.Context, installationToken int64, owner, repo string, archiveformat github.ArchiveFormat, opts *github.RepositoryContentGetOptions, followRedirects bool) (*url.URL, *github.Response, error)
+	CompareCommits(ctx context.Context, installationToken int64, owner, repo string, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error)
+	ListReviews(ctx context.Context, installationToken int64, owner string, repo string, number int) ([]*github.PullRequestReview, error)
+	GetPullRequest(ctx context.Context, installationToken int64, owner, repo string, number int) (*github.PullRequest, *github.Response, error)
+	ListCommits(ctx context.Context, installationToken int64, owner string, repo string, number int) ([]*github.RepositoryCommit, error)
+	ListPullRequestsWithCommit(ctx context.Context, installationToken int64, owner string, repo string, sha string) ([]*github.PullRequest, error)
+	DismissReview(ctx context.Context, installationToken int64, owner, repo string, number int, reviewID int64, review *github.PullRequestReviewDismissalRequest) (*github.PullRequestReview, *github.Response, error)
+	ListTeamMembers(ctx context.Context, installationToken int64, org string, teamSlug string) ([]*github.User, error)
+	CreateComment(ctx context.Context, installationToken int64, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	EditComment(ctx context.Context, installationToken int64, owner string, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
 }
 
 type DiffDirection string
@@ -60,6 +62,8 @@ type githubActivities struct {
 	LinkBuilder LinkBuilder
 	Getter      gogetter
 	Allocator   feature.Allocator
+	// TeamMembers caches team memberships, every request lists the team from github if nil
+	TeamMembers *teamMembershipCache
 }
 
 type CreateCheckRunRequest struct {
@@ -96,7 +100,9 @@ type UpdateCheckRunResponse struct {
 
 func (a *githubActivities) GithubUpdateCheckRun(ctx context.Context, request UpdateCheckRunRequest) (UpdateCheckRunResponse, error) {
 	shouldAllocate, err := a.Allocator.ShouldAllocate(feature.LegacyDeprecation, feature.FeatureContext{
-		RepoName: request.Repo.GetFullName(),
+		RepoName:     request.Repo.GetFullName(),
+		Org:          request.Repo.Owner,
+		WorkflowMode: request.Mode.String(),
 	})
 	if err != nil {
 		activity.GetLogger(ctx).Error("unable to allocate legacy deprecation feature flag", key.ErrKey, err)
@@ -137,6 +143,7 @@ func (a *githubActivities) GithubUpdateCheckRun(ctx context.Context, request Upd
 
 	run, _, err := a.Client.UpdateCheckRun(
 		ctx,
+		request.Repo.Credentials.InstallationToken,
 		request.Repo.Owner, request.Repo.Name, request.ID, opts,
 	)
 
@@ -152,7 +159,9 @@ func (a *githubActivities) GithubUpdateCheckRun(ctx context.Context, request Upd
 
 func (a *githubActivities) GithubCreateCheckRun(ctx context.Context, request CreateCheckRunRequest) (CreateCheckRunResponse, error) {
 	shouldAllocate, err := a.Allocator.ShouldAllocate(feature.LegacyDeprecation, feature.FeatureContext{
-		RepoName: request.Repo.GetFullName(),
+		RepoName:     request.Repo.GetFullName(),
+		Org:          request.Repo.Owner,
+		WorkflowMode: request.Mode.String(),
 	})
 	if err != nil {
 		activity.GetLogger(ctx).Error("unable to allocate legacy deprecation feature flag", key.ErrKey, err)
@@ -195,6 +204,7 @@ func (a *githubActivities) GithubCreateCheckRun(ctx context.Context, request Cre
 
 	run, _, err := a.Client.CreateCheckRun(
 		ctx,
+		request.Repo.Credentials.InstallationToken,
 		request.Repo.Owner, request.Repo.Name, opts,
 	)
 
@@ -274,7 +284,7 @@ func (a *githubActivities) GithubFetchRoot(ctx context.Context, request FetchRoo
 		Ref: request.Revision,
 	}
 	// note: this link exists for 5 minutes when fetching a private repository archive
-	archiveLink, resp, err := a.Client.GetArchiveLink(ctx, request.Repo.Owner, request.Repo.Name, github.Zipball, opts, true)
+	archiveLink, resp, err := a.Client.GetArchiveLink(ctx, request.Repo.Credentials.InstallationToken, request.Repo.Owner, request.Repo.Name, github.Zipball, opts, true)
 	if err != nil {
 		return FetchRootResponse{}, errors.Wrap(err, "getting repo archive link")
 	}
@@ -311,10 +321,12 @@ type CompareCommitRequest struct {
 
 type CompareCommitResponse struct {
 	CommitComparison DiffDirection
+	// MergeBaseRevision is the best common ancestor of both revisions
+	MergeBaseRevision string
 }
 
 func (a *githubActivities) GithubCompareCommit(ctx context.Context, request CompareCommitRequest) (CompareCommitResponse, error) {
-	comparison, resp, err := a.Client.CompareCommits(ctx, request.Repo.Owner, request.Repo.Name, request.LatestDeployedRevision, request.DeployRequestRevision, &github.ListOptions{})
+	comparison, resp, err := a.Client.CompareCommits(ctx, request.Repo.Credentials.InstallationToken, request.Repo.Owner, request.Repo.Name, request.LatestDeployedRevision, request.DeployRequestRevision, &github.ListOptions{})
 
 	if err != nil {
 		return CompareCommitResponse{}, errors.Wrap(err, "comparing commits")
@@ -325,7 +337,8 @@ func (a *githubActivities) GithubCompareCommit(ctx context.Context, request Comp
 	}
 
 	return CompareCommitResponse{
-		CommitComparison: DiffDirection(comparison.GetStatus()),
+		CommitComparison:  DiffDirection(comparison.GetStatus()),
+		MergeBaseRevision: comparison.GetMergeBaseCommit().GetSHA(),
 	}, nil
 }
 
@@ -341,6 +354,7 @@ type GetPullRequestStateResponse struct {
 func (a *githubActivities) GithubGetPullRequestState(ctx context.Context, request GetPullRequestStateRequest) (GetPullRequestStateResponse, error) {
 	resp, _, err := a.Client.GetPullRequest(
 		ctx,
+		request.Repo.Credentials.InstallationToken,
 		request.Repo.Owner,
 		request.Repo.Name,
 		request.PRNumber,
@@ -365,6 +379,7 @@ type ListPRReviewsResponse struct {
 func (a *githubActivities) GithubListPRReviews(ctx context.Context, request ListPRReviewsRequest) (ListPRReviewsResponse, error) {
 	reviews, err := a.Client.ListReviews(
 		ctx,
+		request.Repo.Credentials.InstallationToken,
 		request.Repo.Owner,
 		request.Repo.Name,
 		request.PRNumber,
@@ -389,6 +404,7 @@ type ListPRCommitsResponse struct {
 func (a *githubActivities) GithubListPRCommits(ctx context.Context, request ListPRCommitsRequest) (ListPRCommitsResponse, error) {
 	commits, err := a.Client.ListCommits(
 		ctx,
+		request.Repo.Credentials.InstallationToken,
 		request.Repo.Owner,
 		request.Repo.Name,
 		request.PRNumber,
@@ -401,6 +417,37 @@ func (a *githubActivities) GithubListPRCommits(ctx context.Context, request List
 	}, nil
 }
 
+type GetMergedPullRequestHeadRequest struct {
+	Repo     internal.Repo
+	Revision string
+}
+
+type GetMergedPullRequestHeadResponse struct {
+	// HeadRevision is the last revision of the PR merged as the requested revision, empty if there is none
+	HeadRevision string
+}
+
+func (a *githubActivities) GithubGetMergedPullRequestHead(ctx context.Context, request GetMergedPullRequestHeadRequest) (GetMergedPullRequestHeadResponse, error) {
+	prs, err := a.Client.ListPullRequestsWithCommit(
+		ctx,
+		request.Repo.Credentials.InstallationToken,
+		request.Repo.Owner,
+		request.Repo.Name,
+		request.Revision,
+	)
+	if err != nil {
+		return GetMergedPullRequestHeadResponse{}, errors.Wrap(err, "listing prs of commit")
+	}
+	for _, pr := range prs {
+		if pr.GetMerged() && pr.GetMergeCommitSHA() == request.Revision {
+			return GetMergedPullRequestHeadResponse{
+				HeadRevision: pr.GetHead().GetSHA(),
+			}, nil
+		}
+	}
+	return GetMergedPullRequestHeadResponse{}, nil
+}
+
 type DismissRequest struct {
 	Repo          internal.Repo
 	PRNumber      int
@@ -412,7 +459,9 @@ type DismissResponse struct{}
 
 func (a *githubActivities) GithubDismiss(ctx context.Context, request DismissRequest) (DismissResponse, error) {
 	shouldAllocate, err := a.Allocator.ShouldAllocate(feature.LegacyDeprecation, feature.FeatureContext{
-		RepoName: request.Repo.GetFullName(),
+		RepoName:     request.Repo.GetFullName(),
+		Org:          request.Repo.Owner,
+		WorkflowMode: terraform.PR.String(),
 	})
 	if err != nil {
 		return DismissResponse{}, errors.Wrap(err, "unable to allocate legacy deprecation feature flag")
@@ -426,6 +475,7 @@ func (a *githubActivities) GithubDismiss(ctx context.Context, request DismissReq
 	}
 	_, _, err = a.Client.DismissReview(
 		ctx,
+		request.Repo.Credentials.InstallationToken,
 		request.Repo.Owner,
 		request.Repo.Name,
 		request.PRNumber,
@@ -442,6 +492,8 @@ type ListTeamMembersRequest struct {
 	Repo     internal.Repo
 	Org      string
 	TeamSlug string
+	// Refresh bypasses the cached membership, it should be set once the team is known to have changed
+	Refresh bool
 }
 
 type ListTeamMembersResponse struct {
@@ -449,8 +501,17 @@ type ListTeamMembersResponse struct {
 }
 
 func (a *githubActivities) GithubListTeamMembers(ctx context.Context, request ListTeamMembersRequest) (ListTeamMembersResponse, error) {
+	if a.TeamMembers != nil && !request.Refresh {
+		if members, ok := a.TeamMembers.Get(request.Org, request.TeamSlug); ok {
+			return ListTeamMembersResponse{
+				Members: members,
+			}, nil
+		}
+	}
+
 	users, err := a.Client.ListTeamMembers(
 		ctx,
+		request.Repo.Credentials.InstallationToken,
 		request.Org,
 		request.TeamSlug,
 	)
@@ -461,6 +522,9 @@ func (a *githubActivities) GithubListTeamMembers(ctx context.Context, request Li
 	for _, user := range users {
 		members = append(members, user.GetLogin())
 	}
+	if a.TeamMembers != nil {
+		a.TeamMembers.Set(request.Org, request.TeamSlug, members)
+	}
 	return ListTeamMembersResponse{
 		Members: members,
 	}, nil
@@ -472,14 +536,17 @@ type CreateCommentRequest struct {
 	CommentBody string
 }
 
-type CreateCommentResponse struct{}
+type CreateCommentResponse struct {
+	CommentID int64
+}
 
 func (a *githubActivities) GithubCreateComment(ctx context.Context, request CreateCommentRequest) (CreateCommentResponse, error) {
 	comment := &github.IssueComment{
 		Body: github.String(request.CommentBody),
 	}
-	_, _, err := a.Client.CreateComment(
+	created, _, err := a.Client.CreateComment(
 		ctx,
+		request.Repo.Credentials.InstallationToken,
 		request.Repo.Owner,
 		request.Repo.Name,
 		request.PRNumber,
@@ -488,5 +555,33 @@ func (a *githubActivities) GithubCreateComment(ctx context.Context, request Crea
 	if err != nil {
 		return CreateCommentResponse{}, errors.Wrap(err, "creating comment on PR")
 	}
-	return CreateCommentResponse{}, nil
+	return CreateCommentResponse{
+		CommentID: created.GetID(),
+	}, nil
+}
+
+type UpdateCommentRequest struct {
+	Repo        internal.Repo
+	CommentID   int64
+	CommentBody string
+}
+
+type UpdateCommentResponse struct{}
+
+func (a *githubActivities) GithubUpdateComment(ctx context.Context, request UpdateCommentRequest) (UpdateCommentResponse, error) {
+	comment := &github.IssueComment{
+		Body: github.String(request.CommentBody),
+	}
+	_, _, err := a.Client.EditComment(
+		ctx,
+		request.Repo.Credentials.InstallationToken,
+		request.Repo.Owner,
+		request.Repo.Name,
+		request.CommentID,
+		comment,
+	)
+	if err != nil {
+		return UpdateCommentResponse{}, errors.Wrap(err, "updating comment on PR")
+	}
+	return UpdateCommentResponse{}, nil
 }
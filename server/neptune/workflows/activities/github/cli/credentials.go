@@ -2,22 +2,17 @@ package cli
 
 import (
 	"context"
-	"fmt"
 	"net/http"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"strings"
 	"sync"
 
 	"github.com/bradleyfalzon/ghinstallation"
-	"github.com/mitchellh/go-homedir"
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
-	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/file"
-	"go.temporal.io/sdk/activity"
+	"github.com/runatlantis/atlantis/server/vcs/provider/github"
 )
 
+const defaultGithubHostname = "github.com"
+
 type ghInstallationTransportCreator struct{}
 
 func (t ghInstallationTransportCreator) New(tr http.RoundTripper, appID, installationID int64, privateKey []byte) (Transport, error) {
@@ -32,121 +27,66 @@ type transportCreator interface {
 	New(tr http.RoundTripper, appID, installationID int64, privateKey []byte) (Transport, error)
 }
 
+// Credentials provides git cli credentials scoped to a single github app installation.
+// Credentials are returned as environment variables for the calling command instead of being
+// written to global git config, so commands for different installations can run concurrently.
 type Credentials struct {
 	Cfg              githubapp.Config
-	FileLock         *file.RWLock
 	TransportCreator transportCreator
-	HomeDir          string
-	Git              func(...string) error
+	// Hostname of the github host the credentials authenticate against, defaults to github.com
+	Hostname string
 
-	once      sync.Once
-	transport Transport
+	mu         sync.Mutex
+	transports map[int64]Transport
 }
 
-func NewCredentials(cfg githubapp.Config, fileLock *file.RWLock) (*Credentials, error) {
-	home, err := homedir.Dir()
-	if err != nil {
-		return nil, errors.Wrap(err, "getting home dir")
-	}
+func NewCredentials(cfg githubapp.Config, hostname string) *Credentials {
 	return &Credentials{
-		HomeDir:          home,
 		TransportCreator: ghInstallationTransportCreator{},
 		Cfg:              cfg,
-		FileLock:         fileLock,
-		Git:              git,
-	}, nil
-}
-
-func (c *Credentials) Refresh(ctx context.Context, installationID int64) error {
-	// initialize our transport once here. We don't support multiple installation ids atm
-	// since we are using a global git config
-	var initErr error
-	c.once.Do(func() {
-		transport, err := c.TransportCreator.New(http.DefaultTransport, c.Cfg.App.IntegrationID, installationID, []byte(c.Cfg.App.PrivateKey))
-		if err != nil {
-			initErr = err
-			return
-		}
-		c.transport = transport
-	})
-
-	if initErr != nil {
-		return errors.Wrap(initErr, "initializing transport")
+		Hostname:         hostname,
 	}
+}
 
-	token, err := c.transport.Token(ctx)
+// Envs returns the environment variables a git command needs in order to authenticate as the
+// given installation.  Tokens are cached and refreshed by the installation's transport.
+func (c *Credentials) Envs(ctx context.Context, installationID int64) (map[string]string, error) {
+	transport, err := c.transport(installationID)
 	if err != nil {
-		return errors.Wrap(err, "refreshing token in transport")
+		return nil, errors.Wrap(err, "initializing transport")
 	}
 
-	return errors.Wrap(
-		c.writeCredentials(ctx, filepath.Join(c.HomeDir, ".git-credentials"), token),
-		"writing credentials",
-	)
-}
-
-func (c *Credentials) safeWriteFile(file string, contents []byte, perm os.FileMode) error {
-	c.FileLock.Lock()
-	defer c.FileLock.Unlock()
-
-	return errors.Wrap(
-		os.WriteFile(file, contents, perm),
-		"writing file",
-	)
-}
-
-func (c *Credentials) safeReadFile(file string) (string, error) {
-	c.FileLock.RLock()
-	defer c.FileLock.RUnlock()
-
-	contents, err := os.ReadFile(file)
+	token, err := transport.Token(ctx)
 	if err != nil {
-		return "", errors.Wrap(err, "reading file")
+		return nil, errors.Wrap(err, "refreshing token in transport")
 	}
 
-	// for some reason this gets read in with an additional new line. Maybe git config
-	// might be responsible :shrug
-	return strings.TrimSuffix(string(contents), "\n"), nil
+	return github.GitCredentialEnvs(c.hostname(), token), nil
 }
 
-func (c *Credentials) writeConfig(file string, contents []byte) error {
-	if err := c.safeWriteFile(file, contents, os.ModePerm); err != nil { //nolint:gosec
-		return err
-	}
-	if err := c.Git("config", "--global", "credential.helper", "store"); err != nil {
-		return err
+func (c *Credentials) hostname() string {
+	if c.Hostname == "" {
+		return defaultGithubHostname
 	}
-
-	return c.Git("config", "--global", "url.https://x-access-token@github.com.insteadOf", "ssh://git@github.com")
+	return c.Hostname
 }
 
-func (c *Credentials) writeCredentials(ctx context.Context, file string, token string) error {
-	toWrite := fmt.Sprintf(`https://x-access-token:%s@github.com`, token)
+func (c *Credentials) transport(installationID int64) (Transport, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	// if it doesn't exist write to file
-	if _, err := os.Stat(file); err != nil {
-		activity.GetLogger(ctx).Info("writing global .git-credentials file")
-		return c.writeConfig(file, []byte(toWrite))
+	if transport, ok := c.transports[installationID]; ok {
+		return transport, nil
 	}
 
-	contents, err := c.safeReadFile(file)
+	transport, err := c.TransportCreator.New(http.DefaultTransport, c.Cfg.App.IntegrationID, installationID, []byte(c.Cfg.App.PrivateKey))
 	if err != nil {
-		return errors.Wrap(err, "reading existing credentials")
+		return nil, err
 	}
 
-	// our token was refreshed so let's write it
-	if contents != toWrite {
-		activity.GetLogger(ctx).Info("token was refreshed, rewriting credentials")
-		return c.writeConfig(file, []byte(toWrite))
+	if c.transports == nil {
+		c.transports = make(map[int64]Transport)
 	}
-
-	return nil
-}
-
-func git(args ...string) error {
-	if _, err := exec.Command("git", args...).CombinedOutput(); err != nil {
-		return errors.Wrapf(err, "running git command with args %s", strings.Join(args, ","))
-	}
-
-	return nil
+	c.transports[installationID] = transport
+	return transport, nil
 }
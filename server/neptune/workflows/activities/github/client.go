@@ -10,53 +10,91 @@ import (
 	gh_helper "github.com/runatlantis/atlantis/server/vcs/provider/github"
 )
 
+type installationFinder interface {
+	FindOrganizationInstallation(ctx context.Context, org string) (gh_helper.Installation, error)
+	Invalidate(org string)
+}
+
 type Client struct {
-	ClientCreator  githubapp.ClientCreator
+	ClientCreator githubapp.ClientCreator
+
+	// InstallationID is used for requests without an installation unless Installations is set
 	InstallationID int64
+
+	// Installations resolves the installation for the owner of requests without an installation
+	Installations installationFinder
 }
 
-func (c *Client) CreateCheckRun(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
-	client, err := c.ClientCreator.NewInstallationClient(c.InstallationID)
+// installationClient uses the installation of the request's repo when it's known, falling back
+// to resolving the installation of the owner.
+func (c *Client) installationClient(ctx context.Context, installationToken int64, owner string) (*github.Client, error) {
+	if installationToken != 0 {
+		return c.newInstallationClient(installationToken)
+	}
+
+	installationID := c.InstallationID
+	if c.Installations != nil {
+		installation, err := c.Installations.FindOrganizationInstallation(ctx, owner)
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding installation for %s", owner)
+		}
+		installationID = installation.Token
+	}
+
+	client, err := c.newInstallationClient(installationID)
+	if err != nil && c.Installations != nil {
+		// look the installation up again on the next request rather than reusing a bad one
+		c.Installations.Invalidate(owner)
+	}
+	return client, err
+}
 
+func (c *Client) newInstallationClient(installationID int64) (*github.Client, error) {
+	client, err := c.ClientCreator.NewInstallationClient(installationID)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "creating client from installation")
+		return nil, errors.Wrap(err, "creating client from installation")
+	}
+	return client, nil
+}
+
+func (c *Client) CreateCheckRun(ctx context.Context, installationToken int64, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	client, err := c.installationClient(ctx, installationToken, owner)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return client.Checks.CreateCheckRun(ctx, owner, repo, opts)
 }
-func (c *Client) UpdateCheckRun(ctx context.Context, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
-	client, err := c.ClientCreator.NewInstallationClient(c.InstallationID)
-
+func (c *Client) UpdateCheckRun(ctx context.Context, installationToken int64, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	client, err := c.installationClient(ctx, installationToken, owner)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "creating client from installation")
+		return nil, nil, err
 	}
 
 	return client.Checks.UpdateCheckRun(ctx, owner, repo, checkRunID, opts)
 }
-func (c *Client) GetArchiveLink(ctx context.Context, owner, repo string, archiveformat github.ArchiveFormat, opts *github.RepositoryContentGetOptions, followRedirects bool) (*url.URL, *github.Response, error) {
-	client, err := c.ClientCreator.NewInstallationClient(c.InstallationID)
-
+func (c *Client) GetArchiveLink(ctx context.Context, installationToken int64, owner, repo string, archiveformat github.ArchiveFormat, opts *github.RepositoryContentGetOptions, followRedirects bool) (*url.URL, *github.Response, error) {
+	client, err := c.installationClient(ctx, installationToken, owner)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "creating client from installation")
+		return nil, nil, err
 	}
 
 	return client.Repositories.GetArchiveLink(ctx, owner, repo, archiveformat, opts, followRedirects)
 }
 
-func (c *Client) CompareCommits(ctx context.Context, owner, repo string, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
-	client, err := c.ClientCreator.NewInstallationClient(c.InstallationID)
-
+func (c *Client) CompareCommits(ctx context.Context, installationToken int64, owner, repo string, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
+	client, err := c.installationClient(ctx, installationToken, owner)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "creating client from installation")
+		return nil, nil, err
 	}
 
 	return client.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
 }
 
-func (c *Client) ListReviews(ctx context.Context, owner string, repo string, number int) ([]*github.PullRequestReview, error) {
-	client, err := c.ClientCreator.NewInstallationClient(c.InstallationID)
+func (c *Client) ListReviews(ctx context.Context, installationToken int64, owner string, repo string, number int) ([]*github.PullRequestReview, error) {
+	client, err := c.installationClient(ctx, installationToken, owner)
 	if err != nil {
-		return nil, errors.Wrap(err, "creating client from installation")
+		return nil, err
 	}
 
 	run := func(ctx context.Context, nextPage int) ([]*github.PullRequestReview, *github.Response, error) {
@@ -69,18 +107,18 @@ func (c *Client) ListReviews(ctx context.Context, owner string, repo string, num
 	return gh_helper.Iterate(ctx, run)
 }
 
-func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
-	client, err := c.ClientCreator.NewInstallationClient(c.InstallationID)
+func (c *Client) GetPullRequest(ctx context.Context, installationToken int64, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	client, err := c.installationClient(ctx, installationToken, owner)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "creating client from installation")
+		return nil, nil, err
 	}
 	return client.PullRequests.Get(ctx, owner, repo, number)
 }
 
-func (c *Client) ListCommits(ctx context.Context, owner string, repo string, number int) ([]*github.RepositoryCommit, error) {
-	client, err := c.ClientCreator.NewInstallationClient(c.InstallationID)
+func (c *Client) ListCommits(ctx context.Context, installationToken int64, owner string, repo string, number int) ([]*github.RepositoryCommit, error) {
+	client, err := c.installationClient(ctx, installationToken, owner)
 	if err != nil {
-		return nil, errors.Wrap(err, "creating client from installation")
+		return nil, err
 	}
 
 	run := func(ctx context.Context, nextPage int) ([]*github.RepositoryCommit, *github.Response, error) {
@@ -93,18 +131,36 @@ func (c *Client) ListCommits(ctx context.Context, owner string, repo string, num
 	return gh_helper.Iterate(ctx, run)
 }
 
-func (c *Client) DismissReview(ctx context.Context, owner string, repo string, number int, reviewID int64, review *github.PullRequestReviewDismissalRequest) (*github.PullRequestReview, *github.Response, error) {
-	client, err := c.ClientCreator.NewInstallationClient(c.InstallationID)
+func (c *Client) ListPullRequestsWithCommit(ctx context.Context, installationToken int64, owner string, repo string, sha string) ([]*github.PullRequest, error) {
+	client, err := c.installationClient(ctx, installationToken, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	run := func(ctx context.Context, nextPage int) ([]*github.PullRequest, *github.Response, error) {
+		listOptions := github.PullRequestListOptions{
+			ListOptions: github.ListOptions{
+				PerPage: 100,
+			},
+		}
+		listOptions.Page = nextPage
+		return client.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, sha, &listOptions)
+	}
+	return gh_helper.Iterate(ctx, run)
+}
+
+func (c *Client) DismissReview(ctx context.Context, installationToken int64, owner string, repo string, number int, reviewID int64, review *github.PullRequestReviewDismissalRequest) (*github.PullRequestReview, *github.Response, error) {
+	client, err := c.installationClient(ctx, installationToken, owner)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "creating client from installation")
+		return nil, nil, err
 	}
 	return client.PullRequests.DismissReview(ctx, owner, repo, number, reviewID, review)
 }
 
-func (c *Client) ListTeamMembers(ctx context.Context, org string, teamSlug string) ([]*github.User, error) {
-	client, err := c.ClientCreator.NewInstallationClient(c.InstallationID)
+func (c *Client) ListTeamMembers(ctx context.Context, installationToken int64, org string, teamSlug string) ([]*github.User, error) {
+	client, err := c.installationClient(ctx, installationToken, org)
 	if err != nil {
-		return nil, errors.Wrap(err, "creating client from installation")
+		return nil, err
 	}
 
 	run := func(ctx context.Context, nextPage int) ([]*github.User, *github.Response, error) {
@@ -119,10 +175,18 @@ func (c *Client) ListTeamMembers(ctx context.Context, org string, teamSlug strin
 	return gh_helper.Iterate(ctx, run)
 }
 
-func (c *Client) CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
-	client, err := c.ClientCreator.NewInstallationClient(c.InstallationID)
+func (c *Client) CreateComment(ctx context.Context, installationToken int64, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	client, err := c.installationClient(ctx, installationToken, owner)
 	if err != nil {
 		return nil, nil, err
 	}
 	return client.Issues.CreateComment(ctx, owner, repo, number, comment)
 }
+
+func (c *Client) EditComment(ctx context.Context, installationToken int64, owner string, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	client, err := c.installationClient(ctx, installationToken, owner)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.Issues.EditComment(ctx, owner, repo, commentID, comment)
+}
@@ -5,6 +5,7 @@ import (
 	_ "embed" //embedding files
 	"fmt"
 	"html/template"
+	"strings"
 
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
@@ -18,9 +19,13 @@ var planConfirmStr string
 //go:embed templates/checkrun.tmpl
 var checkrunTemplateStr string
 
+//go:embed templates/prsummary.tmpl
+var prSummaryTemplateStr string
+
 // panics if we can't read the template
 var checkrunTemplate = template.Must(template.New("").Parse(checkrunTemplateStr))
 var planConfirmTemplate = template.Must(template.New("").Parse(planConfirmStr))
+var prSummaryTemplate = template.Must(template.New("").Parse(prSummaryTemplateStr))
 
 type planconfirmTemplateData struct {
 	Revision              string
@@ -32,6 +37,32 @@ type planconfirmTemplateData struct {
 	LatestOnDefaultBranch bool
 }
 
+type prSummaryTemplateData struct {
+	RevisionURL string
+	Roots       []prSummaryRootData
+}
+
+type prSummaryRootData struct {
+	Name               string
+	PlanSummary        string
+	PolicyStatus       string
+	PlanLogURL         string
+	ValidateLogURL     string
+	HasResourceChanges bool
+	Creations          []string
+	Updates            []string
+	Deletions          []string
+	PreviousRevision   string
+	PlanDiffLines      []string
+}
+
+// PRSummaryRoot is a root of the revision summarized in the PR comment, State is nil if the
+// root's workflow didn't complete
+type PRSummaryRoot struct {
+	Name  string
+	State *state.Workflow
+}
+
 type checkrunTemplateData struct {
 	ApplyActionsSummary     string
 	PlanStatus              string
@@ -49,7 +80,10 @@ type checkrunTemplateData struct {
 	Skipped                 bool
 	ValidationError         bool
 	BypassedError           bool
+	PartialPlan             bool
 	PlanSummary             string
+	PlanDiffSummary         string
+	PlanDiffLines           []string
 	ValidateSummary         string
 }
 
@@ -68,6 +102,7 @@ func RenderWorkflowStateTmpl(workflowState *state.Workflow) string {
 	skipped := workflowState.Result.Reason == state.SkippedCompletionReason
 	validation := workflowState.Result.Reason == state.ValidationFailedReason
 	bypassed := workflowState.Result.Reason == state.BypassedFailedValidationReason
+	partial := workflowState.Result.Reason == state.PartialPlanCompletionReason
 	var prMode bool
 	if workflowState.Mode != nil {
 		prMode = *workflowState.Mode == terraform.PR
@@ -79,10 +114,16 @@ func RenderWorkflowStateTmpl(workflowState *state.Workflow) string {
 	}
 
 	var planSummary string
+	var planDiffSummary string
+	var planDiffLines []string
 	var validateSummary string
 	if prMode {
 		if workflowState.Plan != nil && workflowState.Plan.IsComplete() && workflowState.Plan.Output != nil {
 			planSummary = workflowState.Plan.Output.PlanSummary.String()
+			if diff := workflowState.Plan.Output.PlanDiff; diff != nil {
+				planDiffSummary = diff.String()
+				planDiffLines = diff.Lines()
+			}
 		}
 
 		if workflowState.Validate != nil && workflowState.Validate.IsComplete() && workflowState.Validate.Output != nil {
@@ -94,6 +135,8 @@ func RenderWorkflowStateTmpl(workflowState *state.Workflow) string {
 		PlanStatus:              planStatus,
 		PlanLogURL:              planLogURL,
 		PlanSummary:             planSummary,
+		PlanDiffSummary:         planDiffSummary,
+		PlanDiffLines:           planDiffLines,
 		ValidateStatus:          validateStatus,
 		ValidateLogURL:          validateLogURL,
 		ValidateSummary:         validateSummary,
@@ -103,6 +146,7 @@ func RenderWorkflowStateTmpl(workflowState *state.Workflow) string {
 		InternalError:           internalError,
 		ValidationError:         validation,
 		BypassedError:           bypassed,
+		PartialPlan:             partial,
 		TimedOut:                timedOut,
 		ActivityDurationTimeout: activityDurationTimeout,
 		SchedulingTimeout:       schedulingTimeout,
@@ -124,6 +168,72 @@ func RenderPlanConfirm(user string, commit github.Commit, deployedBranch string,
 	return renderTemplate(planConfirmTemplate, data)
 }
 
+// RenderPRSummary renders a single comment summarizing the plans and policy checks of every root of the revision
+func RenderPRSummary(revision string, repo github.Repo, roots []PRSummaryRoot) string {
+	data := prSummaryTemplateData{
+		RevisionURL: github.BuildRevisionURLMarkdown(repo.GetFullName(), revision),
+	}
+	for _, root := range roots {
+		data.Roots = append(data.Roots, buildPRSummaryRootData(root))
+	}
+	return renderTemplate(prSummaryTemplate, data)
+}
+
+func buildPRSummaryRootData(root PRSummaryRoot) prSummaryRootData {
+	data := prSummaryRootData{
+		Name:         root.Name,
+		PlanSummary:  "N/A",
+		PolicyStatus: "N/A",
+	}
+	if root.State == nil {
+		return data
+	}
+
+	planStatus, planLogURL := getJobStatusAndOutput(root.State.Plan)
+	_, validateLogURL := getJobStatusAndOutput(root.State.Validate)
+	data.PlanLogURL = planLogURL
+	data.ValidateLogURL = validateLogURL
+
+	if plan := root.State.Plan; plan != nil && plan.IsComplete() && plan.Output != nil {
+		summary := plan.Output.PlanSummary
+		data.PlanSummary = summary.String()
+		data.Creations = resourceAddresses(summary.Creations)
+		data.Updates = resourceAddresses(summary.Updates)
+		data.Deletions = resourceAddresses(summary.Deletions)
+		data.HasResourceChanges = !summary.IsEmpty()
+		if diff := plan.Output.PlanDiff; diff != nil {
+			data.PreviousRevision = diff.PreviousRevision
+			data.PlanDiffLines = diff.Lines()
+		}
+	} else if planStatus != "" {
+		data.PlanSummary = fmt.Sprintf("`%s`", planStatus)
+	}
+
+	if validate := root.State.Validate; validate != nil && validate.IsComplete() && validate.Output != nil {
+		data.PolicyStatus = policyStatus(root.State.Result.Reason, validate.Output.ValidateSummary.Failures)
+	}
+	return data
+}
+
+func policyStatus(reason state.WorkflowCompletionReason, failures []string) string {
+	switch {
+	case len(failures) == 0:
+		return ":white_check_mark: Passing"
+	case reason == state.BypassedFailedValidationReason:
+		return fmt.Sprintf(":white_check_mark: Bypassed (%s)", strings.Join(failures, ", "))
+	default:
+		return fmt.Sprintf(":no_entry_sign: Failing (%s)", strings.Join(failures, ", "))
+	}
+}
+
+func resourceAddresses(resources []terraform.ResourceSummary) []string {
+	var addresses []string
+	for _, r := range resources {
+		addresses = append(addresses, r.Address)
+	}
+	return addresses
+}
+
 func getJobStatusAndOutput(jobState *state.Job) (string, string) {
 	var status string
 	var output string
@@ -1,5 +1,7 @@
 package github
 
+const defaultHostname = "github.com"
+
 type Repo struct {
 	// Owner is just the repo owner
 	Owner string
@@ -10,6 +12,8 @@ type Repo struct {
 	URL string
 	// Repo's default branch
 	DefaultBranch string
+	// Hostname of the github instance hosting the repo, ex. github.com
+	Hostname string
 
 	Credentials AppCredentials
 }
@@ -18,6 +22,15 @@ func (r Repo) GetFullName() string {
 	return r.Owner + "/" + r.Name
 }
 
+// GetHostname returns the hostname of the repo's github instance, defaulting to github.com
+// for requests made before the hostname was included.
+func (r Repo) GetHostname() string {
+	if r.Hostname == "" {
+		return defaultHostname
+	}
+	return r.Hostname
+}
+
 type AppCredentials struct {
 	InstallationToken int64
 }
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/runatlantis/atlantis/server/neptune/lyft/feature"
 
@@ -21,6 +22,7 @@ import (
 	internal "github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github/cli"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github/link"
+	gh_helper "github.com/runatlantis/atlantis/server/vcs/provider/github"
 	"github.com/slack-go/slack"
 )
 
@@ -45,7 +47,7 @@ type Deploy struct {
 	*slackActivities
 }
 
-func NewDeploy(deploymentStoreCfg valid.StoreConfig) (*Deploy, error) {
+func NewDeploy(deploymentStoreCfg valid.StoreConfig, slackToken string) (*Deploy, error) {
 	storageClient, err := storage.NewClient(deploymentStoreCfg)
 	if err != nil {
 		return nil, errors.Wrap(err, "intializing stow client")
@@ -60,8 +62,7 @@ func NewDeploy(deploymentStoreCfg valid.StoreConfig) (*Deploy, error) {
 		dbActivities: &dbActivities{
 			DeploymentInfoStore: deploymentStore,
 		},
-		// TODO: Add token once bot is created
-		slackActivities: &slackActivities{Client: slack.New("",
+		slackActivities: &slackActivities{Client: slack.New(slackToken,
 			slack.OptionHTTPClient(http.DefaultClient))},
 	}, nil
 }
@@ -73,6 +74,7 @@ type Terraform struct {
 	*workerInfoActivity
 	*cleanupActivities
 	*jobActivities
+	*planActivities
 }
 
 type StreamCloser interface {
@@ -81,15 +83,49 @@ type StreamCloser interface {
 }
 
 type TerraformOptions struct {
-	TFVersionCache          cache.ExecutionVersionCache
-	ConftestVersionCache    cache.ExecutionVersionCache
-	GitCredentialsRefresher gitCredentialsRefresher
+	TFVersionCache       cache.ExecutionVersionCache
+	ConftestVersionCache cache.ExecutionVersionCache
+	GitCredentials       gitCredentials
+	// GithubHostname is the host git credentials are created for when GitCredentials isn't set
+	GithubHostname string
+	// PlanStore persists PR plans for comparison at deploy time, plans aren't persisted if nil
+	PlanStore planStore
 }
 
 type PolicySet struct {
-	Name  string
-	Owner string
+	Name      string
+	Owner     string
+	Paths     []string
+	AppliesTo PolicySetScope
+	// Source is the remote bundle Paths are relative to, nil for local policy sets
+	Source *PolicySource
+}
+
+// Version returns the version of the policy set's bundle, empty for local policy sets
+func (p PolicySet) Version() string {
+	if p.Source == nil {
+		return ""
+	}
+	return p.Source.Version
+}
+
+// PolicyException bypasses failures of a policy set, see valid.PolicyException
+type PolicyException struct {
+	PolicySet     string
+	Repo          string
+	Root          string
+	Resource      string
+	Justification string
+	Owner         string
+	Expiry        time.Time
+}
+
+// PolicySetScope matches the roots a policy set is run against, see valid.PolicySetScope
+type PolicySetScope struct {
+	Repos []string
+	Roots []string
 	Paths []string
+	Tags  map[string]string
 }
 
 func NewTerraform(tfConfig config.TerraformConfig, validationConfig config.ValidationConfig, ghAppConfig githubapp.Config, dataDir string, serverURL *url.URL, taskQueue string, installationID int64, streamHandler StreamCloser, opts ...TerraformOptions) (*Terraform, error) {
@@ -102,11 +138,12 @@ func NewTerraform(tfConfig config.TerraformConfig, validationConfig config.Valid
 	if err != nil {
 		return nil, err
 	}
-	gitCredentialsFileLock := &file.RWLock{}
 
 	var tfVersionCache cache.ExecutionVersionCache
 	var conftestVersionCache cache.ExecutionVersionCache
-	var credentialsRefresher gitCredentialsRefresher
+	var credentials gitCredentials
+	var githubHostname string
+	var store planStore
 	for _, o := range opts {
 		if o.TFVersionCache != nil {
 			tfVersionCache = o.TFVersionCache
@@ -116,8 +153,16 @@ func NewTerraform(tfConfig config.TerraformConfig, validationConfig config.Valid
 			conftestVersionCache = o.ConftestVersionCache
 		}
 
-		if credentialsRefresher != nil {
-			credentialsRefresher = o.GitCredentialsRefresher
+		if o.GitCredentials != nil {
+			credentials = o.GitCredentials
+		}
+
+		if o.GithubHostname != "" {
+			githubHostname = o.GithubHostname
+		}
+
+		if o.PlanStore != nil {
+			store = o.PlanStore
 		}
 	}
 
@@ -139,11 +184,8 @@ func NewTerraform(tfConfig config.TerraformConfig, validationConfig config.Valid
 		)
 	}
 
-	if credentialsRefresher == nil {
-		credentialsRefresher, err = cli.NewCredentials(ghAppConfig, gitCredentialsFileLock)
-		if err != nil {
-			return nil, errors.Wrap(err, "initializing credentials")
-		}
+	if credentials == nil {
+		credentials = cli.NewCredentials(ghAppConfig, githubHostname)
 	}
 
 	defaultTfVersion, err := version.NewVersion(tfConfig.DefaultVersion)
@@ -171,7 +213,13 @@ func NewTerraform(tfConfig config.TerraformConfig, validationConfig config.Valid
 		}
 	}
 
+	policyBundleDir, err := mkSubDir(dataDir, PolicyBundleDirName)
+	if err != nil {
+		return nil, err
+	}
+
 	policies := convertPolicies(validationConfig.Policies.PolicySets)
+	exceptions := convertPolicyExceptions(validationConfig.Policies.Exceptions)
 
 	return &Terraform{
 		executeCommandActivities: &executeCommandActivities{},
@@ -180,25 +228,29 @@ func NewTerraform(tfConfig config.TerraformConfig, validationConfig config.Valid
 			TaskQueue: taskQueue,
 		},
 		terraformActivities: &terraformActivities{
-			TerraformClient:        tfClient,
-			StreamHandler:          streamHandler,
-			DefaultTFVersion:       defaultTfVersion,
-			GitCLICredentials:      credentialsRefresher,
-			GitCredentialsFileLock: gitCredentialsFileLock,
-			FileWriter:             &file.Writer{},
-			CacheDir:               cacheDir,
-			InstallationID:         installationID,
+			TerraformClient:   tfClient,
+			StreamHandler:     streamHandler,
+			DefaultTFVersion:  defaultTfVersion,
+			GitCLICredentials: credentials,
+			FileWriter:        &file.Writer{},
+			CacheDir:          cacheDir,
+			InstallationID:    installationID,
 		},
 		conftestActivity: &conftestActivity{
 			DefaultConftestVersion: defaultConftestVersion,
 			ConftestClient:         conftestClient,
 			StreamHandler:          streamHandler,
 			Policies:               policies,
+			Exceptions:             exceptions,
 			FileValidator:          &file.Validator{},
+			PolicyBundles:          NewPolicyBundleCache(policyBundleDir, conftestClient, defaultConftestVersion, credentials, installationID),
 		},
 		jobActivities: &jobActivities{
 			StreamCloser: streamHandler,
 		},
+		planActivities: &planActivities{
+			PlanStore: store,
+		},
 	}, nil
 }
 
@@ -226,6 +278,11 @@ func NewGithub(clientCreator githubapp.ClientCreator, installationID int64, data
 	client := &internal.Client{
 		ClientCreator:  clientCreator,
 		InstallationID: installationID,
+		Installations: &gh_helper.InstallationCache{
+			Retriever: &gh_helper.InstallationRetriever{
+				ClientCreator: clientCreator,
+			},
+		},
 	}
 
 	return NewGithubWithClient(client, dataDir, HashiGetter, allocator)
@@ -247,7 +304,41 @@ func convertPolicies(policies []valid.PolicySet) []PolicySet {
 			Name:  policy.Name,
 			Owner: policy.Owner,
 			Paths: policy.Paths,
+			AppliesTo: PolicySetScope{
+				Repos: policy.AppliesTo.Repos,
+				Roots: policy.AppliesTo.Roots,
+				Paths: policy.AppliesTo.Paths,
+				Tags:  policy.AppliesTo.Tags,
+			},
+			Source: convertPolicySource(policy.Source),
 		})
 	}
 	return convertedPolicies
 }
+
+func convertPolicySource(source *valid.PolicySource) *PolicySource {
+	if source == nil {
+		return nil
+	}
+	return &PolicySource{
+		Type:    source.Type,
+		URL:     source.URL,
+		Version: source.Version,
+	}
+}
+
+func convertPolicyExceptions(exceptions []valid.PolicyException) []PolicyException {
+	var convertedExceptions []PolicyException
+	for _, exception := range exceptions {
+		convertedExceptions = append(convertedExceptions, PolicyException{
+			PolicySet:     exception.PolicySet,
+			Repo:          exception.Repo,
+			Root:          exception.Root,
+			Resource:      exception.Resource,
+			Justification: exception.Justification,
+			Owner:         exception.Owner,
+			Expiry:        exception.Expiry,
+		})
+	}
+	return convertedExceptions
+}
@@ -0,0 +1,189 @@
+package activities
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/plan"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/temporal"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
+)
+
+type planStore interface {
+	GetPlan(ctx context.Context, repoName string, rootName string, fingerprint plan.Fingerprint) (*plan.Info, error)
+	SetPlan(ctx context.Context, info *plan.Info, planFile []byte, planJSON []byte) error
+	GetRevisionPlan(ctx context.Context, repoName string, rootName string, revision string) (*plan.Info, error)
+	GetPlanJSON(ctx context.Context, info *plan.Info) ([]byte, error)
+}
+
+// planActivities persist PR plans so deploys can be compared against what was reviewed,
+// both are noops when no store is configured.
+type planActivities struct {
+	PlanStore planStore
+}
+
+type PersistPlanRequest struct {
+	LocalRoot    *terraform.LocalRoot
+	Revision     string
+	BaseRevision string
+	PlanFile     string
+	PlanJSONFile string
+	Summary      terraform.PlanSummary
+}
+
+type PersistPlanResponse struct {
+	Fingerprint plan.Fingerprint
+}
+
+func (a *planActivities) PersistPlan(ctx context.Context, request PersistPlanRequest) (PersistPlanResponse, error) {
+	if a.PlanStore == nil {
+		return PersistPlanResponse{}, nil
+	}
+	cancel := temporal.StartHeartbeat(ctx, temporal.HeartbeatTimeout)
+	defer cancel()
+
+	fingerprint, err := plan.NewFingerprint(request.LocalRoot, request.BaseRevision, request.PlanFile, request.PlanJSONFile)
+	if err != nil {
+		return PersistPlanResponse{}, errors.Wrap(err, "fingerprinting plan")
+	}
+
+	planFile, err := os.ReadFile(request.PlanFile)
+	if err != nil {
+		return PersistPlanResponse{}, errors.Wrap(err, "reading plan file")
+	}
+
+	var planJSON []byte
+	if request.PlanJSONFile != "" {
+		planJSON, err = os.ReadFile(request.PlanJSONFile)
+		if err != nil {
+			return PersistPlanResponse{}, errors.Wrap(err, "reading plan json")
+		}
+	}
+
+	info := &plan.Info{
+		Version:     plan.InfoSchemaVersion,
+		Repo:        request.LocalRoot.Repo.GetFullName(),
+		Root:        request.LocalRoot.Root.Name,
+		Revision:    request.Revision,
+		Fingerprint: fingerprint,
+		Summary:     request.Summary,
+	}
+	if err := a.PlanStore.SetPlan(ctx, info, planFile, planJSON); err != nil {
+		return PersistPlanResponse{}, errors.Wrapf(err, "persisting plan for %s/%s", info.Repo, info.Root)
+	}
+
+	return PersistPlanResponse{
+		Fingerprint: fingerprint,
+	}, nil
+}
+
+type FetchReviewedPlanRequest struct {
+	LocalRoot *terraform.LocalRoot
+	// BaseRevision is the merge base of the planned revision and the default branch
+	BaseRevision string
+	PlanFile     string
+	// ReviewedRevision is the PR revision the deploy is expected to have been reviewed at,
+	// its plan is returned when no PR plan was generated from the same inputs
+	ReviewedRevision string
+}
+
+type FetchReviewedPlanResponse struct {
+	// Plan is the PR plan generated from the same inputs, or the plan of the reviewed revision
+	// if there is none.  Nil if neither was persisted.
+	Plan *plan.Info
+	// Fingerprint of the requested plan
+	Fingerprint plan.Fingerprint
+	// InputsMatch is set when Plan was generated from the same inputs as the requested plan
+	InputsMatch bool
+}
+
+func (a *planActivities) FetchReviewedPlan(ctx context.Context, request FetchReviewedPlanRequest) (FetchReviewedPlanResponse, error) {
+	if a.PlanStore == nil {
+		return FetchReviewedPlanResponse{}, nil
+	}
+	cancel := temporal.StartHeartbeat(ctx, temporal.HeartbeatTimeout)
+	defer cancel()
+
+	fingerprint, err := plan.NewFingerprint(request.LocalRoot, request.BaseRevision, request.PlanFile)
+	if err != nil {
+		return FetchReviewedPlanResponse{}, errors.Wrap(err, "fingerprinting plan")
+	}
+
+	repoName := request.LocalRoot.Repo.GetFullName()
+	rootName := request.LocalRoot.Root.Name
+	info, err := a.PlanStore.GetPlan(ctx, repoName, rootName, fingerprint)
+	if err != nil {
+		return FetchReviewedPlanResponse{}, errors.Wrapf(err, "fetching reviewed plan for %s/%s", repoName, rootName)
+	}
+	if info != nil {
+		return FetchReviewedPlanResponse{
+			Plan:        info,
+			Fingerprint: fingerprint,
+			InputsMatch: true,
+		}, nil
+	}
+
+	if request.ReviewedRevision == "" {
+		return FetchReviewedPlanResponse{
+			Fingerprint: fingerprint,
+		}, nil
+	}
+
+	info, err = a.PlanStore.GetRevisionPlan(ctx, repoName, rootName, request.ReviewedRevision)
+	if err != nil {
+		return FetchReviewedPlanResponse{}, errors.Wrapf(err, "fetching plan of %s for %s/%s", request.ReviewedRevision, repoName, rootName)
+	}
+
+	return FetchReviewedPlanResponse{
+		Plan:        info,
+		Fingerprint: fingerprint,
+	}, nil
+}
+
+type DiffPlanRequest struct {
+	LocalRoot        *terraform.LocalRoot
+	PreviousRevision string
+	PlanJSONFile     string
+}
+
+type DiffPlanResponse struct {
+	// Diff against the plan of the previous revision, nil if it wasn't persisted
+	Diff *terraform.PlanDiff
+}
+
+func (a *planActivities) DiffPlan(ctx context.Context, request DiffPlanRequest) (DiffPlanResponse, error) {
+	if a.PlanStore == nil || request.PreviousRevision == "" || request.PlanJSONFile == "" {
+		return DiffPlanResponse{}, nil
+	}
+	cancel := temporal.StartHeartbeat(ctx, temporal.HeartbeatTimeout)
+	defer cancel()
+
+	repoName := request.LocalRoot.Repo.GetFullName()
+	rootName := request.LocalRoot.Root.Name
+	info, err := a.PlanStore.GetRevisionPlan(ctx, repoName, rootName, request.PreviousRevision)
+	if err != nil {
+		return DiffPlanResponse{}, errors.Wrapf(err, "fetching plan of %s for %s/%s", request.PreviousRevision, repoName, rootName)
+	}
+	if info == nil {
+		return DiffPlanResponse{}, nil
+	}
+
+	previousJSON, err := a.PlanStore.GetPlanJSON(ctx, info)
+	if err != nil {
+		return DiffPlanResponse{}, errors.Wrapf(err, "fetching plan json of %s for %s/%s", request.PreviousRevision, repoName, rootName)
+	}
+
+	currentJSON, err := os.ReadFile(request.PlanJSONFile)
+	if err != nil {
+		return DiffPlanResponse{}, errors.Wrap(err, "reading plan json")
+	}
+
+	diff, err := terraform.NewPlanDiffFromJSON(request.PreviousRevision, currentJSON, previousJSON)
+	if err != nil {
+		return DiffPlanResponse{}, errors.Wrap(err, "diffing plans")
+	}
+	return DiffPlanResponse{
+		Diff: &diff,
+	}, nil
+}
@@ -0,0 +1,162 @@
+package plan
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
+)
+
+const (
+	ProviderLockFile = ".terraform.lock.hcl"
+
+	// name of the prior state within a plan file
+	planStateFile = "tfstate"
+)
+
+// NewFingerprint fingerprints the inputs of a local root, the state serial is read from the plan file.
+// Files generated by the workflow itself (ie. the plan file) should be excluded since they differ
+// between runs.
+func NewFingerprint(localRoot *terraform.LocalRoot, baseRevision string, planFile string, excludes ...string) (Fingerprint, error) {
+	inputs, err := hashInputs(localRoot, append(excludes, planFile)...)
+	if err != nil {
+		return Fingerprint{}, errors.Wrap(err, "hashing root inputs")
+	}
+
+	providerLock, err := hashFiles(localRoot.Path, filepath.Join(localRoot.Path, ProviderLockFile))
+	if err != nil {
+		return Fingerprint{}, errors.Wrap(err, "hashing provider lock")
+	}
+
+	serial, err := stateSerial(planFile)
+	if err != nil {
+		return Fingerprint{}, errors.Wrap(err, "reading state serial")
+	}
+
+	return Fingerprint{
+		BaseRevision: baseRevision,
+		Inputs:       inputs,
+		ProviderLock: providerLock,
+		StateSerial:  serial,
+	}, nil
+}
+
+// hashInputs hashes every file of the repo matching the root's tracked files, or every file within
+// the root if it doesn't track any.
+func hashInputs(localRoot *terraform.LocalRoot, excludes ...string) (string, error) {
+	repoDir := localRoot.RepoDir()
+
+	patterns := localRoot.Root.GetTrackedFilesRelativeToRepo()
+	if len(patterns) == 0 {
+		patterns = []string{filepath.Join(localRoot.Root.Path, "**", "*")}
+	}
+	pm, err := fileutils.NewPatternMatcher(patterns)
+	if err != nil {
+		return "", errors.Wrap(err, "building tracked files matcher")
+	}
+
+	excluded := map[string]bool{
+		filepath.Join(localRoot.Path, ProviderLockFile): true,
+	}
+	for _, e := range excludes {
+		excluded[e] = true
+	}
+
+	var files []string
+	err = filepath.WalkDir(repoDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".terraform" || d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if excluded[path] {
+			return nil
+		}
+		relPath, err := filepath.Rel(repoDir, path)
+		if err != nil {
+			return err
+		}
+		match, err := pm.Matches(relPath)
+		if err != nil || !match {
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "walking repo")
+	}
+
+	return hashFiles(repoDir, files...)
+}
+
+// hashFiles hashes the path relative to dir and the contents of each file, missing files are skipped
+func hashFiles(dir string, files ...string) (string, error) {
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, file := range files {
+		f, err := os.Open(file)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		relPath, err := filepath.Rel(dir, file)
+		if err != nil {
+			f.Close()
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\n", relPath)
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// stateSerial reads the serial of the prior state stored within the plan file, 0 if there was no prior state
+func stateSerial(planFile string) (uint64, error) {
+	r, err := zip.OpenReader(planFile)
+	if err != nil {
+		return 0, errors.Wrap(err, "opening plan file")
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if f.Name != planStateFile {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return 0, errors.Wrap(err, "opening plan state")
+		}
+		defer rc.Close()
+
+		var state struct {
+			Serial uint64 `json:"serial"`
+		}
+		if err := json.NewDecoder(rc).Decode(&state); err != nil {
+			return 0, errors.Wrap(err, "decoding plan state")
+		}
+		return state.Serial, nil
+	}
+	return 0, nil
+}
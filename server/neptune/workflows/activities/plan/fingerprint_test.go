@@ -0,0 +1,83 @@
+package plan_test
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/plan"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, path string, contents string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	assert.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+}
+
+func writePlanFile(t *testing.T, path string, state string) {
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	entry, err := w.Create("tfstate")
+	assert.NoError(t, err)
+	_, err = entry.Write([]byte(state))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+}
+
+func TestNewFingerprint(t *testing.T) {
+	repoDir := t.TempDir()
+	rootDir := filepath.Join(repoDir, "roots", "app")
+	localRoot := &terraform.LocalRoot{
+		Root: terraform.Root{
+			Name:         "app",
+			Path:         "roots/app",
+			TrackedFiles: []string{"**/*.tf*"},
+		},
+		Path: rootDir,
+	}
+	planFile := filepath.Join(rootDir, "output.tfplan")
+
+	writeFile(t, filepath.Join(rootDir, "main.tf"), `resource "null_resource" "this" {}`)
+	writeFile(t, filepath.Join(rootDir, plan.ProviderLockFile), "lock")
+	writeFile(t, filepath.Join(repoDir, "README.md"), "readme")
+	writePlanFile(t, planFile, `{"serial": 3}`)
+
+	base, err := plan.NewFingerprint(localRoot, "sha", planFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "sha", base.BaseRevision)
+	assert.Equal(t, uint64(3), base.StateSerial)
+
+	t.Run("ignores generated and untracked files", func(t *testing.T) {
+		writeFile(t, filepath.Join(repoDir, "README.md"), "updated")
+		writeFile(t, filepath.Join(rootDir, ".terraform", "modules.tf.json"), "{}")
+		writePlanFile(t, planFile, `{"serial": 4}`)
+
+		result, err := plan.NewFingerprint(localRoot, "sha", planFile)
+		assert.NoError(t, err)
+		assert.Equal(t, base.Inputs, result.Inputs)
+		assert.Equal(t, base.ProviderLock, result.ProviderLock)
+		assert.Equal(t, uint64(4), result.StateSerial)
+	})
+
+	t.Run("tracked file change", func(t *testing.T) {
+		writeFile(t, filepath.Join(rootDir, "variables.tf"), `variable "name" {}`)
+
+		result, err := plan.NewFingerprint(localRoot, "sha", planFile)
+		assert.NoError(t, err)
+		assert.NotEqual(t, base.Inputs, result.Inputs)
+		assert.Equal(t, base.ProviderLock, result.ProviderLock)
+	})
+
+	t.Run("provider lock change", func(t *testing.T) {
+		writeFile(t, filepath.Join(rootDir, plan.ProviderLockFile), "updated")
+
+		result, err := plan.NewFingerprint(localRoot, "sha", planFile)
+		assert.NoError(t, err)
+		assert.NotEqual(t, base.ProviderLock, result.ProviderLock)
+	})
+}
@@ -0,0 +1,29 @@
+package plan
+
+import "github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
+
+const InfoSchemaVersion = 1
+
+// Fingerprint identifies the inputs a plan was generated from.  Plans are looked up by their inputs
+// and provider lock, the base revision and state serial tell plans of the same inputs apart.
+type Fingerprint struct {
+	// BaseRevision is the merge base of the planned revision and the default branch
+	BaseRevision string
+	// Inputs is a hash of the files tracked by the root
+	Inputs string
+	// ProviderLock is a hash of the root's dependency lock file
+	ProviderLock string
+	// StateSerial is the serial of the state the plan was generated against
+	StateSerial uint64
+}
+
+// Info describes a persisted PR plan.
+// These objects are persisted, please ensure changes going forward are backwards compatible.
+type Info struct {
+	Version     int
+	Repo        string
+	Root        string
+	Revision    string
+	Fingerprint Fingerprint
+	Summary     terraform.PlanSummary
+}
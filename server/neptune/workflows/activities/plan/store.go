@@ -0,0 +1,119 @@
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/neptune/storage"
+)
+
+const (
+	infoObject     = "info.json"
+	planFileObject = "output.tfplan"
+	planJSONObject = "output.json"
+)
+
+type client interface {
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Set(ctx context.Context, key string, object []byte) error
+}
+
+func NewStore(stowClient client) (*Store, error) {
+	return &Store{
+		stowClient: stowClient,
+	}, nil
+}
+
+// Store persists PR plans keyed by the inputs of the root they were generated from, such that
+// a deploy of the same inputs can look up the plan which was reviewed.
+type Store struct {
+	stowClient client
+}
+
+// GetPlan returns the info of the plan generated from the fingerprinted inputs, nil if there is none.
+func (s *Store) GetPlan(ctx context.Context, repoName string, rootName string, fingerprint Fingerprint) (*Info, error) {
+	return s.getInfo(ctx, BuildKey(repoName, rootName, fingerprint, infoObject))
+}
+
+// GetRevisionPlan returns the info of the last plan generated for the revision, nil if there is none.
+func (s *Store) GetRevisionPlan(ctx context.Context, repoName string, rootName string, revision string) (*Info, error) {
+	return s.getInfo(ctx, BuildRevisionKey(repoName, rootName, revision))
+}
+
+// GetPlanJSON returns the plan json persisted alongside the plan info
+func (s *Store) GetPlanJSON(ctx context.Context, info *Info) ([]byte, error) {
+	reader, err := s.stowClient.Get(ctx, BuildKey(info.Repo, info.Root, info.Fingerprint, planJSONObject))
+	if err != nil {
+		return nil, errors.Wrap(err, "getting item")
+	}
+	defer reader.Close()
+
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading item")
+	}
+	return b, nil
+}
+
+func (s *Store) getInfo(ctx context.Context, key string) (*Info, error) {
+	reader, err := s.stowClient.Get(ctx, key)
+	if err != nil {
+		switch err.(type) {
+		case *storage.ContainerNotFoundError:
+			return nil, err
+
+		// these inputs were never planned in a PR
+		case *storage.ItemNotFoundError:
+			return nil, nil
+
+		default:
+			return nil, errors.Wrap(err, "getting item")
+		}
+	}
+	defer reader.Close()
+
+	var info Info
+	if err := json.NewDecoder(reader).Decode(&info); err != nil {
+		return nil, errors.Wrap(err, "decoding item")
+	}
+
+	return &info, nil
+}
+
+// SetPlan writes the plan file and its json alongside the plan info.  The info is written last
+// so that its presence guarantees the plan files exist, it's also indexed by the planned revision.
+func (s *Store) SetPlan(ctx context.Context, info *Info, planFile []byte, planJSON []byte) error {
+	if err := s.stowClient.Set(ctx, BuildKey(info.Repo, info.Root, info.Fingerprint, planFileObject), planFile); err != nil {
+		return errors.Wrap(err, "writing plan file to store")
+	}
+
+	if err := s.stowClient.Set(ctx, BuildKey(info.Repo, info.Root, info.Fingerprint, planJSONObject), planJSON); err != nil {
+		return errors.Wrap(err, "writing plan json to store")
+	}
+
+	object, err := json.Marshal(info)
+	if err != nil {
+		return errors.Wrap(err, "marshalling plan info")
+	}
+
+	if err := s.stowClient.Set(ctx, BuildKey(info.Repo, info.Root, info.Fingerprint, infoObject), object); err != nil {
+		return errors.Wrap(err, "writing plan info to store")
+	}
+
+	// plans of a PR are diffed against the plan of its previous revision
+	if err := s.stowClient.Set(ctx, BuildRevisionKey(info.Repo, info.Root, info.Revision), object); err != nil {
+		return errors.Wrap(err, "writing plan info of revision to store")
+	}
+	return nil
+}
+
+func BuildKey(repo string, root string, fingerprint Fingerprint, object string) string {
+	return fmt.Sprintf("%s/%s/plans/%s/%s/%s", repo, root, fingerprint.Inputs, fingerprint.ProviderLock, object)
+}
+
+func BuildRevisionKey(repo string, root string, revision string) string {
+	return fmt.Sprintf("%s/%s/revisions/%s/%s", repo, root, revision, infoObject)
+}
@@ -0,0 +1,131 @@
+package activities
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/command"
+)
+
+const PolicyBundleDirName = "policies"
+
+// PolicySource is a versioned remote policy bundle, see valid.PolicySource
+type PolicySource struct {
+	Type    string
+	URL     string
+	Version string
+}
+
+// PullURL builds the url conftest pulls the bundle from
+func (s PolicySource) PullURL() string {
+	if s.Type == valid.OCIPolicySource {
+		return fmt.Sprintf("oci://%s:%s", strings.TrimPrefix(s.URL, "oci://"), s.Version)
+	}
+	return fmt.Sprintf("git::%s?ref=%s", strings.TrimPrefix(s.URL, "git::"), s.Version)
+}
+
+func (s PolicySource) String() string {
+	return fmt.Sprintf("%s %s", s.Type, s.URL)
+}
+
+type policyBundleCache interface {
+	Get(ctx context.Context, source PolicySource) (string, error)
+}
+
+// PolicyBundleCache pulls remote policy bundles with conftest and caches them on disk by version,
+// bundles already on disk are reused across worker restarts.
+type PolicyBundleCache struct {
+	Dir            string
+	ConftestClient asyncClient
+	Version        *version.Version
+
+	// git sources may be private repos of the installation, so pulls are authenticated as it
+	GitCredentials gitCredentials
+	InstallationID int64
+
+	// serializes pulls, they are infrequent since bundles are only pulled once per version
+	lock  sync.Mutex
+	cache map[string]string
+}
+
+func NewPolicyBundleCache(dir string, conftestClient asyncClient, conftestVersion *version.Version, gitCredentials gitCredentials, installationID int64) *PolicyBundleCache {
+	return &PolicyBundleCache{
+		Dir:            dir,
+		ConftestClient: conftestClient,
+		Version:        conftestVersion,
+		GitCredentials: gitCredentials,
+		InstallationID: installationID,
+		cache:          make(map[string]string),
+	}
+}
+
+// Get returns the local directory of the bundle, pulling it if it isn't cached yet
+func (c *PolicyBundleCache) Get(ctx context.Context, source PolicySource) (string, error) {
+	key := c.key(source)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if dir, ok := c.cache[key]; ok {
+		return dir, nil
+	}
+
+	dir := filepath.Join(c.Dir, key)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if err := c.pull(ctx, source, dir); err != nil {
+			return "", errors.Wrapf(err, "pulling %s at version %s", source, source.Version)
+		}
+	} else if err != nil {
+		return "", errors.Wrapf(err, "checking for cached bundle %s", dir)
+	}
+
+	c.cache[key] = dir
+	return dir, nil
+}
+
+// pull loads the bundle into a temporary directory first so that a partial pull is never cached
+func (c *PolicyBundleCache) pull(ctx context.Context, source PolicySource, dest string) error {
+	tmpDir, err := os.MkdirTemp(c.Dir, "pull-")
+	if err != nil {
+		return errors.Wrap(err, "creating temp dir")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	envs, err := c.GitCredentials.Envs(ctx, c.InstallationID)
+	if err != nil {
+		return errors.Wrap(err, "loading git cli credentials")
+	}
+
+	buf := &bytes.Buffer{}
+	err = c.ConftestClient.RunCommand(ctx, &command.RunCommandRequest{
+		RootPath: c.Dir,
+		SubCommand: command.NewSubCommand(command.ConftestPull).
+			WithArgs(command.Argument{Key: "p", Value: tmpDir}).
+			WithInput(source.PullURL()),
+		AdditionalEnvVars: envs,
+		Version:           c.Version,
+	}, command.RunOptions{
+		StdOut: buf,
+		StdErr: buf,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "running conftest pull: %s", buf.String())
+	}
+
+	return os.Rename(tmpDir, dest)
+}
+
+func (c *PolicyBundleCache) key(source PolicySource) string {
+	h := sha256.Sum256([]byte(source.PullURL()))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,80 @@
+package activities
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/command"
+	"github.com/stretchr/testify/assert"
+)
+
+// testPullClient writes a policy into the destination of each conftest pull
+type testPullClient struct {
+	t     *testing.T
+	pulls []string
+	envs  map[string]string
+}
+
+func (c *testPullClient) RunCommand(ctx context.Context, request *command.RunCommandRequest, options ...command.RunOptions) error {
+	assert.Equal(c.t, c.envs, request.AdditionalEnvVars)
+
+	args := request.SubCommand.Build()
+	assert.Equal(c.t, string(command.ConftestPull), args[0])
+
+	dest := args[1][len("-p="):]
+	c.pulls = append(c.pulls, args[2])
+	return os.WriteFile(filepath.Join(dest, "policy.rego"), []byte("package main"), 0600)
+}
+
+func TestPolicySource_PullURL(t *testing.T) {
+	git := PolicySource{Type: valid.GitPolicySource, URL: "github.com/owner/policies//bundle", Version: "v1.2.0"}
+	assert.Equal(t, "git::github.com/owner/policies//bundle?ref=v1.2.0", git.PullURL())
+
+	oci := PolicySource{Type: valid.OCIPolicySource, URL: "oci://ghcr.io/owner/policies", Version: "1.2.0"}
+	assert.Equal(t, "oci://ghcr.io/owner/policies:1.2.0", oci.PullURL())
+}
+
+func TestPolicyBundleCache_Get(t *testing.T) {
+	v, err := version.NewVersion("0.20.0")
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	envs := map[string]string{"GIT_CONFIG_COUNT": "3"}
+	client := &testPullClient{t: t, envs: envs}
+	credentials := &testGitCredentials{t: t, expectedInstallationID: 1, envs: envs}
+	source := PolicySource{Type: valid.GitPolicySource, URL: "github.com/owner/policies", Version: "v1.2.0"}
+
+	cache := NewPolicyBundleCache(dir, client, v, credentials, 1)
+	bundleDir, err := cache.Get(context.Background(), source)
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(bundleDir, "policy.rego"))
+	assert.True(t, credentials.called)
+
+	t.Run("cached in memory", func(t *testing.T) {
+		result, err := cache.Get(context.Background(), source)
+		assert.NoError(t, err)
+		assert.Equal(t, bundleDir, result)
+		assert.Len(t, client.pulls, 1)
+	})
+
+	t.Run("cached on disk", func(t *testing.T) {
+		result, err := NewPolicyBundleCache(dir, client, v, credentials, 1).Get(context.Background(), source)
+		assert.NoError(t, err)
+		assert.Equal(t, bundleDir, result)
+		assert.Len(t, client.pulls, 1)
+	})
+
+	t.Run("new version", func(t *testing.T) {
+		updated := source
+		updated.Version = "v1.3.0"
+
+		result, err := cache.Get(context.Background(), updated)
+		assert.NoError(t, err)
+		assert.NotEqual(t, bundleDir, result)
+		assert.Equal(t, []string{source.PullURL(), updated.PullURL()}, client.pulls)
+	})
+}
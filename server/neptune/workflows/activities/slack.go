@@ -2,10 +2,57 @@ package activities
 
 import (
 	"context"
+	"net/url"
 
+	"github.com/pkg/errors"
 	"github.com/slack-go/slack"
 )
 
+// SlackPlanReviewCallbackID identifies interactive plan review messages. Buttons on these
+// messages are named after the review action (ie. Confirm/Reject) and their value is an
+// encoded SlackPlanReviewValue.
+const SlackPlanReviewCallbackID = "atlantis_plan_review"
+
+const (
+	slackPlanReviewWorkflowIDKey = "workflow_id"
+	slackPlanReviewRepoKey       = "repo"
+	slackPlanReviewRootKey       = "root"
+)
+
+// SlackPlanReviewValue is the context attached to plan review buttons so that the gateway
+// can authorize the reviewer and signal the terraform workflow awaiting review.
+type SlackPlanReviewValue struct {
+	WorkflowID string
+	// Repo is the full name of the repository, ie. owner/repo
+	Repo string
+	Root string
+}
+
+func (v SlackPlanReviewValue) Encode() string {
+	return url.Values{
+		slackPlanReviewWorkflowIDKey: {v.WorkflowID},
+		slackPlanReviewRepoKey:       {v.Repo},
+		slackPlanReviewRootKey:       {v.Root},
+	}.Encode()
+}
+
+func DecodeSlackPlanReviewValue(value string) (SlackPlanReviewValue, error) {
+	values, err := url.ParseQuery(value)
+	if err != nil {
+		return SlackPlanReviewValue{}, errors.Wrap(err, "parsing value")
+	}
+
+	v := SlackPlanReviewValue{
+		WorkflowID: values.Get(slackPlanReviewWorkflowIDKey),
+		Repo:       values.Get(slackPlanReviewRepoKey),
+		Root:       values.Get(slackPlanReviewRootKey),
+	}
+	if v.WorkflowID == "" || v.Repo == "" || v.Root == "" {
+		return SlackPlanReviewValue{}, errors.New("workflow id, repo and root are required")
+	}
+	return v, nil
+}
+
 type slackActivities struct {
 	Client *slack.Client
 }
@@ -14,6 +61,9 @@ type MessageChannelRequest struct {
 	ChannelID   string
 	Message     string
 	Attachments []slack.Attachment
+
+	// ThreadTS replies in the thread of the given message when set
+	ThreadTS string
 }
 
 type MessageChannelResponse struct {
@@ -21,12 +71,16 @@ type MessageChannelResponse struct {
 }
 
 func (a *slackActivities) MessageChannel(ctx context.Context, request MessageChannelRequest) (MessageChannelResponse, error) {
-	_, ts, err := a.Client.PostMessageContext(
-		ctx, request.ChannelID,
+	options := []slack.MsgOption{
 		slack.MsgOptionText(request.Message, true),
 		slack.MsgOptionPostMessageParameters(slack.PostMessageParameters{Markdown: true}),
 		slack.MsgOptionAttachments(request.Attachments...),
-	)
+	}
+	if request.ThreadTS != "" {
+		options = append(options, slack.MsgOptionTS(request.ThreadTS))
+	}
+
+	_, ts, err := a.Client.PostMessageContext(ctx, request.ChannelID, options...)
 	if err != nil {
 		return MessageChannelResponse{}, err
 	}
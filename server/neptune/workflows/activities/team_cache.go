@@ -0,0 +1,58 @@
+package activities
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultTeamMembershipTTL bounds how stale a cached team membership can be, memberships are
+// refreshed sooner when workflows are signaled about a change.
+const DefaultTeamMembershipTTL = 10 * time.Minute
+
+// teamMembershipCache caches team members per worker so that re-evaluating policy approvals
+// doesn't list every owner team from github each time.
+type teamMembershipCache struct {
+	ttl     time.Duration
+	lock    sync.RWMutex
+	entries map[string]teamMembership
+	now     func() time.Time
+}
+
+type teamMembership struct {
+	members   []string
+	fetchedAt time.Time
+}
+
+func newTeamMembershipCache(ttl time.Duration) *teamMembershipCache {
+	return &teamMembershipCache{
+		ttl:     ttl,
+		entries: make(map[string]teamMembership),
+		now:     time.Now,
+	}
+}
+
+// Get returns the members of the team if they were fetched within the ttl
+func (c *teamMembershipCache) Get(org string, slug string) ([]string, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	entry, ok := c.entries[c.key(org, slug)]
+	if !ok || c.now().Sub(entry.fetchedAt) >= c.ttl {
+		return nil, false
+	}
+	return entry.members, true
+}
+
+func (c *teamMembershipCache) Set(org string, slug string, members []string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries[c.key(org, slug)] = teamMembership{
+		members:   members,
+		fetchedAt: c.now(),
+	}
+}
+
+func (c *teamMembershipCache) key(org string, slug string) string {
+	return org + "/" + slug
+}
@@ -0,0 +1,29 @@
+package activities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamMembershipCache(t *testing.T) {
+	now := time.Date(2022, time.June, 15, 12, 0, 0, 0, time.UTC)
+	cache := newTeamMembershipCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	_, ok := cache.Get("org", "team")
+	assert.False(t, ok)
+
+	cache.Set("org", "team", []string{"alice"})
+	members, ok := cache.Get("org", "team")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"alice"}, members)
+
+	_, ok = cache.Get("other", "team")
+	assert.False(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Get("org", "team")
+	assert.False(t, ok)
+}
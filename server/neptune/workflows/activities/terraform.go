@@ -18,7 +18,6 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
-	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/file"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/temporal"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
 )
@@ -82,8 +81,8 @@ type streamer interface {
 	RegisterJob(id string) chan string
 }
 
-type gitCredentialsRefresher interface {
-	Refresh(ctx context.Context, token int64) error
+type gitCredentials interface {
+	Envs(ctx context.Context, installationID int64) (map[string]string, error)
 }
 
 type writer interface {
@@ -91,36 +90,33 @@ type writer interface {
 }
 
 type terraformActivities struct {
-	TerraformClient        TerraformClient
-	DefaultTFVersion       *version.Version
-	StreamHandler          streamer
-	GHAppConfig            githubapp.Config
-	GitCLICredentials      gitCredentialsRefresher
-	GitCredentialsFileLock *file.RWLock
-	FileWriter             writer
-	CacheDir               string
-	InstallationID         int64
+	TerraformClient   TerraformClient
+	DefaultTFVersion  *version.Version
+	StreamHandler     streamer
+	GHAppConfig       githubapp.Config
+	GitCLICredentials gitCredentials
+	FileWriter        writer
+	CacheDir          string
+	InstallationID    int64
 }
 
 func NewTerraformActivities(
 	client TerraformClient,
 	defaultTfVersion *version.Version,
 	streamHandler streamer,
-	gitCredentialsRefresher gitCredentialsRefresher,
-	gitCredentialsFileLock *file.RWLock,
+	gitCredentials gitCredentials,
 	fileWriter writer,
 	cacheDir string,
 	installationID int64,
 ) *terraformActivities { //nolint:revive // avoiding refactor while adding linter action
 	return &terraformActivities{
-		TerraformClient:        client,
-		DefaultTFVersion:       defaultTfVersion,
-		StreamHandler:          streamHandler,
-		GitCLICredentials:      gitCredentialsRefresher,
-		GitCredentialsFileLock: gitCredentialsFileLock,
-		InstallationID:         installationID,
-		FileWriter:             fileWriter,
-		CacheDir:               cacheDir,
+		TerraformClient:   client,
+		DefaultTFVersion:  defaultTfVersion,
+		StreamHandler:     streamHandler,
+		GitCLICredentials: gitCredentials,
+		InstallationID:    installationID,
+		FileWriter:        fileWriter,
+		CacheDir:          cacheDir,
 	}
 }
 
@@ -181,16 +177,19 @@ func (t *terraformActivities) TerraformInit(ctx context.Context, request Terrafo
 		Version:           tfVersion,
 	}
 
-	err = t.GitCLICredentials.Refresh(ctx, t.InstallationID)
+	// terraform init clones private modules using the git cli, credentials are scoped to
+	// this command so concurrent inits for other installations aren't affected.
+	installationID := request.GithubInstallationID
+	if installationID == 0 {
+		installationID = t.InstallationID
+	}
+	gitEnvs, err := t.GitCLICredentials.Envs(ctx, installationID)
 	if err != nil {
-		activity.GetLogger(ctx).Error("Error refreshing git cli credentials. This is bug and will likely cause fetching of private modules to fail", key.ErrKey, err)
+		activity.GetLogger(ctx).Error("Error loading git cli credentials. This is bug and will likely cause fetching of private modules to fail", key.ErrKey, err)
+	}
+	for k, v := range gitEnvs {
+		envs[k] = v
 	}
-
-	// terraform init clones repos using git cli auth of which we chose git global configs.
-	// let's ensure we are locking access to this file so it's not rewritten to during the duration of our
-	// operation
-	t.GitCredentialsFileLock.RLock()
-	defer t.GitCredentialsFileLock.RUnlock()
 
 	out, err := t.runCommandWithOutputStream(ctx, request.JobID, r)
 	if err != nil {
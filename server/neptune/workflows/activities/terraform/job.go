@@ -8,6 +8,9 @@ import (
 type PlanApproval struct {
 	Type   PlanApprovalType
 	Reason string
+
+	// Requester is the user who may not confirm the plan, empty if anyone can.
+	Requester string
 }
 
 type PlanApprovalType int
@@ -55,3 +58,15 @@ const (
 	PR
 	Adhoc
 )
+
+func (m WorkflowMode) String() string {
+	switch m {
+	case Deploy:
+		return "deploy"
+	case PR:
+		return "pr"
+	case Adhoc:
+		return "adhoc"
+	}
+	return "unknown"
+}
@@ -0,0 +1,181 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-json"
+	"github.com/pkg/errors"
+)
+
+// ResourceDiff describes how the planned change of a resource differs between two plans.
+// Only attribute names are kept so that sensitive values never end up in check runs or comments.
+type ResourceDiff struct {
+	Address string
+	// Actions is set to the new actions if they differ from the previous plan
+	Actions string
+	// Attributes whose planned values differ
+	Attributes []string
+}
+
+// PlanDiff is the semantic diff of a root's plan against its plan for a previous revision
+type PlanDiff struct {
+	PreviousRevision string
+	// Added resources are only changed by the new plan
+	Added []ResourceSummary
+	// Removed resources are only changed by the previous plan
+	Removed []ResourceSummary
+	Changed []ResourceDiff
+}
+
+func (d PlanDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+func (d PlanDiff) String() string {
+	if d.IsEmpty() {
+		return fmt.Sprintf("No changes to the plan since %s.", d.PreviousRevision)
+	}
+	return fmt.Sprintf("Since %s: %d added to the plan, %d removed from the plan, %d changed.", d.PreviousRevision, len(d.Added), len(d.Removed), len(d.Changed))
+}
+
+// Lines renders the diff with one resource per line, prefixed like a unified diff
+func (d PlanDiff) Lines() []string {
+	var lines []string
+	for _, r := range d.Added {
+		lines = append(lines, fmt.Sprintf("+ %s", r.Address))
+	}
+	for _, r := range d.Removed {
+		lines = append(lines, fmt.Sprintf("- %s", r.Address))
+	}
+	for _, r := range d.Changed {
+		var details []string
+		if r.Actions != "" {
+			details = append(details, fmt.Sprintf("now %s", r.Actions))
+		}
+		if len(r.Attributes) > 0 {
+			details = append(details, strings.Join(r.Attributes, ", "))
+		}
+		lines = append(lines, fmt.Sprintf("! %s: %s", r.Address, strings.Join(details, "; ")))
+	}
+	return lines
+}
+
+// NewPlanDiffFromJSON diffs the resource changes of two plan json files, resources which
+// aren't changed by a plan (ie. no-ops) are not considered part of it.
+func NewPlanDiffFromJSON(previousRevision string, current []byte, previous []byte) (PlanDiff, error) {
+	currentChanges, err := resourceChanges(current)
+	if err != nil {
+		return PlanDiff{}, errors.Wrap(err, "parsing current plan")
+	}
+	previousChanges, err := resourceChanges(previous)
+	if err != nil {
+		return PlanDiff{}, errors.Wrap(err, "parsing previous plan")
+	}
+
+	diff := PlanDiff{
+		PreviousRevision: previousRevision,
+	}
+	for _, address := range sortedAddresses(currentChanges) {
+		change := currentChanges[address]
+		previousChange, ok := previousChanges[address]
+		if !ok {
+			diff.Added = append(diff.Added, ResourceSummary{Address: address})
+			continue
+		}
+
+		resourceDiff := ResourceDiff{
+			Address:    address,
+			Attributes: changedAttributes(previousChange, change),
+		}
+		if !reflect.DeepEqual(change.Actions, previousChange.Actions) {
+			resourceDiff.Actions = actionsString(change.Actions)
+		}
+		if resourceDiff.Actions != "" || len(resourceDiff.Attributes) > 0 {
+			diff.Changed = append(diff.Changed, resourceDiff)
+		}
+	}
+	for _, address := range sortedAddresses(previousChanges) {
+		if _, ok := currentChanges[address]; !ok {
+			diff.Removed = append(diff.Removed, ResourceSummary{Address: address})
+		}
+	}
+	return diff, nil
+}
+
+func resourceChanges(b []byte) (map[string]*tfjson.Change, error) {
+	changes := make(map[string]*tfjson.Change)
+	if len(b) == 0 {
+		return changes, nil
+	}
+
+	var plan tfjson.Plan
+	if err := json.Unmarshal(b, &plan); err != nil {
+		return nil, errors.Wrap(err, "parsing plan json")
+	}
+	for _, c := range plan.ResourceChanges {
+		if c.Change == nil || c.Change.Actions.NoOp() {
+			continue
+		}
+		changes[c.Address] = c.Change
+	}
+	return changes, nil
+}
+
+// changedAttributes returns the top level attributes whose planned or unknown values differ
+func changedAttributes(previous *tfjson.Change, current *tfjson.Change) []string {
+	changed := make(map[string]bool)
+	for _, pair := range [][2]interface{}{
+		{previous.After, current.After},
+		{previous.AfterUnknown, current.AfterUnknown},
+	} {
+		before, _ := pair[0].(map[string]interface{})
+		after, _ := pair[1].(map[string]interface{})
+		for k, v := range before {
+			if !reflect.DeepEqual(v, after[k]) {
+				changed[k] = true
+			}
+		}
+		for k, v := range after {
+			if !reflect.DeepEqual(v, before[k]) {
+				changed[k] = true
+			}
+		}
+	}
+
+	var attributes []string
+	for k := range changed {
+		attributes = append(attributes, k)
+	}
+	sort.Strings(attributes)
+	return attributes
+}
+
+func actionsString(actions tfjson.Actions) string {
+	switch {
+	case actions.Replace():
+		return "replace"
+	case actions.Create():
+		return "create"
+	case actions.Delete():
+		return "delete"
+	case actions.Update():
+		return "update"
+	case actions.Read():
+		return "read"
+	default:
+		return "no-op"
+	}
+}
+
+func sortedAddresses(changes map[string]*tfjson.Change) []string {
+	var addresses []string
+	for address := range changes {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
@@ -0,0 +1,57 @@
+package terraform_test
+
+import (
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanDiff(t *testing.T) {
+	previous := `{"format_version": "1.0","resource_changes":[
+		{"address":"type.unchanged","change":{"actions":["update"],"after":{"name":"a"}}},
+		{"address":"type.changed","change":{"actions":["update"],"after":{"name":"a","size":1}}},
+		{"address":"type.replaced","change":{"actions":["update"],"after":{"name":"a"}}},
+		{"address":"type.removed","change":{"actions":["create"],"after":{"name":"a"}}}
+	]}`
+	current := `{"format_version": "1.0","resource_changes":[
+		{"address":"type.unchanged","change":{"actions":["update"],"after":{"name":"a"}}},
+		{"address":"type.changed","change":{"actions":["update"],"after":{"name":"a","size":2},"after_unknown":{"id":true}}},
+		{"address":"type.replaced","change":{"actions":["delete","create"],"after":{"name":"a"}}},
+		{"address":"type.added","change":{"actions":["create"],"after":{"name":"a"}}},
+		{"address":"type.noop","change":{"actions":["no-op"],"after":{"name":"a"}}}
+	]}`
+
+	t.Run("changed", func(t *testing.T) {
+		diff, err := terraform.NewPlanDiffFromJSON("abc", []byte(current), []byte(previous))
+		assert.NoError(t, err)
+		assert.Equal(t, terraform.PlanDiff{
+			PreviousRevision: "abc",
+			Added:            []terraform.ResourceSummary{{Address: "type.added"}},
+			Removed:          []terraform.ResourceSummary{{Address: "type.removed"}},
+			Changed: []terraform.ResourceDiff{
+				{Address: "type.changed", Attributes: []string{"id", "size"}},
+				{Address: "type.replaced", Actions: "replace"},
+			},
+		}, diff)
+		assert.Equal(t, "Since abc: 1 added to the plan, 1 removed from the plan, 2 changed.", diff.String())
+		assert.Equal(t, []string{
+			"+ type.added",
+			"- type.removed",
+			"! type.changed: id, size",
+			"! type.replaced: now replace",
+		}, diff.Lines())
+	})
+
+	t.Run("identical", func(t *testing.T) {
+		diff, err := terraform.NewPlanDiffFromJSON("abc", []byte(current), []byte(current))
+		assert.NoError(t, err)
+		assert.True(t, diff.IsEmpty())
+		assert.Equal(t, "No changes to the plan since abc.", diff.String())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := terraform.NewPlanDiffFromJSON("abc", []byte(current), []byte("{{"))
+		assert.Error(t, err)
+	})
+}
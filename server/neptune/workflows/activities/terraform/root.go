@@ -8,6 +8,14 @@ import (
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
 )
 
+// PolicyTarget are the attributes of a root policy set scopes are matched against
+type PolicyTarget struct {
+	Name string
+	// Path is the relative path from the repo
+	Path string
+	Tags map[string]string
+}
+
 // Root is the definition of a root
 type Root struct {
 	Name string
@@ -20,8 +28,22 @@ type Root struct {
 	Validate     execute.Job
 	TrackedFiles []string
 
+	// Owners are github users or org/team slugs which own the root, empty if unowned
+	Owners []string
+
+	// PolicyTarget is the root as configured on the default branch, policy set scopes are matched
+	// against it.  Nil if the root isn't on the default branch yet.
+	PolicyTarget *PolicyTarget
+
 	TriggerInfo TriggerInfo
 
+	// PartialPlan is set when the plan was run with user supplied terraform args, ex. -target.
+	// Partial plans can't be reviewed, carried forward or satisfy policies.
+	PartialPlan bool
+
+	// TwoPersonApproval requires the plan to be confirmed by someone other than the initiating user
+	TwoPersonApproval bool
+
 	// replace with trigger info
 	Trigger Trigger
 	Rerun   bool
@@ -81,6 +103,15 @@ func (r *LocalRoot) RelativePathFromRepo() string {
 	return r.Root.Path
 }
 
+// RepoDir returns the path on disk of the repo containing the root
+func (r *LocalRoot) RepoDir() string {
+	relPath := filepath.Clean(r.Root.Path)
+	if relPath == "." {
+		return r.Path
+	}
+	return strings.TrimSuffix(filepath.Clean(r.Path), string(filepath.Separator)+relPath)
+}
+
 func BuildLocalRoot(root Root, repo github.Repo, path string) *LocalRoot {
 	return &LocalRoot{
 		Root: root,
@@ -70,3 +70,46 @@ func (s PlanSummary) String() string {
 	}
 	return fmt.Sprintf("Plan: %d to add, %d to change, %d to destroy.", len(s.Creations), len(s.Updates), len(s.Deletions))
 }
+
+// PlanSummaryDelta holds the resource changes which only exist in one of two plan summaries
+type PlanSummaryDelta struct {
+	// Added changes are only in the new summary
+	Added PlanSummary
+	// Removed changes are only in the old summary
+	Removed PlanSummary
+}
+
+func (d PlanSummaryDelta) IsEmpty() bool {
+	return d.Added.IsEmpty() && d.Removed.IsEmpty()
+}
+
+// Diff returns the resource changes of s which are not in old and vice versa
+func (s PlanSummary) Diff(old PlanSummary) PlanSummaryDelta {
+	return PlanSummaryDelta{
+		Added: PlanSummary{
+			Creations: subtractResources(s.Creations, old.Creations),
+			Deletions: subtractResources(s.Deletions, old.Deletions),
+			Updates:   subtractResources(s.Updates, old.Updates),
+		},
+		Removed: PlanSummary{
+			Creations: subtractResources(old.Creations, s.Creations),
+			Deletions: subtractResources(old.Deletions, s.Deletions),
+			Updates:   subtractResources(old.Updates, s.Updates),
+		},
+	}
+}
+
+func subtractResources(a []ResourceSummary, b []ResourceSummary) []ResourceSummary {
+	addresses := make(map[string]bool)
+	for _, r := range b {
+		addresses[r.Address] = true
+	}
+
+	var result []ResourceSummary
+	for _, r := range a {
+		if !addresses[r.Address] {
+			result = append(result, r)
+		}
+	}
+	return result
+}
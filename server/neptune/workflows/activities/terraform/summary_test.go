@@ -76,3 +76,32 @@ func TestSummary_string(t *testing.T) {
 
 	assert.Equal(t, "Plan: 1 to add, 1 to change, 1 to destroy.", summary.String())
 }
+
+func TestSummary_diff(t *testing.T) {
+	reviewed := terraform.PlanSummary{
+		Creations: []terraform.ResourceSummary{{Address: "type.created"}},
+		Updates:   []terraform.ResourceSummary{{Address: "type.updated"}},
+	}
+
+	t.Run("identical", func(t *testing.T) {
+		assert.True(t, reviewed.Diff(reviewed).IsEmpty())
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		current := terraform.PlanSummary{
+			Creations: []terraform.ResourceSummary{{Address: "type.created"}},
+			Deletions: []terraform.ResourceSummary{{Address: "type.deleted"}},
+		}
+
+		delta := current.Diff(reviewed)
+		assert.False(t, delta.IsEmpty())
+		assert.Equal(t, terraform.PlanSummaryDelta{
+			Added: terraform.PlanSummary{
+				Deletions: []terraform.ResourceSummary{{Address: "type.deleted"}},
+			},
+			Removed: terraform.PlanSummary{
+				Updates: []terraform.ResourceSummary{{Address: "type.updated"}},
+			},
+		}, delta)
+	})
+}
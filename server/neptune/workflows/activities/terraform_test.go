@@ -10,22 +10,22 @@ import (
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/command"
 
 	"github.com/hashicorp/go-version"
-	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/file"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
 	"github.com/stretchr/testify/assert"
 	"go.temporal.io/sdk/testsuite"
 )
 
-type testCredsRefresher struct {
+type testGitCredentials struct {
 	called                 bool
 	expectedInstallationID int64
+	envs                   map[string]string
 	t                      *testing.T
 }
 
-func (t *testCredsRefresher) Refresh(ctx context.Context, installationID int64) error {
+func (t *testGitCredentials) Envs(ctx context.Context, installationID int64) (map[string]string, error) {
 	assert.Equal(t.t, t.expectedInstallationID, installationID)
 	t.called = true
-	return nil
+	return t.envs, nil
 }
 
 type testStreamHandler struct {
@@ -214,7 +214,7 @@ func TestTerraformInit_RequestValidation(t *testing.T) {
 				Args:        c.RequestArgs,
 			}
 
-			credsRefresher := &testCredsRefresher{
+			gitCredentials := &testGitCredentials{
 				expectedInstallationID: 1235,
 				t:                      t,
 			}
@@ -225,8 +225,7 @@ func TestTerraformInit_RequestValidation(t *testing.T) {
 				&testStreamHandler{
 					t: t,
 				},
-				credsRefresher,
-				&file.RWLock{},
+				gitCredentials,
 				&mockWriter{},
 				"some/dir",
 				1235)
@@ -235,7 +234,7 @@ func TestTerraformInit_RequestValidation(t *testing.T) {
 			_, err = env.ExecuteActivity(tfActivity.TerraformInit, req)
 			assert.NoError(t, err)
 
-			assert.True(t, credsRefresher.called)
+			assert.True(t, gitCredentials.called)
 		})
 	}
 }
@@ -288,12 +287,12 @@ func TestTerraformInit_StreamsOutput(t *testing.T) {
 		expectedJobID: jobID,
 	}
 
-	credsRefresher := &testCredsRefresher{
+	gitCredentials := &testGitCredentials{
 		expectedInstallationID: 1235,
 		t:                      t,
 	}
 
-	tfActivity := NewTerraformActivities(testTfClient, expectedVersion, streamHandler, credsRefresher, &file.RWLock{}, &mockWriter{}, "some/dir", 1235)
+	tfActivity := NewTerraformActivities(testTfClient, expectedVersion, streamHandler, gitCredentials, &mockWriter{}, "some/dir", 1235)
 	env.RegisterActivity(tfActivity)
 
 	_, err = env.ExecuteActivity(tfActivity.TerraformInit, req)
@@ -304,6 +303,56 @@ func TestTerraformInit_StreamsOutput(t *testing.T) {
 	assert.True(t, streamHandler.called)
 }
 
+func TestTerraformInit_GitCredentials(t *testing.T) {
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestActivityEnvironment()
+
+	path := "some/path"
+	jobID := "1234"
+
+	expectedVersion, err := version.NewVersion("1.0.2")
+	assert.NoError(t, err)
+
+	testTfClient := &testTfClient{
+		t:     t,
+		jobID: jobID,
+		path:  path,
+		cmd: command.NewSubCommand(command.TerraformInit).WithUniqueArgs(command.Argument{
+			Key:   "input",
+			Value: "false",
+		}),
+		customEnvVars: map[string]string{
+			"ATLANTIS_TERRAFORM_VERSION": "1.0.2",
+			"DIR":                        "some/path",
+			"TF_IN_AUTOMATION":           "true",
+			"TF_PLUGIN_CACHE_DIR":        "some/dir",
+			"TF_PLUGIN_CACHE_MAY_BREAK_DEPENDENCY_LOCK_FILE": "true",
+			"GIT_CONFIG_COUNT": "1",
+		},
+		version: expectedVersion,
+	}
+
+	// the request's installation takes precedence over the worker's default
+	gitCredentials := &testGitCredentials{
+		expectedInstallationID: 5678,
+		envs: map[string]string{
+			"GIT_CONFIG_COUNT": "1",
+		},
+		t: t,
+	}
+
+	tfActivity := NewTerraformActivities(testTfClient, expectedVersion, &testStreamHandler{t: t}, gitCredentials, &mockWriter{}, "some/dir", 1235)
+	env.RegisterActivity(tfActivity)
+
+	_, err = env.ExecuteActivity(tfActivity.TerraformInit, TerraformInitRequest{
+		JobID:                jobID,
+		Path:                 path,
+		GithubInstallationID: 5678,
+	})
+	assert.NoError(t, err)
+	assert.True(t, gitCredentials.called)
+}
+
 func TestTerraformPlan_RequestValidation(t *testing.T) {
 	defaultArgs := []command.Argument{
 		{
@@ -465,7 +514,7 @@ func TestTerraformPlan_RequestValidation(t *testing.T) {
 				WorkflowMode: c.WorkflowMode,
 			}
 
-			credsRefresher := &testCredsRefresher{}
+			gitCredentials := &testGitCredentials{}
 			fileWriter := &mockWriter{
 				t:            t,
 				expectedName: "some/path/output.json",
@@ -473,7 +522,7 @@ func TestTerraformPlan_RequestValidation(t *testing.T) {
 
 			tfActivity := NewTerraformActivities(&testTfClient, expectedVersion, &testStreamHandler{
 				t: t,
-			}, credsRefresher, &file.RWLock{}, fileWriter, "some/dir", 0)
+			}, gitCredentials, fileWriter, "some/dir", 0)
 			env.RegisterActivity(tfActivity)
 
 			_, err = env.ExecuteActivity(tfActivity.TerraformPlan, req)
@@ -555,9 +604,9 @@ func TestTerraformPlan_ReturnsResponse(t *testing.T) {
 		expectedJobID: jobID,
 	}
 
-	credsRefresher := &testCredsRefresher{}
+	gitCredentials := &testGitCredentials{}
 
-	tfActivity := NewTerraformActivities(&testTfClient, expectedVersion, streamHandler, credsRefresher, &file.RWLock{}, &mockWriter{}, "some/dir", 0)
+	tfActivity := NewTerraformActivities(&testTfClient, expectedVersion, streamHandler, gitCredentials, &mockWriter{}, "some/dir", 0)
 
 	env.RegisterActivity(tfActivity)
 
@@ -694,7 +743,7 @@ func TestTerraformApply_RequestValidation(t *testing.T) {
 
 			tfActivity := NewTerraformActivities(testClient, expectedVersion, &testStreamHandler{
 				t: t,
-			}, &testCredsRefresher{}, &file.RWLock{}, &mockWriter{}, "some/dir", 0)
+			}, &testGitCredentials{}, &mockWriter{}, "some/dir", 0)
 			env.RegisterActivity(tfActivity)
 
 			_, err = env.ExecuteActivity(tfActivity.TerraformApply, req)
@@ -752,7 +801,7 @@ func TestTerraformApply_StreamsOutput(t *testing.T) {
 		expectedJobID: jobID,
 	}
 
-	tfActivity := NewTerraformActivities(testTfClient, expectedVersion, streamHandler, &testCredsRefresher{}, &file.RWLock{}, &mockWriter{}, "some/dir", 0)
+	tfActivity := NewTerraformActivities(testTfClient, expectedVersion, streamHandler, &testGitCredentials{}, &mockWriter{}, "some/dir", 0)
 	env.RegisterActivity(tfActivity)
 
 	_, err = env.ExecuteActivity(tfActivity.TerraformApply, req)
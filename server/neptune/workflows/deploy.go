@@ -23,6 +23,8 @@ type User = request.User
 type PlanMode = request.PlanMode
 type Trigger = request.Trigger
 type DeployTriggerInfo = request.TriggerInfo
+type DeployNotifications = request.Notifications
+type DeploySlackNotification = request.SlackNotification
 
 const DestroyPlanMode = request.DestroyPlanMode
 const NormalPlanMode = request.NormalPlanMode
@@ -33,11 +33,11 @@ type a struct {
 	*activities.Deploy
 }
 
-// we don't want to mess up all our gitconfig for testing purposes
-type noopCredentialsRefresher struct{}
+// tests have no github app to mint installation tokens from
+type noopGitCredentials struct{}
 
-func (r noopCredentialsRefresher) Refresh(ctx context.Context, token int64) error {
-	return nil
+func (r noopGitCredentials) Envs(ctx context.Context, installationID int64) (map[string]string, error) {
+	return nil, nil
 }
 
 func TestDeployWorkflow(t *testing.T) {
@@ -163,7 +163,7 @@ func buildConfig(t *testing.T) config.Config {
 func initAndRegisterActivities(t *testing.T, env *testsuite.TestWorkflowEnvironment) *testSingletons {
 	cfg := buildConfig(t)
 
-	deployActivities, err := activities.NewDeploy(cfg.DeploymentConfig)
+	deployActivities, err := activities.NewDeploy(cfg.DeploymentConfig, "")
 
 	assert.NoError(t, err)
 
@@ -181,9 +181,9 @@ func initAndRegisterActivities(t *testing.T, env *testsuite.TestWorkflowEnvironm
 		0,
 		streamCloser,
 		activities.TerraformOptions{
-			TFVersionCache:          cache.NewLocalBinaryCache("terraform"),
-			ConftestVersionCache:    cache.NewLocalBinaryCache("conftest"),
-			GitCredentialsRefresher: noopCredentialsRefresher{},
+			TFVersionCache:       cache.NewLocalBinaryCache("terraform"),
+			ConftestVersionCache: cache.NewLocalBinaryCache("conftest"),
+			GitCredentials:       noopGitCredentials{},
 		},
 	)
 
@@ -262,23 +262,31 @@ type testGithubClient struct {
 	DeploymentID string
 }
 
-func (c *testGithubClient) CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+func (c *testGithubClient) CreateComment(ctx context.Context, installationToken int64, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	return &github.IssueComment{}, &github.Response{}, nil
+}
+
+func (c *testGithubClient) EditComment(ctx context.Context, installationToken int64, owner string, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
 	return &github.IssueComment{}, &github.Response{}, nil
 }
 
-func (c *testGithubClient) ListTeamMembers(ctx context.Context, org string, teamSlug string) ([]*github.User, error) {
+func (c *testGithubClient) ListTeamMembers(ctx context.Context, installationToken int64, org string, teamSlug string) ([]*github.User, error) {
 	return []*github.User{}, nil
 }
 
-func (c *testGithubClient) ListCommits(ctx context.Context, owner string, repo string, number int) ([]*github.RepositoryCommit, error) {
+func (c *testGithubClient) ListCommits(ctx context.Context, installationToken int64, owner string, repo string, number int) ([]*github.RepositoryCommit, error) {
 	return []*github.RepositoryCommit{}, nil
 }
 
-func (c *testGithubClient) DismissReview(ctx context.Context, owner, repo string, number int, reviewID int64, review *github.PullRequestReviewDismissalRequest) (*github.PullRequestReview, *github.Response, error) {
+func (c *testGithubClient) ListPullRequestsWithCommit(ctx context.Context, installationToken int64, owner string, repo string, sha string) ([]*github.PullRequest, error) {
+	return []*github.PullRequest{}, nil
+}
+
+func (c *testGithubClient) DismissReview(ctx context.Context, installationToken int64, owner, repo string, number int, reviewID int64, review *github.PullRequestReviewDismissalRequest) (*github.PullRequestReview, *github.Response, error) {
 	return &github.PullRequestReview{}, &github.Response{}, nil
 }
 
-func (c *testGithubClient) ListReviews(ctx context.Context, owner string, repo string, number int) ([]*github.PullRequestReview, error) {
+func (c *testGithubClient) ListReviews(ctx context.Context, installationToken int64, owner string, repo string, number int) ([]*github.PullRequestReview, error) {
 	return []*github.PullRequestReview{
 		{
 			State: github.String("APPROVED"),
@@ -286,17 +294,17 @@ func (c *testGithubClient) ListReviews(ctx context.Context, owner string, repo s
 	}, nil
 }
 
-func (c *testGithubClient) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+func (c *testGithubClient) GetPullRequest(ctx context.Context, installationToken int64, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
 	return &github.PullRequest{}, &github.Response{}, nil
 }
 
-func (c *testGithubClient) CreateCheckRun(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+func (c *testGithubClient) CreateCheckRun(ctx context.Context, installationToken int64, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
 	c.DeploymentID = opts.GetExternalID()
 	return &github.CheckRun{
 		ID: github.Int64(123),
 	}, &github.Response{}, nil
 }
-func (c *testGithubClient) UpdateCheckRun(ctx context.Context, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+func (c *testGithubClient) UpdateCheckRun(ctx context.Context, installationToken int64, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
 	c.DeploymentID = opts.GetExternalID()
 	update := CheckRunUpdate{
 		Summary:    opts.GetOutput().GetSummary(),
@@ -308,12 +316,12 @@ func (c *testGithubClient) UpdateCheckRun(ctx context.Context, owner, repo strin
 
 	return &github.CheckRun{}, &github.Response{}, nil
 }
-func (c *testGithubClient) GetArchiveLink(ctx context.Context, owner, repo string, archiveformat github.ArchiveFormat, opts *github.RepositoryContentGetOptions, followRedirects bool) (*url.URL, *github.Response, error) {
+func (c *testGithubClient) GetArchiveLink(ctx context.Context, installationToken int64, owner, repo string, archiveformat github.ArchiveFormat, opts *github.RepositoryContentGetOptions, followRedirects bool) (*url.URL, *github.Response, error) {
 	url, _ := url.Parse("www.testurl.com")
 
 	return url, &github.Response{Response: &http.Response{StatusCode: http.StatusFound}}, nil
 }
-func (c *testGithubClient) CompareCommits(ctx context.Context, owner, repo string, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
+func (c *testGithubClient) CompareCommits(ctx context.Context, installationToken int64, owner, repo string, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
 	return &github.CommitsComparison{
 		Status: github.String("ahead"),
 	}, &github.Response{}, nil
@@ -0,0 +1,24 @@
+package converter
+
+import (
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/deploy/request"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/deploy/terraform"
+)
+
+func Notifications(external request.Notifications) terraform.NotificationConfig {
+	var slack []terraform.SlackConfig
+	for _, s := range external.Slack {
+		var events []valid.DeploySlackEvent
+		for _, e := range s.Events {
+			events = append(events, valid.DeploySlackEvent(e))
+		}
+		slack = append(slack, terraform.SlackConfig{
+			ChannelID: s.ChannelID,
+			Events:    events,
+		})
+	}
+	return terraform.NotificationConfig{
+		Slack: slack,
+	}
+}
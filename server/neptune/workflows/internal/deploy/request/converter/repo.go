@@ -14,6 +14,7 @@ func Repo(external request.Repo) github.Repo {
 			InstallationToken: external.Credentials.InstallationToken,
 		},
 		DefaultBranch: external.DefaultBranch,
+		Hostname:      external.Hostname,
 	}
 }
 
@@ -27,10 +27,11 @@ func Root(external request.Root) terraform.Root {
 			Force: external.TriggerInfo.Force,
 			Rerun: external.TriggerInfo.Rerun,
 		},
-		Trigger:      terraform.Trigger(external.TriggerInfo.Type),
-		Force:        external.TriggerInfo.Force,
-		Rerun:        external.TriggerInfo.Rerun,
-		TrackedFiles: external.TrackedFiles,
+		Trigger:           terraform.Trigger(external.TriggerInfo.Type),
+		Force:             external.TriggerInfo.Force,
+		Rerun:             external.TriggerInfo.Rerun,
+		TrackedFiles:      external.TrackedFiles,
+		TwoPersonApproval: external.TwoPersonApproval,
 	}
 }
 
@@ -0,0 +1,11 @@
+package request
+
+type Notifications struct {
+	Slack []SlackNotification
+}
+
+// SlackNotification is a slack route resolved for a root, empty Events enables every event.
+type SlackNotification struct {
+	ChannelID string
+	Events    []string
+}
@@ -16,6 +16,8 @@ type Repo struct {
 	RebaseEnabled bool
 	// Repo's default branch
 	DefaultBranch string
+	// Hostname of the github instance hosting the repo, ex. github.com
+	Hostname string
 
 	Credentials AppCredentials
 }
@@ -42,6 +42,9 @@ type Root struct {
 	PlanApproval PlanApproval
 	TriggerInfo  TriggerInfo
 
+	// TwoPersonApproval requires the plan to be confirmed by someone other than the initiating user
+	TwoPersonApproval bool
+
 	// todo: keeping for backwards compatibility with existing workflows
 	// remove once ALL workers are reading the new field.
 	Trigger Trigger
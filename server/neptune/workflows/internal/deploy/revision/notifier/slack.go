@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config/valid"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/deploy/lock"
@@ -41,14 +42,22 @@ func (s *Slack) Notify(ctx workflow.Context) error {
 	revision := infos[0].Commit.Revision
 	repo := infos[0].Repo.GetFullName()
 	root := infos[0].Root.Name
-	slackConfig := infos[0].Notifications.Slack
 
-	if len(slackConfig.ChannelID) == 0 {
-		return nil
+	for _, slackConfig := range infos[0].Notifications.Slack {
+		if !slackConfig.IsSubscribed(valid.LockedDeploySlackEvent) {
+			continue
+		}
+		if err := s.message(ctx, slackConfig.ChannelID, repo, root, revision); err != nil {
+			return err
+		}
 	}
 
+	return nil
+}
+
+func (s *Slack) message(ctx workflow.Context, channelID string, repo string, root string, revision string) error {
 	err := workflow.ExecuteActivity(ctx, s.Activities.MessageChannel, activities.MessageChannelRequest{
-		ChannelID: slackConfig.ChannelID,
+		ChannelID: channelID,
 		Message:   fmt.Sprintf("Deploys are locked for *%s* in *%s*.  Please navigate to the revision's check run to unlock the root", root, repo),
 		Attachments: []slack.Attachment{
 			{
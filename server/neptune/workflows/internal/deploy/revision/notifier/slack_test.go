@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/runatlantis/atlantis/server/config/valid"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
 	terraformActivities "github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
@@ -165,8 +166,15 @@ func TestNotifier(t *testing.T) {
 					},
 
 					Notifications: terraform.NotificationConfig{
-						Slack: terraform.SlackConfig{
-							ChannelID: "1234",
+						Slack: []terraform.SlackConfig{
+							{
+								ChannelID: "1234",
+							},
+							{
+								// not subscribed to lock messages
+								ChannelID: "5678",
+								Events:    []valid.DeploySlackEvent{valid.FailedDeploySlackEvent},
+							},
 						},
 					},
 				},
@@ -74,6 +74,10 @@ func (t *testDeployActivity) GithubUpdateCheckRun(ctx context.Context, deployerR
 	return activities.UpdateCheckRunResponse{}, nil
 }
 
+func (t *testDeployActivity) MessageChannel(ctx context.Context, request activities.MessageChannelRequest) (activities.MessageChannelResponse, error) {
+	return activities.MessageChannelResponse{}, nil
+}
+
 type deployerRequest struct {
 	Info              terraform.DeploymentInfo
 	LatestDeploy      *deployment.Info
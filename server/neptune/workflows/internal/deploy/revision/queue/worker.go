@@ -1,6 +1,7 @@
 package queue
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/notifier"
@@ -10,6 +11,7 @@ import (
 	metricNames "github.com/runatlantis/atlantis/server/metrics"
 
 	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/deployment"
 	tfModel "github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/deploy/lock"
@@ -34,8 +36,13 @@ type deployer interface {
 	Deploy(ctx workflow.Context, requestedDeployment terraform.DeploymentInfo, latestDeployment *deployment.Info, scope metrics.Scope) (*deployment.Info, error)
 }
 
+type slackActivities interface {
+	MessageChannel(ctx context.Context, request activities.MessageChannelRequest) (activities.MessageChannelResponse, error)
+}
+
 type workerActivities interface {
 	deployerActivities
+	slackActivities
 }
 
 type WorkerState string
@@ -99,7 +106,11 @@ func NewWorker(
 		},
 	}
 
-	tfWorkflowRunner := terraform.NewWorkflowRunner(q, tfWorkflow, githubCheckRunCache, notifiers, additionalNotifiers...)
+	deploymentNotifiers := []terraform.DeploymentNotifier{
+		terraform.NewSlackNotifier(a),
+	}
+
+	tfWorkflowRunner := terraform.NewWorkflowRunner(q, tfWorkflow, githubCheckRunCache, notifiers, deploymentNotifiers, additionalNotifiers...)
 	deployer := &Deployer{
 		Activities:              a,
 		TerraformWorkflowRunner: tfWorkflowRunner,
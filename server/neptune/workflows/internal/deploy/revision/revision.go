@@ -10,6 +10,7 @@ import (
 	key "github.com/runatlantis/atlantis/server/neptune/context"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
 	activity "github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
@@ -18,6 +19,7 @@ import (
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/deploy/request/converter"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/deploy/revision/queue"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/deploy/terraform"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 )
@@ -40,6 +42,7 @@ type NewRevisionRequest struct {
 	Root           request.Root
 	Repo           request.Repo
 	Tags           map[string]string
+	Notifications  request.Notifications
 }
 
 type Queue interface {
@@ -58,22 +61,24 @@ type Activities interface {
 	GithubCreateCheckRun(ctx context.Context, request activities.CreateCheckRunRequest) (activities.CreateCheckRunResponse, error)
 }
 
-func NewReceiver(ctx workflow.Context, queue Queue, checkRunClient CheckRunClient, generator idGenerator, worker DeploymentStore) *Receiver {
+func NewReceiver(ctx workflow.Context, queue Queue, checkRunClient CheckRunClient, generator idGenerator, worker DeploymentStore, additionalNotifiers ...plugins.TerraformWorkflowNotifier) *Receiver {
 	return &Receiver{
-		queue:          queue,
-		ctx:            ctx,
-		idGenerator:    generator,
-		worker:         worker,
-		checkRunClient: checkRunClient,
+		queue:               queue,
+		ctx:                 ctx,
+		idGenerator:         generator,
+		worker:              worker,
+		checkRunClient:      checkRunClient,
+		additionalNotifiers: additionalNotifiers,
 	}
 }
 
 type Receiver struct {
-	queue          Queue
-	ctx            workflow.Context
-	idGenerator    idGenerator
-	worker         DeploymentStore
-	checkRunClient CheckRunClient
+	queue               Queue
+	ctx                 workflow.Context
+	idGenerator         idGenerator
+	worker              DeploymentStore
+	checkRunClient      CheckRunClient
+	additionalNotifiers []plugins.TerraformWorkflowNotifier
 }
 
 func (n *Receiver) Receive(c workflow.ReceiveChannel, more bool) {
@@ -121,18 +126,34 @@ func (n *Receiver) Receive(c workflow.ReceiveChannel, more bool) {
 			Revision: request.Revision,
 		})
 	}
-	n.queue.Push(terraform.DeploymentInfo{
+	deploymentInfo := terraform.DeploymentInfo{
 		ID:             id,
 		Root:           root,
 		CheckRunID:     checkRunID,
 		Repo:           repo,
 		InitiatingUser: initiatingUser,
 		Tags:           request.Tags,
+		Notifications:  converter.Notifications(request.Notifications),
 		Commit: github.Commit{
 			Revision: request.Revision,
 			Branch:   request.Branch,
 		},
-	})
+	}
+	n.queue.Push(deploymentInfo)
+	n.notifyQueued(ctx, deploymentInfo)
+}
+
+func (n *Receiver) notifyQueued(ctx workflow.Context, deploymentInfo terraform.DeploymentInfo) {
+	for _, additionalNotifier := range n.additionalNotifiers {
+		queuedNotifier, ok := additionalNotifier.(plugins.TerraformDeploymentQueuedNotifier)
+		if !ok {
+			continue
+		}
+		if err := queuedNotifier.NotifyQueued(ctx, deploymentInfo.ToExternalInfo()); err != nil {
+			workflow.GetMetricsHandler(ctx).Counter("notifier_plugin_failure").Inc(1)
+			workflow.GetLogger(ctx).Error(errors.Wrap(err, "notifying queued deployment").Error())
+		}
+	}
 }
 
 func (n *Receiver) createCheckRun(ctx workflow.Context, id, revision string, root activity.Root, repo github.Repo) int64 {
@@ -16,6 +16,7 @@ import (
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/deploy/revision"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/deploy/revision/queue"
 	terraformWorkflow "github.com/runatlantis/atlantis/server/neptune/workflows/internal/deploy/terraform"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
 	"github.com/stretchr/testify/assert"
 	"go.temporal.io/sdk/testsuite"
 	"go.temporal.io/sdk/workflow"
@@ -78,6 +79,19 @@ func (t testWorker) GetCurrentDeploymentState() queue.CurrentDeployment {
 	return t.Current
 }
 
+type testQueuedNotifier struct {
+	revisions []string
+}
+
+func (n *testQueuedNotifier) Notify(workflow.Context, plugins.TerraformDeploymentInfo, *plugins.TerraformWorkflowState) error {
+	return nil
+}
+
+func (n *testQueuedNotifier) NotifyQueued(_ workflow.Context, info plugins.TerraformDeploymentInfo) error {
+	n.revisions = append(n.revisions, info.Commit.Revision)
+	return nil
+}
+
 type req struct {
 	ID              uuid.UUID
 	Lock            lock.LockState
@@ -91,6 +105,7 @@ type response struct {
 	Queue   []terraformWorkflow.DeploymentInfo
 	Lock    lock.LockState
 	Timeout bool
+	Queued  []string
 }
 
 func testWorkflow(ctx workflow.Context, r req) (response, error) {
@@ -106,13 +121,14 @@ func testWorkflow(ctx workflow.Context, r req) (response, error) {
 	worker := &testWorker{
 		Current: r.Current,
 	}
+	queuedNotifier := &testQueuedNotifier{}
 
 	receiver := revision.NewReceiver(ctx, queue, &testCheckRunClient{
 		expectedRequest: r.ExpectedRequest,
 		expectedT:       r.ExpectedT,
 	}, func(ctx workflow.Context) (uuid.UUID, error) {
 		return r.ID, nil
-	}, worker)
+	}, worker, queuedNotifier)
 	selector := workflow.NewSelector(ctx)
 
 	selector.AddReceive(workflow.GetSignalChannel(ctx, "test-signal"), receiver.Receive)
@@ -129,6 +145,7 @@ func testWorkflow(ctx workflow.Context, r req) (response, error) {
 		Queue:   queue.Queue,
 		Lock:    queue.Lock,
 		Timeout: timeout,
+		Queued:  queuedNotifier.revisions,
 	}, nil
 }
 
@@ -191,6 +208,7 @@ func TestEnqueue(t *testing.T) {
 		Status: lock.UnlockedStatus,
 	}, resp.Lock)
 	assert.False(t, resp.Timeout)
+	assert.Equal(t, []string{rev}, resp.Queued)
 }
 
 func TestEnqueue_ManualTrigger(t *testing.T) {
@@ -1,6 +1,7 @@
 package terraform
 
 import (
+	"github.com/runatlantis/atlantis/server/config/valid"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/notifier"
 
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/deployment"
@@ -12,11 +13,31 @@ import (
 )
 
 type NotificationConfig struct {
-	Slack SlackConfig
+	// Slack channels routed to the root, each is notified independently
+	Slack []SlackConfig
 }
 
 type SlackConfig struct {
 	ChannelID string
+
+	// Events the channel is subscribed to, empty means all events.
+	Events []valid.DeploySlackEvent
+}
+
+// IsSubscribed returns true if the channel should be notified of the given event.
+func (c SlackConfig) IsSubscribed(event valid.DeploySlackEvent) bool {
+	if c.ChannelID == "" {
+		return false
+	}
+	if len(c.Events) == 0 {
+		return true
+	}
+	for _, e := range c.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
 }
 
 type DeploymentInfo struct {
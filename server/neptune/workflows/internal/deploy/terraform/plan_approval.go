@@ -1,6 +1,8 @@
 package terraform
 
 import (
+	"strings"
+
 	constants "github.com/runatlantis/atlantis/server/metrics"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/deployment"
@@ -9,7 +11,24 @@ import (
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/metrics"
 )
 
+const TwoPersonApprovalReason = "This deploy follows the two person rule and must be confirmed by someone other than the requester."
+
 func BuildPlanApproval(requestedDeployment DeploymentInfo, latestDeployment *deployment.Info, diffDirection activities.DiffDirection, scope metrics.Scope) terraform.PlanApproval {
+	approval := buildPlanApproval(requestedDeployment, latestDeployment, diffDirection, scope)
+
+	if requestedDeployment.Root.TwoPersonApproval {
+		approval.Type = terraform.ManualApproval
+		approval.Requester = requestedDeployment.InitiatingUser.Username
+		if approval.Reason != "" && !strings.HasSuffix(approval.Reason, "\n\n") {
+			approval.Reason += "\n\n"
+		}
+		approval.Reason += TwoPersonApprovalReason
+	}
+
+	return approval
+}
+
+func buildPlanApproval(requestedDeployment DeploymentInfo, latestDeployment *deployment.Info, diffDirection activities.DiffDirection, scope metrics.Scope) terraform.PlanApproval {
 	if diffDirection == activities.DirectionDiverged {
 		scope.SubScopeWithTags(map[string]string{
 			constants.ManualOverrideReasonTag: DivergedMetric,
@@ -6,6 +6,7 @@ import (
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/deployment"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
+	terraformActivities "github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/deploy/terraform"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/metrics"
 	"github.com/stretchr/testify/assert"
@@ -30,3 +31,18 @@ func TestPlanAppr_Default(t *testing.T) {
 
 	assert.Equal(t, "Requested Revision has diverged from deployed revision [rev](https://github.com/owner/nish/commit/rev) triggered by @nishkrishnan\n\nDeployed revision contains unmerged changes.  Deploying this revision could cause an outage, please confirm with revision owner @nishkrishnan whether this is desirable.\n\n", output.Reason)
 }
+
+func TestPlanAppr_TwoPersonApproval(t *testing.T) {
+	output := terraform.BuildPlanApproval(terraform.DeploymentInfo{
+		Repo:           github.Repo{Name: "nish", Owner: "owner", DefaultBranch: "main"},
+		InitiatingUser: github.User{Username: "nishkrishnan"},
+		Commit:         github.Commit{Branch: "main"},
+		Root:           terraformActivities.Root{TwoPersonApproval: true},
+	}, &deployment.Info{Branch: "main", Revision: "rev"}, activities.DirectionAhead, metrics.NewNullableScope())
+
+	assert.Equal(t, terraformActivities.PlanApproval{
+		Type:      terraformActivities.ManualApproval,
+		Reason:    terraform.TwoPersonApprovalReason,
+		Requester: "nishkrishnan",
+	}, output)
+}
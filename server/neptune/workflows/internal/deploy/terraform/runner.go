@@ -40,13 +40,14 @@ type deployQueue interface {
 	GetLockState() lock.LockState
 }
 
-func NewWorkflowRunner(queue deployQueue, w Workflow, githubCheckRunCache CheckRunClient, internalNotifiers []WorkflowNotifier, additionalNotifiers ...plugins.TerraformWorkflowNotifier) *WorkflowRunner {
+func NewWorkflowRunner(queue deployQueue, w Workflow, githubCheckRunCache CheckRunClient, internalNotifiers []WorkflowNotifier, deploymentNotifiers []DeploymentNotifier, additionalNotifiers ...plugins.TerraformWorkflowNotifier) *WorkflowRunner {
 	return &WorkflowRunner{
 		Workflow: w,
 		StateReceiver: &StateReceiver{
 			Queue:               queue,
 			CheckRunCache:       githubCheckRunCache,
 			InternalNotifiers:   internalNotifiers,
+			DeploymentNotifiers: deploymentNotifiers,
 			AdditionalNotifiers: additionalNotifiers,
 		},
 	}
@@ -0,0 +1,196 @@
+package terraform
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/terraform/state"
+	"github.com/slack-go/slack"
+	"go.temporal.io/sdk/workflow"
+)
+
+// SlackNotifierVersion guards the activity calls made by the slack notifier so that it can
+// safely be added to already running workflows.
+const SlackNotifierVersion = "deploy-slack-notifier"
+
+type slackActivities interface {
+	MessageChannel(ctx context.Context, request activities.MessageChannelRequest) (activities.MessageChannelResponse, error)
+}
+
+type slackMessage struct {
+	event       valid.DeploySlackEvent
+	jobID       string
+	text        string
+	attachments []slack.Attachment
+}
+
+// SlackNotifier messages every channel routed to a deployment's root on deploy outcomes.
+// All messages for a revision are threaded under the first message sent for it in each channel.
+type SlackNotifier struct {
+	Activities slackActivities
+
+	// mutable
+	emitted        map[string]bool
+	threadRevision string
+	threadTS       map[string]string
+}
+
+func NewSlackNotifier(a slackActivities) *SlackNotifier {
+	return &SlackNotifier{
+		Activities: a,
+		emitted:    make(map[string]bool),
+		threadTS:   make(map[string]string),
+	}
+}
+
+func (n *SlackNotifier) Notify(ctx workflow.Context, deploymentInfo DeploymentInfo, workflowState *state.Workflow) error {
+	if len(deploymentInfo.Notifications.Slack) == 0 {
+		return nil
+	}
+
+	v := workflow.GetVersion(ctx, SlackNotifierVersion, workflow.DefaultVersion, workflow.Version(1))
+	if v == workflow.DefaultVersion {
+		return nil
+	}
+
+	// deployments are processed serially so we only need to keep track of the latest threads
+	if n.threadRevision != deploymentInfo.Commit.Revision {
+		n.threadRevision = deploymentInfo.Commit.Revision
+		n.threadTS = make(map[string]string)
+	}
+
+	// a channel failing to be messaged shouldn't prevent the others from being notified
+	var result *multierror.Error
+	messages := buildSlackMessages(deploymentInfo, workflowState)
+	for _, slackConfig := range deploymentInfo.Notifications.Slack {
+		for _, msg := range messages {
+			key := fmt.Sprintf("%s/%s/%s", slackConfig.ChannelID, msg.event, msg.jobID)
+			if n.emitted[key] || !slackConfig.IsSubscribed(msg.event) {
+				continue
+			}
+			n.emitted[key] = true
+
+			if err := n.send(ctx, slackConfig.ChannelID, msg); err != nil {
+				result = multierror.Append(result, errors.Wrapf(err, "messaging channel %s on %s", slackConfig.ChannelID, msg.event))
+			}
+		}
+	}
+
+	return result.ErrorOrNil()
+}
+
+func (n *SlackNotifier) send(ctx workflow.Context, channelID string, msg slackMessage) error {
+	var resp activities.MessageChannelResponse
+	err := workflow.ExecuteActivity(ctx, n.Activities.MessageChannel, activities.MessageChannelRequest{
+		ChannelID:   channelID,
+		Message:     msg.text,
+		Attachments: msg.attachments,
+		ThreadTS:    n.threadTS[channelID],
+	}).Get(ctx, &resp)
+	if err != nil {
+		return err
+	}
+
+	if n.threadTS[channelID] == "" {
+		n.threadTS[channelID] = resp.MessageID
+	}
+	return nil
+}
+
+func buildSlackMessages(info DeploymentInfo, workflowState *state.Workflow) []slackMessage {
+	var messages []slackMessage
+	subject := fmt.Sprintf("*%s* in *%s* at %s", info.Root.Name, info.Repo.GetFullName(), revisionLink(info))
+
+	if job := workflowState.Plan; job != nil {
+		switch job.Status {
+		case state.InProgressJobStatus:
+			messages = append(messages, slackMessage{
+				event: valid.StartedDeploySlackEvent,
+				jobID: job.ID,
+				text:  fmt.Sprintf("Deploy started for %s", subject),
+			})
+		case state.FailedJobStatus:
+			messages = append(messages, slackMessage{
+				event: valid.FailedDeploySlackEvent,
+				jobID: job.ID,
+				text:  fmt.Sprintf("Plan failed for %s", subject),
+			})
+		case state.SuccessJobStatus:
+			// a rerun of a revision which produces changes means the root has drifted
+			// from what was last applied.
+			if info.Root.TriggerInfo.Rerun && job.Output != nil && !job.Output.PlanSummary.IsEmpty() {
+				messages = append(messages, slackMessage{
+					event: valid.DriftedDeploySlackEvent,
+					jobID: job.ID,
+					text:  fmt.Sprintf("Drift detected for %s. %s", subject, job.Output.PlanSummary.String()),
+				})
+			}
+		}
+	}
+
+	if job := workflowState.Apply; job != nil {
+		switch job.Status {
+		case state.WaitingJobStatus:
+			if len(job.OnWaitingActions.Actions) > 0 {
+				messages = append(messages, slackMessage{
+					event:       valid.AwaitingConfirmationDeploySlackEvent,
+					jobID:       job.ID,
+					text:        fmt.Sprintf("Plan for %s is awaiting confirmation", subject),
+					attachments: []slack.Attachment{reviewAttachment(info, job.OnWaitingActions)},
+				})
+			}
+		case state.SuccessJobStatus:
+			messages = append(messages, slackMessage{
+				event: valid.AppliedDeploySlackEvent,
+				jobID: job.ID,
+				text:  fmt.Sprintf("Applied %s", subject),
+			})
+		case state.FailedJobStatus:
+			messages = append(messages, slackMessage{
+				event: valid.FailedDeploySlackEvent,
+				jobID: job.ID,
+				text:  fmt.Sprintf("Apply failed for %s", subject),
+			})
+		}
+	}
+
+	return messages
+}
+
+// reviewAttachment renders the job actions as interactive buttons which are handled
+// by the gateway.
+func reviewAttachment(info DeploymentInfo, jobActions state.JobActions) slack.Attachment {
+	value := activities.SlackPlanReviewValue{
+		WorkflowID: info.ID.String(),
+		Repo:       info.Repo.GetFullName(),
+		Root:       info.Root.Name,
+	}.Encode()
+
+	var actions []slack.AttachmentAction
+	for _, a := range jobActions.Actions {
+		style := "primary"
+		if a.ID == state.RejectAction {
+			style = "danger"
+		}
+		actions = append(actions, slack.AttachmentAction{
+			Name:  a.ID,
+			Text:  a.ID,
+			Type:  "button",
+			Value: value,
+			Style: style,
+		})
+	}
+
+	return slack.Attachment{
+		CallbackID: activities.SlackPlanReviewCallbackID,
+		Actions:    actions,
+	}
+}
+
+func revisionLink(info DeploymentInfo) string {
+	return fmt.Sprintf("<https://%s/%s/commit/%s|%s>", info.Repo.GetHostname(), info.Repo.GetFullName(), info.Commit.Revision, info.Commit.Revision)
+}
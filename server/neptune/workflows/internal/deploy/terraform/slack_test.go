@@ -0,0 +1,254 @@
+package terraform_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
+	internalTerraform "github.com/runatlantis/atlantis/server/neptune/workflows/internal/deploy/terraform"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/terraform/state"
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+type testSlackActivities struct {
+	requests []activities.MessageChannelRequest
+}
+
+func (a *testSlackActivities) MessageChannel(ctx context.Context, request activities.MessageChannelRequest) (activities.MessageChannelResponse, error) {
+	a.requests = append(a.requests, request)
+	return activities.MessageChannelResponse{MessageID: request.Message}, nil
+}
+
+type slackNotifierRequest struct {
+	Info   internalTerraform.DeploymentInfo
+	States []*state.Workflow
+}
+
+func testSlackNotifierWorkflow(ctx workflow.Context, r slackNotifierRequest) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToCloseTimeout: 5 * time.Second,
+	})
+
+	var a *testSlackActivities
+	subject := internalTerraform.NewSlackNotifier(a)
+	for _, s := range r.States {
+		if err := subject.Notify(ctx, r.Info, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runSlackNotifier(t *testing.T, info internalTerraform.DeploymentInfo, states []*state.Workflow) []activities.MessageChannelRequest {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	a := &testSlackActivities{}
+	env.RegisterActivity(a)
+	env.ExecuteWorkflow(testSlackNotifierWorkflow, slackNotifierRequest{
+		Info:   info,
+		States: states,
+	})
+	assert.NoError(t, env.GetWorkflowError())
+	return a.requests
+}
+
+func TestSlackNotifier_ThreadsMessagesPerRevision(t *testing.T) {
+	info := internalTerraform.DeploymentInfo{
+		ID:     uuid.New(),
+		Root:   terraform.Root{Name: "root"},
+		Repo:   github.Repo{Owner: "owner", Name: "repo"},
+		Commit: github.Commit{Revision: "1234"},
+		Notifications: internalTerraform.NotificationConfig{
+			Slack: []internalTerraform.SlackConfig{{ChannelID: "C123"}},
+		},
+	}
+
+	planSuccess := &state.Job{ID: "plan", Status: state.SuccessJobStatus}
+	states := []*state.Workflow{
+		{
+			Plan: &state.Job{ID: "plan", Status: state.InProgressJobStatus},
+		},
+		{
+			Plan: planSuccess,
+		},
+		{
+			Plan: planSuccess,
+			Apply: &state.Job{
+				ID:     "apply",
+				Status: state.WaitingJobStatus,
+				OnWaitingActions: state.JobActions{
+					Actions: []state.JobAction{{ID: state.ConfirmAction}, {ID: state.RejectAction}},
+				},
+			},
+		},
+		{
+			Plan:  planSuccess,
+			Apply: &state.Job{ID: "apply", Status: state.InProgressJobStatus},
+		},
+		{
+			Plan:  planSuccess,
+			Apply: &state.Job{ID: "apply", Status: state.SuccessJobStatus},
+		},
+	}
+
+	requests := runSlackNotifier(t, info, states)
+	assert.Len(t, requests, 3)
+
+	started := requests[0]
+	assert.Equal(t, "C123", started.ChannelID)
+	assert.Empty(t, started.ThreadTS)
+	assert.Contains(t, started.Message, "Deploy started")
+
+	awaiting := requests[1]
+	assert.Equal(t, started.Message, awaiting.ThreadTS)
+	assert.Len(t, awaiting.Attachments, 1)
+	assert.Equal(t, activities.SlackPlanReviewCallbackID, awaiting.Attachments[0].CallbackID)
+	assert.Len(t, awaiting.Attachments[0].Actions, 2)
+	assert.Equal(t, state.ConfirmAction, awaiting.Attachments[0].Actions[0].Name)
+	value, err := activities.DecodeSlackPlanReviewValue(awaiting.Attachments[0].Actions[0].Value)
+	assert.NoError(t, err)
+	assert.Equal(t, activities.SlackPlanReviewValue{
+		WorkflowID: info.ID.String(),
+		Repo:       "owner/repo",
+		Root:       "root",
+	}, value)
+	assert.Equal(t, state.RejectAction, awaiting.Attachments[0].Actions[1].Name)
+
+	applied := requests[2]
+	assert.Equal(t, started.Message, applied.ThreadTS)
+	assert.Contains(t, applied.Message, "Applied")
+}
+
+func TestSlackNotifier_FiltersEvents(t *testing.T) {
+	info := internalTerraform.DeploymentInfo{
+		ID:     uuid.New(),
+		Root:   terraform.Root{Name: "root"},
+		Repo:   github.Repo{Owner: "owner", Name: "repo"},
+		Commit: github.Commit{Revision: "1234"},
+		Notifications: internalTerraform.NotificationConfig{
+			Slack: []internalTerraform.SlackConfig{
+				{
+					ChannelID: "C123",
+					Events:    []valid.DeploySlackEvent{valid.FailedDeploySlackEvent},
+				},
+			},
+		},
+	}
+
+	states := []*state.Workflow{
+		{
+			Plan: &state.Job{ID: "plan", Status: state.InProgressJobStatus},
+		},
+		{
+			Plan: &state.Job{ID: "plan", Status: state.FailedJobStatus},
+		},
+	}
+
+	requests := runSlackNotifier(t, info, states)
+	assert.Len(t, requests, 1)
+	assert.Contains(t, requests[0].Message, "Plan failed")
+}
+
+func TestSlackNotifier_Drift(t *testing.T) {
+	info := internalTerraform.DeploymentInfo{
+		ID: uuid.New(),
+		Root: terraform.Root{
+			Name: "root",
+			TriggerInfo: terraform.TriggerInfo{
+				Type:  terraform.ManualTrigger,
+				Rerun: true,
+			},
+		},
+		Repo:   github.Repo{Owner: "owner", Name: "repo"},
+		Commit: github.Commit{Revision: "1234"},
+		Notifications: internalTerraform.NotificationConfig{
+			Slack: []internalTerraform.SlackConfig{
+				{
+					ChannelID: "C123",
+					Events:    []valid.DeploySlackEvent{valid.DriftedDeploySlackEvent},
+				},
+			},
+		},
+	}
+
+	states := []*state.Workflow{
+		{
+			Plan: &state.Job{
+				ID:     "plan",
+				Status: state.SuccessJobStatus,
+				Output: &state.JobOutput{
+					PlanSummary: terraform.PlanSummary{
+						Updates: []terraform.ResourceSummary{{Address: "aws_s3_bucket.b"}},
+					},
+				},
+			},
+		},
+	}
+
+	requests := runSlackNotifier(t, info, states)
+	assert.Len(t, requests, 1)
+	assert.Contains(t, requests[0].Message, "Drift detected")
+}
+
+func TestSlackNotifier_MultipleChannels(t *testing.T) {
+	info := internalTerraform.DeploymentInfo{
+		ID:     uuid.New(),
+		Root:   terraform.Root{Name: "root"},
+		Repo:   github.Repo{Owner: "owner", Name: "repo", Hostname: "github.example.com"},
+		Commit: github.Commit{Revision: "1234"},
+		Notifications: internalTerraform.NotificationConfig{
+			Slack: []internalTerraform.SlackConfig{
+				{ChannelID: "C123"},
+				{
+					ChannelID: "C456",
+					Events:    []valid.DeploySlackEvent{valid.AppliedDeploySlackEvent},
+				},
+			},
+		},
+	}
+
+	states := []*state.Workflow{
+		{
+			Plan: &state.Job{ID: "plan", Status: state.InProgressJobStatus},
+		},
+		{
+			Plan:  &state.Job{ID: "plan", Status: state.SuccessJobStatus},
+			Apply: &state.Job{ID: "apply", Status: state.SuccessJobStatus},
+		},
+	}
+
+	requests := runSlackNotifier(t, info, states)
+	assert.Len(t, requests, 3)
+
+	// each channel is threaded independently
+	assert.Equal(t, "C123", requests[0].ChannelID)
+	assert.Contains(t, requests[0].Message, "Deploy started")
+	assert.Contains(t, requests[0].Message, "<https://github.example.com/owner/repo/commit/1234|1234>")
+	assert.Equal(t, "C123", requests[1].ChannelID)
+	assert.Equal(t, requests[0].Message, requests[1].ThreadTS)
+	assert.Equal(t, "C456", requests[2].ChannelID)
+	assert.Empty(t, requests[2].ThreadTS)
+	assert.Contains(t, requests[2].Message, "Applied")
+}
+
+func TestSlackNotifier_NoChannel(t *testing.T) {
+	info := internalTerraform.DeploymentInfo{
+		ID:   uuid.New(),
+		Root: terraform.Root{Name: "root"},
+		Repo: github.Repo{Owner: "owner", Name: "repo"},
+	}
+
+	requests := runSlackNotifier(t, info, []*state.Workflow{
+		{
+			Plan: &state.Job{ID: "plan", Status: state.InProgressJobStatus},
+		},
+	})
+	assert.Empty(t, requests)
+}
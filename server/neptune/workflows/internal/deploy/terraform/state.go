@@ -17,6 +17,12 @@ type WorkflowNotifier interface {
 	Notify(workflow.Context, notifier.Info, *state.Workflow) error
 }
 
+// DeploymentNotifier is notified with the full deployment info, this is used by
+// notifiers which depend on deploy specific configuration (ie. notification routing).
+type DeploymentNotifier interface {
+	Notify(workflow.Context, DeploymentInfo, *state.Workflow) error
+}
+
 type CheckRunClient interface {
 	CreateOrUpdate(ctx workflow.Context, deploymentID string, request notifier.GithubCheckRunRequest) (int64, error)
 }
@@ -28,6 +34,7 @@ type StateReceiver struct {
 	Queue               deployQueue
 	CheckRunCache       CheckRunClient
 	InternalNotifiers   []WorkflowNotifier
+	DeploymentNotifiers []DeploymentNotifier
 	AdditionalNotifiers []plugins.TerraformWorkflowNotifier
 }
 
@@ -57,6 +64,12 @@ func (n *StateReceiver) Receive(ctx workflow.Context, c workflow.ReceiveChannel,
 			workflow.GetLogger(ctx).Error(errors.Wrap(err, "notifying workflow state change").Error())
 		}
 	}
+	for _, notifier := range n.DeploymentNotifiers {
+		if err := notifier.Notify(ctx, deploymentInfo, workflowState); err != nil {
+			workflow.GetMetricsHandler(ctx).Counter("notifier_failure").Inc(1)
+			workflow.GetLogger(ctx).Error(errors.Wrap(err, "notifying workflow state change").Error())
+		}
+	}
 
 	// Updates all other deployments waiting in queue when the current deployment is pending a confirm/reject user action. Current deployment is not on the queue at this point since its child TerraformWorkflow was started.
 	// CheckRunCache.CreateOrUpdate executes an activity and is a nondeterministic operation (i.e. it is not guaranteed to be executed in the same order across different workflow runs). This is why we need to check the workflow version to determine if we should update the check run.
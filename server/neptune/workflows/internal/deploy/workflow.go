@@ -125,7 +125,7 @@ func newRunner(ctx workflow.Context, request Request, children ChildWorkflows, p
 		return nil, err
 	}
 
-	revisionReceiver := revision.NewReceiver(ctx, revisionQueue, checkRunCache, sideeffect.GenerateUUID, worker)
+	revisionReceiver := revision.NewReceiver(ctx, revisionQueue, checkRunCache, sideeffect.GenerateUUID, worker, plugins.Notifiers...)
 
 	return &Runner{
 		Queue:                    revisionQueue,
@@ -0,0 +1,99 @@
+package limiter
+
+import (
+	key "github.com/runatlantis/atlantis/server/neptune/context"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/sideeffect"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Client acquires and releases permits of the repo's limiter workflow on behalf of the calling workflow,
+// grants are dispatched to the pending permits as they're signaled back.
+type Client struct {
+	pending map[string]workflow.Settable
+}
+
+func NewClient(ctx workflow.Context) *Client {
+	c := &Client{
+		pending: make(map[string]workflow.Settable),
+	}
+	ch := workflow.GetSignalChannel(ctx, GrantSignalID)
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		for {
+			var grant Grant
+			ch.Receive(ctx, &grant)
+			c.onGrant(ctx, grant)
+		}
+	})
+	return c
+}
+
+// Acquire requests a permit from the repo's limiter, the returned future resolves once the permit is granted
+// or fails if ctx is cancelled first. The permit ID is empty if the limiter couldn't be reached in which case
+// the future resolves right away, since we don't want to block plans on the limiter.
+func (c *Client) Acquire(ctx workflow.Context, repoFullName string) (string, workflow.Future) {
+	result, resultSettable := workflow.NewFuture(ctx)
+
+	id, err := sideeffect.GenerateUUID(ctx)
+	if err != nil {
+		workflow.GetLogger(ctx).Error("generating permit id", key.ErrKey, err)
+		resultSettable.Set(nil, nil)
+		return "", result
+	}
+	permitID := id.String()
+	granted, grantedSettable := workflow.NewFuture(ctx)
+	c.pending[permitID] = grantedSettable
+
+	info := workflow.GetInfo(ctx)
+	err = workflow.SignalExternalWorkflow(ctx, BuildWorkflowID(repoFullName), "", AcquireSignalID, AcquireRequest{
+		PermitID:   permitID,
+		WorkflowID: info.WorkflowExecution.ID,
+		RunID:      info.WorkflowExecution.RunID,
+	}).Get(ctx, nil)
+	if err != nil {
+		workflow.GetLogger(ctx).Error("acquiring permit", key.ErrKey, err)
+		delete(c.pending, permitID)
+		resultSettable.Set(nil, nil)
+		return "", result
+	}
+
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		s := workflow.NewSelector(ctx)
+		s.AddFuture(granted, func(f workflow.Future) {
+			resultSettable.Set(nil, nil)
+		})
+		s.AddReceive(ctx.Done(), func(c workflow.ReceiveChannel, _ bool) {
+			resultSettable.SetError(ctx.Err())
+		})
+		s.Select(ctx)
+	})
+	return permitID, result
+}
+
+// Release gives the permit back to the repo's limiter, permits which haven't been granted yet are dropped from its queue
+func (c *Client) Release(ctx workflow.Context, repoFullName string, permitID string) {
+	if permitID == "" {
+		return
+	}
+	delete(c.pending, permitID)
+	c.release(ctx, BuildWorkflowID(repoFullName), permitID)
+}
+
+func (c *Client) release(ctx workflow.Context, limiterID string, permitID string) {
+	err := workflow.SignalExternalWorkflow(ctx, limiterID, "", ReleaseSignalID, ReleaseRequest{
+		PermitID: permitID,
+	}).Get(ctx, nil)
+	if err != nil {
+		workflow.GetLogger(ctx).Error("releasing permit", key.ErrKey, err)
+	}
+}
+
+func (c *Client) onGrant(ctx workflow.Context, grant Grant) {
+	settable, ok := c.pending[grant.PermitID]
+	if !ok {
+		// the permit was released before it was granted, so give it back
+		c.release(ctx, grant.LimiterID, grant.PermitID)
+		return
+	}
+	delete(c.pending, grant.PermitID)
+	settable.Set(nil, nil)
+}
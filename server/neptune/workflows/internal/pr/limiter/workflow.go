@@ -0,0 +1,262 @@
+package limiter
+
+import (
+	"fmt"
+	"time"
+
+	key "github.com/runatlantis/atlantis/server/neptune/context"
+	temporalInternal "github.com/runatlantis/atlantis/server/neptune/workflows/internal/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	AcquireSignalID = "plan-limiter-acquire"
+	ReleaseSignalID = "plan-limiter-release"
+	LimitSignalID   = "plan-limiter-limit"
+	// GrantSignalID is signaled back to the workflow which acquired the permit
+	GrantSignalID = "plan-limiter-grant"
+
+	// LeaseTimeout reclaims permits which were never released, ex. if the PR workflow holding them was terminated
+	LeaseTimeout = 2 * time.Hour
+	ReclaimTick  = 10 * time.Minute
+
+	// MaxSignals bounds the history of the workflow, it continues as new once reached
+	MaxSignals = 1000
+)
+
+// BuildWorkflowID returns the ID of the limiter workflow shared by all PRs of the repo
+func BuildWorkflowID(repoFullName string) string {
+	return fmt.Sprintf("%s||plan-limiter", repoFullName)
+}
+
+// Request is the state of the limiter, it's carried over when the workflow continues as new
+type Request struct {
+	Limit   int
+	Holders []Holder
+	Waiters []AcquireRequest
+}
+
+type AcquireRequest struct {
+	PermitID   string
+	WorkflowID string
+	RunID      string
+}
+
+type ReleaseRequest struct {
+	PermitID string
+}
+
+type LimitRequest struct {
+	Limit int
+}
+
+type Grant struct {
+	PermitID string
+	// LimiterID is the workflow ID of the limiter, used to release permits which are no longer needed
+	LimiterID string
+}
+
+type Holder struct {
+	AcquireRequest
+	AcquiredAt time.Time
+}
+
+// Workflow is a semaphore capping the number of roots planned at once across all PRs of a repo.
+// Permits are granted in the order they're requested and the workflow exits once it's been idle
+// for a full ReclaimTick.
+func Workflow(ctx workflow.Context, request Request) error {
+	runner := &Runner{
+		AcquireSignalChannel: workflow.GetSignalChannel(ctx, AcquireSignalID),
+		ReleaseSignalChannel: workflow.GetSignalChannel(ctx, ReleaseSignalID),
+		LimitSignalChannel:   workflow.GetSignalChannel(ctx, LimitSignalID),
+		LeaseTimeout:         LeaseTimeout,
+		ReclaimTick:          ReclaimTick,
+		MaxSignals:           MaxSignals,
+		limit:                request.Limit,
+		holders:              request.Holders,
+		waiters:              request.Waiters,
+	}
+	return runner.Run(ctx)
+}
+
+type Runner struct {
+	AcquireSignalChannel workflow.ReceiveChannel
+	ReleaseSignalChannel workflow.ReceiveChannel
+	LimitSignalChannel   workflow.ReceiveChannel
+	LeaseTimeout         time.Duration
+	ReclaimTick          time.Duration
+	MaxSignals           int
+
+	// mutable state
+	limit   int
+	holders []Holder
+	waiters []AcquireRequest
+	signals int
+}
+
+func (r *Runner) Run(ctx workflow.Context) error {
+	s := temporalInternal.SelectorWithTimeout{
+		Selector: workflow.NewSelector(ctx),
+	}
+	var received, ticked bool
+	s.AddReceive(r.AcquireSignalChannel, func(c workflow.ReceiveChannel, _ bool) {
+		r.receiveAcquire(ctx, c)
+		received = true
+	})
+	s.AddReceive(r.ReleaseSignalChannel, func(c workflow.ReceiveChannel, _ bool) {
+		r.receiveRelease(ctx, c)
+		received = true
+	})
+	s.AddReceive(r.LimitSignalChannel, func(c workflow.ReceiveChannel, _ bool) {
+		r.receiveLimit(ctx, c)
+		received = true
+	})
+	onTick := func(f workflow.Future) {
+		ticked = true
+	}
+	_, _ = s.AddTimeout(ctx, r.ReclaimTick, onTick)
+
+	for {
+		s.Select(ctx)
+
+		if ticked {
+			ticked = false
+			r.reclaim(ctx)
+			if !received && len(r.holders) == 0 && len(r.waiters) == 0 && !r.drain() {
+				workflow.GetLogger(ctx).Info("limiter is idle, shutting down")
+				return nil
+			}
+			received = false
+			_, _ = s.AddTimeout(ctx, r.ReclaimTick, onTick)
+		}
+		r.grant(ctx)
+
+		if r.signals >= r.MaxSignals {
+			r.drain()
+			r.grant(ctx)
+			return workflow.NewContinueAsNewError(ctx, workflow.GetInfo(ctx).WorkflowType.Name, Request{
+				Limit:   r.limit,
+				Holders: r.holders,
+				Waiters: r.waiters,
+			})
+		}
+	}
+}
+
+func (r *Runner) receiveAcquire(ctx workflow.Context, c workflow.ReceiveChannel) {
+	var request AcquireRequest
+	c.Receive(ctx, &request)
+	r.signals++
+	r.acquire(request)
+}
+
+func (r *Runner) receiveRelease(ctx workflow.Context, c workflow.ReceiveChannel) {
+	var request ReleaseRequest
+	c.Receive(ctx, &request)
+	r.signals++
+	r.release(request.PermitID)
+}
+
+func (r *Runner) receiveLimit(ctx workflow.Context, c workflow.ReceiveChannel) {
+	var request LimitRequest
+	c.Receive(ctx, &request)
+	r.signals++
+	r.limit = request.Limit
+}
+
+// drain handles any signals buffered in the channels, signals are lost if the workflow
+// completes or continues as new without receiving them
+func (r *Runner) drain() bool {
+	var drained bool
+	for {
+		var acquire AcquireRequest
+		if ok := r.AcquireSignalChannel.ReceiveAsync(&acquire); ok {
+			r.signals++
+			r.acquire(acquire)
+			drained = true
+			continue
+		}
+		var release ReleaseRequest
+		if ok := r.ReleaseSignalChannel.ReceiveAsync(&release); ok {
+			r.signals++
+			r.release(release.PermitID)
+			drained = true
+			continue
+		}
+		var limit LimitRequest
+		if ok := r.LimitSignalChannel.ReceiveAsync(&limit); ok {
+			r.signals++
+			r.limit = limit.Limit
+			drained = true
+			continue
+		}
+		return drained
+	}
+}
+
+func (r *Runner) acquire(request AcquireRequest) {
+	for _, h := range r.holders {
+		if h.PermitID == request.PermitID {
+			return
+		}
+	}
+	for _, w := range r.waiters {
+		if w.PermitID == request.PermitID {
+			return
+		}
+	}
+	r.waiters = append(r.waiters, request)
+}
+
+func (r *Runner) release(permitID string) {
+	for i, h := range r.holders {
+		if h.PermitID == permitID {
+			r.holders = append(r.holders[:i], r.holders[i+1:]...)
+			return
+		}
+	}
+	// permits can be released before they're granted if the revision is cancelled
+	for i, w := range r.waiters {
+		if w.PermitID == permitID {
+			r.waiters = append(r.waiters[:i], r.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// reclaim drops the permits held for longer than the lease timeout
+func (r *Runner) reclaim(ctx workflow.Context) {
+	now := workflow.Now(ctx)
+	var holders []Holder
+	for _, h := range r.holders {
+		if now.Sub(h.AcquiredAt) >= r.LeaseTimeout {
+			workflow.GetLogger(ctx).Warn("reclaiming expired permit", "permit-id", h.PermitID, "workflow-id", h.WorkflowID)
+			continue
+		}
+		holders = append(holders, h)
+	}
+	r.holders = holders
+}
+
+// grant hands out permits to waiters while there's room, a limit of 0 means there is no limit
+func (r *Runner) grant(ctx workflow.Context) {
+	limiterID := workflow.GetInfo(ctx).WorkflowExecution.ID
+	for len(r.waiters) > 0 && (r.limit <= 0 || len(r.holders) < r.limit) {
+		next := r.waiters[0]
+		r.waiters = r.waiters[1:]
+
+		err := workflow.SignalExternalWorkflow(ctx, next.WorkflowID, next.RunID, GrantSignalID, Grant{
+			PermitID:  next.PermitID,
+			LimiterID: limiterID,
+		}).Get(ctx, nil)
+		if err != nil {
+			// the requesting workflow is most likely gone, so there's no one to hold the permit
+			workflow.GetLogger(ctx).Warn("granting permit", key.ErrKey, err, "workflow-id", next.WorkflowID)
+			continue
+		}
+		r.holders = append(r.holders, Holder{
+			AcquireRequest: next,
+			AcquiredAt:     workflow.Now(ctx),
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package limiter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/pr/limiter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestWorkflow(t *testing.T) {
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+
+	var grants []string
+	env.OnSignalExternalWorkflow(mock.Anything, mock.Anything, mock.Anything, limiter.GrantSignalID, mock.Anything).
+		Run(func(args mock.Arguments) {
+			grants = append(grants, args.Get(4).(limiter.Grant).PermitID)
+		}).
+		Return(nil)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(limiter.AcquireSignalID, limiter.AcquireRequest{PermitID: "a", WorkflowID: "repo||1"})
+		env.SignalWorkflow(limiter.AcquireSignalID, limiter.AcquireRequest{PermitID: "b", WorkflowID: "repo||2"})
+		env.SignalWorkflow(limiter.AcquireSignalID, limiter.AcquireRequest{PermitID: "c", WorkflowID: "repo||3"})
+	}, time.Minute)
+	env.RegisterDelayedCallback(func() {
+		// only the first permit is granted while the limit is 1
+		assert.Equal(t, []string{"a"}, grants)
+
+		// c is released before it's granted so it's dropped from the queue
+		env.SignalWorkflow(limiter.ReleaseSignalID, limiter.ReleaseRequest{PermitID: "c"})
+		env.SignalWorkflow(limiter.ReleaseSignalID, limiter.ReleaseRequest{PermitID: "a"})
+	}, 2*time.Minute)
+
+	env.ExecuteWorkflow(limiter.Workflow, limiter.Request{Limit: 1})
+
+	// b's permit is never released so its lease expires before the workflow goes idle and exits
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+	assert.Equal(t, []string{"a", "b"}, grants)
+}
+
+func TestWorkflow_IdleExit(t *testing.T) {
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+
+	env.ExecuteWorkflow(limiter.Workflow, limiter.Request{Limit: 1})
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+}
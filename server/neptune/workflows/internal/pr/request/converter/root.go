@@ -17,8 +17,23 @@ func Root(external request.Root) terraform.Root {
 		Validate: execute.Job{
 			Steps: steps(external.Validate.Steps),
 		},
-		Path:      external.RepoRelPath,
-		TfVersion: external.TfVersion,
+		Path:         external.RepoRelPath,
+		TfVersion:    external.TfVersion,
+		Owners:       external.Owners,
+		PolicyTarget: policyTarget(external.PolicyTarget),
+
+		PartialPlan: external.PartialPlan,
+	}
+}
+
+func policyTarget(external *request.PolicyTarget) *terraform.PolicyTarget {
+	if external == nil {
+		return nil
+	}
+	return &terraform.PolicyTarget{
+		Name: external.Name,
+		Path: external.Path,
+		Tags: external.Tags,
 	}
 }
 
@@ -14,6 +14,21 @@ type Root struct {
 	RepoRelPath string
 	TfVersion   string
 	PlanMode    PlanMode
+	// Owners must approve before failing policies are bypassed for the root
+	Owners []string
+	// Fingerprint identifies the contents of the root, empty if unknown
+	Fingerprint string
+	// PolicyTarget is the root as configured on the default branch, nil if it isn't on it yet
+	PolicyTarget *PolicyTarget
+	// PartialPlan is set when the plan steps include user supplied terraform args
+	PartialPlan bool
+}
+
+// PolicyTarget are the attributes of a root policy set scopes are matched against
+type PolicyTarget struct {
+	Name string
+	Path string
+	Tags map[string]string
 }
 
 type Job struct {
@@ -2,6 +2,8 @@ package policy
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v45/github"
@@ -33,19 +35,63 @@ type notifier interface {
 	Notify(ctx workflow.Context, workflowState *state.Workflow, roots map[string]revision.RootInfo)
 }
 
+type summaryCommenter interface {
+	Refresh(ctx workflow.Context, prRevision revision.Revision, roots map[string]revision.RootInfo, updated []terraform.Response)
+}
+
+// BypassedPolicySummary is the change id guarding refreshing the PR summary comment once failing policies are bypassed
+const BypassedPolicySummary = "bypassed-policy-summary"
+
+// EventDrivenApproval is the change id guarding the removal of the hourly approval poll,
+// approvals are only re-evaluated on review and team membership signals.
+const EventDrivenApproval = "event-driven-approval"
+
 type NewReviewRequest struct {
 	Revision string
 }
 
+// PolicyCheckRequest is signaled by `atlantis policy-check` to re-evaluate failing policies of the revision
+// against fresh team memberships
+type PolicyCheckRequest struct {
+	Revision string
+}
+
+// ApprovePoliciesRequest is signaled by `atlantis approve-policies`, the user's approval is treated like an
+// approving review of the revision when filtering failing policies
+type ApprovePoliciesRequest struct {
+	Revision string
+	User     string
+}
+
+// TeamMembershipChangedRequest is signaled when members are added to or removed from a team
+type TeamMembershipChangedRequest struct {
+	Org      string
+	TeamSlug string
+}
+
 type FailedPolicyHandler struct {
-	ReviewSignalChannel workflow.ReceiveChannel
-	Dismisser           dismisser
-	PolicyFilter        policyFilter
-	GithubActivities    githubActivities
-	PRNumber            int
-	Org                 string
-	Scope               metrics.Scope
-	Notifier            notifier
+	ReviewSignalChannel          workflow.ReceiveChannel
+	TeamMembershipSignalChannel  workflow.ReceiveChannel
+	PolicyCheckSignalChannel     workflow.ReceiveChannel
+	ApprovePoliciesSignalChannel workflow.ReceiveChannel
+	Dismisser                    dismisser
+	PolicyFilter                 policyFilter
+	GithubActivities             githubActivities
+	PRNumber                     int
+	Org                          string
+	Scope                        metrics.Scope
+	Notifier                     notifier
+	SummaryCommenter             summaryCommenter
+
+	// teams whose cached membership is known to be stale
+	staleTeams map[string]bool
+	// approvals of the policies of the current revision made through comments
+	commentApprovers []commentApproval
+}
+
+type commentApproval struct {
+	user string
+	time time.Time
 }
 
 type Action int64
@@ -55,6 +101,9 @@ const (
 	onPollTick
 	onSkip
 	onShutdown
+	onTeamMembershipSignal
+	onPolicyCheckSignal
+	onApprovePoliciesSignal
 )
 
 // Handle processes the roots corresponding to each Terraform workflow response and determines if any policies are failing
@@ -65,6 +114,10 @@ func (f *FailedPolicyHandler) Handle(ctx workflow.Context, revision revision.Rev
 		return
 	}
 
+	version := workflow.GetVersion(ctx, EventDrivenApproval, workflow.DefaultVersion, workflow.Version(1))
+	// comment approvals only apply to the revision they were made for
+	f.commentApprovers = nil
+
 	var action Action
 	s := temporalInternal.SelectorWithTimeout{
 		Selector: workflow.NewSelector(ctx),
@@ -88,6 +141,64 @@ func (f *FailedPolicyHandler) Handle(ctx workflow.Context, revision revision.Rev
 			Counter(metricNames.SignalReceive).
 			Inc(1)
 	})
+	if f.TeamMembershipSignalChannel != nil {
+		s.AddReceive(f.TeamMembershipSignalChannel, func(c workflow.ReceiveChannel, more bool) {
+			action = onTeamMembershipSignal
+			if !more {
+				return
+			}
+			var membershipRequest TeamMembershipChangedRequest
+			c.Receive(ctx, &membershipRequest)
+			// skip signal if the team belongs to another org
+			if membershipRequest.Org != f.Org {
+				action = onSkip
+			} else {
+				f.markStale(membershipRequest.TeamSlug)
+			}
+			scope.SubScopeWithTags(map[string]string{metricNames.SignalNameTag: "team-membership"}).
+				Counter(metricNames.SignalReceive).
+				Inc(1)
+		})
+	}
+	if f.PolicyCheckSignalChannel != nil {
+		s.AddReceive(f.PolicyCheckSignalChannel, func(c workflow.ReceiveChannel, more bool) {
+			action = onPolicyCheckSignal
+			if !more {
+				return
+			}
+			var policyCheckRequest PolicyCheckRequest
+			c.Receive(ctx, &policyCheckRequest)
+			if policyCheckRequest.Revision != revision.Revision {
+				action = onSkip
+			} else {
+				f.markFailingPoliciesStale(failingTerraformWorkflows)
+			}
+			scope.SubScopeWithTags(map[string]string{metricNames.SignalNameTag: "policy-check"}).
+				Counter(metricNames.SignalReceive).
+				Inc(1)
+		})
+	}
+	if f.ApprovePoliciesSignalChannel != nil {
+		s.AddReceive(f.ApprovePoliciesSignalChannel, func(c workflow.ReceiveChannel, more bool) {
+			action = onApprovePoliciesSignal
+			if !more {
+				return
+			}
+			var approveRequest ApprovePoliciesRequest
+			c.Receive(ctx, &approveRequest)
+			if approveRequest.Revision != revision.Revision {
+				action = onSkip
+			} else {
+				f.commentApprovers = append(f.commentApprovers, commentApproval{
+					user: approveRequest.User,
+					time: workflow.Now(ctx),
+				})
+			}
+			scope.SubScopeWithTags(map[string]string{metricNames.SignalNameTag: "approve-policies"}).
+				Counter(metricNames.SignalReceive).
+				Inc(1)
+		})
+	}
 	onTimeout := func(f workflow.Future) {
 		_ = f.Get(ctx, nil)
 		action = onPollTick
@@ -106,22 +217,40 @@ func (f *FailedPolicyHandler) Handle(ctx workflow.Context, revision revision.Rev
 		case onSkip:
 			continue
 		case onPollTick:
-			// TODO: evaluate a better polling rate for approvals, or remove all together
 			cancelTimer()
-			cancelTimer, _ = s.AddTimeout(ctx, time.Hour, onTimeout)
+			// workflows started before approvals were event driven keep polling
+			if version == workflow.DefaultVersion {
+				cancelTimer, _ = s.AddTimeout(ctx, time.Hour, onTimeout)
+			}
 		}
 
-		// onPollTick and onReviewSignal actions, filter out failing policies that have been approved and identify if
+		// remaining actions, filter out failing policies that have been approved and identify if
 		// any previously failing terraform workflows are now successful
-		remainingFailedPolicies := f.filterOutBypassedPolicies(ctx, revision, failingTerraformWorkflows)
+		remainingFailedPolicies, currentReviews := f.filterOutBypassedPolicies(ctx, revision, failingTerraformWorkflows)
 		successfulTerraformWorkflows := partitionWorkflowsByResult(failingTerraformWorkflows, remainingFailedPolicies, true)
 		failingTerraformWorkflows = partitionWorkflowsByResult(failingTerraformWorkflows, remainingFailedPolicies, false)
-		// for newly successful workflows, update their corresponding check statuses to passing
-		f.updateCheckStatuses(ctx, roots, successfulTerraformWorkflows)
+
+		// owned roots additionally need an approval from one of their owners before policies are bypassed
+		successfulTerraformWorkflows, awaitingOwnerTerraformWorkflows := f.partitionWorkflowsByOwnerApproval(ctx, revision, roots, successfulTerraformWorkflows, currentReviews)
+		failingTerraformWorkflows = append(failingTerraformWorkflows, awaitingOwnerTerraformWorkflows...)
+		// for newly successful workflows, update their corresponding check statuses and the summary to passing
+		bypassedTerraformWorkflows := markBypassed(successfulTerraformWorkflows)
+		f.updateCheckStatuses(ctx, roots, bypassedTerraformWorkflows)
+		f.updateSummary(ctx, revision, roots, bypassedTerraformWorkflows)
 	}
 }
 
-func (f *FailedPolicyHandler) filterOutBypassedPolicies(ctx workflow.Context, revision revision.Revision, failingTerraformWorkflowResponses []terraform.Response) []activities.PolicySet {
+func (f *FailedPolicyHandler) updateSummary(ctx workflow.Context, revision revision.Revision, roots map[string]revision.RootInfo, bypassedWorkflows []terraform.Response) {
+	if f.SummaryCommenter == nil || len(bypassedWorkflows) == 0 {
+		return
+	}
+	if workflow.GetVersion(ctx, BypassedPolicySummary, workflow.DefaultVersion, workflow.Version(1)) == workflow.DefaultVersion {
+		return
+	}
+	f.SummaryCommenter.Refresh(ctx, revision, roots, bypassedWorkflows)
+}
+
+func (f *FailedPolicyHandler) filterOutBypassedPolicies(ctx workflow.Context, revision revision.Revision, failingTerraformWorkflowResponses []terraform.Response) ([]activities.PolicySet, []*github.PullRequestReview) {
 	// Process set of currently failing policies
 	failedPolicies := fetchAllFailingPolicies(failingTerraformWorkflowResponses)
 
@@ -133,7 +262,7 @@ func (f *FailedPolicyHandler) filterOutBypassedPolicies(ctx workflow.Context, re
 	}).Get(ctx, &listPRReviewsResponse)
 	if err != nil {
 		workflow.GetLogger(ctx).Error(err.Error())
-		return failedPolicies
+		return failedPolicies, nil
 	}
 
 	// Fetch current policy team memberships
@@ -142,7 +271,7 @@ func (f *FailedPolicyHandler) filterOutBypassedPolicies(ctx workflow.Context, re
 		members, err := f.fetchTeamMembers(ctx, revision.Repo, policy.Owner)
 		if err != nil {
 			workflow.GetLogger(ctx).Error(err.Error())
-			return failedPolicies
+			return failedPolicies, nil
 		}
 		teams[policy.Name] = members
 	}
@@ -152,12 +281,81 @@ func (f *FailedPolicyHandler) filterOutBypassedPolicies(ctx workflow.Context, re
 	currentReviews, err = f.Dismisser.Dismiss(ctx, revision, teams, currentReviews)
 	if err != nil {
 		workflow.GetLogger(ctx).Error("failed to dismiss stale reviews")
-		return failedPolicies
+		return failedPolicies, nil
+	}
+
+	// Filter out failed policies from policy approvals, comment approvals only count towards policies and
+	// not root owner approvals
+	remainingFailedPolicies := f.PolicyFilter.Filter(teams, f.withCommentApprovals(revision, currentReviews), failedPolicies)
+	return remainingFailedPolicies, currentReviews
+}
+
+// withCommentApprovals represents approvals made through comments as approving reviews of the revision.  Reviews
+// are ordered chronologically so that a later review requesting changes still takes precedence over a comment approval.
+func (f *FailedPolicyHandler) withCommentApprovals(revision revision.Revision, reviews []*github.PullRequestReview) []*github.PullRequestReview {
+	if len(f.commentApprovers) == 0 {
+		return reviews
 	}
+	merged := append([]*github.PullRequestReview{}, reviews...)
+	for _, approval := range f.commentApprovers {
+		submittedAt := approval.time
+		merged = append(merged, &github.PullRequestReview{
+			User:        &github.User{Login: github.String(approval.user)},
+			State:       github.String(ApprovalState),
+			CommitID:    github.String(revision.Revision),
+			SubmittedAt: &submittedAt,
+		})
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].GetSubmittedAt().Before(merged[j].GetSubmittedAt())
+	})
+	return merged
+}
+
+// partitionWorkflowsByOwnerApproval splits workflows into those whose root is unowned or approved by an owner
+// and those still awaiting an owner's approval.  Roots of workflows started before owners were supported
+// have none, so no activities are executed for them and replays remain deterministic.
+func (f *FailedPolicyHandler) partitionWorkflowsByOwnerApproval(ctx workflow.Context, revision revision.Revision, roots map[string]revision.RootInfo, workflows []terraform.Response, reviews []*github.PullRequestReview) ([]terraform.Response, []terraform.Response) {
+	approvers := findRecentApprovers(reviews)
+
+	var approved, pending []terraform.Response
+	for _, w := range workflows {
+		owners := roots[w.WorkflowState.ID].Root.Owners
+		if len(owners) == 0 || f.approvedByOwner(ctx, revision.Repo, approvers, owners) {
+			approved = append(approved, w)
+			continue
+		}
+		pending = append(pending, w)
+	}
+	return approved, pending
+}
+
+func (f *FailedPolicyHandler) approvedByOwner(ctx workflow.Context, repo gh.Repo, approvers []string, owners []string) bool {
+	for _, owner := range owners {
+		members := []string{owner}
+
+		// owners with a separator are org/team slugs, teams can only be resolved within our org
+		if org, slug, isTeam := strings.Cut(owner, "/"); isTeam {
+			if org != f.Org {
+				continue
+			}
+			var err error
+			members, err = f.fetchTeamMembers(ctx, repo, slug)
+			if err != nil {
+				workflow.GetLogger(ctx).Error(err.Error())
+				continue
+			}
+		}
 
-	// Filter out failed policies from policy approvals
-	remainingFailedPolicies := f.PolicyFilter.Filter(teams, currentReviews, failedPolicies)
-	return remainingFailedPolicies
+		for _, member := range members {
+			for _, approver := range approvers {
+				if member == approver {
+					return true
+				}
+			}
+		}
+	}
+	return false
 }
 
 func (f *FailedPolicyHandler) fetchTeamMembers(ctx workflow.Context, repo gh.Repo, slug string) ([]string, error) {
@@ -166,10 +364,28 @@ func (f *FailedPolicyHandler) fetchTeamMembers(ctx workflow.Context, repo gh.Rep
 		Repo:     repo,
 		Org:      f.Org,
 		TeamSlug: slug,
+		Refresh:  f.staleTeams[slug],
 	}).Get(ctx, &listTeamMembersResponse)
+	if err == nil {
+		delete(f.staleTeams, slug)
+	}
 	return listTeamMembersResponse.Members, err
 }
 
+func (f *FailedPolicyHandler) markStale(slug string) {
+	if f.staleTeams == nil {
+		f.staleTeams = make(map[string]bool)
+	}
+	f.staleTeams[slug] = true
+}
+
+// markFailingPoliciesStale refreshes the memberships of the owners of every failing policy on the next evaluation
+func (f *FailedPolicyHandler) markFailingPoliciesStale(workflows []terraform.Response) {
+	for _, policy := range fetchAllFailingPolicies(workflows) {
+		f.markStale(policy.Owner)
+	}
+}
+
 func partitionWorkflowsByResult(workflows []terraform.Response, failingPolicies []activities.PolicySet, success bool) []terraform.Response {
 	var partitionedWorkflows []terraform.Response
 	for _, workflow := range workflows {
@@ -182,6 +398,17 @@ func partitionWorkflowsByResult(workflows []terraform.Response, failingPolicies
 	return partitionedWorkflows
 }
 
+// markBypassed returns copies of the workflows whose states are completed with their failing policies bypassed
+func markBypassed(workflows []terraform.Response) []terraform.Response {
+	var bypassed []terraform.Response
+	for _, w := range workflows {
+		w.WorkflowState.Result.Status = state.CompleteWorkflowStatus
+		w.WorkflowState.Result.Reason = state.BypassedFailedValidationReason
+		bypassed = append(bypassed, w)
+	}
+	return bypassed
+}
+
 // updateCheckStatuses marks each successful TF workflow's corresponding check status as passing (i.e. each root)
 func (f *FailedPolicyHandler) updateCheckStatuses(ctx workflow.Context, roots map[string]revision.RootInfo, successfulWorkflows []terraform.Response) {
 	numUpdates := 0
@@ -192,8 +419,6 @@ func (f *FailedPolicyHandler) updateCheckStatuses(ctx workflow.Context, roots ma
 				numUpdates++
 			}()
 			workflowState := sw.WorkflowState
-			workflowState.Result.Status = state.CompleteWorkflowStatus
-			workflowState.Result.Reason = state.BypassedFailedValidationReason
 			f.Notifier.Notify(c, &workflowState, roots)
 		})
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/go-github/v45/github"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
 	gh "github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
+	tfActivities "github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/metrics"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/pr/revision"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/pr/revision/policy"
@@ -27,10 +28,14 @@ type request struct {
 	DismissResponse     []*github.PullRequestReview
 	DismissErr          error
 	FilterResponse      []activities.PolicySet
-	FilterErr           error
-	GithubActivities    *mockGithubActivities
-	Roots               map[string]revision.RootInfo
-	State               *state.Workflow
+	// FilterResponses are returned by successive filter calls, FilterResponse is used if empty
+	FilterResponses  [][]activities.PolicySet
+	FilterErr        error
+	GithubActivities *mockGithubActivities
+	Roots            map[string]revision.RootInfo
+	State            *state.Workflow
+	// CommentApprovals are merged into the dismissed reviews so they aren't asserted on
+	CommentApprovals bool
 }
 
 type response struct {
@@ -39,11 +44,16 @@ type response struct {
 	DismisserErr     error
 	FilterCalls      int
 	FilterPolicies   []activities.PolicySet
+	FilterApprovals  []*github.PullRequestReview
 	NotifierCalls    int
+	Refreshed        []terraform.Response
 }
 
 const (
-	reviewID = "review"
+	reviewID          = "review"
+	membershipID      = "membership"
+	policyCheckID     = "policy-check"
+	approvePoliciesID = "approve-policies"
 )
 
 func testWorkflow(ctx workflow.Context, r request) (response, error) {
@@ -54,9 +64,14 @@ func testWorkflow(ctx workflow.Context, r request) (response, error) {
 		expectedReviews: r.DismissResponse,
 		err:             r.DismissErr,
 	}
+	expectedApprovals := r.DismissResponse
+	if r.CommentApprovals {
+		expectedApprovals = nil
+	}
 	filter := &mockFilter{
-		expectedApprovals: r.DismissResponse,
+		expectedApprovals: expectedApprovals,
 		filteredPolicies:  r.FilterResponse,
+		responses:         r.FilterResponses,
 		t:                 r.T,
 	}
 	notifier := &mockNotifier{
@@ -64,14 +79,20 @@ func testWorkflow(ctx workflow.Context, r request) (response, error) {
 		expectedRoots:         r.Roots,
 		expectedWorkflowState: r.State,
 	}
+	summary := &mockSummaryCommenter{}
 	handler := &policy.FailedPolicyHandler{
-		ReviewSignalChannel: workflow.GetSignalChannel(ctx, reviewID),
-		Dismisser:           dismisser,
-		PolicyFilter:        filter,
-		GithubActivities:    r.GithubActivities,
-		PRNumber:            1,
-		Scope:               metrics.NewNullableScope(),
-		Notifier:            notifier,
+		ReviewSignalChannel:          workflow.GetSignalChannel(ctx, reviewID),
+		TeamMembershipSignalChannel:  workflow.GetSignalChannel(ctx, membershipID),
+		PolicyCheckSignalChannel:     workflow.GetSignalChannel(ctx, policyCheckID),
+		ApprovePoliciesSignalChannel: workflow.GetSignalChannel(ctx, approvePoliciesID),
+		Dismisser:                    dismisser,
+		PolicyFilter:                 filter,
+		GithubActivities:             r.GithubActivities,
+		PRNumber:                     1,
+		Org:                          "org",
+		Scope:                        metrics.NewNullableScope(),
+		Notifier:                     notifier,
+		SummaryCommenter:             summary,
 	}
 	handler.Handle(ctx, r.Revision, r.Roots, r.WorkflowResponses)
 	_ = workflow.Sleep(ctx, 5*time.Second) //sleep to test notifier called
@@ -81,7 +102,9 @@ func testWorkflow(ctx workflow.Context, r request) (response, error) {
 		DismisserErr:     dismisser.err,
 		FilterCalls:      filter.calls,
 		FilterPolicies:   filter.filteredPolicies,
+		FilterApprovals:  filter.lastApprovals,
 		NotifierCalls:    notifier.calls,
+		Refreshed:        summary.refreshed,
 	}, nil
 }
 
@@ -159,6 +182,221 @@ func TestFailedPolicyHandlerRunner_Handle(t *testing.T) {
 	assert.Empty(t, resp.FilterPolicies)
 	assert.Equal(t, testApproval, resp.DismisserReviews[0])
 	assert.Equal(t, 1, resp.NotifierCalls)
+	assert.Len(t, resp.Refreshed, 1)
+	assert.Equal(t, state.BypassedFailedValidationReason, resp.Refreshed[0].WorkflowState.Result.Reason)
+}
+
+func TestFailedPolicyHandlerRunner_OwnerTeamFromAnotherOrg(t *testing.T) {
+	testApproval := &github.PullRequestReview{
+		User:  &github.User{Login: github.String("alice")},
+		State: github.String(policy.ApprovalState),
+	}
+	ga := &mockGithubActivities{
+		reviews: activities.ListPRReviewsResponse{Reviews: []*github.PullRequestReview{testApproval}},
+	}
+	req := request{
+		T:        t,
+		Revision: revision.Revision{Repo: gh.Repo{Name: "repo"}, Revision: "sha"},
+		WorkflowResponses: []terraform.Response{
+			{
+				WorkflowState: state.Workflow{ID: "testRoot"},
+				ValidationResults: []activities.ValidationResult{
+					{
+						Status:    activities.Fail,
+						PolicySet: activities.PolicySet{Name: "policy1", Owner: "team"},
+					},
+				},
+			},
+		},
+		GithubActivities: ga,
+		DismissResponse:  []*github.PullRequestReview{testApproval},
+		Roots: map[string]revision.RootInfo{
+			"testRoot": {Root: tfActivities.Root{Owners: []string{"other-org/owners"}}},
+		},
+	}
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	env.RegisterActivity(ga)
+	env.RegisterDelayedCallback(func() {
+		env.CancelWorkflow()
+	}, 2*time.Second)
+	env.ExecuteWorkflow(testWorkflow, req)
+	var resp response
+	err := env.GetWorkflowResult(&resp)
+	assert.NoError(t, err)
+	// teams of other orgs can't be resolved so the root keeps awaiting an owner's approval
+	assert.Equal(t, []activities.ListTeamMembersRequest{
+		{Repo: req.Revision.Repo, Org: "org", TeamSlug: "team"},
+	}, ga.teamRequests)
+	assert.Equal(t, 0, resp.NotifierCalls)
+	assert.Empty(t, resp.Refreshed)
+}
+
+func TestFailedPolicyHandlerRunner_TeamMembershipChanged(t *testing.T) {
+	testApproval := &github.PullRequestReview{
+		State: github.String(policy.ApprovalState),
+	}
+	ga := &mockGithubActivities{
+		reviews: activities.ListPRReviewsResponse{Reviews: []*github.PullRequestReview{testApproval}},
+	}
+	failingPolicy := activities.PolicySet{Name: "policy1", Owner: "team"}
+	req := request{
+		T:        t,
+		Revision: revision.Revision{Repo: gh.Repo{Name: "repo"}, Revision: "sha"},
+		WorkflowResponses: []terraform.Response{
+			{
+				ValidationResults: []activities.ValidationResult{
+					{
+						Status:    activities.Fail,
+						PolicySet: failingPolicy,
+					},
+				},
+			},
+		},
+		GithubActivities: ga,
+		DismissResponse:  []*github.PullRequestReview{testApproval},
+		// the approver only becomes an owner once they're added to the team
+		FilterResponses: [][]activities.PolicySet{{failingPolicy}, {}},
+		Roots: map[string]revision.RootInfo{
+			"testRoot": {},
+		},
+		State: &state.Workflow{Result: state.WorkflowResult{
+			Status: state.CompleteWorkflowStatus,
+			Reason: state.ValidationFailedReason,
+		}},
+	}
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	env.RegisterActivity(ga)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(membershipID, policy.TeamMembershipChangedRequest{Org: "other", TeamSlug: "team"})
+	}, 2*time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(membershipID, policy.TeamMembershipChangedRequest{Org: "org", TeamSlug: "team"})
+	}, 3*time.Second)
+	env.ExecuteWorkflow(testWorkflow, req)
+	var resp response
+	err := env.GetWorkflowResult(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resp.FilterCalls)
+	assert.Equal(t, 1, resp.NotifierCalls)
+	assert.Equal(t, []activities.ListTeamMembersRequest{
+		{Repo: req.Revision.Repo, Org: "org", TeamSlug: "team"},
+		{Repo: req.Revision.Repo, Org: "org", TeamSlug: "team", Refresh: true},
+	}, ga.teamRequests)
+}
+
+func TestFailedPolicyHandlerRunner_PolicyCheck(t *testing.T) {
+	ga := &mockGithubActivities{}
+	failingPolicy := activities.PolicySet{Name: "policy1", Owner: "team"}
+	req := request{
+		T:        t,
+		Revision: revision.Revision{Repo: gh.Repo{Name: "repo"}, Revision: "sha"},
+		WorkflowResponses: []terraform.Response{
+			{
+				ValidationResults: []activities.ValidationResult{
+					{
+						Status:    activities.Fail,
+						PolicySet: failingPolicy,
+					},
+				},
+			},
+		},
+		GithubActivities: ga,
+		FilterResponses:  [][]activities.PolicySet{{failingPolicy}, {failingPolicy}},
+		Roots: map[string]revision.RootInfo{
+			"testRoot": {},
+		},
+	}
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	env.RegisterActivity(ga)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(policyCheckID, policy.PolicyCheckRequest{Revision: "stale"})
+	}, 2*time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(policyCheckID, policy.PolicyCheckRequest{Revision: "sha"})
+	}, 3*time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.CancelWorkflow()
+	}, 4*time.Second)
+	env.ExecuteWorkflow(testWorkflow, req)
+	var resp response
+	err := env.GetWorkflowResult(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resp.FilterCalls)
+	// team memberships are refreshed when policies are re-checked
+	assert.Equal(t, []activities.ListTeamMembersRequest{
+		{Repo: req.Revision.Repo, Org: "org", TeamSlug: "team"},
+		{Repo: req.Revision.Repo, Org: "org", TeamSlug: "team", Refresh: true},
+	}, ga.teamRequests)
+}
+
+func TestFailedPolicyHandlerRunner_ApprovePolicies(t *testing.T) {
+	ga := &mockGithubActivities{}
+	earlier := time.Unix(0, 0)
+	later := time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)
+	earlierReview := &github.PullRequestReview{
+		User:        &github.User{Login: github.String("earlier")},
+		State:       github.String(policy.ApprovalState),
+		SubmittedAt: &earlier,
+	}
+	laterReview := &github.PullRequestReview{
+		User:        &github.User{Login: github.String("later")},
+		State:       github.String("CHANGES_REQUESTED"),
+		SubmittedAt: &later,
+	}
+	failingPolicy := activities.PolicySet{Name: "policy1", Owner: "team"}
+	req := request{
+		T:        t,
+		Revision: revision.Revision{Repo: gh.Repo{Name: "repo"}, Revision: "sha"},
+		WorkflowResponses: []terraform.Response{
+			{
+				ValidationResults: []activities.ValidationResult{
+					{
+						Status:    activities.Fail,
+						PolicySet: failingPolicy,
+					},
+				},
+			},
+		},
+		GithubActivities: ga,
+		FilterResponses:  [][]activities.PolicySet{{failingPolicy}, {}},
+		Roots: map[string]revision.RootInfo{
+			"testRoot": {},
+		},
+		State: &state.Workflow{Result: state.WorkflowResult{
+			Status: state.CompleteWorkflowStatus,
+			Reason: state.BypassedFailedValidationReason,
+		}},
+		DismissResponse:  []*github.PullRequestReview{earlierReview, laterReview},
+		CommentApprovals: true,
+	}
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	env.RegisterActivity(ga)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(approvePoliciesID, policy.ApprovePoliciesRequest{Revision: "stale", User: "someone"})
+	}, 2*time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(approvePoliciesID, policy.ApprovePoliciesRequest{Revision: "sha", User: "owner"})
+	}, 3*time.Second)
+	env.ExecuteWorkflow(testWorkflow, req)
+	var resp response
+	err := env.GetWorkflowResult(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resp.FilterCalls)
+	assert.Equal(t, 1, resp.NotifierCalls)
+	// comment approvals are ordered chronologically amongst reviews
+	var logins []string
+	for _, review := range resp.FilterApprovals {
+		logins = append(logins, review.GetUser().GetLogin()+":"+review.GetState())
+	}
+	assert.Equal(t, []string{
+		"earlier:" + policy.ApprovalState,
+		"owner:" + policy.ApprovalState,
+		"later:CHANGES_REQUESTED",
+	}, logins)
 }
 
 type mockDismisser struct {
@@ -176,22 +414,33 @@ type mockFilter struct {
 	calls             int
 	expectedApprovals []*github.PullRequestReview
 	filteredPolicies  []activities.PolicySet
+	responses         [][]activities.PolicySet
+	lastApprovals     []*github.PullRequestReview
 	t                 *testing.T
 }
 
 func (m *mockFilter) Filter(teams map[string][]string, currentApprovals []*github.PullRequestReview, failedPolicies []activities.PolicySet) []activities.PolicySet {
 	m.calls++
-	assert.Equal(m.t, m.expectedApprovals, currentApprovals)
+	m.lastApprovals = currentApprovals
+	if m.expectedApprovals != nil {
+		assert.Equal(m.t, m.expectedApprovals, currentApprovals)
+	}
+	if len(m.responses) > 0 {
+		m.filteredPolicies = m.responses[0]
+		m.responses = m.responses[1:]
+	}
 	return m.filteredPolicies
 }
 
 type mockGithubActivities struct {
-	called  bool
-	reviews activities.ListPRReviewsResponse
-	err     error
+	called       bool
+	reviews      activities.ListPRReviewsResponse
+	err          error
+	teamRequests []activities.ListTeamMembersRequest
 }
 
 func (g *mockGithubActivities) GithubListTeamMembers(ctx context.Context, request activities.ListTeamMembersRequest) (activities.ListTeamMembersResponse, error) {
+	g.teamRequests = append(g.teamRequests, request)
 	return activities.ListTeamMembersResponse{}, nil
 }
 
@@ -212,3 +461,11 @@ func (m *mockNotifier) Notify(ctx workflow.Context, workflowState *state.Workflo
 	assert.Equal(m.t, m.expectedWorkflowState, workflowState)
 	assert.Equal(m.t, m.expectedRoots, roots)
 }
+
+type mockSummaryCommenter struct {
+	refreshed []terraform.Response
+}
+
+func (m *mockSummaryCommenter) Refresh(ctx workflow.Context, prRevision revision.Revision, roots map[string]revision.RootInfo, updated []terraform.Response) {
+	m.refreshed = append(m.refreshed, updated...)
+}
@@ -18,22 +18,43 @@ import (
 )
 
 const (
-	ReviewSignalID         = "pr-review"
-	CheckRunCancelled      = "Checkrun was cancelled, please review latest revision for Terraform changes."
-	SkipCancelingCheckRuns = "skip-cancelling-checkruns"
-	ParentRequestCancel    = "parent-request-cancel"
+	ReviewSignalID          = "pr-review"
+	TeamMembershipSignalID  = "team-membership"
+	PolicyCheckSignalID     = "pr-policy-check"
+	ApprovePoliciesSignalID = "pr-approve-policies"
+	CheckRunCancelled       = "Checkrun was cancelled, please review latest revision for Terraform changes."
+	SkipCancelingCheckRuns  = "skip-cancelling-checkruns"
+	ParentRequestCancel     = "parent-request-cancel"
+	BoundedRootConcurrency  = "bounded-root-concurrency"
+	IncrementalReplan       = "incremental-replan"
+	PRSummaryComment        = "pr-summary-comment"
+	PlanDiff                = "plan-diff"
+	RepoConcurrency         = "repo-concurrency"
+	CheckRunQueued          = "Queued, waiting for other roots in this revision to finish planning."
+	CheckRunRepoQueued      = "Queued, waiting for other plans in this repo to finish."
 )
 
 type TFWorkflow func(ctx workflow.Context, request terraform.Request) (terraform.Response, error)
 
 type TFStateReceiver interface {
 	Receive(ctx workflow.Context, c workflow.ReceiveChannel, rootCache map[string]RootInfo)
+	Notify(ctx workflow.Context, workflowState *state.Workflow, rootCache map[string]RootInfo)
 }
 
 type PolicyHandler interface {
 	Handle(ctx workflow.Context, prRevision Revision, roots map[string]RootInfo, workflowResponses []terraform.Response)
 }
 
+type SummaryCommenter interface {
+	Update(ctx workflow.Context, prRevision Revision, roots map[string]RootInfo, responses []terraform.Response)
+}
+
+// RepoLimiter hands out the permits capping the number of roots planned at once across all PRs of a repo
+type RepoLimiter interface {
+	Acquire(ctx workflow.Context, repoFullName string) (string, workflow.Future)
+	Release(ctx workflow.Context, repoFullName string, permitID string)
+}
+
 type CheckRunClient interface {
 	CreateOrUpdate(ctx workflow.Context, id string, request notifier.GithubCheckRunRequest) (int64, error)
 }
@@ -43,7 +64,20 @@ type Processor struct {
 	TFWorkflow          TFWorkflow
 	PolicyHandler       PolicyHandler
 	GithubCheckRunCache CheckRunClient
+	SummaryCommenter    SummaryCommenter
+	RepoLimiter         RepoLimiter
 	Scope               metrics.Scope
+
+	// mutable state
+	lastPlans map[string]plannedRoot
+	// last revision each root was planned for, plans are diffed against it
+	lastPlannedRevisions map[string]string
+}
+
+// plannedRoot is the last successful plan of a root and the fingerprint it was planned at
+type plannedRoot struct {
+	fingerprint string
+	response    terraform.Response
 }
 
 // Process handles spinning off child Terraform workflows per root and
@@ -51,16 +85,24 @@ type Processor struct {
 func (p *Processor) Process(ctx workflow.Context, prRevision Revision) {
 	roots := make(map[string]RootInfo)
 	var futures []workflow.ChildWorkflowFuture
+	// roots waiting for a permit from the repo's limiter before they're started
+	var acquiring []RootInfo
+	var queued []RootInfo
 	scope := p.Scope.SubScope("policies").SubScopeWithTags(map[string]string{
 		metricNames.RevisionTag: prRevision.Revision,
 	})
+	limit := p.concurrencyLimit(ctx, prRevision)
+	repoLimited := p.repoLimited(ctx, prRevision)
+	incremental := workflow.GetVersion(ctx, IncrementalReplan, workflow.DefaultVersion, workflow.Version(1)) != workflow.DefaultVersion
+	var carried []terraform.Response
+	// roots are started in the order they were received which matches their order in the repo config
 	for _, root := range prRevision.Roots {
 		id, err := sideeffect.GenerateUUID(ctx)
 		if err != nil {
 			workflow.GetLogger(workflow.WithValue(ctx, internalContext.ErrKey, err)).Error("generating uuid")
 			continue
 		}
-		roots[id.String()] = RootInfo{
+		rootInfo := RootInfo{
 			ID: id,
 			Commit: github.Commit{
 				Revision: prRevision.Revision,
@@ -68,9 +110,25 @@ func (p *Processor) Process(ctx workflow.Context, prRevision Revision) {
 			Root: root,
 			Repo: prRevision.Repo,
 		}
+		roots[id.String()] = rootInfo
+		if incremental {
+			if resp, ok := p.carryForward(ctx, prRevision, rootInfo, roots); ok {
+				carried = append(carried, resp)
+				continue
+			}
+		}
+		if len(futures)+len(acquiring) >= limit {
+			queued = append(queued, rootInfo)
+			continue
+		}
+		if repoLimited {
+			acquiring = append(acquiring, rootInfo)
+			continue
+		}
 		future := p.processRoot(ctx, root, prRevision, id)
 		futures = append(futures, future)
 	}
+	p.markCheckRunsQueued(ctx, prRevision, queued, CheckRunQueued)
 
 	// Mark checkruns as aborted if the context was cancelled, this typically happens if revisions arrive in quick succession
 	defer func() {
@@ -84,10 +142,20 @@ func (p *Processor) Process(ctx workflow.Context, prRevision Revision) {
 		p.markCombinedCheckRun(ctx, prRevision, github.CheckRunSuccess, "")
 	}()
 
-	terraformWorkflowResponses := p.awaitChildTerraformWorkflows(ctx, futures, roots)
+	terraformWorkflowResponses := p.awaitChildTerraformWorkflows(ctx, prRevision, futures, acquiring, queued, roots, repoLimited)
+	p.recordPlannedRevisions(ctx, prRevision, roots, terraformWorkflowResponses)
+	if incremental {
+		p.recordPlans(prRevision, roots, terraformWorkflowResponses)
+		terraformWorkflowResponses = append(terraformWorkflowResponses, carried...)
+	}
+	p.updateSummaryComment(ctx, prRevision, roots, terraformWorkflowResponses)
 	// Count all policy successes/failures + handle any failures by listening for approvals in PolicyHandler
 	var failingTerraformWorkflowResponses []terraform.Response
 	for _, resp := range terraformWorkflowResponses {
+		// partial plans can't satisfy policies, so there's nothing to approve
+		if roots[resp.WorkflowState.ID].Root.PartialPlan {
+			continue
+		}
 		for _, validationResult := range resp.ValidationResults {
 			switch validationResult.Status {
 			case activities.Fail:
@@ -101,6 +169,107 @@ func (p *Processor) Process(ctx workflow.Context, prRevision Revision) {
 	p.PolicyHandler.Handle(ctx, prRevision, roots, failingTerraformWorkflowResponses)
 }
 
+// updateSummaryComment summarizes every root of the revision in a single PR comment
+func (p *Processor) updateSummaryComment(ctx workflow.Context, prRevision Revision, roots map[string]RootInfo, responses []terraform.Response) {
+	if p.SummaryCommenter == nil || ctx.Err() != nil {
+		return
+	}
+	version := workflow.GetVersion(ctx, PRSummaryComment, workflow.DefaultVersion, workflow.Version(1))
+	if version == workflow.DefaultVersion {
+		return
+	}
+	p.SummaryCommenter.Update(ctx, prRevision, roots, responses)
+}
+
+// carryForward reuses the last successful plan of a root if its fingerprint hasn't changed since, the plan's
+// final state is notified again for the new revision which carries its check run forward.
+func (p *Processor) carryForward(ctx workflow.Context, prRevision Revision, rootInfo RootInfo, roots map[string]RootInfo) (terraform.Response, bool) {
+	if prRevision.ForceReplan {
+		return terraform.Response{}, false
+	}
+	fingerprint, ok := prRevision.Fingerprints[rootInfo.Root.Name]
+	if !ok {
+		return terraform.Response{}, false
+	}
+	lastPlan, ok := p.lastPlans[rootInfo.Root.Name]
+	if !ok || lastPlan.fingerprint != fingerprint {
+		return terraform.Response{}, false
+	}
+
+	resp := lastPlan.response
+	resp.WorkflowState.ID = rootInfo.ID.String()
+	p.TFStateReceiver.Notify(ctx, &resp.WorkflowState, roots)
+	return resp, true
+}
+
+// recordPlans keeps track of the successful plans of the revision's fingerprinted roots
+func (p *Processor) recordPlans(prRevision Revision, roots map[string]RootInfo, responses []terraform.Response) {
+	if p.lastPlans == nil {
+		p.lastPlans = make(map[string]plannedRoot)
+	}
+	for _, resp := range responses {
+		rootInfo, ok := roots[resp.WorkflowState.ID]
+		if !ok {
+			continue
+		}
+		name := rootInfo.Root.Name
+		fingerprint, ok := prRevision.Fingerprints[name]
+		if !ok || rootInfo.Root.PartialPlan {
+			delete(p.lastPlans, name)
+			continue
+		}
+		p.lastPlans[name] = plannedRoot{
+			fingerprint: fingerprint,
+			response:    resp,
+		}
+	}
+}
+
+// recordPlannedRevisions keeps track of the revision each root was last planned for, carried plans
+// are excluded since they were planned for an earlier revision.
+func (p *Processor) recordPlannedRevisions(ctx workflow.Context, prRevision Revision, roots map[string]RootInfo, responses []terraform.Response) {
+	if workflow.GetVersion(ctx, PlanDiff, workflow.DefaultVersion, workflow.Version(1)) == workflow.DefaultVersion {
+		return
+	}
+	if p.lastPlannedRevisions == nil {
+		p.lastPlannedRevisions = make(map[string]string)
+	}
+	for _, resp := range responses {
+		rootInfo, ok := roots[resp.WorkflowState.ID]
+		if !ok {
+			continue
+		}
+		p.lastPlannedRevisions[rootInfo.Root.Name] = prRevision.Revision
+	}
+}
+
+// DiscardPlans forgets the plans carried forward between revisions, every root is re-planned for the next revision
+func (p *Processor) DiscardPlans() {
+	p.lastPlans = nil
+}
+
+// concurrencyLimit returns the max number of child terraform workflows to run at once for the revision
+func (p *Processor) concurrencyLimit(ctx workflow.Context, prRevision Revision) int {
+	limit := len(prRevision.Roots)
+	version := workflow.GetVersion(ctx, BoundedRootConcurrency, workflow.DefaultVersion, workflow.Version(1))
+	if version == workflow.DefaultVersion {
+		return limit
+	}
+	if prRevision.MaxConcurrentRoots > 0 && prRevision.MaxConcurrentRoots < limit {
+		limit = prRevision.MaxConcurrentRoots
+	}
+	return limit
+}
+
+// repoLimited returns whether roots need a permit from the repo's limiter before they're planned
+func (p *Processor) repoLimited(ctx workflow.Context, prRevision Revision) bool {
+	version := workflow.GetVersion(ctx, RepoConcurrency, workflow.DefaultVersion, workflow.Version(1))
+	if version == workflow.DefaultVersion {
+		return false
+	}
+	return p.RepoLimiter != nil && prRevision.MaxConcurrentRepoRoots > 0
+}
+
 func (p *Processor) processRoot(ctx workflow.Context, root terraformActivities.Root, prRevision Revision, id uuid.UUID) workflow.ChildWorkflowFuture {
 	ctx = workflow.WithValue(ctx, internalContext.ProjectKey, root.Name)
 	// Use workflow versioning to safely add ParentClosePolicy without breaking existing workflows
@@ -134,13 +303,18 @@ func (p *Processor) processRoot(ctx workflow.Context, root terraformActivities.R
 		Revision:     prRevision.Revision,
 		WorkflowMode: terraformActivities.PR,
 	}
+	if workflow.GetVersion(ctx, PlanDiff, workflow.DefaultVersion, workflow.Version(1)) != workflow.DefaultVersion {
+		request.PreviousRevision = p.lastPlannedRevisions[root.Name]
+	}
 	future := workflow.ExecuteChildWorkflow(ctx, p.TFWorkflow, request)
 	return future
 }
 
 // awaitChildTerraformWorkflows creates a selector to listen to the completion of each root's child workflow future and any state
-// change signals they send over the shared WorkflowStateChangeSignal channel; we only return when all workflows complete
-func (p *Processor) awaitChildTerraformWorkflows(ctx workflow.Context, futures []workflow.ChildWorkflowFuture, roots map[string]RootInfo) []terraform.Response {
+// change signals they send over the shared WorkflowStateChangeSignal channel; queued roots are started as running workflows
+// complete and we only return when all workflows complete. If the repo is limited, roots are only started once the repo's
+// limiter grants them a permit which is released when their workflow completes.
+func (p *Processor) awaitChildTerraformWorkflows(ctx workflow.Context, prRevision Revision, futures []workflow.ChildWorkflowFuture, acquiring []RootInfo, queued []RootInfo, roots map[string]RootInfo, repoLimited bool) []terraform.Response {
 	selector := workflow.NewNamedSelector(ctx, "TerraformChildWorkflow")
 	ch := workflow.GetSignalChannel(ctx, state.WorkflowStateChangeSignal)
 	selector.AddReceive(ch, func(c workflow.ReceiveChannel, _ bool) {
@@ -148,11 +322,33 @@ func (p *Processor) awaitChildTerraformWorkflows(ctx workflow.Context, futures [
 	})
 
 	var results []terraform.Response
-	workflowsLeft := len(futures)
-	for _, future := range futures {
+	workflowsLeft := len(futures) + len(acquiring) + len(queued)
+	var addFuture func(future workflow.ChildWorkflowFuture, permitID string)
+	var acquire func(rootInfo RootInfo)
+	startNext := func() {
+		// queued roots are never started once the revision is cancelled
+		if ctx.Err() != nil {
+			workflowsLeft -= len(queued)
+			queued = nil
+		}
+
+		// a slot has opened up so let's start the next queued root, if any
+		if len(queued) > 0 {
+			next := queued[0]
+			queued = queued[1:]
+			if repoLimited {
+				acquire(next)
+				return
+			}
+			addFuture(p.processRoot(ctx, next.Root, prRevision, next.ID), "")
+		}
+	}
+	addFuture = func(future workflow.ChildWorkflowFuture, permitID string) {
 		selector.AddFuture(future, func(f workflow.Future) {
 			defer func() {
 				workflowsLeft--
+				p.releasePermit(ctx, prRevision, permitID)
+				startNext()
 			}()
 			var resp terraform.Response
 			if err := f.Get(ctx, &resp); err != nil {
@@ -163,16 +359,44 @@ func (p *Processor) awaitChildTerraformWorkflows(ctx workflow.Context, futures [
 			results = append(results, resp)
 		})
 	}
+	acquire = func(rootInfo RootInfo) {
+		p.markCheckRunsQueued(ctx, prRevision, []RootInfo{rootInfo}, CheckRunRepoQueued)
+		permitID, granted := p.RepoLimiter.Acquire(ctx, prRevision.Repo.GetFullName())
+		selector.AddFuture(granted, func(f workflow.Future) {
+			if err := f.Get(ctx, nil); err != nil {
+				// the revision was cancelled before the permit was granted
+				workflowsLeft--
+				p.releasePermit(ctx, prRevision, permitID)
+				startNext()
+				return
+			}
+			addFuture(p.processRoot(ctx, rootInfo.Root, prRevision, rootInfo.ID), permitID)
+		})
+	}
+	for _, future := range futures {
+		addFuture(future, "")
+	}
+	for _, rootInfo := range acquiring {
+		acquire(rootInfo)
+	}
 
-	for {
+	for workflowsLeft > 0 {
 		selector.Select(ctx)
-		if workflowsLeft == 0 {
-			break
-		}
 	}
 	return results
 }
 
+// releasePermit gives the root's permit back to the repo's limiter, even if the revision was cancelled
+func (p *Processor) releasePermit(ctx workflow.Context, prRevision Revision, permitID string) {
+	if permitID == "" {
+		return
+	}
+	if ctx.Err() != nil {
+		ctx, _ = workflow.NewDisconnectedContext(ctx)
+	}
+	p.RepoLimiter.Release(ctx, prRevision.Repo.GetFullName(), permitID)
+}
+
 func (p *Processor) markCombinedCheckRun(ctx workflow.Context, revision Revision, state github.CheckRunState, summary string) {
 	ctx = workflow.WithRetryPolicy(ctx, temporal.RetryPolicy{
 		MaximumAttempts: 3,
@@ -193,6 +417,27 @@ func (p *Processor) markCombinedCheckRun(ctx workflow.Context, revision Revision
 	}
 }
 
+func (p *Processor) markCheckRunsQueued(ctx workflow.Context, revision Revision, queued []RootInfo, summary string) {
+	for _, rootInfo := range queued {
+		ctx := workflow.WithRetryPolicy(ctx, temporal.RetryPolicy{
+			MaximumAttempts: 3,
+		})
+
+		request := notifier.GithubCheckRunRequest{
+			Title:   notifier.BuildPlanCheckRunTitle(rootInfo.Root.Name),
+			Sha:     revision.Revision,
+			Repo:    revision.Repo,
+			State:   github.CheckRunQueued,
+			Mode:    terraformActivities.PR,
+			Summary: summary,
+		}
+		_, err := p.GithubCheckRunCache.CreateOrUpdate(ctx, rootInfo.ID.String(), request)
+		if err != nil {
+			workflow.GetLogger(ctx).Error("unable to mark check run as queued", internalContext.ErrKey, err)
+		}
+	}
+}
+
 func (p *Processor) markCheckRunsAborted(ctx workflow.Context, revision Revision, roots map[string]RootInfo) {
 	p.markCombinedCheckRun(ctx, revision, github.CheckRunCancelled, CheckRunCancelled)
 	version := workflow.GetVersion(ctx, SkipCancelingCheckRuns, workflow.DefaultVersion, 1)
@@ -1,6 +1,7 @@
 package revision_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/notifier"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/pr/revision"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/terraform"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/terraform/state"
 	"github.com/stretchr/testify/assert"
 	"go.temporal.io/sdk/testsuite"
 	"go.temporal.io/sdk/workflow"
@@ -94,6 +96,336 @@ func TestProcess(t *testing.T) {
 	})
 }
 
+type boundedProcessRevisionResponse struct {
+	QueuedRoots       []string
+	FailingResponses  int
+	CombinedCheckRuns int
+}
+
+func TestProcess_BoundedConcurrency(t *testing.T) {
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(testTFWorkflow)
+	env.ExecuteWorkflow(testBoundedProcessRevisionWorkflow, revision.Revision{
+		Repo: github.Repo{},
+		Roots: []terraformActivities.Root{
+			{Name: "root-1"},
+			{Name: "root-2"},
+			{Name: "root-3"},
+		},
+		MaxConcurrentRoots: 1,
+	})
+	env.AssertExpectations(t)
+
+	var result boundedProcessRevisionResponse
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, boundedProcessRevisionResponse{
+		QueuedRoots: []string{
+			notifier.BuildPlanCheckRunTitle("root-2"),
+			notifier.BuildPlanCheckRunTitle("root-3"),
+		},
+		// each root has two failing policies
+		FailingResponses:  6,
+		CombinedCheckRuns: 1,
+	}, result)
+}
+
+func testBoundedProcessRevisionWorkflow(ctx workflow.Context, r revision.Revision) (boundedProcessRevisionResponse, error) {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToCloseTimeout: 5 * time.Second,
+	})
+
+	checkRunCache := &recordingCheckRunCache{}
+	policyHandler := &recordingPolicyHandler{}
+	processor := revision.Processor{
+		TFStateReceiver:     &revision.StateReceiver{},
+		TFWorkflow:          testTFWorkflow,
+		PolicyHandler:       policyHandler,
+		GithubCheckRunCache: checkRunCache,
+		Scope:               metrics.NewNullableScope(),
+	}
+	processor.Process(ctx, r)
+
+	var queued []string
+	var combined int
+	for _, request := range checkRunCache.requests {
+		switch request.State {
+		case github.CheckRunQueued:
+			queued = append(queued, request.Title)
+		case github.CheckRunSuccess:
+			combined++
+		}
+	}
+	return boundedProcessRevisionResponse{
+		QueuedRoots:       queued,
+		FailingResponses:  len(policyHandler.responses),
+		CombinedCheckRuns: combined,
+	}, nil
+}
+
+type repoLimitedProcessRevisionResponse struct {
+	QueuedSummaries  []string
+	Released         []string
+	MaxHeld          int
+	FailingResponses int
+}
+
+func TestProcess_RepoConcurrency(t *testing.T) {
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(testTFWorkflow)
+	env.ExecuteWorkflow(testRepoLimitedProcessRevisionWorkflow, revision.Revision{
+		Repo: github.Repo{},
+		Roots: []terraformActivities.Root{
+			{Name: "root-1"},
+			{Name: "root-2"},
+			{Name: "root-3"},
+		},
+		MaxConcurrentRepoRoots: 1,
+	})
+	env.AssertExpectations(t)
+
+	var result repoLimitedProcessRevisionResponse
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, repoLimitedProcessRevisionResponse{
+		QueuedSummaries: []string{
+			revision.CheckRunRepoQueued,
+			revision.CheckRunRepoQueued,
+			revision.CheckRunRepoQueued,
+		},
+		Released: []string{"permit-0", "permit-1", "permit-2"},
+		MaxHeld:  1,
+		// each root has two failing policies
+		FailingResponses: 6,
+	}, result)
+}
+
+func testRepoLimitedProcessRevisionWorkflow(ctx workflow.Context, r revision.Revision) (repoLimitedProcessRevisionResponse, error) {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToCloseTimeout: 5 * time.Second,
+	})
+
+	checkRunCache := &recordingCheckRunCache{}
+	policyHandler := &recordingPolicyHandler{}
+	repoLimiter := &testRepoLimiter{limit: r.MaxConcurrentRepoRoots}
+	processor := revision.Processor{
+		TFStateReceiver:     &revision.StateReceiver{},
+		TFWorkflow:          testTFWorkflow,
+		PolicyHandler:       policyHandler,
+		GithubCheckRunCache: checkRunCache,
+		RepoLimiter:         repoLimiter,
+		Scope:               metrics.NewNullableScope(),
+	}
+	processor.Process(ctx, r)
+
+	var queued []string
+	for _, request := range checkRunCache.requests {
+		if request.State == github.CheckRunQueued {
+			queued = append(queued, request.Summary)
+		}
+	}
+	return repoLimitedProcessRevisionResponse{
+		QueuedSummaries:  queued,
+		Released:         repoLimiter.released,
+		MaxHeld:          repoLimiter.maxHeld,
+		FailingResponses: len(policyHandler.responses),
+	}, nil
+}
+
+// testRepoLimiter grants permits in order while fewer than limit are held
+type testRepoLimiter struct {
+	limit    int
+	acquired int
+	held     int
+	maxHeld  int
+	waiters  []workflow.Settable
+	released []string
+}
+
+func (l *testRepoLimiter) Acquire(ctx workflow.Context, _ string) (string, workflow.Future) {
+	future, settable := workflow.NewFuture(ctx)
+	permitID := fmt.Sprintf("permit-%d", l.acquired)
+	l.acquired++
+	if l.held < l.limit {
+		l.grant(settable)
+	} else {
+		l.waiters = append(l.waiters, settable)
+	}
+	return permitID, future
+}
+
+func (l *testRepoLimiter) Release(_ workflow.Context, _ string, permitID string) {
+	l.released = append(l.released, permitID)
+	l.held--
+	if len(l.waiters) > 0 {
+		next := l.waiters[0]
+		l.waiters = l.waiters[1:]
+		l.grant(next)
+	}
+}
+
+func (l *testRepoLimiter) grant(settable workflow.Settable) {
+	l.held++
+	if l.held > l.maxHeld {
+		l.maxHeld = l.held
+	}
+	settable.Set(nil, nil)
+}
+
+type recordingCheckRunCache struct {
+	requests []notifier.GithubCheckRunRequest
+}
+
+func (c *recordingCheckRunCache) CreateOrUpdate(ctx workflow.Context, id string, request notifier.GithubCheckRunRequest) (int64, error) {
+	c.requests = append(c.requests, request)
+	return 0, nil
+}
+
+type recordingPolicyHandler struct {
+	responses []terraform.Response
+}
+
+func (p *recordingPolicyHandler) Handle(ctx workflow.Context, revision revision.Revision, roots map[string]revision.RootInfo, responses []terraform.Response) {
+	p.responses = responses
+}
+
+type incrementalProcessResponse struct {
+	// per revision
+	Carried   []int
+	Responses []int
+}
+
+func TestProcess_IncrementalReplan(t *testing.T) {
+	roots := []terraformActivities.Root{
+		{Name: "root-1"},
+		{Name: "root-2"},
+	}
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(testTFWorkflowWithState)
+	env.ExecuteWorkflow(testIncrementalProcessWorkflow, []revision.Revision{
+		{
+			Revision:     "1",
+			Roots:        roots,
+			Fingerprints: map[string]string{"root-1": "a", "root-2": "b"},
+		},
+		{
+			Revision:     "2",
+			Roots:        roots,
+			Fingerprints: map[string]string{"root-1": "a", "root-2": "c"},
+		},
+		{
+			Revision:     "3",
+			Roots:        roots,
+			Fingerprints: map[string]string{"root-1": "a", "root-2": "c"},
+			ForceReplan:  true,
+		},
+		{
+			Revision: "4",
+			Roots:    roots,
+		},
+	})
+	env.AssertExpectations(t)
+
+	var result incrementalProcessResponse
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, incrementalProcessResponse{
+		// root-1 is unchanged in the second revision, the third one forces a re-plan
+		// and the last one isn't fingerprinted
+		Carried: []int{0, 1, 0, 0},
+		// carried plans are still handled by the policy handler
+		Responses: []int{2, 2, 2, 2},
+	}, result)
+}
+
+func TestProcess_PartialPlan(t *testing.T) {
+	fingerprints := map[string]string{"root-1": "a", "root-2": "b"}
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(testTFWorkflowWithState)
+	env.ExecuteWorkflow(testIncrementalProcessWorkflow, []revision.Revision{
+		{
+			Revision: "1",
+			Roots: []terraformActivities.Root{
+				{Name: "root-1", PartialPlan: true},
+				{Name: "root-2"},
+			},
+			Fingerprints: fingerprints,
+		},
+		{
+			Revision: "2",
+			Roots: []terraformActivities.Root{
+				{Name: "root-1"},
+				{Name: "root-2"},
+			},
+			Fingerprints: fingerprints,
+		},
+	})
+	env.AssertExpectations(t)
+
+	var result incrementalProcessResponse
+	err := env.GetWorkflowResult(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, incrementalProcessResponse{
+		// the partial plan of root-1 isn't carried forward
+		Carried: []int{0, 1},
+		// and its failing policies aren't handled since it can't pass them
+		Responses: []int{1, 2},
+	}, result)
+}
+
+func testIncrementalProcessWorkflow(ctx workflow.Context, revisions []revision.Revision) (incrementalProcessResponse, error) {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToCloseTimeout: 5 * time.Second,
+	})
+
+	stateReceiver := &recordingStateReceiver{}
+	policyHandler := &recordingPolicyHandler{}
+	processor := revision.Processor{
+		TFStateReceiver:     stateReceiver,
+		TFWorkflow:          testTFWorkflowWithState,
+		PolicyHandler:       policyHandler,
+		GithubCheckRunCache: &recordingCheckRunCache{},
+		Scope:               metrics.NewNullableScope(),
+	}
+
+	var result incrementalProcessResponse
+	for _, r := range revisions {
+		stateReceiver.notified = nil
+		processor.Process(ctx, r)
+		result.Carried = append(result.Carried, len(stateReceiver.notified))
+		result.Responses = append(result.Responses, len(policyHandler.responses))
+	}
+	return result, nil
+}
+
+// testTFWorkflowWithState returns a response with a single failing policy for the root
+func testTFWorkflowWithState(_ workflow.Context, request terraform.Request) (terraform.Response, error) {
+	return terraform.Response{
+		ValidationResults: []activities.ValidationResult{
+			{
+				Status:    activities.Fail,
+				PolicySet: activities.PolicySet{Name: badPolicy},
+			},
+		},
+		WorkflowState: state.Workflow{
+			ID: request.DeploymentID,
+		},
+	}, nil
+}
+
+type recordingStateReceiver struct {
+	revision.StateReceiver
+	notified []*state.Workflow
+}
+
+func (r *recordingStateReceiver) Notify(ctx workflow.Context, workflowState *state.Workflow, roots map[string]revision.RootInfo) {
+	r.notified = append(r.notified, workflowState)
+}
+
 func testProcessRevisionWorkflow(ctx workflow.Context, r processRevisionRequest) (processRevisionResponse, error) {
 	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
 		ScheduleToCloseTimeout: 5 * time.Second,
@@ -23,12 +23,24 @@ type NewTerraformRevisionRequest struct {
 	Repo     request.Repo
 	Revision string
 	Roots    []request.Root
+	// MaxConcurrentRoots caps the number of child terraform workflows running at once, 0 means no limit
+	MaxConcurrentRoots int
+	// MaxConcurrentRepoRoots caps the number of roots planned at once across all PRs of the repo, 0 means no limit.
+	// The limit itself is enforced by the repo's limiter workflow.
+	MaxConcurrentRepoRoots int
+	// ForceReplan plans all roots even if their fingerprint hasn't changed since their last plan
+	ForceReplan bool
 }
 
 type Revision struct {
-	Repo     github.Repo
-	Revision string
-	Roots    []terraform.Root
+	Repo                   github.Repo
+	Revision               string
+	Roots                  []terraform.Root
+	MaxConcurrentRoots     int
+	MaxConcurrentRepoRoots int
+	ForceReplan            bool
+	// Fingerprints of the roots keyed by root name
+	Fingerprints map[string]string
 }
 
 func NewRevisionReceiver(ctx workflow.Context, scope workflowMetrics.Scope) Receiver {
@@ -52,12 +64,20 @@ func (r *Receiver) Receive(c workflow.ReceiveChannel, more bool) Revision {
 
 	repo := converter.Repo(request.Repo)
 	var roots []terraform.Root
+	fingerprints := make(map[string]string)
 	for _, root := range request.Roots {
 		roots = append(roots, converter.Root(root))
+		if root.Fingerprint != "" {
+			fingerprints[root.Name] = root.Fingerprint
+		}
 	}
 	return Revision{
-		Repo:     repo,
-		Revision: request.Revision,
-		Roots:    roots,
+		Repo:                   repo,
+		Revision:               request.Revision,
+		Roots:                  roots,
+		MaxConcurrentRoots:     request.MaxConcurrentRoots,
+		MaxConcurrentRepoRoots: request.MaxConcurrentRepoRoots,
+		ForceReplan:            request.ForceReplan,
+		Fingerprints:           fingerprints,
 	}
 }
@@ -0,0 +1,100 @@
+package revision
+
+import (
+	"context"
+
+	internalContext "github.com/runatlantis/atlantis/server/neptune/context"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github/markdown"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/terraform"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/terraform/state"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+type commentActivities interface {
+	GithubCreateComment(ctx context.Context, request activities.CreateCommentRequest) (activities.CreateCommentResponse, error)
+	GithubUpdateComment(ctx context.Context, request activities.UpdateCommentRequest) (activities.UpdateCommentResponse, error)
+}
+
+// SummaryComment maintains a single PR comment summarizing every root of the latest revision,
+// the comment is created for the first revision and edited in place for subsequent ones.
+type SummaryComment struct {
+	GithubActivities commentActivities
+	PRNumber         int
+
+	// mutable state
+	commentID int64
+	responses []terraform.Response
+}
+
+func (c *SummaryComment) Update(ctx workflow.Context, prRevision Revision, roots map[string]RootInfo, responses []terraform.Response) {
+	c.responses = append([]terraform.Response{}, responses...)
+	c.render(ctx, prRevision, roots)
+}
+
+// Refresh replaces the states of the given roots of the revision last summarized, e.g. once their failing
+// policies are bypassed.  Nothing is done if there's no summary comment to edit.
+func (c *SummaryComment) Refresh(ctx workflow.Context, prRevision Revision, roots map[string]RootInfo, updated []terraform.Response) {
+	if c.commentID == 0 || len(updated) == 0 {
+		return
+	}
+	for _, u := range updated {
+		for i, resp := range c.responses {
+			if resp.WorkflowState.ID == u.WorkflowState.ID {
+				c.responses[i] = u
+			}
+		}
+	}
+	c.render(ctx, prRevision, roots)
+}
+
+func (c *SummaryComment) render(ctx workflow.Context, prRevision Revision, roots map[string]RootInfo) {
+	ctx = workflow.WithRetryPolicy(ctx, temporal.RetryPolicy{
+		MaximumAttempts: 3,
+	})
+
+	states := make(map[string]*state.Workflow)
+	for _, resp := range c.responses {
+		rootInfo, ok := roots[resp.WorkflowState.ID]
+		if !ok {
+			continue
+		}
+		workflowState := resp.WorkflowState
+		states[rootInfo.Root.Name] = &workflowState
+	}
+
+	// roots are listed in the order they're configured in the repo
+	var summaryRoots []markdown.PRSummaryRoot
+	for _, root := range prRevision.Roots {
+		summaryRoots = append(summaryRoots, markdown.PRSummaryRoot{
+			Name:  root.Name,
+			State: states[root.Name],
+		})
+	}
+	body := markdown.RenderPRSummary(prRevision.Revision, prRevision.Repo, summaryRoots)
+
+	if c.commentID != 0 {
+		err := workflow.ExecuteActivity(ctx, c.GithubActivities.GithubUpdateComment, activities.UpdateCommentRequest{
+			Repo:        prRevision.Repo,
+			CommentID:   c.commentID,
+			CommentBody: body,
+		}).Get(ctx, nil)
+		if err != nil {
+			workflow.GetLogger(ctx).Error("unable to update summary comment", internalContext.ErrKey, err)
+		}
+		return
+	}
+
+	var resp activities.CreateCommentResponse
+	err := workflow.ExecuteActivity(ctx, c.GithubActivities.GithubCreateComment, activities.CreateCommentRequest{
+		Repo:        prRevision.Repo,
+		PRNumber:    c.PRNumber,
+		CommentBody: body,
+	}).Get(ctx, &resp)
+	if err != nil {
+		workflow.GetLogger(ctx).Error("unable to create summary comment", internalContext.ErrKey, err)
+		return
+	}
+	c.commentID = resp.CommentID
+}
@@ -0,0 +1,148 @@
+package revision_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/conftest"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
+	terraformActivities "github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/pr/revision"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/terraform"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/terraform/state"
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+type testCommentActivities struct {
+	created []activities.CreateCommentRequest
+	updated []activities.UpdateCommentRequest
+}
+
+func (a *testCommentActivities) GithubCreateComment(ctx context.Context, request activities.CreateCommentRequest) (activities.CreateCommentResponse, error) {
+	a.created = append(a.created, request)
+	return activities.CreateCommentResponse{CommentID: 123}, nil
+}
+
+func (a *testCommentActivities) GithubUpdateComment(ctx context.Context, request activities.UpdateCommentRequest) (activities.UpdateCommentResponse, error) {
+	a.updated = append(a.updated, request)
+	return activities.UpdateCommentResponse{}, nil
+}
+
+func TestSummaryComment_Update(t *testing.T) {
+	ga := &testCommentActivities{}
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	env.RegisterActivity(ga)
+	env.ExecuteWorkflow(testSummaryCommentWorkflow, ga)
+	assert.NoError(t, env.GetWorkflowError())
+
+	// the comment is only created for the first revision and edited afterwards
+	assert.Len(t, ga.created, 1)
+	assert.Equal(t, 1, ga.created[0].PRNumber)
+	assert.Contains(t, ga.created[0].CommentBody, "root-1")
+	assert.Contains(t, ga.created[0].CommentBody, "root-2")
+
+	assert.Len(t, ga.updated, 1)
+	assert.Equal(t, int64(123), ga.updated[0].CommentID)
+	assert.Contains(t, ga.updated[0].CommentBody, "rev-2")
+}
+
+func testSummaryCommentWorkflow(ctx workflow.Context, ga *testCommentActivities) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToCloseTimeout: 5 * time.Second,
+	})
+
+	roots := map[string]revision.RootInfo{
+		"id-1": {Root: terraformActivities.Root{Name: "root-1"}},
+	}
+	responses := []terraform.Response{
+		{
+			WorkflowState: state.Workflow{ID: "id-1"},
+		},
+	}
+
+	comment := &revision.SummaryComment{
+		GithubActivities: ga,
+		PRNumber:         1,
+	}
+	for _, rev := range []string{"rev-1", "rev-2"} {
+		comment.Update(ctx, revision.Revision{
+			Repo:     github.Repo{Owner: "owner", Name: "repo"},
+			Revision: rev,
+			Roots: []terraformActivities.Root{
+				{Name: "root-1"},
+				{Name: "root-2"},
+			},
+		}, roots, responses)
+	}
+	return nil
+}
+
+func TestSummaryComment_Refresh(t *testing.T) {
+	ga := &testCommentActivities{}
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	env.RegisterActivity(ga)
+	env.ExecuteWorkflow(testSummaryRefreshWorkflow, ga)
+	assert.NoError(t, env.GetWorkflowError())
+
+	assert.Len(t, ga.created, 1)
+	assert.Contains(t, ga.created[0].CommentBody, "Failing (policy-1)")
+
+	// the bypassed root is rendered in place of its failing state
+	assert.Len(t, ga.updated, 1)
+	assert.Equal(t, int64(123), ga.updated[0].CommentID)
+	assert.Contains(t, ga.updated[0].CommentBody, "Bypassed (policy-1)")
+}
+
+func testSummaryRefreshWorkflow(ctx workflow.Context, ga *testCommentActivities) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToCloseTimeout: 5 * time.Second,
+	})
+
+	prRevision := revision.Revision{
+		Repo:     github.Repo{Owner: "owner", Name: "repo"},
+		Revision: "rev-1",
+		Roots: []terraformActivities.Root{
+			{Name: "root-1"},
+		},
+	}
+	roots := map[string]revision.RootInfo{
+		"id-1": {Root: terraformActivities.Root{Name: "root-1"}},
+	}
+	failed := terraform.Response{
+		WorkflowState: state.Workflow{
+			ID: "id-1",
+			Result: state.WorkflowResult{
+				Status: state.CompleteWorkflowStatus,
+				Reason: state.ValidationFailedReason,
+			},
+			Validate: &state.Job{
+				Status: state.FailedJobStatus,
+				Output: &state.JobOutput{
+					URL:             &url.URL{Scheme: "https", Host: "atlantis", Path: "/jobs/validate"},
+					ValidateSummary: conftest.ValidateSummary{Failures: []string{"policy-1"}},
+				},
+			},
+		},
+	}
+
+	comment := &revision.SummaryComment{
+		GithubActivities: ga,
+		PRNumber:         1,
+	}
+
+	// nothing to refresh until the summary is created
+	comment.Refresh(ctx, prRevision, roots, []terraform.Response{failed})
+	comment.Update(ctx, prRevision, roots, []terraform.Response{failed})
+
+	bypassed := failed
+	bypassed.WorkflowState.Result.Reason = state.BypassedFailedValidationReason
+	comment.Refresh(ctx, prRevision, roots, []terraform.Response{bypassed})
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	tfModel "github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/notifier"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/pr/limiter"
 
 	metricNames "github.com/runatlantis/atlantis/server/metrics"
 	internalContext "github.com/runatlantis/atlantis/server/neptune/context"
@@ -27,6 +28,7 @@ const (
 	onShutdown
 	onCancel
 	onTimeout
+	onUnlock
 )
 
 type RunnerState int64
@@ -39,13 +41,21 @@ const (
 
 const (
 	ShutdownSignalID = "pr-close"
+	UnlockSignalID   = "pr-unlock"
 	TimeoutComment   = "Atlantis has deleted the state it managed for this PR due to inactivity, please rerun `atlantis plan` to rebuild the state."
+	UnlockComment    = "Atlantis has discarded the plans of this PR, please rerun `atlantis plan` to plan it again."
 )
 
 type NewShutdownRequest struct{}
 
+// NewUnlockRequest is signaled by `atlantis unlock` to cancel in progress plans and discard the plans of the PR
+type NewUnlockRequest struct {
+	User string
+}
+
 type RevisionProcessor interface {
 	Process(ctx workflow.Context, prRevision revision.Revision)
+	DiscardPlans()
 }
 
 type ShutdownChecker interface {
@@ -60,6 +70,7 @@ type Runner struct {
 	RevisionSignalChannel workflow.ReceiveChannel
 	RevisionReceiver      *revision.Receiver
 	ShutdownSignalChannel workflow.ReceiveChannel
+	UnlockSignalChannel   workflow.ReceiveChannel
 	RevisionProcessor     RevisionProcessor
 	ShutdownChecker       ShutdownChecker
 	Scope                 workflowMetrics.Scope
@@ -92,20 +103,30 @@ func newRunner(ctx workflow.Context, scope workflowMetrics.Scope, org string, tf
 		GithubActivities: ga,
 		PRNumber:         prNum,
 	}
+	summaryComment := &revision.SummaryComment{
+		GithubActivities: ga,
+		PRNumber:         prNum,
+	}
 	revisionProcessor := revision.Processor{
 		TFWorkflow:      tfWorkflow,
 		TFStateReceiver: &stateReceiver,
 		PolicyHandler: &policy.FailedPolicyHandler{
-			ReviewSignalChannel: workflow.GetSignalChannel(ctx, revision.ReviewSignalID),
-			GithubActivities:    ga,
-			PRNumber:            prNum,
-			Dismisser:           &dismisser,
-			PolicyFilter:        &policy.Filter{},
-			Org:                 org,
-			Scope:               scope,
-			Notifier:            &stateReceiver,
+			ReviewSignalChannel:          workflow.GetSignalChannel(ctx, revision.ReviewSignalID),
+			TeamMembershipSignalChannel:  workflow.GetSignalChannel(ctx, revision.TeamMembershipSignalID),
+			PolicyCheckSignalChannel:     workflow.GetSignalChannel(ctx, revision.PolicyCheckSignalID),
+			ApprovePoliciesSignalChannel: workflow.GetSignalChannel(ctx, revision.ApprovePoliciesSignalID),
+			GithubActivities:             ga,
+			PRNumber:                     prNum,
+			Dismisser:                    &dismisser,
+			PolicyFilter:                 &policy.Filter{},
+			Org:                          org,
+			Scope:                        scope,
+			Notifier:                     &stateReceiver,
+			SummaryCommenter:             summaryComment,
 		},
 		GithubCheckRunCache: checkRunCache,
+		SummaryCommenter:    summaryComment,
+		RepoLimiter:         limiter.NewClient(ctx),
 		Scope:               scope,
 	}
 	shutdownChecker := ShutdownStateChecker{
@@ -116,6 +137,7 @@ func newRunner(ctx workflow.Context, scope workflowMetrics.Scope, org string, tf
 		RevisionSignalChannel: workflow.GetSignalChannel(ctx, revision.TerraformRevisionSignalID),
 		RevisionReceiver:      &revisionReceiver,
 		ShutdownSignalChannel: workflow.GetSignalChannel(ctx, ShutdownSignalID),
+		UnlockSignalChannel:   workflow.GetSignalChannel(ctx, UnlockSignalID),
 		Scope:                 scope,
 		RevisionProcessor:     &revisionProcessor,
 		ShutdownChecker:       &shutdownChecker,
@@ -172,6 +194,19 @@ func (r *Runner) Run(ctx workflow.Context) error {
 			Counter(metricNames.SignalReceive).
 			Inc(1)
 	})
+	if r.UnlockSignalChannel != nil {
+		s.AddReceive(r.UnlockSignalChannel, func(c workflow.ReceiveChannel, more bool) {
+			action = onUnlock
+			if !more {
+				return
+			}
+			var request NewUnlockRequest
+			c.Receive(ctx, &request)
+			r.Scope.SubScopeWithTags(map[string]string{metricNames.SignalNameTag: UnlockSignalID}).
+				Counter(metricNames.SignalReceive).
+				Inc(1)
+		})
+	}
 
 	onShutdownPollTick := func(f workflow.Future) {
 		action = onShutdownPoll
@@ -192,6 +227,9 @@ func (r *Runner) Run(ctx workflow.Context) error {
 			continue
 		case onCancel:
 			continue
+		case onUnlock:
+			r.onUnlock(ctx, revisionCancel, prRevision)
+			continue
 		case onTimeout:
 			workflow.GetLogger(ctx).Info("workflow timed out, shutting down")
 			r.notifyOnTimeout(ctx, prRevision)
@@ -234,6 +272,17 @@ func (r *Runner) onNewRevision(ctx workflow.Context, cancel workflow.CancelFunc,
 	return cancel
 }
 
+// onUnlock cancels the in progress revision, if any, and discards the plans of the PR so that the next
+// plan re-plans every root, even of the same revision.  Anyone who can comment on the PR can unlock it, see
+// comment.DefaultCommands, so nothing is discarded that can't be recreated by planning again.
+func (r *Runner) onUnlock(ctx workflow.Context, cancel workflow.CancelFunc, prRevision revision.Revision) {
+	workflow.GetLogger(ctx).Info("received unlock signal")
+	cancel()
+	r.RevisionProcessor.DiscardPlans()
+	r.lastAttemptedRevision = ""
+	r.createComment(ctx, prRevision, UnlockComment)
+}
+
 func (r *Runner) shouldProcessRevision(prRevision revision.Revision) bool {
 	// ignore reruns when revision is still in progress
 	if r.lastAttemptedRevision == prRevision.Revision && r.state != waiting {
@@ -243,13 +292,17 @@ func (r *Runner) shouldProcessRevision(prRevision revision.Revision) bool {
 }
 
 func (r *Runner) notifyOnTimeout(ctx workflow.Context, prRevision revision.Revision) {
+	r.createComment(ctx, prRevision, TimeoutComment)
+}
+
+func (r *Runner) createComment(ctx workflow.Context, prRevision revision.Revision, body string) {
 	ctx = workflow.WithRetryPolicy(ctx, temporal.RetryPolicy{
 		MaximumAttempts: 3,
 	})
 	createCommentRequest := activities.CreateCommentRequest{
 		Repo:        prRevision.Repo,
 		PRNumber:    r.PRNumber,
-		CommentBody: TimeoutComment,
+		CommentBody: body,
 	}
 	err := workflow.ExecuteActivity(ctx, r.GithubActivities.GithubCreateComment, createCommentRequest).Get(ctx, nil)
 	if err != nil {
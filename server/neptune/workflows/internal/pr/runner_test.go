@@ -26,11 +26,13 @@ type request struct {
 
 type response struct {
 	ProcessCount int
+	DiscardCount int
 }
 
 const (
 	revisionID = "revision"
 	shutdownID = "shutdown"
+	unlockID   = "unlock"
 )
 
 type testActivities struct{}
@@ -52,6 +54,7 @@ func testWorkflow(ctx workflow.Context, r request) (response, error) {
 		RevisionSignalChannel: workflow.GetSignalChannel(ctx, revisionID),
 		RevisionReceiver:      &revisionReceiver,
 		ShutdownSignalChannel: workflow.GetSignalChannel(ctx, shutdownID),
+		UnlockSignalChannel:   workflow.GetSignalChannel(ctx, unlockID),
 		RevisionProcessor:     mockRevisionProcessor,
 		ShutdownChecker:       mockShutdownChecker,
 		InactivityTimeout:     r.InactivityTimeout,
@@ -63,6 +66,7 @@ func testWorkflow(ctx workflow.Context, r request) (response, error) {
 	err := runner.Run(ctx)
 	return response{
 		ProcessCount: mockRevisionProcessor.processCalls,
+		DiscardCount: mockRevisionProcessor.discardCalls,
 	}, err
 }
 
@@ -95,6 +99,48 @@ func TestWorkflowRunner_Run(t *testing.T) {
 	assert.Equal(t, 2, resp.ProcessCount)
 }
 
+func TestWorkflowRunner_Run_Unlock(t *testing.T) {
+	a := &testActivities{}
+	req := request{
+		mockRevisionProcessor: testRevisionProcessor{},
+		InactivityTimeout:     time.Minute,
+		ShutdownPollTime:      time.Hour,
+		NumShutdownPollTicks:  1,
+		GithubActivities:      a,
+	}
+	ts := testsuite.WorkflowTestSuite{}
+	env := ts.NewTestWorkflowEnvironment()
+	env.RegisterActivity(a)
+	env.OnActivity(a.GithubCreateComment, mock.Anything, activities.CreateCommentRequest{
+		PRNumber:    1,
+		CommentBody: UnlockComment,
+	}).Return(activities.CreateCommentResponse{}, nil)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(revisionID, revision.NewTerraformRevisionRequest{
+			Revision: "abc",
+		})
+	}, 2*time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(unlockID, NewUnlockRequest{User: "user"})
+	}, 4*time.Second)
+	// the same revision is processed again once its plans are discarded
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(revisionID, revision.NewTerraformRevisionRequest{
+			Revision: "abc",
+		})
+	}, 6*time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(shutdownID, NewShutdownRequest{})
+	}, 8*time.Second)
+	env.ExecuteWorkflow(testWorkflow, req)
+	var resp response
+	err := env.GetWorkflowResult(&resp)
+	assert.NoError(t, err)
+	env.AssertExpectations(t)
+	assert.Equal(t, 2, resp.ProcessCount)
+	assert.Equal(t, 1, resp.DiscardCount)
+}
+
 func TestWorkflowRunner_Run_InactivityTimeout(t *testing.T) {
 	a := &testActivities{}
 	req := request{
@@ -146,12 +192,17 @@ func TestWorkflowRunner_Run_ShutdownPoll(t *testing.T) {
 
 type testRevisionProcessor struct {
 	processCalls int
+	discardCalls int
 }
 
 func (t *testRevisionProcessor) Process(_ workflow.Context, _ revision.Revision) {
 	t.processCalls = t.processCalls + 1
 }
 
+func (t *testRevisionProcessor) DiscardPlans() {
+	t.discardCalls++
+}
+
 type testShutdownChecker struct {
 	ShouldShutdownAfterNTicks int
 	calls                     int
@@ -7,6 +7,7 @@ import (
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
 	workflowMetrics "github.com/runatlantis/atlantis/server/neptune/workflows/internal/metrics"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/pr/revision"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
 	"go.temporal.io/sdk/workflow"
 )
 
@@ -16,7 +17,7 @@ type prActivities struct {
 	*activities.Github
 }
 
-func Workflow(ctx workflow.Context, request Request, tfWorkflow revision.TFWorkflow) error {
+func Workflow(ctx workflow.Context, request Request, tfWorkflow revision.TFWorkflow, plugins plugins.PR) error {
 	options := workflow.ActivityOptions{
 		TaskQueue:           TaskQueue,
 		StartToCloseTimeout: 5 * time.Second,
@@ -26,6 +27,6 @@ func Workflow(ctx workflow.Context, request Request, tfWorkflow revision.TFWorkf
 		"repo":   request.RepoFullName,
 		"pr-num": strconv.Itoa(request.PRNum),
 	})
-	runner := newRunner(ctx, scope, request.Organization, tfWorkflow, request.PRNum)
+	runner := newRunner(ctx, scope, request.Organization, tfWorkflow, request.PRNum, plugins.Notifiers...)
 	return runner.Run(ctx)
 }
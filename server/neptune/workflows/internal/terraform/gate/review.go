@@ -1,6 +1,7 @@
 package gate
 
 import (
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -46,7 +47,8 @@ type ActionsClient interface {
 }
 
 // Await blocks until the plan is approved, rejected, or times out.
-// On approval it returns the approver username and the time of approval.
+// On approval it returns the approver username and the time of approval.  Approvals from
+// the requester of a plan which follows the two person rule are ignored.
 func (r *Review) Await(ctx workflow.Context, root terraform.Root, planSummary terraform.PlanSummary) (ReviewResult, error) {
 	if root.Plan.Approval.Type == terraform.AutoApproval || planSummary.IsEmpty() {
 		return ReviewResult{Status: Approved}, nil
@@ -82,10 +84,17 @@ func (r *Review) Await(ctx workflow.Context, root terraform.Root, planSummary te
 		return ReviewResult{Status: Rejected}, errors.Wrap(err, "updating approval actions")
 	}
 
-	selector.Select(ctx)
+	for {
+		selector.Select(ctx)
 
-	if timedOut {
-		return ReviewResult{Status: Rejected}, nil
+		if timedOut {
+			return ReviewResult{Status: Rejected}, nil
+		}
+
+		if !isSelfApproval(root.Plan.Approval, planReview) {
+			break
+		}
+		workflow.GetLogger(ctx).Warn("Ignoring plan approval from the requester, a second person must confirm.", "user", planReview.User)
 	}
 
 	return ReviewResult{
@@ -94,3 +103,7 @@ func (r *Review) Await(ctx workflow.Context, root terraform.Root, planSummary te
 		ApprovedTime: approvedAt,
 	}, nil
 }
+
+func isSelfApproval(approval terraform.PlanApproval, review PlanReviewSignalRequest) bool {
+	return approval.Requester != "" && review.Status == Approved && strings.EqualFold(approval.Requester, review.User)
+}
@@ -118,3 +118,42 @@ func TestAwait_autoApprove(t *testing.T) {
 	assert.Equal(t, gate.Approved, r.ReviewResult.Status)
 	assert.Empty(t, r.ReviewResult.ApprovedBy)
 }
+
+func TestAwait_ignoresRequesterApproval(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(gate.PlanReviewSignalName, gate.PlanReviewSignalRequest{
+			Status: gate.Approved,
+			User:   "requester",
+		})
+	}, time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(gate.PlanReviewSignalName, gate.PlanReviewSignalRequest{
+			Status: gate.Approved,
+			User:   "reviewer",
+		})
+	}, 2*time.Second)
+
+	env.ExecuteWorkflow(testReviewWorkflow, req{
+		PlanSummary: terraform.PlanSummary{
+			Updates: []terraform.ResourceSummary{
+				{
+					Address: "addr",
+				},
+			},
+		},
+		ApprovalOverride: terraform.PlanApproval{
+			Type:      terraform.ManualApproval,
+			Requester: "requester",
+		},
+	})
+
+	var r res
+	err := env.GetWorkflowResult(&r)
+	assert.NoError(t, err)
+
+	assert.Equal(t, gate.Approved, r.ReviewResult.Status)
+	assert.Equal(t, "reviewer", r.ReviewResult.ApprovedBy)
+}
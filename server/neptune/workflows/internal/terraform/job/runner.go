@@ -112,7 +112,7 @@ func (r *JobRunner) Validate(ctx workflow.Context, localRoot *terraform.LocalRoo
 		var err error
 		switch step.StepName {
 		case "policy_check":
-			validateResults, err = r.validate(jobCtx, showFile, step)
+			validateResults, err = r.validate(jobCtx, localRoot, showFile, step)
 		}
 
 		if err != nil {
@@ -157,7 +157,7 @@ func (r *JobRunner) Apply(ctx workflow.Context, localRoot *terraform.LocalRoot,
 	return nil
 }
 
-func (r *JobRunner) validate(executionCtx *ExecutionContext, showFile string, step execute.Step) ([]activities.ValidationResult, error) {
+func (r *JobRunner) validate(executionCtx *ExecutionContext, localRoot *terraform.LocalRoot, showFile string, step execute.Step) ([]activities.ValidationResult, error) {
 	args, err := command.NewArgumentList(step.ExtraArgs)
 	if err != nil {
 		return nil, errors.Wrapf(err, "creating argument list")
@@ -175,6 +175,7 @@ func (r *JobRunner) validate(executionCtx *ExecutionContext, showFile string, st
 		Path:        executionCtx.Path,
 		JobID:       executionCtx.JobID,
 		ShowFile:    showFile,
+		Target:      policyTarget(localRoot),
 	}).Get(executionCtx, &resp)
 	if err != nil {
 		return resp.ValidationResults, errors.Wrap(err, "running conftest activity")
@@ -303,3 +304,23 @@ func (r *JobRunner) closeTerraformJob(executionCtx *ExecutionContext) {
 		workflow.GetLogger(ctx).Error("Error closing job", key.ErrKey, err)
 	}
 }
+
+// policyTarget scopes policy sets on the root as configured on the default branch, roots which
+// aren't on it yet are only scoped on their repo.
+func policyTarget(localRoot *terraform.LocalRoot) activities.PolicyTarget {
+	target := localRoot.Root.PolicyTarget
+	if target == nil {
+		return activities.PolicyTarget{
+			Repo:      localRoot.Repo.GetFullName(),
+			Root:      localRoot.Root.Name,
+			Path:      localRoot.Root.Path,
+			Untrusted: true,
+		}
+	}
+	return activities.PolicyTarget{
+		Repo: localRoot.Repo.GetFullName(),
+		Root: target.Name,
+		Path: target.Path,
+		Tags: target.Tags,
+	}
+}
@@ -0,0 +1,44 @@
+package terraform
+
+import (
+	key "github.com/runatlantis/atlantis/server/neptune/context"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// PlanDiffVersion is the change id guarding diffing PR plans against the plan of the previous revision
+const PlanDiffVersion = "plan-diff"
+
+// diffPlan diffs the PR plan against the root's plan for the previous revision of the PR so that reviewers
+// only need to look at what changed.  Failing to do so shouldn't fail the PR.
+func (r *Runner) diffPlan(ctx workflow.Context, root *terraform.LocalRoot, planResponse activities.TerraformPlanResponse) {
+	if r.Request.PreviousRevision == "" {
+		return
+	}
+
+	// cap these retries since we don't want to block the PR in the event we fail to do so.
+	ctx = workflow.WithRetryPolicy(ctx, temporal.RetryPolicy{
+		MaximumAttempts: 3,
+	})
+
+	var response activities.DiffPlanResponse
+	err := workflow.ExecuteActivity(ctx, r.TerraformActivities.DiffPlan, activities.DiffPlanRequest{
+		LocalRoot:        root,
+		PreviousRevision: r.Request.PreviousRevision,
+		PlanJSONFile:     planResponse.PlanJSONFile,
+	}).Get(ctx, &response)
+	if err != nil {
+		workflow.GetLogger(ctx).Warn("unable to diff plan against previous revision", key.ErrKey, err)
+		return
+	}
+
+	if response.Diff == nil {
+		return
+	}
+
+	if err := r.Store.UpdatePlanDiff(*response.Diff); err != nil {
+		workflow.GetLogger(ctx).Warn("unable to update plan diff", key.ErrKey, err)
+	}
+}
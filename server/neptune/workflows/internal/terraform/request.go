@@ -14,6 +14,8 @@ type Request struct {
 	DeploymentID string
 	Revision     string
 	WorkflowMode terraform.WorkflowMode
+	// PreviousRevision is the last revision of the PR the root was planned for, if any
+	PreviousRevision string
 }
 
 const (
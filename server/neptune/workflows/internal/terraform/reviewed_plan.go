@@ -0,0 +1,137 @@
+package terraform
+
+import (
+	"fmt"
+	"strings"
+
+	key "github.com/runatlantis/atlantis/server/neptune/context"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/plan"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// ReviewedPlanVersion is the change id guarding the persistence of PR plans and their comparison at deploy time
+const ReviewedPlanVersion = "reviewed-plan"
+
+// persistPlan stores the PR plan so that the deploy of the same inputs can be compared against it.
+// Failing to do so shouldn't fail the PR, the deploy will just have nothing to compare against.
+func (r *Runner) persistPlan(ctx workflow.Context, root *terraform.LocalRoot, planResponse activities.TerraformPlanResponse) {
+	// cap these retries since we don't want to block the PR in the event we fail to do so.
+	ctx = workflow.WithRetryPolicy(ctx, temporal.RetryPolicy{
+		MaximumAttempts: 3,
+	})
+
+	var compareResponse activities.CompareCommitResponse
+	err := workflow.ExecuteActivity(ctx, r.GithubActivities.GithubCompareCommit, activities.CompareCommitRequest{
+		Repo:                   r.Request.Repo,
+		LatestDeployedRevision: r.Request.Repo.DefaultBranch,
+		DeployRequestRevision:  r.Request.Revision,
+	}).Get(ctx, &compareResponse)
+	if err != nil {
+		workflow.GetLogger(ctx).Warn("unable to determine base revision of plan", key.ErrKey, err)
+	}
+
+	err = workflow.ExecuteActivity(ctx, r.TerraformActivities.PersistPlan, activities.PersistPlanRequest{
+		LocalRoot:    root,
+		Revision:     r.Request.Revision,
+		BaseRevision: compareResponse.MergeBaseRevision,
+		PlanFile:     planResponse.PlanFile,
+		PlanJSONFile: planResponse.PlanJSONFile,
+		Summary:      planResponse.Summary,
+	}).Get(ctx, nil)
+	if err != nil {
+		workflow.GetLogger(ctx).Warn("unable to persist plan", key.ErrKey, err)
+	}
+}
+
+// requireReviewOfPlanDelta requires a manual approval of the deploy plan if its resource changes differ
+// from the plan reviewed in the PR, or if it wasn't planned from the inputs reviewed in the PR.
+// Roots without a reviewed plan are left as is.
+func (r *Runner) requireReviewOfPlanDelta(ctx workflow.Context, root *terraform.LocalRoot, planResponse activities.TerraformPlanResponse) {
+	// cap these retries since we don't want to block the deploy in the event we fail to do so.
+	ctx = workflow.WithRetryPolicy(ctx, temporal.RetryPolicy{
+		MaximumAttempts: 3,
+	})
+
+	// deploys of a merge commit were reviewed at the head of the merged PR, other deploys
+	// (ie. force applies) at their own revision
+	reviewedRevision := r.Request.Revision
+	var headResponse activities.GetMergedPullRequestHeadResponse
+	err := workflow.ExecuteActivity(ctx, r.GithubActivities.GithubGetMergedPullRequestHead, activities.GetMergedPullRequestHeadRequest{
+		Repo:     r.Request.Repo,
+		Revision: r.Request.Revision,
+	}).Get(ctx, &headResponse)
+	if err != nil {
+		workflow.GetLogger(ctx).Warn("unable to determine merged pull request", key.ErrKey, err)
+	}
+	if headResponse.HeadRevision != "" {
+		reviewedRevision = headResponse.HeadRevision
+	}
+
+	var response activities.FetchReviewedPlanResponse
+	err = workflow.ExecuteActivity(ctx, r.TerraformActivities.FetchReviewedPlan, activities.FetchReviewedPlanRequest{
+		LocalRoot: root,
+		// deploys are planned from the default branch so they're their own base
+		BaseRevision:     r.Request.Revision,
+		PlanFile:         planResponse.PlanFile,
+		ReviewedRevision: reviewedRevision,
+	}).Get(ctx, &response)
+	if err != nil {
+		workflow.GetLogger(ctx).Warn("unable to fetch reviewed plan", key.ErrKey, err)
+		return
+	}
+
+	if response.Plan == nil {
+		return
+	}
+
+	delta := planResponse.Summary.Diff(response.Plan.Summary)
+	if response.InputsMatch && delta.IsEmpty() {
+		return
+	}
+
+	approval := root.Root.Plan.Approval
+	approval.Type = terraform.ManualApproval
+	if approval.Reason != "" && !strings.HasSuffix(approval.Reason, "\n\n") {
+		approval.Reason += "\n\n"
+	}
+	approval.Reason += renderPlanDelta(response.Plan, response.Fingerprint, response.InputsMatch, delta)
+	root.Root = root.Root.WithPlanApprovalOverride(approval)
+}
+
+func renderPlanDelta(reviewed *plan.Info, fingerprint plan.Fingerprint, inputsMatch bool, delta terraform.PlanSummaryDelta) string {
+	var b strings.Builder
+	if !inputsMatch {
+		fmt.Fprintf(&b, "This plan wasn't generated from the inputs reviewed for revision %s and must be confirmed before proceeding.", reviewed.Revision)
+	} else {
+		fmt.Fprintf(&b, "This plan differs from the plan reviewed for revision %s and must be confirmed before proceeding.", reviewed.Revision)
+	}
+	// a plan of the same inputs can still differ if it was generated against a newer state
+	if reviewed.Fingerprint.StateSerial != fingerprint.StateSerial || reviewed.Fingerprint.BaseRevision != fingerprint.BaseRevision {
+		fmt.Fprintf(&b, "\n\nThe reviewed plan was generated against state serial %d on base %s, this plan against state serial %d on base %s.",
+			reviewed.Fingerprint.StateSerial, reviewed.Fingerprint.BaseRevision, fingerprint.StateSerial, fingerprint.BaseRevision)
+	}
+	if delta.IsEmpty() {
+		return b.String()
+	}
+	b.WriteString("\n\n")
+	b.WriteString("```diff\n")
+	writeResources(&b, "+", delta.Added)
+	writeResources(&b, "-", delta.Removed)
+	b.WriteString("```")
+	return b.String()
+}
+
+func writeResources(b *strings.Builder, prefix string, summary terraform.PlanSummary) {
+	for _, r := range summary.Creations {
+		fmt.Fprintf(b, "%s create %s\n", prefix, r.Address)
+	}
+	for _, r := range summary.Updates {
+		fmt.Fprintf(b, "%s update %s\n", prefix, r.Address)
+	}
+	for _, r := range summary.Deletions {
+		fmt.Fprintf(b, "%s delete %s\n", prefix, r.Address)
+	}
+}
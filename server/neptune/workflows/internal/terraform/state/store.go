@@ -163,6 +163,12 @@ func (s *WorkflowStore) UpdatePlanJobWithStatus(status JobStatus, options ...Upd
 	return s.notifier(s.state)
 }
 
+func (s *WorkflowStore) UpdatePlanDiff(diff terraform.PlanDiff) error {
+	s.state.Plan.Output.PlanDiff = &diff
+
+	return s.notifier(s.state)
+}
+
 func (s *WorkflowStore) UpdateApplyJobWithStatus(status JobStatus, options ...UpdateOptions) error {
 	switch status {
 	case InProgressJobStatus:
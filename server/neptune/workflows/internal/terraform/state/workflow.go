@@ -42,6 +42,9 @@ const (
 	SkippedCompletionReason
 	ValidationFailedReason
 	BypassedFailedValidationReason
+	// PartialPlanCompletionReason marks plans run with user supplied terraform args, they
+	// don't represent all of the root's changes so they never succeed
+	PartialPlanCompletionReason
 )
 
 type JobOutput struct {
@@ -49,6 +52,8 @@ type JobOutput struct {
 
 	// populated for plan jobs
 	PlanSummary terraform.PlanSummary
+	// populated for PR plan jobs with a plan for a previous revision
+	PlanDiff *terraform.PlanDiff
 
 	// populated for validate jobs
 	ValidateSummary conftest.ValidateSummary
@@ -94,6 +99,8 @@ func (j *Job) toExternalJob() *plugins.JobState {
 		EndTime:      j.EndTime,
 		ApprovedBy:   j.ApprovedBy,
 		ApprovedTime: j.ApprovedTime,
+
+		AwaitingApproval: j.Status == WaitingJobStatus && len(j.OnWaitingActions.Actions) > 0,
 	}
 }
 
@@ -26,11 +26,16 @@ import (
 
 type githubActivities interface {
 	GithubFetchRoot(ctx context.Context, request activities.FetchRootRequest) (activities.FetchRootResponse, error)
+	GithubCompareCommit(ctx context.Context, request activities.CompareCommitRequest) (activities.CompareCommitResponse, error)
+	GithubGetMergedPullRequestHead(ctx context.Context, request activities.GetMergedPullRequestHeadRequest) (activities.GetMergedPullRequestHeadResponse, error)
 }
 
 type terraformActivities interface {
 	Cleanup(ctx context.Context, request activities.CleanupRequest) (activities.CleanupResponse, error)
 	GetWorkerInfo(ctx context.Context) (*activities.GetWorkerInfoResponse, error)
+	PersistPlan(ctx context.Context, request activities.PersistPlanRequest) (activities.PersistPlanResponse, error)
+	FetchReviewedPlan(ctx context.Context, request activities.FetchReviewedPlanRequest) (activities.FetchReviewedPlanResponse, error)
+	DiffPlan(ctx context.Context, request activities.DiffPlanRequest) (activities.DiffPlanResponse, error)
 }
 
 // jobRunner runs a deploy plan/apply job
@@ -319,6 +324,10 @@ func (r *Runner) Run(ctx workflow.Context) (Response, error) {
 			reason = state.ValidationFailedReason
 		}
 
+		if reason == state.SuccessfulCompletionReason && r.Request.Root.PartialPlan {
+			reason = state.PartialPlanCompletionReason
+		}
+
 		updateErr := r.Store.UpdateCompletion(state.WorkflowResult{
 			Status: state.CompleteWorkflowStatus,
 			Reason: reason,
@@ -404,6 +413,15 @@ func (r *Runner) run(ctx workflow.Context) (Response, error) {
 	}
 
 	if r.Request.WorkflowMode == terraform.PR {
+		// partial plans are never reviewed so there's nothing to compare the deploy against
+		if workflow.GetVersion(ctx, ReviewedPlanVersion, workflow.DefaultVersion, workflow.Version(1)) != workflow.DefaultVersion && !r.Request.Root.PartialPlan {
+			r.persistPlan(ctx, root, planResponse)
+		}
+
+		if workflow.GetVersion(ctx, PlanDiffVersion, workflow.DefaultVersion, workflow.Version(1)) != workflow.DefaultVersion {
+			r.diffPlan(ctx, root, planResponse)
+		}
+
 		validationResults, err := r.Validate(ctx, root, response.ServerURL, planResponse.PlanJSONFile)
 		if err != nil {
 			return Response{}, r.toExternalError(err, "running validate job")
@@ -415,6 +433,10 @@ func (r *Runner) run(ctx workflow.Context) (Response, error) {
 		return resp, nil
 	}
 
+	if workflow.GetVersion(ctx, ReviewedPlanVersion, workflow.DefaultVersion, workflow.Version(1)) != workflow.DefaultVersion {
+		r.requireReviewOfPlanDelta(ctx, root, planResponse)
+	}
+
 	if err = r.Apply(ctx, root, response.ServerURL, planResponse); err != nil {
 		return Response{}, r.toExternalError(err, "running apply job")
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/execute"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/plan"
 	terraformModel "github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/terraform"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/terraform/gate"
@@ -93,8 +94,28 @@ func (a *githubActivities) GithubFetchRoot(_ context.Context, _ activities.Fetch
 	}, nil
 }
 
+func (a *githubActivities) GithubCompareCommit(_ context.Context, _ activities.CompareCommitRequest) (activities.CompareCommitResponse, error) {
+	return activities.CompareCommitResponse{}, nil
+}
+
+func (a *githubActivities) GithubGetMergedPullRequestHead(_ context.Context, _ activities.GetMergedPullRequestHeadRequest) (activities.GetMergedPullRequestHeadResponse, error) {
+	return activities.GetMergedPullRequestHeadResponse{}, nil
+}
+
 type terraformActivities struct{}
 
+func (a *terraformActivities) PersistPlan(_ context.Context, _ activities.PersistPlanRequest) (activities.PersistPlanResponse, error) {
+	return activities.PersistPlanResponse{}, nil
+}
+
+func (a *terraformActivities) FetchReviewedPlan(_ context.Context, _ activities.FetchReviewedPlanRequest) (activities.FetchReviewedPlanResponse, error) {
+	return activities.FetchReviewedPlanResponse{}, nil
+}
+
+func (a *terraformActivities) DiffPlan(_ context.Context, _ activities.DiffPlanRequest) (activities.DiffPlanResponse, error) {
+	return activities.DiffPlanResponse{}, nil
+}
+
 func (a *terraformActivities) Cleanup(ctx context.Context, request activities.CleanupRequest) (activities.CleanupResponse, error) {
 	return activities.CleanupResponse{}, nil
 }
@@ -136,6 +157,8 @@ type request struct {
 	ShouldErrorDuringJobUpdate bool
 	WorkflowMode               terraformModel.WorkflowMode
 	ValidateResults            []activities.ValidationResult
+	PreviousRevision           string
+	PartialPlan                bool
 }
 
 type response struct {
@@ -172,11 +195,13 @@ func testTerraformWorkflow(ctx workflow.Context, req request) (*response, error)
 	}, &testURLGenerator{}, req.WorkflowMode, "")
 
 	runnerReq := terraform.Request{
-		Root:         testLocalRoot.Root,
-		Repo:         testGithubRepo,
-		DeploymentID: testDeploymentID,
-		WorkflowMode: req.WorkflowMode,
+		Root:             testLocalRoot.Root,
+		Repo:             testGithubRepo,
+		DeploymentID:     testDeploymentID,
+		WorkflowMode:     req.WorkflowMode,
+		PreviousRevision: req.PreviousRevision,
 	}
+	runnerReq.Root.PartialPlan = req.PartialPlan
 
 	if req.WorkflowMode == terraformModel.Adhoc {
 		tAct = nil
@@ -238,6 +263,7 @@ func copy(s *state.Workflow) state.Workflow {
 			Output: &state.JobOutput{
 				URL:         s.Plan.Output.URL,
 				PlanSummary: s.Plan.Output.PlanSummary,
+				PlanDiff:    s.Plan.Output.PlanDiff,
 			},
 		}
 	}
@@ -1136,3 +1162,182 @@ func TestCleanupErrorReturnsNoError(t *testing.T) {
 	err := env.GetWorkflowResult(&resp)
 	assert.NoError(t, err)
 }
+
+func TestDeployMode_ReviewedPlanDelta(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	ga := &githubActivities{}
+	ta := &terraformActivities{}
+	env.RegisterActivity(ga)
+	env.RegisterActivity(ta)
+
+	env.OnActivity(ta.FetchReviewedPlan, mock.Anything, mock.Anything).Return(activities.FetchReviewedPlanResponse{
+		Plan: &plan.Info{
+			Revision: "abc",
+			Summary: terraformModel.PlanSummary{
+				Updates: []terraformModel.ResourceSummary{
+					{
+						Address: "reviewed",
+					},
+				},
+			},
+		},
+		InputsMatch: true,
+	}, nil)
+
+	// send approval of plan
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow("planreview", gate.PlanReviewSignalRequest{
+			Status: gate.Approved,
+		})
+	}, 5*time.Second)
+
+	env.ExecuteWorkflow(testTerraformWorkflow, request{})
+	assert.True(t, env.IsWorkflowCompleted())
+
+	var resp response
+	err := env.GetWorkflowResult(&resp)
+	assert.NoError(t, err)
+	env.AssertExpectations(t)
+
+	var summary string
+	for _, s := range resp.States {
+		if s.Apply != nil && s.Apply.OnWaitingActions.Summary != "" {
+			summary = s.Apply.OnWaitingActions.Summary
+			break
+		}
+	}
+	assert.Equal(t, approvalReason+"\n\nThis plan differs from the plan reviewed for revision abc and must be confirmed before proceeding.\n\n```diff\n+ update addr\n- update reviewed\n```", summary)
+}
+
+func TestDeployMode_ReviewedPlanInputsDiffer(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	ga := &githubActivities{}
+	ta := &terraformActivities{}
+	env.RegisterActivity(ga)
+	env.RegisterActivity(ta)
+
+	env.OnActivity(ga.GithubGetMergedPullRequestHead, mock.Anything, mock.Anything).Return(activities.GetMergedPullRequestHeadResponse{
+		HeadRevision: "head",
+	}, nil)
+	env.OnActivity(ta.FetchReviewedPlan, mock.Anything, mock.MatchedBy(func(request activities.FetchReviewedPlanRequest) bool {
+		return request.ReviewedRevision == "head"
+	})).Return(activities.FetchReviewedPlanResponse{
+		Plan: &plan.Info{
+			Revision: "head",
+			Fingerprint: plan.Fingerprint{
+				BaseRevision: "1234",
+				StateSerial:  3,
+			},
+			Summary: terraformModel.PlanSummary{
+				Updates: []terraformModel.ResourceSummary{
+					{
+						Address: "addr",
+					},
+				},
+			},
+		},
+		// the reviewed plan was made against an older state
+		Fingerprint: plan.Fingerprint{
+			BaseRevision: "deploy",
+			StateSerial:  5,
+		},
+	}, nil)
+
+	// send approval of plan
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow("planreview", gate.PlanReviewSignalRequest{
+			Status: gate.Approved,
+		})
+	}, 5*time.Second)
+
+	env.ExecuteWorkflow(testTerraformWorkflow, request{})
+	assert.True(t, env.IsWorkflowCompleted())
+
+	var resp response
+	err := env.GetWorkflowResult(&resp)
+	assert.NoError(t, err)
+	env.AssertExpectations(t)
+
+	var summary string
+	for _, s := range resp.States {
+		if s.Apply != nil && s.Apply.OnWaitingActions.Summary != "" {
+			summary = s.Apply.OnWaitingActions.Summary
+			break
+		}
+	}
+	assert.Equal(t, approvalReason+"\n\nThis plan wasn't generated from the inputs reviewed for revision head and must be confirmed before proceeding."+
+		"\n\nThe reviewed plan was generated against state serial 3 on base 1234, this plan against state serial 5 on base deploy.", summary)
+}
+
+func TestPRMode_PlanDiff(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	ga := &githubActivities{}
+	ta := &terraformActivities{}
+	env.RegisterActivity(ga)
+	env.RegisterActivity(ta)
+
+	diff := terraformModel.PlanDiff{
+		PreviousRevision: "abc",
+		Added:            []terraformModel.ResourceSummary{{Address: "added"}},
+	}
+	env.OnActivity(ta.DiffPlan, mock.Anything, mock.MatchedBy(func(request activities.DiffPlanRequest) bool {
+		return request.PreviousRevision == "abc"
+	})).Return(activities.DiffPlanResponse{
+		Diff: &diff,
+	}, nil)
+
+	env.ExecuteWorkflow(testTerraformWorkflow, request{
+		WorkflowMode:     terraformModel.PR,
+		PreviousRevision: "abc",
+	})
+	assert.True(t, env.IsWorkflowCompleted())
+
+	var resp response
+	err := env.GetWorkflowResult(&resp)
+	assert.NoError(t, err)
+	env.AssertExpectations(t)
+
+	var planDiff *terraformModel.PlanDiff
+	for _, s := range resp.States {
+		if s.Plan != nil && s.Plan.Output.PlanDiff != nil {
+			planDiff = s.Plan.Output.PlanDiff
+		}
+	}
+	assert.Equal(t, &diff, planDiff)
+}
+
+func TestPRMode_PartialPlan(t *testing.T) {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	ga := &githubActivities{}
+	ta := &terraformActivities{}
+	env.RegisterActivity(ga)
+	env.RegisterActivity(ta)
+
+	env.OnActivity(ta.PersistPlan, mock.Anything, mock.Anything).Return(nil).Never()
+
+	env.ExecuteWorkflow(testTerraformWorkflow, request{
+		WorkflowMode: terraformModel.PR,
+		PartialPlan:  true,
+		ValidateResults: []activities.ValidationResult{
+			{
+				Status:    activities.Success,
+				PolicySet: activities.PolicySet{Name: "policy"},
+			},
+		},
+	})
+	assert.True(t, env.IsWorkflowCompleted())
+
+	var resp response
+	err := env.GetWorkflowResult(&resp)
+	assert.NoError(t, err)
+	env.AssertExpectations(t)
+
+	// partial plans never succeed even if their policies pass
+	finalState := resp.States[len(resp.States)-1]
+	assert.Equal(t, state.CompleteWorkflowStatus, finalState.Result.Status)
+	assert.Equal(t, state.PartialPlanCompletionReason, finalState.Result.Reason)
+}
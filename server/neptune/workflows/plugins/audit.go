@@ -0,0 +1,50 @@
+package plugins
+
+import (
+	"context"
+	"time"
+)
+
+// AuditEventSchemaVersion is bumped whenever a breaking change is made to AuditEvent
+// so that downstream consumers can branch on it.
+const AuditEventSchemaVersion = 1
+
+type AuditEventType string
+
+const (
+	PlanAuditEvent        AuditEventType = "plan"
+	PolicyCheckAuditEvent AuditEventType = "policy_check"
+	ApprovalAuditEvent    AuditEventType = "approval"
+	RejectionAuditEvent   AuditEventType = "rejection"
+	ApplyAuditEvent       AuditEventType = "apply"
+	ForceApplyAuditEvent  AuditEventType = "force_apply"
+	UnlockAuditEvent      AuditEventType = "unlock"
+	AdminAPIAuditEvent    AuditEventType = "admin_api"
+)
+
+// AuditEvent is the versioned schema written to every configured AuditSink.
+type AuditEvent struct {
+	Version    int            `json:"version"`
+	ID         string         `json:"id"`
+	Type       AuditEventType `json:"type"`
+	Status     string         `json:"status"`
+	Timestamp  time.Time      `json:"timestamp"`
+	Repository string         `json:"repository,omitempty"`
+	RootName   string         `json:"root_name,omitempty"`
+	Revision   string         `json:"revision,omitempty"`
+
+	// User is the actor responsible for the event, ie. the initiating user of a deploy
+	// or the caller of an admin API.
+	User       string `json:"user,omitempty"`
+	ApprovedBy string `json:"approved_by,omitempty"`
+
+	Tags     map[string]string `json:"tags,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// AuditSink persists audit events to some external system.  Implementations are
+// expected to be safe for concurrent use.
+// Note: Sinks perform I/O and must only be called from activities or non-workflow code.
+type AuditSink interface {
+	Write(ctx context.Context, event AuditEvent) error
+}
@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
+)
+
+// Activities exposes the configured sink to workflows, register this on any worker
+// running workflows which use the Notifier.
+type Activities struct {
+	Sink plugins.AuditSink
+}
+
+type WriteEventRequest struct {
+	Event plugins.AuditEvent
+}
+
+func (a *Activities) AuditWriteEvent(ctx context.Context, request WriteEventRequest) error {
+	return errors.Wrap(a.Sink.Write(ctx, request.Event), "writing audit event")
+}
@@ -0,0 +1,40 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
+)
+
+// FileSink appends each event as a single JSON line to the file at Path.
+type FileSink struct {
+	Path string
+
+	mutex sync.Mutex
+}
+
+func (s *FileSink) Write(ctx context.Context, event plugins.AuditEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "marshaling audit event")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "opening %s", s.Path)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(payload, '\n')); err != nil {
+		return errors.Wrapf(err, "writing to %s", s.Path)
+	}
+
+	return nil
+}
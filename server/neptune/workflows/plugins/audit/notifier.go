@@ -0,0 +1,133 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
+	"go.temporal.io/sdk/workflow"
+)
+
+// NotifierVersion guards the activity calls made by the notifier so that it can
+// safely be added to already running workflows.
+const NotifierVersion = "audit-notifier"
+
+type activity interface {
+	AuditWriteEvent(ctx context.Context, request WriteEventRequest) error
+}
+
+// Notifier converts terraform workflow state changes into audit events for
+// plans, policy checks, plan approvals/rejections and applies.
+// Each event is emitted at most once per job status.
+type Notifier struct {
+	Activity activity
+
+	// mutable
+	emitted map[string]bool
+}
+
+func NewNotifier(a activity) *Notifier {
+	return &Notifier{
+		Activity: a,
+		emitted:  make(map[string]bool),
+	}
+}
+
+func (n *Notifier) Notify(ctx workflow.Context, deploymentInfo plugins.TerraformDeploymentInfo, workflowState *plugins.TerraformWorkflowState) error {
+	v := workflow.GetVersion(ctx, NotifierVersion, workflow.DefaultVersion, workflow.Version(1))
+	if v == workflow.DefaultVersion {
+		return nil
+	}
+
+	now := workflow.Now(ctx)
+	for _, event := range buildEvents(deploymentInfo, workflowState) {
+		key := fmt.Sprintf("%s/%s/%s", event.Type, event.ID, event.Status)
+		if n.emitted[key] {
+			continue
+		}
+
+		if event.Timestamp.IsZero() {
+			event.Timestamp = now
+		}
+
+		err := workflow.ExecuteActivity(ctx, n.Activity.AuditWriteEvent, WriteEventRequest{Event: event}).Get(ctx, nil)
+		if err != nil {
+			return errors.Wrapf(err, "writing %s audit event", event.Type)
+		}
+		n.emitted[key] = true
+	}
+
+	return nil
+}
+
+func buildEvents(info plugins.TerraformDeploymentInfo, state *plugins.TerraformWorkflowState) []plugins.AuditEvent {
+	var events []plugins.AuditEvent
+
+	if job := state.Plan; job != nil {
+		switch job.Status {
+		case plugins.InProgressJobStatus, plugins.SuccessJobStatus, plugins.FailedJobStatus:
+			events = append(events, newEvent(info, job, plugins.PlanAuditEvent, time.Time{}))
+		}
+	}
+
+	if job := state.Validate; job != nil {
+		switch job.Status {
+		case plugins.SuccessJobStatus, plugins.FailedJobStatus:
+			events = append(events, newEvent(info, job, plugins.PolicyCheckAuditEvent, job.EndTime))
+		}
+	}
+
+	if job := state.Apply; job != nil {
+		if job.Status == plugins.RejectedJobStatus {
+			events = append(events, newEvent(info, job, plugins.RejectionAuditEvent, time.Time{}))
+		}
+
+		if job.ApprovedBy != "" {
+			approval := newEvent(info, job, plugins.ApprovalAuditEvent, job.ApprovedTime)
+			approval.Status = "approved"
+			events = append(events, approval)
+		}
+
+		applyType := plugins.ApplyAuditEvent
+		if info.Root.TriggerInfo.Type == terraform.ManualTrigger && info.Root.TriggerInfo.Force {
+			applyType = plugins.ForceApplyAuditEvent
+		}
+
+		switch job.Status {
+		case plugins.InProgressJobStatus:
+			events = append(events, newEvent(info, job, applyType, job.StartTime))
+		case plugins.SuccessJobStatus, plugins.FailedJobStatus:
+			events = append(events, newEvent(info, job, applyType, job.EndTime))
+		}
+	}
+
+	return events
+}
+
+func newEvent(info plugins.TerraformDeploymentInfo, job *plugins.JobState, eventType plugins.AuditEventType, timestamp time.Time) plugins.AuditEvent {
+	event := plugins.AuditEvent{
+		Version:    plugins.AuditEventSchemaVersion,
+		ID:         job.ID,
+		Type:       eventType,
+		Status:     string(job.Status),
+		Timestamp:  timestamp,
+		Repository: info.Repo.GetFullName(),
+		RootName:   info.Root.Name,
+		Revision:   info.Commit.Revision,
+		User:       info.InitiatingUser.Username,
+		ApprovedBy: job.ApprovedBy,
+		Tags:       info.Tags,
+		Metadata: map[string]string{
+			"deployment_id": info.ID.String(),
+			"trigger":       string(info.Root.TriggerInfo.Type),
+		},
+	}
+
+	if info.Root.TwoPersonApproval {
+		event.Metadata["two_person_rule"] = "true"
+	}
+	return event
+}
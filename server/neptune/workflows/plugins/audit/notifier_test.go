@@ -0,0 +1,152 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins/audit"
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+type notifierRequest struct {
+	StatesToSend   []*plugins.TerraformWorkflowState
+	DeploymentInfo plugins.TerraformDeploymentInfo
+}
+
+func testNotifierWorkflow(ctx workflow.Context, r notifierRequest) error {
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToCloseTimeout: 5 * time.Second,
+	})
+
+	var a *audit.Activities
+	subject := audit.NewNotifier(a)
+
+	for _, s := range r.StatesToSend {
+		if err := subject.Notify(ctx, r.DeploymentInfo, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestNotifier_EmitsEachEventOnce(t *testing.T) {
+	approvedTime := time.Now()
+	info := plugins.TerraformDeploymentInfo{
+		ID: uuid.New(),
+		Root: terraform.Root{
+			Name: "root",
+			TriggerInfo: terraform.TriggerInfo{
+				Type:  terraform.ManualTrigger,
+				Force: true,
+			},
+		},
+		Repo:           github.Repo{Owner: "owner", Name: "repo"},
+		Commit:         github.Commit{Revision: "1234"},
+		InitiatingUser: github.User{Username: "nish"},
+	}
+
+	planSuccess := &plugins.JobState{ID: "plan", Status: plugins.SuccessJobStatus}
+	states := []*plugins.TerraformWorkflowState{
+		{
+			Plan: &plugins.JobState{ID: "plan", Status: plugins.InProgressJobStatus},
+		},
+		{
+			Plan: planSuccess,
+		},
+		{
+			Plan:  planSuccess,
+			Apply: &plugins.JobState{ID: "apply", Status: plugins.WaitingJobStatus},
+		},
+		{
+			Plan: planSuccess,
+			Apply: &plugins.JobState{
+				ID:           "apply",
+				Status:       plugins.InProgressJobStatus,
+				ApprovedBy:   "approver",
+				ApprovedTime: approvedTime,
+			},
+		},
+		{
+			Plan: planSuccess,
+			Apply: &plugins.JobState{
+				ID:           "apply",
+				Status:       plugins.SuccessJobStatus,
+				ApprovedBy:   "approver",
+				ApprovedTime: approvedTime,
+			},
+		},
+	}
+
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	sink := &testSink{}
+	env.RegisterActivity(&audit.Activities{Sink: sink})
+	env.ExecuteWorkflow(testNotifierWorkflow, notifierRequest{
+		StatesToSend:   states,
+		DeploymentInfo: info,
+	})
+	assert.NoError(t, env.GetWorkflowError())
+
+	var types []plugins.AuditEventType
+	for _, e := range sink.events {
+		types = append(types, e.Type)
+		assert.Equal(t, plugins.AuditEventSchemaVersion, e.Version)
+		assert.Equal(t, "owner/repo", e.Repository)
+		assert.Equal(t, "root", e.RootName)
+		assert.Equal(t, "nish", e.User)
+	}
+
+	assert.Equal(t, []plugins.AuditEventType{
+		plugins.PlanAuditEvent,
+		plugins.PlanAuditEvent,
+		plugins.ApprovalAuditEvent,
+		plugins.ForceApplyAuditEvent,
+		plugins.ForceApplyAuditEvent,
+	}, types)
+	assert.Equal(t, "approver", sink.events[2].ApprovedBy)
+}
+
+func TestNotifier_PolicyCheckAndRejection(t *testing.T) {
+	info := plugins.TerraformDeploymentInfo{
+		ID:   uuid.New(),
+		Root: terraform.Root{Name: "root"},
+		Repo: github.Repo{Owner: "owner", Name: "repo"},
+	}
+
+	states := []*plugins.TerraformWorkflowState{
+		{
+			Validate: &plugins.JobState{ID: "validate", Status: plugins.FailedJobStatus},
+		},
+		{
+			Apply: &plugins.JobState{ID: "apply", Status: plugins.RejectedJobStatus},
+		},
+	}
+
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	sink := &testSink{}
+	env.RegisterActivity(&audit.Activities{Sink: sink})
+	env.ExecuteWorkflow(testNotifierWorkflow, notifierRequest{
+		StatesToSend:   states,
+		DeploymentInfo: info,
+	})
+	assert.NoError(t, env.GetWorkflowError())
+
+	assert.Len(t, sink.events, 2)
+	assert.Equal(t, plugins.PolicyCheckAuditEvent, sink.events[0].Type)
+	assert.Equal(t, string(plugins.FailedJobStatus), sink.events[0].Status)
+	assert.Equal(t, plugins.RejectionAuditEvent, sink.events[1].Type)
+}
+
+func TestActivities_WrapsSinkError(t *testing.T) {
+	subject := &audit.Activities{Sink: &testSink{err: assert.AnError}}
+	assert.Error(t, subject.AuditWriteEvent(context.Background(), audit.WriteEventRequest{}))
+}
@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	awsSns "github.com/aws/aws-sdk-go/service/sns"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
+)
+
+// WebhookSinkTimeout bounds each webhook write since events are written from gateway request
+// paths and a slow endpoint shouldn't hold them up.
+const WebhookSinkTimeout = 10 * time.Second
+
+// NewSink builds a single sink which fans out to every sink configured in the global config.
+// An empty config results in a sink which drops all events.
+func NewSink(cfg valid.Audit) (plugins.AuditSink, error) {
+	var sinks MultiSink
+	for _, s := range cfg.Sinks {
+		switch s.Type {
+		case valid.FileAuditSink:
+			sinks = append(sinks, &FileSink{Path: s.Path})
+		case valid.WebhookAuditSink:
+			sinks = append(sinks, &WebhookSink{
+				URL:    s.URL,
+				Secret: []byte(s.Secret),
+				Client: &http.Client{Timeout: WebhookSinkTimeout},
+			})
+		case valid.SNSAuditSink:
+			session, err := session.NewSession()
+			if err != nil {
+				return nil, errors.Wrap(err, "initializing new aws session")
+			}
+			sinks = append(sinks, &SNSSink{
+				Client:   awsSns.New(session),
+				TopicArn: s.TopicArn,
+			})
+		default:
+			return nil, fmt.Errorf("unsupported audit sink type %s", s.Type)
+		}
+	}
+
+	return sinks, nil
+}
+
+// MultiSink writes each event to all of its sinks, an error in one sink
+// does not prevent writes to the others.
+type MultiSink []plugins.AuditSink
+
+func (m MultiSink) Write(ctx context.Context, event plugins.AuditEvent) error {
+	var combinedErrors *multierror.Error
+	for _, s := range m {
+		if err := s.Write(ctx, event); err != nil {
+			combinedErrors = multierror.Append(combinedErrors, err)
+		}
+	}
+	return combinedErrors.ErrorOrNil()
+}
@@ -0,0 +1,117 @@
+package audit_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSink_AppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	subject := &audit.FileSink{Path: path}
+
+	events := []plugins.AuditEvent{
+		{Version: plugins.AuditEventSchemaVersion, ID: "1", Type: plugins.PlanAuditEvent},
+		{Version: plugins.AuditEventSchemaVersion, ID: "2", Type: plugins.UnlockAuditEvent},
+	}
+	for _, e := range events {
+		assert.NoError(t, subject.Write(context.Background(), e))
+	}
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	var result []plugins.AuditEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e plugins.AuditEvent
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		result = append(result, e)
+	}
+	assert.Equal(t, events, result)
+}
+
+func TestWebhookSink_SignsPayload(t *testing.T) {
+	secret := []byte("shh")
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, audit.Sign(secret, body), r.Header.Get(audit.SignatureHeader))
+		assert.Equal(t, string(plugins.AdminAPIAuditEvent), r.Header.Get(audit.EventTypeHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	subject := &audit.WebhookSink{
+		URL:    server.URL,
+		Secret: secret,
+		Client: server.Client(),
+	}
+
+	err := subject.Write(context.Background(), plugins.AuditEvent{Type: plugins.AdminAPIAuditEvent})
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestWebhookSink_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	subject := &audit.WebhookSink{
+		URL:    server.URL,
+		Secret: []byte("shh"),
+		Client: server.Client(),
+	}
+
+	assert.Error(t, subject.Write(context.Background(), plugins.AuditEvent{}))
+}
+
+type testSink struct {
+	events []plugins.AuditEvent
+	err    error
+}
+
+func (s *testSink) Write(_ context.Context, event plugins.AuditEvent) error {
+	s.events = append(s.events, event)
+	return s.err
+}
+
+func TestMultiSink_WritesToAllSinks(t *testing.T) {
+	failing := &testSink{err: assert.AnError}
+	succeeding := &testSink{}
+
+	subject := audit.MultiSink{failing, succeeding}
+	err := subject.Write(context.Background(), plugins.AuditEvent{ID: "1"})
+
+	assert.Error(t, err)
+	assert.Len(t, failing.events, 1)
+	assert.Len(t, succeeding.events, 1)
+}
+
+func TestNewSink_WebhookTimeout(t *testing.T) {
+	sink, err := audit.NewSink(valid.Audit{
+		Sinks: []valid.AuditSink{
+			{Type: valid.WebhookAuditSink, URL: "http://localhost", Secret: "shh"},
+		},
+	})
+	assert.NoError(t, err)
+
+	webhook := sink.(audit.MultiSink)[0].(*audit.WebhookSink)
+	assert.Equal(t, &http.Client{Timeout: audit.WebhookSinkTimeout}, webhook.Client)
+}
@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	awsSns "github.com/aws/aws-sdk-go/service/sns"
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
+)
+
+type snsPublisher interface {
+	PublishWithContext(ctx aws.Context, input *awsSns.PublishInput, opts ...request.Option) (*awsSns.PublishOutput, error)
+}
+
+// SNSSink publishes each event as a JSON message to TopicArn.
+type SNSSink struct {
+	Client   snsPublisher
+	TopicArn string
+}
+
+func (s *SNSSink) Write(ctx context.Context, event plugins.AuditEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "marshaling audit event")
+	}
+
+	if _, err := s.Client.PublishWithContext(ctx, &awsSns.PublishInput{
+		Message:  aws.String(string(payload)),
+		TopicArn: aws.String(s.TopicArn),
+		MessageAttributes: map[string]*awsSns.MessageAttributeValue{
+			"type": {
+				DataType:    aws.String("String"),
+				StringValue: aws.String(string(event.Type)),
+			},
+		},
+	}); err != nil {
+		return errors.Wrap(err, "publishing audit event")
+	}
+
+	return nil
+}
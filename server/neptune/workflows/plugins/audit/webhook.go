@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
+)
+
+const (
+	SignatureHeader = "X-Atlantis-Signature-256"
+	EventTypeHeader = "X-Atlantis-Audit-Event"
+)
+
+type httpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// WebhookSink POSTs each event as JSON to URL.  The body is signed with HMAC-SHA256
+// using Secret and the hex digest is sent in the SignatureHeader in the same format
+// GitHub uses for webhooks (ie. sha256=<digest>).
+type WebhookSink struct {
+	URL    string
+	Secret []byte
+	Client httpClient
+}
+
+func (s *WebhookSink) Write(ctx context.Context, event plugins.AuditEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "marshaling audit event")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "building request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventTypeHeader, string(event.Type))
+	req.Header.Set(SignatureHeader, Sign(s.Secret, payload))
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "posting audit event")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("posting audit event returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the signature header value for the given payload.
+func Sign(secret []byte, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -8,6 +8,12 @@ type TerraformWorkflowNotifier interface {
 	Notify(workflow.Context, TerraformDeploymentInfo, *TerraformWorkflowState) error
 }
 
+// TerraformDeploymentQueuedNotifier can be implemented by notifiers which also need to know when a
+// deployment is queued, since the WorkflowState only changes once the deployment is dequeued.
+type TerraformDeploymentQueuedNotifier interface {
+	NotifyQueued(workflow.Context, TerraformDeploymentInfo) error
+}
+
 // PostDeployExecutor can be used to enable specific actions to occur after a single deploy has been executed
 type PostDeployExecutor interface {
 	Execute(workflow.Context, TerraformDeploymentInfo) error
@@ -0,0 +1,8 @@
+package plugins
+
+// Customizable plugins for the PR workflow
+type PR struct {
+
+	// A set of notifiers that are called for TerraformWorkflowState changes
+	Notifiers []TerraformWorkflowNotifier
+}
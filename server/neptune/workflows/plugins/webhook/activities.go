@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/metrics"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins/audit"
+	"github.com/uber-go/tally/v4"
+)
+
+const (
+	EventTypeHeader  = "X-Atlantis-Deploy-Event"
+	DeliveryIDHeader = "X-Atlantis-Delivery"
+)
+
+type httpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Activities resolves and delivers deploy webhooks, register this on any worker
+// running workflows which use the Notifier.
+type Activities struct {
+	GlobalCfg      valid.GlobalCfg
+	GithubHostname string
+	Client         httpClient
+	Scope          tally.Scope
+}
+
+type ListTargetsRequest struct {
+	RepoFullName string
+	RootName     string
+	Event        valid.DeployWebhookEvent
+}
+
+type ListTargetsResponse struct {
+	// URLs is intentionally the only thing returned here so that webhook secrets
+	// never end up in workflow history.
+	URLs []string
+}
+
+// WebhookListTargets returns the urls of all webhooks configured for the given repo/root/event.
+func (a *Activities) WebhookListTargets(ctx context.Context, request ListTargetsRequest) (ListTargetsResponse, error) {
+	var urls []string
+	for _, w := range a.webhooks(request.RepoFullName) {
+		if w.Matches(request.RootName, request.Event) {
+			urls = append(urls, w.URL)
+		}
+	}
+	return ListTargetsResponse{URLs: urls}, nil
+}
+
+type DeliverRequest struct {
+	RepoFullName string
+	URL          string
+	DeliveryID   string
+	Payload      Payload
+}
+
+// WebhookDeliver POSTs the payload to a single webhook. The body is signed with HMAC-SHA256
+// using the configured secret in the same format as audit webhooks.
+func (a *Activities) WebhookDeliver(ctx context.Context, request DeliverRequest) error {
+	scope := a.Scope.SubScope("deploy_webhook").Tagged(map[string]string{
+		"event": string(request.Payload.Event),
+	})
+
+	start := time.Now()
+	err := a.deliver(ctx, request)
+	scope.Timer(metrics.ExecutionTimeMetric).Record(time.Since(start))
+
+	if err != nil {
+		scope.Counter(metrics.ExecutionErrorMetric).Inc(1)
+		return err
+	}
+	scope.Counter(metrics.ExecutionSuccessMetric).Inc(1)
+	return nil
+}
+
+func (a *Activities) deliver(ctx context.Context, request DeliverRequest) error {
+	webhook, ok := a.findWebhook(request.RepoFullName, request.URL)
+	if !ok {
+		// config has changed since the targets were listed, there is nothing left to deliver to.
+		return nil
+	}
+
+	payload, err := json.Marshal(request.Payload)
+	if err != nil {
+		return errors.Wrap(err, "marshaling payload")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "building request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventTypeHeader, string(request.Payload.Event))
+	req.Header.Set(DeliveryIDHeader, request.DeliveryID)
+	req.Header.Set(audit.SignatureHeader, audit.Sign([]byte(webhook.Secret), payload))
+
+	resp, err := a.Client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "posting deploy webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("posting deploy webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (a *Activities) findWebhook(repoFullName string, url string) (valid.DeployWebhook, bool) {
+	for _, w := range a.webhooks(repoFullName) {
+		if w.URL == url {
+			return w, true
+		}
+	}
+	return valid.DeployWebhook{}, false
+}
+
+func (a *Activities) webhooks(repoFullName string) []valid.DeployWebhook {
+	repo := a.GlobalCfg.MatchingRepo(fmt.Sprintf("%s/%s", a.GithubHostname, repoFullName))
+	if repo == nil {
+		return nil
+	}
+	return repo.DeployWebhooks
+}
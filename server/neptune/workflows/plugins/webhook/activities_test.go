@@ -0,0 +1,89 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins/audit"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally/v4"
+)
+
+func TestActivities_DeliverSignsPayload(t *testing.T) {
+	var body []byte
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		headers = r.Header
+	}))
+	defer server.Close()
+
+	scope := tally.NewTestScope("", map[string]string{})
+	subject := &webhook.Activities{
+		GlobalCfg:      buildGlobalCfg(valid.DeployWebhook{URL: server.URL, Secret: "shh"}),
+		GithubHostname: "github.com",
+		Client:         http.DefaultClient,
+		Scope:          scope,
+	}
+
+	err := subject.WebhookDeliver(context.Background(), webhook.DeliverRequest{
+		RepoFullName: "owner/repo",
+		URL:          server.URL,
+		DeliveryID:   "delivery",
+		Payload:      webhook.Payload{Event: valid.AppliedDeployWebhookEvent},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, audit.Sign([]byte("shh"), body), headers.Get(audit.SignatureHeader))
+	assert.Equal(t, "applied", headers.Get(webhook.EventTypeHeader))
+	assert.Equal(t, "delivery", headers.Get(webhook.DeliveryIDHeader))
+	assert.Equal(t, int64(1), scope.Snapshot().Counters()["deploy_webhook.execution_success+event=applied"].Value())
+}
+
+func TestActivities_DeliverErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	scope := tally.NewTestScope("", map[string]string{})
+	subject := &webhook.Activities{
+		GlobalCfg:      buildGlobalCfg(valid.DeployWebhook{URL: server.URL, Secret: "shh"}),
+		GithubHostname: "github.com",
+		Client:         http.DefaultClient,
+		Scope:          scope,
+	}
+
+	err := subject.WebhookDeliver(context.Background(), webhook.DeliverRequest{
+		RepoFullName: "owner/repo",
+		URL:          server.URL,
+		Payload:      webhook.Payload{Event: valid.FailedDeployWebhookEvent},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, int64(1), scope.Snapshot().Counters()["deploy_webhook.execution_error+event=failed"].Value())
+}
+
+func TestActivities_ListTargets(t *testing.T) {
+	subject := &webhook.Activities{
+		GlobalCfg: buildGlobalCfg(
+			valid.DeployWebhook{URL: "https://all.example.com"},
+			valid.DeployWebhook{URL: "https://root.example.com", Roots: []string{"root"}},
+			valid.DeployWebhook{URL: "https://other.example.com", Roots: []string{"other"}},
+			valid.DeployWebhook{URL: "https://failed.example.com", Events: []valid.DeployWebhookEvent{valid.FailedDeployWebhookEvent}},
+		),
+		GithubHostname: "github.com",
+	}
+
+	resp, err := subject.WebhookListTargets(context.Background(), webhook.ListTargetsRequest{
+		RepoFullName: "owner/repo",
+		RootName:     "root",
+		Event:        valid.PlannedDeployWebhookEvent,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://all.example.com", "https://root.example.com"}, resp.URLs)
+}
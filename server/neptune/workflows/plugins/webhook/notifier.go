@@ -0,0 +1,167 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// NotifierVersion guards the activity calls made by the notifier so that it can
+// safely be added to already running workflows.
+const NotifierVersion = "deploy-webhook-notifier"
+
+type activity interface {
+	WebhookListTargets(ctx context.Context, request ListTargetsRequest) (ListTargetsResponse, error)
+	WebhookDeliver(ctx context.Context, request DeliverRequest) error
+}
+
+// Notifier POSTs deploy lifecycle events to the webhooks configured for the
+// deployed repo/root. Each event is delivered at most once per job and failed
+// deliveries are only logged.
+type Notifier struct {
+	Activity activity
+
+	// mutable
+	emitted map[string]bool
+}
+
+func NewNotifier(a activity) *Notifier {
+	return &Notifier{
+		Activity: a,
+		emitted:  make(map[string]bool),
+	}
+}
+
+func (n *Notifier) Notify(ctx workflow.Context, deploymentInfo plugins.TerraformDeploymentInfo, workflowState *plugins.TerraformWorkflowState) error {
+	v := workflow.GetVersion(ctx, NotifierVersion, workflow.DefaultVersion, workflow.Version(1))
+	if v == workflow.DefaultVersion {
+		return nil
+	}
+
+	for _, payload := range buildPayloads(deploymentInfo, workflowState) {
+		n.send(ctx, payload)
+	}
+	return nil
+}
+
+// NotifyQueued is called once the deployment is pushed onto the deploy queue
+func (n *Notifier) NotifyQueued(ctx workflow.Context, deploymentInfo plugins.TerraformDeploymentInfo) error {
+	v := workflow.GetVersion(ctx, NotifierVersion, workflow.DefaultVersion, workflow.Version(1))
+	if v == workflow.DefaultVersion {
+		return nil
+	}
+
+	n.send(ctx, newPayload(deploymentInfo, &plugins.JobState{}, valid.QueuedDeployWebhookEvent))
+	return nil
+}
+
+// send delivers the payload in the background since webhook endpoints are outside of our control
+// and we don't want retrying them to hold up the deploy or the notifiers which run after us.
+func (n *Notifier) send(ctx workflow.Context, payload Payload) {
+	id := deliveryID(payload)
+	if n.emitted[id] {
+		return
+	}
+	n.emitted[id] = true
+	payload.Timestamp = workflow.Now(ctx)
+
+	// allow longer timeouts than the default and retry a bounded number of times before giving up.
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    5 * time.Second,
+			BackoffCoefficient: 2,
+			MaximumAttempts:    3,
+		},
+	})
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		if err := n.deliver(ctx, payload); err != nil {
+			workflow.GetMetricsHandler(ctx).Counter("notifier_plugin_failure").Inc(1)
+			workflow.GetLogger(ctx).Error(errors.Wrapf(err, "delivering %s webhook", payload.Event).Error())
+		}
+	})
+}
+
+func (n *Notifier) deliver(ctx workflow.Context, payload Payload) error {
+	var targets ListTargetsResponse
+	err := workflow.ExecuteActivity(ctx, n.Activity.WebhookListTargets, ListTargetsRequest{
+		RepoFullName: payload.Repository,
+		RootName:     payload.RootName,
+		Event:        payload.Event,
+	}).Get(ctx, &targets)
+	if err != nil {
+		return errors.Wrap(err, "listing webhook targets")
+	}
+
+	var futures []workflow.Future
+	for _, url := range targets.URLs {
+		futures = append(futures, workflow.ExecuteActivity(ctx, n.Activity.WebhookDeliver, DeliverRequest{
+			RepoFullName: payload.Repository,
+			URL:          url,
+			DeliveryID:   deliveryID(payload),
+			Payload:      payload,
+		}))
+	}
+
+	var result *multierror.Error
+	for i, f := range futures {
+		if err := f.Get(ctx, nil); err != nil {
+			result = multierror.Append(result, errors.Wrapf(err, "posting to %s", targets.URLs[i]))
+		}
+	}
+	return result.ErrorOrNil()
+}
+
+// deliveryID identifies the event of a job, each event is delivered at most once
+func deliveryID(payload Payload) string {
+	return fmt.Sprintf("%s/%s/%s", payload.DeploymentID, payload.JobID, payload.Event)
+}
+
+func buildPayloads(info plugins.TerraformDeploymentInfo, state *plugins.TerraformWorkflowState) []Payload {
+	var payloads []Payload
+
+	if job := state.Plan; job != nil {
+		switch job.Status {
+		case plugins.SuccessJobStatus:
+			payloads = append(payloads, newPayload(info, job, valid.PlannedDeployWebhookEvent))
+		case plugins.FailedJobStatus:
+			payloads = append(payloads, newPayload(info, job, valid.FailedDeployWebhookEvent))
+		}
+	}
+
+	if job := state.Apply; job != nil {
+		switch {
+		case job.AwaitingApproval:
+			payloads = append(payloads, newPayload(info, job, valid.AwaitingApprovalDeployWebhookEvent))
+		case job.Status == plugins.SuccessJobStatus:
+			payloads = append(payloads, newPayload(info, job, valid.AppliedDeployWebhookEvent))
+		case job.Status == plugins.FailedJobStatus:
+			payloads = append(payloads, newPayload(info, job, valid.FailedDeployWebhookEvent))
+		}
+	}
+
+	return payloads
+}
+
+func newPayload(info plugins.TerraformDeploymentInfo, job *plugins.JobState, event valid.DeployWebhookEvent) Payload {
+	return Payload{
+		Version:      PayloadSchemaVersion,
+		Event:        event,
+		DeploymentID: info.ID.String(),
+		Repository:   info.Repo.GetFullName(),
+		RootName:     info.Root.Name,
+		Revision:     info.Commit.Revision,
+		User:         info.InitiatingUser.Username,
+		JobID:        job.ID,
+		JobStatus:    string(job.Status),
+		ApprovedBy:   job.ApprovedBy,
+		Tags:         info.Tags,
+	}
+}
@@ -0,0 +1,184 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/runatlantis/atlantis/server/config/valid"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/github"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities/terraform"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally/v4"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+type notifierRequest struct {
+	StatesToSend   []*plugins.TerraformWorkflowState
+	DeploymentInfo plugins.TerraformDeploymentInfo
+}
+
+func testNotifierWorkflow(ctx workflow.Context, r notifierRequest) error {
+	var a *webhook.Activities
+	subject := webhook.NewNotifier(a)
+
+	if err := subject.NotifyQueued(ctx, r.DeploymentInfo); err != nil {
+		return err
+	}
+	for _, s := range r.StatesToSend {
+		if err := subject.Notify(ctx, r.DeploymentInfo, s); err != nil {
+			return err
+		}
+	}
+
+	// deliveries happen in the background so give them a chance to finish
+	return workflow.Sleep(ctx, time.Minute)
+}
+
+type testServer struct {
+	mutex    sync.Mutex
+	payloads []webhook.Payload
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var p webhook.Payload
+	_ = json.NewDecoder(r.Body).Decode(&p)
+	s.payloads = append(s.payloads, p)
+}
+
+func buildGlobalCfg(webhooks ...valid.DeployWebhook) valid.GlobalCfg {
+	return valid.GlobalCfg{
+		Repos: []valid.Repo{
+			{
+				ID:             "github.com/owner/repo",
+				DeployWebhooks: webhooks,
+			},
+		},
+	}
+}
+
+func TestNotifier_DeliversLifecycleEvents(t *testing.T) {
+	handler := &testServer{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	filtered := &testServer{}
+	filteredServer := httptest.NewServer(filtered)
+	defer filteredServer.Close()
+
+	info := plugins.TerraformDeploymentInfo{
+		ID:             uuid.New(),
+		Root:           terraform.Root{Name: "root"},
+		Repo:           github.Repo{Owner: "owner", Name: "repo"},
+		Commit:         github.Commit{Revision: "1234"},
+		InitiatingUser: github.User{Username: "nish"},
+	}
+
+	planSuccess := &plugins.JobState{ID: "plan", Status: plugins.SuccessJobStatus}
+	states := []*plugins.TerraformWorkflowState{
+		{
+			Plan: &plugins.JobState{ID: "plan", Status: plugins.WaitingJobStatus},
+		},
+		{
+			Plan: &plugins.JobState{ID: "plan", Status: plugins.InProgressJobStatus},
+		},
+		{
+			Plan: planSuccess,
+		},
+		{
+			Plan:  planSuccess,
+			Apply: &plugins.JobState{ID: "apply", Status: plugins.WaitingJobStatus, AwaitingApproval: true},
+		},
+		{
+			Plan:  planSuccess,
+			Apply: &plugins.JobState{ID: "apply", Status: plugins.InProgressJobStatus},
+		},
+		{
+			Plan:  planSuccess,
+			Apply: &plugins.JobState{ID: "apply", Status: plugins.SuccessJobStatus},
+		},
+	}
+
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterActivity(&webhook.Activities{
+		GlobalCfg: buildGlobalCfg(
+			valid.DeployWebhook{URL: server.URL, Secret: "shh"},
+			valid.DeployWebhook{
+				URL:    filteredServer.URL,
+				Secret: "shh",
+				Events: []valid.DeployWebhookEvent{valid.AppliedDeployWebhookEvent},
+			},
+			valid.DeployWebhook{
+				URL:    filteredServer.URL + "/other",
+				Secret: "shh",
+				Roots:  []string{"other-root"},
+			},
+		),
+		GithubHostname: "github.com",
+		Client:         http.DefaultClient,
+		Scope:          tally.NoopScope,
+	})
+	env.ExecuteWorkflow(testNotifierWorkflow, notifierRequest{
+		StatesToSend:   states,
+		DeploymentInfo: info,
+	})
+	assert.NoError(t, env.GetWorkflowError())
+
+	var events []valid.DeployWebhookEvent
+	for _, p := range handler.payloads {
+		events = append(events, p.Event)
+		assert.Equal(t, webhook.PayloadSchemaVersion, p.Version)
+		assert.Equal(t, "owner/repo", p.Repository)
+		assert.Equal(t, "root", p.RootName)
+		assert.Equal(t, "1234", p.Revision)
+		assert.Equal(t, "nish", p.User)
+		assert.Equal(t, info.ID.String(), p.DeploymentID)
+	}
+	// deliveries are concurrent so they can arrive in any order
+	assert.ElementsMatch(t, []valid.DeployWebhookEvent{
+		valid.QueuedDeployWebhookEvent,
+		valid.PlannedDeployWebhookEvent,
+		valid.AwaitingApprovalDeployWebhookEvent,
+		valid.AppliedDeployWebhookEvent,
+	}, events)
+
+	assert.Len(t, filtered.payloads, 1)
+	assert.Equal(t, valid.AppliedDeployWebhookEvent, filtered.payloads[0].Event)
+}
+
+func TestNotifier_NoMatchingRepo(t *testing.T) {
+	info := plugins.TerraformDeploymentInfo{
+		ID:   uuid.New(),
+		Root: terraform.Root{Name: "root"},
+		Repo: github.Repo{Owner: "owner", Name: "unconfigured"},
+	}
+
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterActivity(&webhook.Activities{
+		GlobalCfg:      buildGlobalCfg(valid.DeployWebhook{URL: "http://localhost:1", Secret: "shh"}),
+		GithubHostname: "github.com",
+		Client:         http.DefaultClient,
+		Scope:          tally.NoopScope,
+	})
+	env.ExecuteWorkflow(testNotifierWorkflow, notifierRequest{
+		StatesToSend: []*plugins.TerraformWorkflowState{
+			{
+				Plan: &plugins.JobState{ID: "plan", Status: plugins.FailedJobStatus, EndTime: time.Now()},
+			},
+		},
+		DeploymentInfo: info,
+	})
+	assert.NoError(t, env.GetWorkflowError())
+}
@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/runatlantis/atlantis/server/config/valid"
+)
+
+// PayloadSchemaVersion is bumped whenever a breaking change is made to Payload.
+const PayloadSchemaVersion = 1
+
+// Payload is the JSON body POSTed to deploy webhooks.
+type Payload struct {
+	Version      int                      `json:"version"`
+	Event        valid.DeployWebhookEvent `json:"event"`
+	Timestamp    time.Time                `json:"timestamp"`
+	DeploymentID string                   `json:"deployment_id"`
+	Repository   string                   `json:"repository"`
+	RootName     string                   `json:"root_name"`
+	Revision     string                   `json:"revision"`
+	User         string                   `json:"user,omitempty"`
+	JobID        string                   `json:"job_id"`
+	JobStatus    string                   `json:"job_status"`
+	ApprovedBy   string                   `json:"approved_by,omitempty"`
+	Tags         map[string]string        `json:"tags,omitempty"`
+}
@@ -22,6 +22,9 @@ type JobState struct {
 	EndTime      time.Time
 	ApprovedBy   string
 	ApprovedTime time.Time
+
+	// AwaitingApproval is set when the job is waiting on a user to confirm or reject it.
+	AwaitingApproval bool
 }
 
 // TerraformWorkflowState contains the state of all jobs in the workflow
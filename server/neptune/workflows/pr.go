@@ -1,10 +1,13 @@
 package workflows
 
 import (
+	"github.com/pkg/errors"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/pr"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/pr/limiter"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/pr/request"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/pr/revision"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/pr/revision/policy"
+	"github.com/runatlantis/atlantis/server/neptune/workflows/plugins"
 	"go.temporal.io/sdk/workflow"
 )
 
@@ -12,6 +15,16 @@ var PRTaskQueue = pr.TaskQueue
 var PRTerraformRevisionSignalID = revision.TerraformRevisionSignalID
 var PRShutdownSignalName = pr.ShutdownSignalID
 var PRReviewSignalName = revision.ReviewSignalID
+var PRTeamMembershipSignalName = revision.TeamMembershipSignalID
+var PRPolicyCheckSignalName = revision.PolicyCheckSignalID
+var PRApprovePoliciesSignalName = revision.ApprovePoliciesSignalID
+var PRUnlockSignalName = pr.UnlockSignalID
+var PRPlanLimitSignalName = limiter.LimitSignalID
+var BuildPRPlanLimiterWorkflowID = limiter.BuildWorkflowID
+
+// PRWorkflowName should be used when registering the workflow returned by GetPRWithPlugins
+// so that it matches the name callers use when starting it via PR.
+var PRWorkflowName = "PR"
 
 const PRDestroyPlanMode = request.DestroyPlanMode
 const PRNormalPlanMode = request.NormalPlanMode
@@ -19,14 +32,51 @@ const PRNormalPlanMode = request.NormalPlanMode
 type PRShutdownRequest = pr.NewShutdownRequest
 type PRNewRevisionSignalRequest = revision.NewTerraformRevisionRequest
 type PRReviewRequest = policy.NewReviewRequest
+type PRTeamMembershipRequest = policy.TeamMembershipChangedRequest
+type PRPolicyCheckRequest = policy.PolicyCheckRequest
+type PRApprovePoliciesRequest = policy.ApprovePoliciesRequest
+type PRUnlockRequest = pr.NewUnlockRequest
 type PRRepo = request.Repo
 type PRRoot = request.Root
 type PRJob = request.Job
 type PRStep = request.Step
 type PRPlanMode = request.PlanMode
+type PRPolicyTarget = request.PolicyTarget
 type PRAppCredentials = request.AppCredentials
 type PRRequest = pr.Request
+type PRPlanLimiterRequest = limiter.Request
+type PRPlanLimitRequest = limiter.LimitRequest
+
+// Workflow function is a closure, so make sure to register with PRWorkflowName
+type PRFunc func(workflow.Context, PRRequest) error
+
+// This is used to have user defined components of the workflow.
+// Note: This can be dangerous as changes to these could have non-deterministic effects
+// on your workflows. Use this with caution.
+type InitPRPlugins func(workflow.Context, PRRequest) (plugins.PR, error)
+
+func NoPRPlugins(ctx workflow.Context, req PRRequest) (plugins.PR, error) {
+	return plugins.PR{}, nil
+}
+
+// GetPRWithPlugins returns a function closure for the PR workflow with any custom plugins
+// initialized before the workflow is run.
+func GetPRWithPlugins(initPlugins InitPRPlugins) PRFunc {
+	return func(ctx workflow.Context, request PRRequest) error {
+		plugins, err := initPlugins(ctx, request)
+		if err != nil {
+			return errors.Wrap(err, "initializing plugins")
+		}
+		return pr.Workflow(ctx, request, Terraform, plugins)
+	}
+}
 
 func PR(ctx workflow.Context, request PRRequest) error {
-	return pr.Workflow(ctx, request, Terraform)
+	return GetPRWithPlugins(NoPRPlugins)(ctx, request)
+}
+
+// PRPlanLimiter caps the number of roots planned at once across all PRs of a repo,
+// it runs on the PR task queue.
+func PRPlanLimiter(ctx workflow.Context, request PRPlanLimiterRequest) error {
+	return limiter.Workflow(ctx, request)
 }
@@ -1,6 +1,7 @@
 package workflows
 
 import (
+	"github.com/runatlantis/atlantis/server/neptune/workflows/activities"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/terraform"
 	"github.com/runatlantis/atlantis/server/neptune/workflows/internal/terraform/gate"
 	"go.temporal.io/sdk/workflow"
@@ -19,6 +20,12 @@ const RejectedPlanReviewStatus = gate.Rejected
 
 const TerraformPlanReviewSignalName = gate.PlanReviewSignalName
 
+const TerraformSlackPlanReviewCallbackID = activities.SlackPlanReviewCallbackID
+
+type TerraformSlackPlanReviewValue = activities.SlackPlanReviewValue
+
+var DecodeTerraformSlackPlanReviewValue = activities.DecodeSlackPlanReviewValue
+
 func Terraform(ctx workflow.Context, request TerraformRequest) (TerraformResponse, error) {
 	return terraform.Workflow(ctx, request)
 }
@@ -90,3 +90,87 @@ func (r *CheckRunsFetcher) listFailedCheckRunsMatchingRegex(ctx context.Context,
 	}
 	return failedCheckRuns, nil
 }
+
+// CheckResult is the latest outcome of a check run or commit status context on a ref.
+type CheckResult struct {
+	Name string
+	URL  string
+
+	// Status is the conclusion of a completed check run, the status of one which
+	// is still running or the state of a commit status.
+	Status string
+}
+
+// IsSuccessful mirrors how github's branch protection evaluates required checks.
+func (r CheckResult) IsSuccessful() bool {
+	switch r.Status {
+	case "success", "neutral", "skipped":
+		return true
+	}
+	return false
+}
+
+// ListCheckResults returns the latest check runs of every app along with the commit
+// statuses of the ref, keyed by check run name or status context.
+func (r *CheckRunsFetcher) ListCheckResults(ctx context.Context, installationToken int64, repo models.Repo, ref string) (map[string]CheckResult, error) {
+	client, err := r.ClientCreator.NewInstallationClient(installationToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating installation client")
+	}
+
+	listCheckRuns := func(ctx context.Context, nextPage int) ([]*gh.CheckRun, *gh.Response, error) {
+		listOptions := gh.ListCheckRunsOptions{
+			Filter: gh.String("latest"),
+			ListOptions: gh.ListOptions{
+				PerPage: 100,
+			},
+		}
+		listOptions.Page = nextPage
+		checkRunResults, resp, err := client.Checks.ListCheckRunsForRef(ctx, repo.Owner, repo.Name, ref, &listOptions)
+		if checkRunResults != nil {
+			return checkRunResults.CheckRuns, resp, err
+		}
+		return nil, resp, errors.Wrap(err, "unable to retrieve check runs from GH check run results")
+	}
+	checkRuns, err := Iterate(ctx, listCheckRuns)
+	if err != nil {
+		return nil, errors.Wrap(err, "iterating through check runs")
+	}
+
+	listStatuses := func(ctx context.Context, nextPage int) ([]*gh.RepoStatus, *gh.Response, error) {
+		listOptions := gh.ListOptions{
+			PerPage: 100,
+			Page:    nextPage,
+		}
+		combinedStatus, resp, err := client.Repositories.GetCombinedStatus(ctx, repo.Owner, repo.Name, ref, &listOptions)
+		if combinedStatus != nil {
+			return combinedStatus.Statuses, resp, err
+		}
+		return nil, resp, errors.Wrap(err, "unable to retrieve combined status")
+	}
+	statuses, err := Iterate(ctx, listStatuses)
+	if err != nil {
+		return nil, errors.Wrap(err, "iterating through statuses")
+	}
+
+	results := make(map[string]CheckResult)
+	for _, s := range statuses {
+		results[s.GetContext()] = CheckResult{
+			Name:   s.GetContext(),
+			URL:    s.GetTargetURL(),
+			Status: s.GetState(),
+		}
+	}
+	for _, c := range checkRuns {
+		status := c.GetStatus()
+		if status == CompletedStatus {
+			status = c.GetConclusion()
+		}
+		results[c.GetName()] = CheckResult{
+			Name:   c.GetName(),
+			URL:    c.GetDetailsURL(),
+			Status: status,
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,146 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/models"
+)
+
+// codeOwnersPaths are the locations github looks for a CODEOWNERS file, in order of precedence.
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+type codeOwnersRule struct {
+	pattern  []string
+	anchored bool
+	owners   []string
+}
+
+// CodeOwners is a parsed CODEOWNERS file.
+type CodeOwners struct {
+	rules []codeOwnersRule
+}
+
+// ParseCodeOwners parses the contents of a CODEOWNERS file.  Owners are returned without
+// the leading @, emails are ignored since we can't resolve them to github users.
+func ParseCodeOwners(data []byte) CodeOwners {
+	var codeOwners CodeOwners
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		var owners []string
+		for _, f := range fields[1:] {
+			if strings.HasPrefix(f, "#") {
+				break
+			}
+			if strings.HasPrefix(f, "@") {
+				owners = append(owners, strings.TrimPrefix(f, "@"))
+			}
+		}
+
+		pattern := strings.TrimSuffix(fields[0], "/")
+		// patterns are relative to the repo root if they contain a separator, otherwise they
+		// match a file or directory with that name anywhere in the repo.
+		anchored := strings.Contains(pattern, "/")
+		pattern = strings.TrimPrefix(pattern, "/")
+
+		codeOwners.rules = append(codeOwners.rules, codeOwnersRule{
+			pattern:  strings.Split(pattern, "/"),
+			anchored: anchored,
+			owners:   owners,
+		})
+	}
+	return codeOwners
+}
+
+// Owners returns the owners of the directory or file at the given repo relative path.  A rule matching
+// a directory applies to everything beneath it and, as with github, the last matching rule wins.
+func (c CodeOwners) Owners(relPath string) []string {
+	var segments []string
+	if cleaned := path.Clean(relPath); cleaned != "." {
+		segments = strings.Split(cleaned, "/")
+	}
+
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].matches(segments) {
+			return c.rules[i].owners
+		}
+	}
+	return nil
+}
+
+func (r codeOwnersRule) matches(segments []string) bool {
+	if len(r.pattern) == 1 && (r.pattern[0] == "*" || r.pattern[0] == "**") {
+		return true
+	}
+
+	for i := range segments {
+		if r.anchored {
+			// the path or any of its parent directories
+			if matchSegments(r.pattern, segments[:i+1]) {
+				return true
+			}
+			continue
+		}
+
+		if ok, _ := path.Match(r.pattern[0], segments[i]); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern []string, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+type fileContentsFetcher interface {
+	GetFileContents(ctx context.Context, repo models.Repo, installationToken int64, ref string, path string) (bool, []byte, error)
+}
+
+// CodeOwnersFetcher reads the CODEOWNERS file from the repo's default branch.  Reading from
+// the default branch rather than the PR prevents authors from granting themselves ownership.
+type CodeOwnersFetcher struct {
+	ContentsFetcher fileContentsFetcher
+}
+
+func (f *CodeOwnersFetcher) FetchCodeOwners(ctx context.Context, repo models.Repo, installationToken int64) (CodeOwners, error) {
+	for _, p := range codeOwnersPaths {
+		found, data, err := f.ContentsFetcher.GetFileContents(ctx, repo, installationToken, repo.DefaultBranch, p)
+		if err != nil {
+			return CodeOwners{}, errors.Wrapf(err, "fetching %s", p)
+		}
+		if found {
+			return ParseCodeOwners(data), nil
+		}
+	}
+	return CodeOwners{}, nil
+}
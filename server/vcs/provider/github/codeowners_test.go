@@ -0,0 +1,89 @@
+package github_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/models"
+	"github.com/runatlantis/atlantis/server/vcs/provider/github"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCodeOwners = `
+# default owners
+*                  @org/infra
+
+/terraform/        @org/platform # platform roots
+terraform/shared/** @alice @bob
+modules            @carol
+docs/*.md          docs@example.com
+/terraform/legacy/ @dave
+`
+
+func TestCodeOwners_Owners(t *testing.T) {
+	codeOwners := github.ParseCodeOwners([]byte(testCodeOwners))
+
+	cases := []struct {
+		path     string
+		expected []string
+	}{
+		{path: ".", expected: []string{"org/infra"}},
+		{path: "service", expected: []string{"org/infra"}},
+		{path: "terraform", expected: []string{"org/platform"}},
+		{path: "terraform/network/prod", expected: []string{"org/platform"}},
+		{path: "terraform/shared", expected: []string{"alice", "bob"}},
+		{path: "terraform/shared/iam", expected: []string{"alice", "bob"}},
+		{path: "service/modules/vpc", expected: []string{"carol"}},
+		{path: "terraform/legacy/db", expected: []string{"dave"}},
+		{path: "docs/readme.md", expected: nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			assert.Equal(t, c.expected, codeOwners.Owners(c.path))
+		})
+	}
+}
+
+type testContentsFetcher struct {
+	files map[string]string
+	err   error
+}
+
+func (f testContentsFetcher) GetFileContents(_ context.Context, _ models.Repo, _ int64, ref string, path string) (bool, []byte, error) {
+	data, ok := f.files[ref+":"+path]
+	return ok, []byte(data), f.err
+}
+
+func TestCodeOwnersFetcher_FetchCodeOwners(t *testing.T) {
+	repo := models.Repo{DefaultBranch: "main"}
+
+	t.Run("reads from the default branch", func(t *testing.T) {
+		subject := &github.CodeOwnersFetcher{
+			ContentsFetcher: testContentsFetcher{files: map[string]string{
+				"main:CODEOWNERS":      "* @owner",
+				"branch:CODEOWNERS":    "* @author",
+				"main:docs/CODEOWNERS": "* @docs",
+			}},
+		}
+
+		codeOwners, err := subject.FetchCodeOwners(context.Background(), repo, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"owner"}, codeOwners.Owners("dir"))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		subject := &github.CodeOwnersFetcher{ContentsFetcher: testContentsFetcher{}}
+
+		codeOwners, err := subject.FetchCodeOwners(context.Background(), repo, 1)
+		assert.NoError(t, err)
+		assert.Empty(t, codeOwners.Owners("dir"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		subject := &github.CodeOwnersFetcher{ContentsFetcher: testContentsFetcher{err: assert.AnError}}
+
+		_, err := subject.FetchCodeOwners(context.Background(), repo, 1)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"context"
+	"net/http"
+
+	gh "github.com/google/go-github/v45/github"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/models"
+)
+
+// ContentsFetcher reads files and directories of a repository at a given ref through
+// the contents API which allows us to avoid a clone when we only need a handful of paths.
+type ContentsFetcher struct {
+	ClientCreator githubapp.ClientCreator
+}
+
+// GetFileContents returns the decoded contents of the file at path, found is false if
+// the file does not exist at the given ref.
+func (f *ContentsFetcher) GetFileContents(ctx context.Context, repo models.Repo, installationToken int64, ref string, path string) (bool, []byte, error) {
+	fileContent, _, found, err := f.getContents(ctx, repo, installationToken, ref, path)
+	if err != nil || !found {
+		return found, nil, err
+	}
+
+	if fileContent == nil {
+		return true, nil, errors.Errorf("%s is not a file", path)
+	}
+
+	content, err := fileContent.GetContent()
+	if err != nil {
+		return true, nil, errors.Wrap(err, "decoding file content")
+	}
+	return true, []byte(content), nil
+}
+
+// PathExists returns whether a file or directory exists at path for the given ref.
+func (f *ContentsFetcher) PathExists(ctx context.Context, repo models.Repo, installationToken int64, ref string, path string) (bool, error) {
+	_, _, found, err := f.getContents(ctx, repo, installationToken, ref, path)
+	return found, err
+}
+
+func (f *ContentsFetcher) getContents(ctx context.Context, repo models.Repo, installationToken int64, ref string, path string) (*gh.RepositoryContent, []*gh.RepositoryContent, bool, error) {
+	client, err := f.ClientCreator.NewInstallationClient(installationToken)
+	if err != nil {
+		return nil, nil, false, errors.Wrap(err, "creating installation client")
+	}
+
+	fileContent, dirContent, resp, err := client.Repositories.GetContents(ctx, repo.Owner, repo.Name, path, &gh.RepositoryContentGetOptions{Ref: ref})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, nil, false, nil
+	}
+	if err != nil {
+		return nil, nil, false, errors.Wrapf(err, "getting contents of %s", path)
+	}
+	return fileContent, dirContent, true, nil
+}
+
+// GetTree returns the blob hashes of every file in the repository at ref keyed by their path
+// relative to the repository root.
+func (f *ContentsFetcher) GetTree(ctx context.Context, repo models.Repo, installationToken int64, ref string) (map[string]string, error) {
+	client, err := f.ClientCreator.NewInstallationClient(installationToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating installation client")
+	}
+
+	tree, _, err := client.Git.GetTree(ctx, repo.Owner, repo.Name, ref, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting tree of %s", ref)
+	}
+	if tree.GetTruncated() {
+		return nil, errors.Errorf("tree of %s is too large to be listed", ref)
+	}
+
+	blobs := make(map[string]string)
+	for _, entry := range tree.Entries {
+		if entry.GetType() != "blob" {
+			continue
+		}
+		blobs[entry.GetPath()] = entry.GetSHA()
+	}
+	return blobs, nil
+}
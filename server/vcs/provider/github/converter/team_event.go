@@ -0,0 +1,41 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/event"
+)
+
+// MembershipEvent converts members being added to or removed from a team
+type MembershipEvent struct{}
+
+func (c MembershipEvent) Convert(e *github.MembershipEvent) (event.TeamMembership, error) {
+	if e.GetTeam() == nil {
+		return event.TeamMembership{}, fmt.Errorf("team is nil")
+	}
+
+	return event.TeamMembership{
+		InstallationToken: githubapp.GetInstallationIDFromEvent(e),
+		Action:            e.GetAction(),
+		Org:               e.GetOrg().GetLogin(),
+		TeamSlug:          e.GetTeam().GetSlug(),
+	}, nil
+}
+
+// TeamEvent converts teams being created, edited or deleted
+type TeamEvent struct{}
+
+func (c TeamEvent) Convert(e *github.TeamEvent) (event.TeamMembership, error) {
+	if e.GetTeam() == nil {
+		return event.TeamMembership{}, fmt.Errorf("team is nil")
+	}
+
+	return event.TeamMembership{
+		InstallationToken: githubapp.GetInstallationIDFromEvent(e),
+		Action:            e.GetAction(),
+		Org:               e.GetOrg().GetLogin(),
+		TeamSlug:          e.GetTeam().GetSlug(),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/runatlantis/atlantis/server/neptune/gateway/event"
+	"github.com/runatlantis/atlantis/server/vcs/provider/github/converter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert_MembershipEvent(t *testing.T) {
+	subject := converter.MembershipEvent{}
+
+	result, err := subject.Convert(&github.MembershipEvent{
+		Action:       github.String("removed"),
+		Org:          &github.Organization{Login: github.String("org")},
+		Team:         &github.Team{Slug: github.String("team")},
+		Installation: &github.Installation{ID: github.Int64(123)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, event.TeamMembership{
+		InstallationToken: 123,
+		Action:            "removed",
+		Org:               "org",
+		TeamSlug:          "team",
+	}, result)
+
+	_, err = subject.Convert(&github.MembershipEvent{})
+	assert.Error(t, err)
+}
+
+func TestConvert_TeamEvent(t *testing.T) {
+	subject := converter.TeamEvent{}
+
+	result, err := subject.Convert(&github.TeamEvent{
+		Action:       github.String("deleted"),
+		Org:          &github.Organization{Login: github.String("org")},
+		Team:         &github.Team{Slug: github.String("team")},
+		Installation: &github.Installation{ID: github.Int64(123)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, event.TeamMembership{
+		InstallationToken: 123,
+		Action:            "deleted",
+		Org:               "org",
+		TeamSlug:          "team",
+	}, result)
+
+	_, err = subject.Convert(&github.TeamEvent{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package github
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+const gitAccessTokenUser = "x-access-token"
+
+// GitCredentialEnvs returns environment variables which authenticate git commands against
+// hostname using a github app installation token.  Config is injected through GIT_CONFIG_*
+// variables so that credentials are scoped to a single invocation rather than written to the
+// global git config, which allows concurrent commands to use different installations.
+// See: https://git-scm.com/docs/git-config#Documentation/git-config.txt-GITCONFIGCOUNT
+func GitCredentialEnvs(hostname string, token string) map[string]string {
+	auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", gitAccessTokenUser, token)))
+	baseURL := fmt.Sprintf("https://%s/", hostname)
+
+	configs := [][2]string{
+		// https://developer.github.com/apps/building-github-apps/authenticating-with-github-apps/#http-based-git-access-by-an-installation
+		{fmt.Sprintf("http.%s.extraheader", baseURL), fmt.Sprintf("Authorization: Basic %s", auth)},
+
+		// modules can be sourced over ssh which we rewrite so that the header above is used
+		{fmt.Sprintf("url.%s.insteadOf", baseURL), fmt.Sprintf("ssh://git@%s/", hostname)},
+		{fmt.Sprintf("url.%s.insteadOf", baseURL), fmt.Sprintf("git@%s:", hostname)},
+	}
+
+	envs := map[string]string{
+		"GIT_CONFIG_COUNT": strconv.Itoa(len(configs)),
+
+		// fail instead of hanging on a prompt if the credentials are rejected
+		"GIT_TERMINAL_PROMPT": "0",
+	}
+	for i, c := range configs {
+		envs[fmt.Sprintf("GIT_CONFIG_KEY_%d", i)] = c[0]
+		envs[fmt.Sprintf("GIT_CONFIG_VALUE_%d", i)] = c[1]
+	}
+	return envs
+}
+
+// envList converts envs into the KEY=value form expected by exec.Cmd, sorted for stability.
+func envList(envs map[string]string) []string {
+	var list []string
+	for k, v := range envs {
+		list = append(list, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,33 @@
+package github_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/vcs/provider/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitCredentialEnvs(t *testing.T) {
+	envs := github.GitCredentialEnvs("hostname", "token")
+
+	gitConfig := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"config"}, args...)...)
+		cmd.Env = os.Environ()
+		for k, v := range envs {
+			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+		}
+		out, err := cmd.CombinedOutput()
+		assert.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+
+	auth := base64.StdEncoding.EncodeToString([]byte("x-access-token:token"))
+	assert.Equal(t, "Authorization: Basic "+auth, gitConfig("--get", "http.https://hostname/.extraheader"))
+	assert.Equal(t, "ssh://git@hostname/\ngit@hostname:", gitConfig("--get-all", "url.https://hostname/.insteadOf"))
+	assert.Equal(t, "0", envs["GIT_TERMINAL_PROMPT"])
+}
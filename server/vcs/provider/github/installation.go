@@ -2,6 +2,8 @@ package github
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
@@ -30,3 +32,76 @@ func (r *InstallationRetriever) FindOrganizationInstallation(ctx context.Context
 		Token: installation.GetID(),
 	}, nil
 }
+
+type installationRetriever interface {
+	FindOrganizationInstallation(ctx context.Context, org string) (Installation, error)
+}
+
+// DefaultInstallationTTL bounds how long a cached installation is used before it's looked up again,
+// so that an app which is reinstalled on an organization is picked up without a restart.
+const DefaultInstallationTTL = time.Hour
+
+// InstallationCache resolves the installation for each organization and caches it since
+// installations rarely change and we otherwise spend an app api call per event.
+type InstallationCache struct {
+	Retriever installationRetriever
+
+	// TTL defaults to DefaultInstallationTTL
+	TTL time.Duration
+
+	mu            sync.Mutex
+	installations map[string]cachedInstallation
+	now           func() time.Time
+}
+
+type cachedInstallation struct {
+	installation Installation
+	fetchedAt    time.Time
+}
+
+func (c *InstallationCache) FindOrganizationInstallation(ctx context.Context, org string) (Installation, error) {
+	c.mu.Lock()
+	entry, ok := c.installations[org]
+	c.mu.Unlock()
+	if ok && c.clock().Sub(entry.fetchedAt) < c.ttl() {
+		return entry.installation, nil
+	}
+
+	installation, err := c.Retriever.FindOrganizationInstallation(ctx, org)
+	if err != nil {
+		return Installation{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.installations == nil {
+		c.installations = make(map[string]cachedInstallation)
+	}
+	c.installations[org] = cachedInstallation{
+		installation: installation,
+		fetchedAt:    c.clock(),
+	}
+	return installation, nil
+}
+
+// Invalidate drops the cached installation of the organization, callers should invalidate
+// an installation once it's been rejected so that the next lookup fetches it again.
+func (c *InstallationCache) Invalidate(org string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.installations, org)
+}
+
+func (c *InstallationCache) ttl() time.Duration {
+	if c.TTL <= 0 {
+		return DefaultInstallationTTL
+	}
+	return c.TTL
+}
+
+func (c *InstallationCache) clock() time.Time {
+	if c.now == nil {
+		return time.Now()
+	}
+	return c.now()
+}
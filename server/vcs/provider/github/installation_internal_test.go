@@ -0,0 +1,47 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type staticInstallationRetriever struct {
+	token int64
+	calls int
+}
+
+func (r *staticInstallationRetriever) FindOrganizationInstallation(_ context.Context, _ string) (Installation, error) {
+	r.calls++
+	return Installation{Token: r.token}, nil
+}
+
+func TestInstallationCache_TTL(t *testing.T) {
+	now := time.Now()
+	retriever := &staticInstallationRetriever{token: 1}
+	subject := &InstallationCache{
+		Retriever: retriever,
+		TTL:       time.Minute,
+		now: func() time.Time {
+			return now
+		},
+	}
+
+	_, err := subject.FindOrganizationInstallation(context.Background(), "org")
+	assert.NoError(t, err)
+
+	now = now.Add(30 * time.Second)
+	_, err = subject.FindOrganizationInstallation(context.Background(), "org")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, retriever.calls)
+
+	// the app was reinstalled so the cached installation is stale once the ttl passes
+	retriever.token = 2
+	now = now.Add(time.Minute)
+	installation, err := subject.FindOrganizationInstallation(context.Background(), "org")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), installation.Token)
+	assert.Equal(t, 2, retriever.calls)
+}
@@ -0,0 +1,85 @@
+package github_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/vcs/provider/github"
+	"github.com/stretchr/testify/assert"
+)
+
+type testInstallationRetriever struct {
+	installations map[string]int64
+	calls         map[string]int
+	err           error
+}
+
+func (r *testInstallationRetriever) FindOrganizationInstallation(_ context.Context, org string) (github.Installation, error) {
+	r.calls[org]++
+	if r.err != nil {
+		return github.Installation{}, r.err
+	}
+	return github.Installation{Token: r.installations[org]}, nil
+}
+
+func TestInstallationCache_FindOrganizationInstallation(t *testing.T) {
+	t.Run("cached per org", func(t *testing.T) {
+		retriever := &testInstallationRetriever{
+			installations: map[string]int64{
+				"org1": 1,
+				"org2": 2,
+			},
+			calls: map[string]int{},
+		}
+		subject := &github.InstallationCache{Retriever: retriever}
+
+		for i := 0; i < 2; i++ {
+			installation, err := subject.FindOrganizationInstallation(context.Background(), "org1")
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), installation.Token)
+
+			installation, err = subject.FindOrganizationInstallation(context.Background(), "org2")
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2), installation.Token)
+		}
+
+		assert.Equal(t, map[string]int{"org1": 1, "org2": 1}, retriever.calls)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		retriever := &testInstallationRetriever{
+			calls: map[string]int{},
+			err:   errors.New("error"),
+		}
+		subject := &github.InstallationCache{Retriever: retriever}
+
+		for i := 0; i < 2; i++ {
+			_, err := subject.FindOrganizationInstallation(context.Background(), "org1")
+			assert.Error(t, err)
+		}
+
+		assert.Equal(t, 2, retriever.calls["org1"])
+	})
+}
+
+func TestInstallationCache_Invalidate(t *testing.T) {
+	retriever := &testInstallationRetriever{
+		installations: map[string]int64{
+			"org1": 1,
+		},
+		calls: map[string]int{},
+	}
+	subject := &github.InstallationCache{Retriever: retriever}
+
+	_, err := subject.FindOrganizationInstallation(context.Background(), "org1")
+	assert.NoError(t, err)
+
+	retriever.installations["org1"] = 2
+	subject.Invalidate("org1")
+
+	installation, err := subject.FindOrganizationInstallation(context.Background(), "org1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), installation.Token)
+	assert.Equal(t, 2, retriever.calls["org1"])
+}
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/bradleyfalzon/ghinstallation"
+	"github.com/pkg/errors"
+)
+
+// InstallationTokens mints access tokens for any installation of a github app.  A transport is
+// kept per installation so that each installation's token is cached until it expires.
+type InstallationTokens struct {
+	AppID      int64
+	PrivateKey []byte
+	// V3APIURL is optional and only needs to be set for github enterprise
+	V3APIURL string
+
+	mu         sync.Mutex
+	transports map[int64]*ghinstallation.Transport
+}
+
+func (t *InstallationTokens) GetToken(ctx context.Context, installationID int64) (string, error) {
+	tr, err := t.transport(installationID)
+	if err != nil {
+		return "", errors.Wrapf(err, "initializing transport for installation %d", installationID)
+	}
+	return tr.Token(ctx)
+}
+
+func (t *InstallationTokens) transport(installationID int64) (*ghinstallation.Transport, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if tr, ok := t.transports[installationID]; ok {
+		return tr, nil
+	}
+
+	tr, err := ghinstallation.New(http.DefaultTransport, t.AppID, installationID, t.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	if t.V3APIURL != "" {
+		tr.BaseURL = strings.TrimSuffix(t.V3APIURL, "/")
+	}
+
+	if t.transports == nil {
+		t.transports = make(map[int64]*ghinstallation.Transport)
+	}
+	t.transports[installationID] = tr
+	return tr, nil
+}
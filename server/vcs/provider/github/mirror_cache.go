@@ -0,0 +1,250 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/models"
+	"github.com/uber-go/tally/v4"
+)
+
+const (
+	mirrorSuffix = ".git"
+
+	mirrorHitMetric     = "hit"
+	mirrorMissMetric    = "miss"
+	mirrorEvictedMetric = "evicted"
+	mirrorSizeMetric    = "size_bytes"
+)
+
+type mirror struct {
+	// fetches into the mirror take the write lock while clones from it take the read lock
+	lock     sync.RWMutex
+	path     string
+	lastUsed time.Time
+	size     int64
+	// users is the number of clones in progress from the mirror, mirrors in use aren't evicted
+	users int
+}
+
+// MirrorCache maintains a bare mirror per repository on disk which is fetched incrementally
+// and used as the source for per request clones.  This keeps bursts of events for the same
+// repository from each downloading it from github.
+// Mirrors are evicted in least recently used order once their total size exceeds MaxBytes.
+type MirrorCache struct {
+	Dir      string
+	MaxBytes int64
+	Logger   logging.Logger
+	Scope    tally.Scope
+
+	mu      sync.Mutex
+	mirrors map[string]*mirror
+}
+
+// NewMirrorCache creates a cache rooted at dir, picking up any mirrors left behind by a
+// previous process so that restarts don't start cold.
+func NewMirrorCache(dir string, maxBytes int64, logger logging.Logger, scope tally.Scope) (*MirrorCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "creating mirror cache directory")
+	}
+
+	c := &MirrorCache{
+		Dir:      dir,
+		MaxBytes: maxBytes,
+		Logger:   logger,
+		Scope:    scope,
+		mirrors:  make(map[string]*mirror),
+	}
+
+	// mirrors are stored as <dir>/<owner>/<repo>.git
+	paths, err := filepath.Glob(filepath.Join(dir, "*", "*"+mirrorSuffix))
+	if err != nil {
+		return nil, errors.Wrap(err, "listing existing mirrors")
+	}
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "stat %s", p)
+		}
+		size, err := dirSize(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "calculating size of %s", p)
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "determining repo for %s", p)
+		}
+		c.mirrors[rel[:len(rel)-len(mirrorSuffix)]] = &mirror{
+			path:     p,
+			lastUsed: info.ModTime(),
+			size:     size,
+		}
+	}
+
+	return c, nil
+}
+
+// Clone fetches the branch into the repo's mirror and clones it from there into destinationPath.
+// envs are only used for the fetch from github since clones from the mirror are local.
+func (c *MirrorCache) Clone(ctx context.Context, repo models.Repo, branch string, destinationPath string, depth int, envs map[string]string) error {
+	m := c.acquire(repo.FullName)
+	defer c.release(m)
+
+	if err := c.fetch(ctx, m, repo, branch, envs); err != nil {
+		return errors.Wrap(err, "fetching into mirror")
+	}
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	// local clones hardlink objects which is cheap, however a depth is only respected
+	// when cloning through the file protocol.
+	source := m.path
+	cloneCmd := []string{"git", "clone", "--branch", branch, "--single-branch"}
+	if depth > 0 {
+		source = fmt.Sprintf("file://%s", m.path)
+		cloneCmd = append(cloneCmd, fmt.Sprintf("--depth=%d", depth))
+	}
+	cloneCmd = append(cloneCmd, source, destinationPath)
+
+	if _, err := runGit(ctx, c.Logger, cloneCmd, destinationPath, nil); err != nil {
+		return errors.Wrap(err, "cloning from mirror")
+	}
+	return nil
+}
+
+// acquire returns the repo's mirror, it isn't evicted until it's released
+func (c *MirrorCache) acquire(repoFullName string) *mirror {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	m, ok := c.mirrors[repoFullName]
+	if !ok {
+		m = &mirror{
+			path: filepath.Join(c.Dir, repoFullName+mirrorSuffix),
+		}
+		c.mirrors[repoFullName] = m
+	}
+	m.lastUsed = time.Now()
+	m.users++
+	return m
+}
+
+func (c *MirrorCache) release(m *mirror) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m.users--
+}
+
+func (c *MirrorCache) fetch(ctx context.Context, m *mirror, repo models.Repo, branch string, envs map[string]string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, err := os.Stat(m.path); os.IsNotExist(err) {
+		c.Scope.Counter(mirrorMissMetric).Inc(1)
+		if err := os.MkdirAll(m.path, 0700); err != nil {
+			return errors.Wrap(err, "creating mirror directory")
+		}
+		if _, err := runGit(ctx, c.Logger, []string{"git", "init", "--bare"}, m.path, nil); err != nil {
+			c.removeMirror(ctx, m)
+			return errors.Wrap(err, "initializing mirror")
+		}
+	} else {
+		c.Scope.Counter(mirrorHitMetric).Inc(1)
+	}
+
+	// the clone url is passed in on every fetch instead of configuring a remote so that the
+	// mirror doesn't hold onto any state about the repo beyond its objects and refs.
+	refspec := fmt.Sprintf("+refs/heads/%s:refs/heads/%s", branch, branch)
+	if _, err := runGit(ctx, c.Logger, []string{"git", "fetch", "--no-tags", repo.CloneURL, refspec}, m.path, envs); err != nil {
+		return err
+	}
+
+	size, err := dirSize(m.path)
+	if err != nil {
+		return errors.Wrap(err, "calculating mirror size")
+	}
+
+	c.mu.Lock()
+	m.size = size
+	c.mu.Unlock()
+
+	c.evict(ctx)
+	return nil
+}
+
+// evict removes least recently used mirrors until we are within budget.  Mirrors which are in use
+// or currently locked are skipped, this includes the current mirror which is held by the caller.
+func (c *MirrorCache) evict(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var total int64
+	var candidates []string
+	for name, m := range c.mirrors {
+		total += m.size
+		if m.users == 0 && m.size > 0 {
+			candidates = append(candidates, name)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return c.mirrors[candidates[i]].lastUsed.Before(c.mirrors[candidates[j]].lastUsed)
+	})
+
+	for _, name := range candidates {
+		if total <= c.MaxBytes {
+			break
+		}
+
+		m := c.mirrors[name]
+		if !m.lock.TryLock() {
+			continue
+		}
+
+		// entries are kept around so that callers which have already retrieved the mirror
+		// continue to share its lock, it will simply be recreated on next use.
+		c.Logger.InfoContext(ctx, fmt.Sprintf("evicting mirror of %s", name))
+		c.removeMirror(ctx, m)
+		total -= m.size
+		m.size = 0
+		c.Scope.Counter(mirrorEvictedMetric).Inc(1)
+		m.lock.Unlock()
+	}
+
+	c.Scope.Gauge(mirrorSizeMetric).Update(float64(total))
+}
+
+func (c *MirrorCache) removeMirror(ctx context.Context, m *mirror) {
+	if err := os.RemoveAll(m.path); err != nil {
+		c.Logger.ErrorContext(ctx, fmt.Sprintf("removing mirror at %s: %s", m.path, err))
+	}
+}
+
+func dirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,44 @@
+package github
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally/v4"
+)
+
+func TestMirrorCache_EvictSkipsMirrorsInUse(t *testing.T) {
+	c := &MirrorCache{
+		Dir:     t.TempDir(),
+		Logger:  logging.NewNoopCtxLogger(t),
+		Scope:   tally.NewTestScope("test", map[string]string{}),
+		mirrors: make(map[string]*mirror),
+	}
+
+	used := c.acquire("owner/used")
+	unused := c.acquire("owner/unused")
+	c.release(unused)
+	for _, m := range []*mirror{used, unused} {
+		assert.NoError(t, os.MkdirAll(m.path, 0700))
+		assert.NoError(t, os.WriteFile(filepath.Join(m.path, "HEAD"), []byte("ref"), 0600))
+		m.size = 3
+	}
+
+	// over budget, but the mirror being cloned from must stay put until it's released
+	c.evict(context.Background())
+
+	_, err := os.Stat(used.path)
+	assert.NoError(t, err)
+	_, err = os.Stat(unused.path)
+	assert.True(t, os.IsNotExist(err))
+
+	c.release(used)
+	c.evict(context.Background())
+
+	_, err = os.Stat(used.path)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,113 @@
+package github_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/runatlantis/atlantis/server/legacy/events/vcs/fixtures"
+	"github.com/runatlantis/atlantis/server/logging"
+	"github.com/runatlantis/atlantis/server/vcs/provider/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally/v4"
+)
+
+func TestFetch_MirrorCache(t *testing.T) {
+	repoDir, cleanupRepo := initRepo(t)
+	defer cleanupRepo()
+	_ = appendCommit(t, repoDir, ".gitkeep", "initial commit")
+	expCommit1 := runCmd(t, repoDir, "git", "rev-parse", "HEAD")
+
+	dataDir, cleanupDataDir := tempDir(t)
+	defer cleanupDataDir()
+	defer disableSSLVerification()()
+	testServer, err := fixtures.GithubAppTestServer(t)
+	assert.NoError(t, err)
+	logger := logging.NewNoopCtxLogger(t)
+	scope := tally.NewTestScope("test", map[string]string{})
+
+	cache, err := github.NewMirrorCache(filepath.Join(dataDir, "mirrors"), 1<<30, logger, scope)
+	assert.NoError(t, err)
+
+	fetcher := &github.RepoFetcher{
+		DataDir:           dataDir,
+		GithubHostname:    testServer,
+		Logger:            logger,
+		GithubCredentials: &testTokenGetter{},
+		Scope:             scope,
+		MirrorCache:       cache,
+	}
+	repo := newBaseRepo(repoDir)
+
+	destinationPath, _, err := fetcher.Fetch(context.Background(), repo, 1, repo.DefaultBranch, expCommit1[:7], github.RepoFetcherOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, expCommit1, runCmd(t, destinationPath, "git", "rev-parse", "HEAD"))
+
+	// new commits should be fetched incrementally into the existing mirror
+	_ = appendCommit(t, repoDir, ".gitignore", "second commit")
+	expCommit2 := runCmd(t, repoDir, "git", "rev-parse", "HEAD")
+
+	destinationPath, _, err = fetcher.Fetch(context.Background(), repo, 1, repo.DefaultBranch, expCommit2[:7], github.RepoFetcherOptions{CloneDepth: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, expCommit2, runCmd(t, destinationPath, "git", "rev-parse", "HEAD"))
+	assert.Equal(t, "1\n", runCmd(t, destinationPath, "git", "rev-list", "--count", "HEAD"))
+
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["test.miss+"].Value())
+	assert.Equal(t, int64(1), counters["test.hit+"].Value())
+	assert.DirExists(t, filepath.Join(dataDir, "mirrors", "nish", "repo.git"))
+}
+
+func TestFetch_MirrorCacheEviction(t *testing.T) {
+	repoDir, cleanupRepo := initRepo(t)
+	defer cleanupRepo()
+	_ = appendCommit(t, repoDir, ".gitkeep", "initial commit")
+	expCommit := runCmd(t, repoDir, "git", "rev-parse", "HEAD")
+
+	dataDir, cleanupDataDir := tempDir(t)
+	defer cleanupDataDir()
+	defer disableSSLVerification()()
+	testServer, err := fixtures.GithubAppTestServer(t)
+	assert.NoError(t, err)
+	logger := logging.NewNoopCtxLogger(t)
+	scope := tally.NewTestScope("test", map[string]string{})
+
+	// a single byte budget means only the most recently used mirror is retained
+	mirrorDir := filepath.Join(dataDir, "mirrors")
+	cache, err := github.NewMirrorCache(mirrorDir, 1, logger, scope)
+	assert.NoError(t, err)
+
+	fetcher := &github.RepoFetcher{
+		DataDir:           dataDir,
+		GithubHostname:    testServer,
+		Logger:            logger,
+		GithubCredentials: &testTokenGetter{},
+		Scope:             scope,
+		MirrorCache:       cache,
+	}
+
+	repo1 := newBaseRepo(repoDir)
+	_, _, err = fetcher.Fetch(context.Background(), repo1, 1, repo1.DefaultBranch, expCommit[:7], github.RepoFetcherOptions{})
+	assert.NoError(t, err)
+
+	repo2 := newBaseRepo(repoDir)
+	repo2.FullName = "nish/other"
+	repo2.Name = "other"
+	_, _, err = fetcher.Fetch(context.Background(), repo2, 1, repo2.DefaultBranch, expCommit[:7], github.RepoFetcherOptions{})
+	assert.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(mirrorDir, "nish", "repo.git"))
+	assert.True(t, os.IsNotExist(err))
+	assert.DirExists(t, filepath.Join(mirrorDir, "nish", "other.git"))
+	assert.Equal(t, int64(1), scope.Snapshot().Counters()["test.evicted+"].Value())
+
+	// existing mirrors are picked up on startup
+	restartScope := tally.NewTestScope("test", map[string]string{})
+	fetcher.MirrorCache, err = github.NewMirrorCache(mirrorDir, 1<<30, logger, restartScope)
+	assert.NoError(t, err)
+
+	_, _, err = fetcher.Fetch(context.Background(), repo2, 1, repo2.DefaultBranch, expCommit[:7], github.RepoFetcherOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), restartScope.Snapshot().Counters()["test.hit+"].Value())
+}
@@ -9,7 +9,6 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
-	"github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
 	"github.com/runatlantis/atlantis/server/config/valid"
 	"github.com/runatlantis/atlantis/server/logging"
@@ -22,7 +21,7 @@ import (
 const workingDirPrefix = "repos"
 
 type tokenGetter interface {
-	GetToken() (string, error)
+	GetToken(ctx context.Context, installationID int64) (string, error)
 }
 
 // RepoFetcher implements repoFetcher through git clone operations
@@ -33,6 +32,9 @@ type RepoFetcher struct {
 	GithubCredentials tokenGetter
 	GlobalCfg         valid.GlobalCfg
 	Scope             tally.Scope
+
+	// MirrorCache is optional, when set clones are made from a local mirror of the repo
+	MirrorCache *MirrorCache
 }
 
 type RepoFetcherOptions struct {
@@ -41,29 +43,19 @@ type RepoFetcherOptions struct {
 	SimplePath bool
 }
 
-func (g *RepoFetcher) Fetch(ctx context.Context, repo models.Repo, branch string, sha string, options RepoFetcherOptions) (string, func(ctx context.Context, filePath string), error) {
-	home, err := homedir.Dir()
-	if err != nil {
-		return "", nil, errors.Wrap(err, "getting home dir to write ~/.git-credentials file")
-	}
-
-	ghToken, err := g.GithubCredentials.GetToken()
+func (g *RepoFetcher) Fetch(ctx context.Context, repo models.Repo, installationToken int64, branch string, sha string, options RepoFetcherOptions) (string, func(ctx context.Context, filePath string), error) {
+	ghToken, err := g.GithubCredentials.GetToken(ctx, installationToken)
 	if err != nil {
 		return "", nil, errors.Wrap(err, "fetching github token")
 	}
 
-	// https://developer.github.com/apps/building-github-apps/authenticating-with-github-apps/#http-based-git-access-by-an-installation
-	if err := WriteGitCreds("x-access-token", ghToken, g.GithubHostname, home, g.Logger, true); err != nil {
-		return "", nil, err
-	}
-	// Realistically, this is a super brittle way of supporting clones using gh app installation tokens
-	// This URL should be built during Repo creation and the struct should be immutable going forward.
-	// Doing this requires a larger refactor however, and can probably be coupled with supporting > 1 installation
-	authURL := fmt.Sprintf("://x-access-token:%s", ghToken)
-	repo.CloneURL = strings.Replace(repo.CloneURL, "://:", authURL, 1)
-	repo.SanitizedCloneURL = strings.Replace(repo.SanitizedCloneURL, "://:", "://x-access-token:", 1)
+	// credentials are supplied to each git command through its environment so the
+	// installation token never ends up in the clone url or on disk.
+	envs := GitCredentialEnvs(g.GithubHostname, ghToken)
+	repo.CloneURL = strings.Replace(repo.CloneURL, "://:@", "://", 1)
+	repo.SanitizedCloneURL = strings.Replace(repo.SanitizedCloneURL, "://:<redacted>@", "://", 1)
 	g.Logger.Info(fmt.Sprintf("about to clone inside RepoFetcher Fetch with params: repo: %v. branch: %s, sha: %s", repo, branch, sha))
-	path, cleanup, err := g.clone(ctx, repo, branch, sha, options)
+	path, cleanup, err := g.clone(ctx, repo, branch, sha, options, envs)
 	if err != nil {
 		g.Scope.Counter(metrics.ExecutionErrorMetric).Inc(1)
 		return path, cleanup, err
@@ -73,7 +65,7 @@ func (g *RepoFetcher) Fetch(ctx context.Context, repo models.Repo, branch string
 	return path, cleanup, err
 }
 
-func (g *RepoFetcher) clone(ctx context.Context, repo models.Repo, branch string, sha string, options RepoFetcherOptions) (string, func(ctx context.Context, filePath string), error) {
+func (g *RepoFetcher) clone(ctx context.Context, repo models.Repo, branch string, sha string, options RepoFetcherOptions, envs map[string]string) (string, func(ctx context.Context, filePath string), error) {
 	destinationPath := g.generateDirPath(repo.Name)
 	// If simple path is enabled, we don't need a prefix and UUID
 	if options.SimplePath {
@@ -91,7 +83,12 @@ func (g *RepoFetcher) clone(ctx context.Context, repo models.Repo, branch string
 	if options.CloneDepth > 0 {
 		cloneCmd = append(cloneCmd, fmt.Sprintf("--depth=%d", options.CloneDepth))
 	}
-	_, err := g.run(ctx, cloneCmd, destinationPath)
+	var err error
+	if g.MirrorCache != nil && !options.SimplePath {
+		err = g.MirrorCache.Clone(ctx, repo, branch, destinationPath, options.CloneDepth, envs)
+	} else {
+		_, err = runGit(ctx, g.Logger, cloneCmd, destinationPath, envs)
+	}
 	if err != nil {
 		debugStr := fmt.Sprintf("destination path is %s, repo is %v, sha is %v", destinationPath, repo, sha)
 		return "", nil, errors.Wrap(err, "failed to clone directory, debug info: "+debugStr)
@@ -127,7 +124,11 @@ func (g *RepoFetcher) generateSimpleDirPath(repoName string) string {
 }
 
 func (g *RepoFetcher) run(ctx context.Context, args []string, destinationPath string) ([]byte, error) {
-	cmd := subprocess_exec.Command(g.Logger, args[0], args[1:]...) // nolint: gosec
+	return runGit(ctx, g.Logger, args, destinationPath, nil)
+}
+
+func runGit(ctx context.Context, logger logging.Logger, args []string, destinationPath string, envs map[string]string) ([]byte, error) {
+	cmd := subprocess_exec.Command(logger, args[0], args[1:]...) // nolint: gosec
 	cmd.Dir = destinationPath
 	// The repo merge command requires these env vars are set.
 	cmd.Env = append(os.Environ(), []string{
@@ -135,6 +136,7 @@ func (g *RepoFetcher) run(ctx context.Context, args []string, destinationPath st
 		"GIT_AUTHOR_NAME=atlantis",
 		"GIT_COMMITTER_NAME=atlantis",
 	}...)
+	cmd.Env = append(cmd.Env, envList(envs)...)
 	var b bytes.Buffer
 	cmd.Stdout = &b
 	cmd.Stderr = &b
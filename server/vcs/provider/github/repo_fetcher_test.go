@@ -26,7 +26,7 @@ type testTokenGetter struct {
 	err   error
 }
 
-func (t *testTokenGetter) GetToken() (string, error) {
+func (t *testTokenGetter) GetToken(_ context.Context, _ int64) (string, error) {
 	return t.token, t.err
 }
 
@@ -55,7 +55,7 @@ func TestFetchSimple(t *testing.T) {
 	}
 	repo := newBaseRepo(repoDir)
 	options := github.RepoFetcherOptions{}
-	destinationPath, _, err := fetcher.Fetch(context.Background(), repo, repo.DefaultBranch, sha2, options)
+	destinationPath, _, err := fetcher.Fetch(context.Background(), repo, 1, repo.DefaultBranch, sha2, options)
 	assert.NoError(t, err)
 
 	// Use rev-parse to verify at correct commit.
@@ -93,7 +93,7 @@ func TestFetchSimple_Shallow(t *testing.T) {
 	}
 	repo := newBaseRepo(repoDir)
 	options := github.RepoFetcherOptions{CloneDepth: 1}
-	destinationPath, _, err := fetcher.Fetch(context.Background(), repo, repo.DefaultBranch, sha2, options)
+	destinationPath, _, err := fetcher.Fetch(context.Background(), repo, 1, repo.DefaultBranch, sha2, options)
 	assert.NoError(t, err)
 
 	// Use rev-parse to verify at correct commit.
@@ -134,7 +134,7 @@ func TestFetchCheckout(t *testing.T) {
 	}
 	repo := newBaseRepo(repoDir)
 	options := github.RepoFetcherOptions{}
-	destinationPath, _, err := fetcher.Fetch(context.Background(), repo, repo.DefaultBranch, sha1, options)
+	destinationPath, _, err := fetcher.Fetch(context.Background(), repo, 1, repo.DefaultBranch, sha1, options)
 	assert.NoError(t, err)
 
 	// Use rev-parse to verify at correct commit.
@@ -168,7 +168,7 @@ func TestFetchNonDefaultBranch(t *testing.T) {
 	}
 	repo := newBaseRepo(repoDir)
 	options := github.RepoFetcherOptions{}
-	destinationPath, _, err := fetcher.Fetch(context.Background(), repo, "test-branch", sha, options)
+	destinationPath, _, err := fetcher.Fetch(context.Background(), repo, 1, "test-branch", sha, options)
 	assert.NoError(t, err)
 
 	// Use rev-parse to verify at correct commit.
@@ -199,7 +199,7 @@ func TestFetchSimpleFailure(t *testing.T) {
 	repo := newBaseRepo(repoDir)
 	repo.DefaultBranch = "invalid-branch"
 	options := github.RepoFetcherOptions{}
-	_, _, err = fetcher.Fetch(context.Background(), repo, repo.DefaultBranch, sha, options)
+	_, _, err = fetcher.Fetch(context.Background(), repo, 1, repo.DefaultBranch, sha, options)
 	assert.Error(t, err)
 }
 
@@ -230,7 +230,7 @@ func TestFetchCheckoutFailure(t *testing.T) {
 	}
 	repo := newBaseRepo(repoDir)
 	options := github.RepoFetcherOptions{}
-	_, _, err = fetcher.Fetch(context.Background(), repo, repo.DefaultBranch, "invalidsha", options)
+	_, _, err = fetcher.Fetch(context.Background(), repo, 1, repo.DefaultBranch, "invalidsha", options)
 	assert.Error(t, err)
 }
 
@@ -259,7 +259,7 @@ func TestFetchNonDefaultBranchFailure(t *testing.T) {
 	}
 	repo := newBaseRepo(repoDir)
 	options := github.RepoFetcherOptions{}
-	_, _, err = fetcher.Fetch(context.Background(), repo, "invalid-branch", sha, options)
+	_, _, err = fetcher.Fetch(context.Background(), repo, 1, "invalid-branch", sha, options)
 	assert.Error(t, err)
 }
 
@@ -286,7 +286,7 @@ func TestFetch_ErrorGettingGHToken(t *testing.T) {
 	}
 	repo := newBaseRepo(repoDir)
 	options := github.RepoFetcherOptions{}
-	_, _, err = fetcher.Fetch(context.Background(), repo, repo.DefaultBranch, sha, options)
+	_, _, err = fetcher.Fetch(context.Background(), repo, 1, repo.DefaultBranch, sha, options)
 	assert.ErrorContains(t, err, "error")
 }
 
@@ -316,7 +316,7 @@ func TestDirPaths(t *testing.T) {
 	optionsSimplePathTrue := github.RepoFetcherOptions{
 		SimplePath: true,
 	}
-	destinationPath, _, err := fetcher.Fetch(context.Background(), repo, repo.DefaultBranch, sha2, optionsSimplePathTrue)
+	destinationPath, _, err := fetcher.Fetch(context.Background(), repo, 1, repo.DefaultBranch, sha2, optionsSimplePathTrue)
 	assert.NoError(t, err)
 
 	// Use rev-parse to verify at correct commit.
@@ -50,6 +50,10 @@ type pullRequestReviewEventHandler interface {
 	Handle(ctx context.Context, e event.PullRequestReview, r *http.BufferedRequest) error
 }
 
+type teamMembershipEventHandler interface {
+	Handle(ctx context.Context, e event.TeamMembership) error
+}
+
 // converter interfaces
 type pullEventConverter interface {
 	Convert(ctx context.Context, e *github.PullRequestEvent) (event.PullRequest, error)
@@ -75,6 +79,14 @@ type pullRequestReviewEventConverter interface {
 	Convert(event *github.PullRequestReviewEvent) (event.PullRequestReview, error)
 }
 
+type membershipEventConverter interface {
+	Convert(event *github.MembershipEvent) (event.TeamMembership, error)
+}
+
+type teamEventConverter interface {
+	Convert(event *github.TeamEvent) (event.TeamMembership, error)
+}
+
 // Matcher matches a provided request against some condition
 type Matcher struct{}
 
@@ -93,6 +105,7 @@ func NewHandler(
 	pullRequestReviewEventHandler pullRequestReviewEventHandler,
 	checkRunHandler checkRunEventHandler,
 	checkSuiteHandler checkSuiteEventHandler,
+	teamMembershipHandler teamMembershipEventHandler,
 	allowDraftPRs bool,
 	repoConverter converter.RepoConverter,
 	pullConverter converter.PullConverter,
@@ -124,10 +137,13 @@ func NewHandler(
 		},
 		checkRunEventConverter:   converter.CheckRunEvent{},
 		checkSuiteEventConverter: converter.CheckSuiteEvent{RepoConverter: repoConverter},
+		membershipEventConverter: converter.MembershipEvent{},
+		teamEventConverter:       converter.TeamEvent{},
 		pushHandler:              pushHandler,
 		checkRunHandler:          checkRunHandler,
 		checkSuiteHandler:        checkSuiteHandler,
 		pullRequestReviewHandler: pullRequestReviewEventHandler,
+		teamMembershipHandler:    teamMembershipHandler,
 		webhookSecret:            webhookSecret,
 		logger:                   logger,
 		scope:                    scope,
@@ -142,6 +158,7 @@ type Handler struct {
 	checkRunHandler                 checkRunEventHandler
 	checkSuiteHandler               checkSuiteEventHandler
 	pullRequestReviewHandler        pullRequestReviewEventHandler
+	teamMembershipHandler           teamMembershipEventHandler
 	parser                          webhookParser
 	pullEventConverter              pullEventConverter
 	commentEventConverter           commentEventConverter
@@ -149,6 +166,8 @@ type Handler struct {
 	checkRunEventConverter          checkRunEventConverter
 	checkSuiteEventConverter        checkSuiteEventConverter
 	pullRequestReviewEventConverter pullRequestReviewEventConverter
+	membershipEventConverter        membershipEventConverter
+	teamEventConverter              teamEventConverter
 	webhookSecret                   []byte
 	logger                          logging.Logger
 	scope                           tally.Scope
@@ -215,6 +234,16 @@ func (h *Handler) Handle(r *http.BufferedRequest) error {
 		timer := scope.Timer(metrics.ExecutionTimeMetric).Start()
 		defer timer.Stop()
 		err = h.handlePullRequestReviewEvent(ctx, event, r)
+	case *github.MembershipEvent:
+		scope = scope.SubScope("membership")
+		timer := scope.Timer(metrics.ExecutionTimeMetric).Start()
+		defer timer.Stop()
+		err = h.handleMembershipEvent(ctx, event)
+	case *github.TeamEvent:
+		scope = scope.SubScope("team")
+		timer := scope.Timer(metrics.ExecutionTimeMetric).Start()
+		defer timer.Stop()
+		err = h.handleTeamEvent(ctx, event)
 	default:
 		h.logger.WarnContext(ctx, "Ignoring unsupported event")
 	}
@@ -302,3 +331,19 @@ func (h *Handler) handlePullRequestReviewEvent(ctx context.Context, e *github.Pu
 	ctx = context.WithValue(ctx, contextInternal.SHAKey, pullRequestReviewEvent.Ref)
 	return h.pullRequestReviewHandler.Handle(ctx, pullRequestReviewEvent, r)
 }
+
+func (h *Handler) handleMembershipEvent(ctx context.Context, e *github.MembershipEvent) error {
+	membershipEvent, err := h.membershipEventConverter.Convert(e)
+	if err != nil {
+		return &errors.EventParsingError{Err: err}
+	}
+	return h.teamMembershipHandler.Handle(ctx, membershipEvent)
+}
+
+func (h *Handler) handleTeamEvent(ctx context.Context, e *github.TeamEvent) error {
+	teamEvent, err := h.teamEventConverter.Convert(e)
+	if err != nil {
+		return &errors.EventParsingError{Err: err}
+	}
+	return h.teamMembershipHandler.Handle(ctx, teamEvent)
+}